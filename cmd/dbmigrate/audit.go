@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+)
+
+// Audit event commands, handled by the tool rather than passed to goose
+const (
+	verifyAuditCommand       = "verify-audit"
+	exportAuditCommand       = "export-audit"
+	verifyAuditExportCommand = "verify-audit-export"
+)
+
+var errAuditFileUnset = errors.New("audit export file must be set with -args")
+
+// runAuditCommand verifies the audit event hash chain, exports every audit
+// event to a signed file or verifies a previously exported file
+func runAuditCommand(cmd, file string, cfg *database.Config) error {
+	if cmd == verifyAuditCommand {
+		v, err := audit.Verify()
+		if err != nil {
+			return err
+		}
+		printVerification(v)
+		return nil
+	}
+
+	if file == "" {
+		return errAuditFileUnset
+	}
+	key, err := config.ResolveSecret(cfg.AuditSigningKey)
+	if err != nil {
+		return err
+	}
+
+	if cmd == exportAuditCommand {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		events, err := audit.Export(f, []byte(key), time.Unix(0, 0), time.Now())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d audit event(s) to %s\n", events, file)
+		return nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	v, err := audit.VerifyExport(f, []byte(key))
+	if err != nil {
+		return err
+	}
+	printVerification(v)
+	return nil
+}
+
+func printVerification(v audit.Verification) {
+	fmt.Printf("Verified %d audit event(s), %d recorded before hash chaining\n", v.Events, v.Unchained)
+	if v.LastHash != "" {
+		fmt.Printf("Last event ID %d hash %s\n", v.LastID, v.LastHash)
+	}
+}
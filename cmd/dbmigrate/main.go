@@ -48,8 +48,8 @@ func main() {
 	fmt.Println(core.Copyright)
 	fmt.Println()
 
-	flag.StringVar(&command, "command", "", "command to run status|up|up-by-one|up-to|down|create")
-	flag.StringVar(&args, "args", "", "arguments to pass to goose")
+	flag.StringVar(&command, "command", "", "command to run status|up|up-by-one|up-to|down|create|verify-audit|export-audit|verify-audit-export")
+	flag.StringVar(&args, "args", "", "arguments to pass to goose, or the file to export audit events to or verify")
 	flag.StringVar(&configFile, "config", config.DefaultFilePath(), "config file to load")
 	flag.StringVar(&defaultDataDir, "datadir", common.GetDefaultDataDir(runtime.GOOS), "default data directory for GoCryptoTrader files")
 	flag.StringVar(&migrationDir, "migrationdir", database.MigrationDir, "override migration folder")
@@ -89,7 +89,13 @@ func main() {
 		return
 	}
 
-	if err = goose.Run(command, dbConn.SQL, drv, migrationDir, args); err != nil {
+	switch command {
+	case verifyAuditCommand, exportAuditCommand, verifyAuditExportCommand:
+		err = runAuditCommand(command, args, &conf.Database)
+	default:
+		err = goose.Run(command, dbConn.SQL, drv, migrationDir, args)
+	}
+	if err != nil {
 		fmt.Println(err)
 	}
 }
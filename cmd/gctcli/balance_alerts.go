@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var getBalanceAlertStreamCommand = cli.Command{
+	Name:      "getbalancealertstream",
+	Usage:     "streams triggered balance alerts",
+	ArgsUsage: "<exchange>",
+	Action:    getBalanceAlertStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange, e",
+			Usage: "optional exchange to filter balance alerts by",
+		},
+	},
+}
+
+func getBalanceAlertStream(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetBalanceAlertStream(context.Background(),
+		&gctrpc.GetBalanceAlertStreamRequest{Exchange: exchangeName})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
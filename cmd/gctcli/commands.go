@@ -173,6 +173,32 @@ func disableSubsystem(c *cli.Context) error {
 	return nil
 }
 
+var reloadConfigCommand = cli.Command{
+	Name:   "reloadconfig",
+	Usage:  "reloads the config file and applies the changes without restarting",
+	Action: reloadConfig,
+}
+
+func reloadConfig(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ReloadConfig(context.Background(),
+		&gctrpc.ReloadConfigRequest{},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var getRPCEndpointsCommand = cli.Command{
 	Name:   "getrpcendpoints",
 	Usage:  "gets GoCryptoTrader endpoints info",
@@ -732,6 +758,10 @@ var getAccountInfoCommand = cli.Command{
 			Name:  "exchange",
 			Usage: "the exchange to get the account info for",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -760,7 +790,8 @@ func getAccountInfo(c *cli.Context) error {
 	client := gctrpc.NewGoCryptoTraderClient(conn)
 	result, err := client.GetAccountInfo(context.Background(),
 		&gctrpc.GetAccountInfoRequest{
-			Exchange: exchange,
+			Exchange:  exchange,
+			AccountId: c.String("account_id"),
 		},
 	)
 	if err != nil {
@@ -841,6 +872,10 @@ var updateAccountInfoCommand = cli.Command{
 			Name:  "exchange",
 			Usage: "the exchange to get the account info for",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -869,7 +904,8 @@ func updateAccountInfo(c *cli.Context) error {
 	client := gctrpc.NewGoCryptoTraderClient(conn)
 	result, err := client.UpdateAccountInfo(context.Background(),
 		&gctrpc.GetAccountInfoRequest{
-			Exchange: exchange,
+			Exchange:  exchange,
+			AccountId: c.String("account_id"),
 		},
 	)
 	if err != nil {
@@ -1197,6 +1233,10 @@ var getOrdersCommand = cli.Command{
 			Name:  "pair",
 			Usage: "the currency pair to get orders for",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -1256,6 +1296,7 @@ func getOrders(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
+		AccountId: c.String("account_id"),
 	})
 	if err != nil {
 		return err
@@ -1388,6 +1429,10 @@ var submitOrderCommand = cli.Command{
 			Name:  "asset",
 			Usage: "required asset type",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -1512,6 +1557,7 @@ func submitOrder(c *cli.Context) error {
 		Price:     price,
 		ClientId:  clientID,
 		AssetType: assetType,
+		AccountId: c.String("account_id"),
 	})
 	if err != nil {
 		return err
@@ -2458,6 +2504,10 @@ var withdrawCryptocurrencyFundsCommand = cli.Command{
 			Name:  "description",
 			Usage: "description to submit with request",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -2538,6 +2588,7 @@ func withdrawCryptocurrencyFunds(c *cli.Context) error {
 			Amount:      amount,
 			Fee:         fee,
 			Description: description,
+			AccountId:   c.String("account_id"),
 		},
 	)
 	if err != nil {
@@ -2573,6 +2624,10 @@ var withdrawFiatFundsCommand = cli.Command{
 			Name:  "description",
 			Usage: "description to submit with request",
 		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the optional named account to use",
+		},
 	},
 }
 
@@ -2635,6 +2690,7 @@ func withdrawFiatFunds(c *cli.Context) error {
 			Amount:        amount,
 			Description:   description,
 			BankAccountId: bankAccountID,
+			AccountId:     c.String("account_id"),
 		},
 	)
 	if err != nil {
@@ -3446,6 +3502,10 @@ var getAuditEventCommand = cli.Command{
 			Value:       100,
 			Destination: &limit,
 		},
+		cli.StringFlag{
+			Name:  "type, t",
+			Usage: "only retrieve events of the type: order, withdrawal, config_change, login, remote_access or command",
+		},
 	},
 }
 
@@ -3506,6 +3566,7 @@ func getAuditEvent(c *cli.Context) error {
 			EndDate:   negateLocalOffset(e),
 			Limit:     int32(limit),
 			OrderBy:   order,
+			Type:      c.String("type"),
 		})
 
 	if err != nil {
@@ -3516,6 +3577,120 @@ func getAuditEvent(c *cli.Context) error {
 	return nil
 }
 
+var verifyAuditEventsCommand = cli.Command{
+	Name:   "verifyauditevents",
+	Usage:  "verifies the hash chain of every audit event to detect edited or deleted events",
+	Action: verifyAuditEvents,
+}
+
+func verifyAuditEvents(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.VerifyAuditEvents(context.Background(),
+		&gctrpc.VerifyAuditEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var exportAuditEventsCommand = cli.Command{
+	Name:      "exportauditevents",
+	Usage:     "exports audit events to a JSON lines file signed with the configured audit signing key",
+	ArgsUsage: "<file> <starttime> <endtime>",
+	Action:    exportAuditEvents,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "the file to write the export to",
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date to export from",
+			Value:       time.Now().AddDate(0, -1, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end date to export to",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+	},
+}
+
+func exportAuditEvents(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "exportauditevents")
+	}
+
+	var file string
+	if c.IsSet("file") {
+		file = c.String("file")
+	} else {
+		file = c.Args().First()
+	}
+	if file == "" {
+		return errors.New("export file must be set")
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ExportAuditEvents(context.Background(),
+		&gctrpc.ExportAuditEventsRequest{
+			StartDate: negateLocalOffset(s),
+			EndDate:   negateLocalOffset(e),
+		})
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(file, result.Data, 0600)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d audit event(s) to %s\n", result.Events, file)
+	return nil
+}
+
 var uuid, filename, path string
 var gctScriptCommand = cli.Command{
 	Name:      "script",
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var futuresDataFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "exchange, e",
+		Usage: "the exchange to retrieve the contract data from",
+	},
+	cli.StringFlag{
+		Name:  "pair, p",
+		Usage: "the contract currency pair",
+	},
+	cli.StringFlag{
+		Name:  "asset, a",
+		Usage: "the derivative asset type e.g. futures, perpetualswap",
+	},
+}
+
+var futuresCommand = cli.Command{
+	Name:      "futures",
+	Usage:     "execute futures and perpetual contract related commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "getfundingrate",
+			Usage:     "gets the current funding rate of a perpetual contract",
+			ArgsUsage: "<exchange> <pair> <asset>",
+			Action:    getFundingRate,
+			Flags:     futuresDataFlags,
+		},
+		{
+			Name:      "getfundingratehistory",
+			Usage:     "gets funding rates between two periods",
+			ArgsUsage: "<exchange> <pair> <asset> <start> <end> <sync> <usedb>",
+			Action:    getFundingRateHistory,
+			Flags: append(futuresDataFlags,
+				cli.StringFlag{
+					Name:        "start",
+					Usage:       "<start>",
+					Value:       time.Now().AddDate(0, 0, -7).Format(common.SimpleTimeFormat),
+					Destination: &startTime,
+				},
+				cli.StringFlag{
+					Name:        "end",
+					Usage:       "<end>",
+					Value:       time.Now().Format(common.SimpleTimeFormat),
+					Destination: &endTime,
+				},
+				cli.BoolFlag{
+					Name:  "sync",
+					Usage: "<true/false> whether to save the retrieved funding rates to the database",
+				},
+				cli.BoolFlag{
+					Name:  "usedb",
+					Usage: "<true/false> whether to retrieve funding rates from the database instead of the exchange",
+				},
+			),
+		},
+		{
+			Name:      "getopeninterest",
+			Usage:     "gets the open interest of a contract",
+			ArgsUsage: "<exchange> <pair> <asset>",
+			Action:    getOpenInterest,
+			Flags:     futuresDataFlags,
+		},
+		{
+			Name:      "getmarkprice",
+			Usage:     "gets the mark and index price of a contract",
+			ArgsUsage: "<exchange> <pair> <asset>",
+			Action:    getMarkPrice,
+			Flags:     futuresDataFlags,
+		},
+		{
+			Name:      "getcontracts",
+			Usage:     "gets the contract specifications of an exchange asset type",
+			ArgsUsage: "<exchange> <asset>",
+			Action:    getFuturesContracts,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "exchange, e",
+					Usage: "the exchange to retrieve the contract specifications from",
+				},
+				cli.StringFlag{
+					Name:  "asset, a",
+					Usage: "the derivative asset type e.g. futures, perpetualswap",
+				},
+			},
+		},
+	},
+}
+
+// parseFuturesDataArgs parses the exchange, pair and asset arguments shared by
+// the futures commands
+func parseFuturesDataArgs(c *cli.Context) (*gctrpc.GetFuturesDataRequest, error) {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if !validExchange(exchangeName) {
+		return nil, errInvalidExchange
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+	if !validPair(currencyPair) {
+		return nil, errInvalidPair
+	}
+
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return nil, errInvalidAsset
+	}
+
+	return &gctrpc.GetFuturesDataRequest{
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+	}, nil
+}
+
+func getFundingRate(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "getfundingrate")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetFundingRate(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getFundingRateHistory(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "getfundingratehistory")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(3) != "" {
+			startTime = c.Args().Get(3)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(4) != "" {
+			endTime = c.Args().Get(4)
+		}
+	}
+
+	var sync bool
+	if c.IsSet("sync") {
+		sync = c.Bool("sync")
+	} else if c.Args().Get(5) != "" {
+		sync, err = strconv.ParseBool(c.Args().Get(5))
+		if err != nil {
+			return err
+		}
+	}
+
+	var useDB bool
+	if c.IsSet("usedb") {
+		useDB = c.Bool("usedb")
+	} else if c.Args().Get(6) != "" {
+		useDB, err = strconv.ParseBool(c.Args().Get(6))
+		if err != nil {
+			return err
+		}
+	}
+
+	var s, e time.Time
+	s, err = time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err = time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetFundingRateHistory(context.Background(),
+		&gctrpc.GetFundingRateHistoryRequest{
+			Exchange:  req.Exchange,
+			AssetType: req.AssetType,
+			Pair:      req.Pair,
+			Start:     negateLocalOffset(s),
+			End:       negateLocalOffset(e),
+			Sync:      sync,
+			UseDb:     useDB,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getOpenInterest(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "getopeninterest")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetOpenInterest(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getMarkPrice(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "getmarkprice")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetMarkPrice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getFuturesContracts(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "getcontracts")
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetFuturesContracts(context.Background(),
+		&gctrpc.GetFuturesContractsRequest{
+			Exchange:  exchangeName,
+			AssetType: assetType,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
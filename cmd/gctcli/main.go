@@ -20,6 +20,7 @@ var (
 	host          string
 	username      string
 	password      string
+	apiToken      string
 	pairDelimiter string
 	certPath      string
 )
@@ -38,11 +39,16 @@ func setupClient() (*grpc.ClientConn, error) {
 		return nil, err
 	}
 
+	var rpcCreds credentials.PerRPCCredentials = auth.BasicAuth{
+		Username: username,
+		Password: password,
+	}
+	if apiToken != "" {
+		rpcCreds = auth.TokenAuth{Token: apiToken}
+	}
+
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
-			Username: username,
-			Password: password,
-		}),
+		grpc.WithPerRPCCredentials(rpcCreds),
 	}
 	conn, err := grpc.Dial(host, opts...)
 	if err != nil {
@@ -77,6 +83,11 @@ func main() {
 			Usage:       "the gRPC password",
 			Destination: &password,
 		},
+		cli.StringFlag{
+			Name:        "rpcapitoken",
+			Usage:       "the gRPC API token, used instead of the username and password when set",
+			Destination: &apiToken,
+		},
 		cli.StringFlag{
 			Name:        "delimiter",
 			Value:       "-",
@@ -95,6 +106,7 @@ func main() {
 		getSubsystemsCommand,
 		enableSubsystemCommand,
 		disableSubsystemCommand,
+		reloadConfigCommand,
 		getRPCEndpointsCommand,
 		getCommunicationRelayersCommand,
 		getExchangesCommand,
@@ -141,12 +153,21 @@ func main() {
 		getTickerStreamCommand,
 		getExchangeTickerStreamCommand,
 		getAuditEventCommand,
+		verifyAuditEventsCommand,
+		exportAuditEventsCommand,
 		getHistoricCandlesCommand,
 		getHistoricCandlesExtendedCommand,
 		findMissingSavedCandleIntervalsCommand,
 		gctScriptCommand,
 		websocketManagerCommand,
 		tradeCommand,
+		futuresCommand,
+		positionsCommand,
+		valuationCommand,
+		rebalanceCommand,
+		withdrawalApprovalCommand,
+		getDepositHistoryCommand,
+		getBalanceAlertStreamCommand,
 	}
 
 	err := app.Run(os.Args)
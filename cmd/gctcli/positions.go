@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var positionsCommand = cli.Command{
+	Name:      "positions",
+	Usage:     "execute leveraged position related commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "get",
+			Usage:     "gets all open leveraged positions for an exchange asset type",
+			ArgsUsage: "<exchange> <asset>",
+			Action:    getLeveragedPositions,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "exchange, e",
+					Usage: "the exchange to retrieve positions from",
+				},
+				cli.StringFlag{
+					Name:  "asset, a",
+					Usage: "the derivative asset type e.g. futures, perpetualswap",
+				},
+			},
+		},
+		{
+			Name:      "setleverage",
+			Usage:     "sets the leverage used for a contract",
+			ArgsUsage: "<exchange> <pair> <asset> <leverage>",
+			Action:    setLeverage,
+			Flags: append(futuresDataFlags, cli.Float64Flag{
+				Name:  "leverage, l",
+				Usage: "the leverage to use e.g. 10",
+			}),
+		},
+		{
+			Name:      "setmargintype",
+			Usage:     "sets whether a contract uses isolated or cross margin",
+			ArgsUsage: "<exchange> <pair> <asset> <margintype>",
+			Action:    setMarginType,
+			Flags: append(futuresDataFlags, cli.StringFlag{
+				Name:  "margintype, m",
+				Usage: "isolated or cross",
+			}),
+		},
+		{
+			Name:      "modifymargin",
+			Usage:     "adds or removes margin from an isolated position",
+			ArgsUsage: "<exchange> <pair> <asset> <amount>",
+			Action:    modifyIsolatedMargin,
+			Flags: append(futuresDataFlags, cli.Float64Flag{
+				Name:  "amount",
+				Usage: "the amount of margin to add, a negative amount removes margin",
+			}),
+		},
+	},
+}
+
+func getLeveragedPositions(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "get")
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetLeveragedPositions(context.Background(),
+		&gctrpc.GetLeveragedPositionsRequest{
+			Exchange:  exchangeName,
+			AssetType: assetType,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func setLeverage(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "setleverage")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	var leverage float64
+	if c.IsSet("leverage") {
+		leverage = c.Float64("leverage")
+	} else {
+		leverage, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SetLeverage(context.Background(),
+		&gctrpc.SetLeverageRequest{
+			Exchange:  req.Exchange,
+			AssetType: req.AssetType,
+			Pair:      req.Pair,
+			Leverage:  leverage,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func setMarginType(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "setmargintype")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	var marginType string
+	if c.IsSet("margintype") {
+		marginType = c.String("margintype")
+	} else {
+		marginType = c.Args().Get(3)
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SetMarginType(context.Background(),
+		&gctrpc.SetMarginTypeRequest{
+			Exchange:   req.Exchange,
+			AssetType:  req.AssetType,
+			Pair:       req.Pair,
+			MarginType: marginType,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func modifyIsolatedMargin(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "modifymargin")
+	}
+
+	req, err := parseFuturesDataArgs(c)
+	if err != nil {
+		return err
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else {
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ModifyIsolatedMargin(context.Background(),
+		&gctrpc.ModifyIsolatedMarginRequest{
+			Exchange:  req.Exchange,
+			AssetType: req.AssetType,
+			Pair:      req.Pair,
+			Amount:    amount,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
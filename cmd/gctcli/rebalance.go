@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var errProposalIDRequired = errors.New("rebalance proposal id required")
+
+var rebalanceProposalIDFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "the rebalance proposal id",
+	},
+}
+
+var rebalanceCommand = cli.Command{
+	Name:      "rebalance",
+	Usage:     "execute inter-exchange rebalancer commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:   "plan",
+			Usage:  "computes new rebalance proposals from current exchange holdings, superseding pending proposals",
+			Action: planRebalance,
+		},
+		{
+			Name:      "getproposals",
+			Usage:     "gets rebalance proposals",
+			ArgsUsage: "<status>",
+			Action:    getRebalanceProposals,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "status",
+					Usage: "optional status filter e.g. pending, blocked, submitting, approved, settled, rejected, failed, superseded",
+				},
+			},
+		},
+		{
+			Name:      "approve",
+			Usage:     "approves a pending rebalance proposal and submits its withdrawal",
+			ArgsUsage: "<id>",
+			Action:    approveRebalanceProposal,
+			Flags:     rebalanceProposalIDFlags,
+		},
+		{
+			Name:      "reject",
+			Usage:     "rejects a pending rebalance proposal",
+			ArgsUsage: "<id>",
+			Action:    rejectRebalanceProposal,
+			Flags:     rebalanceProposalIDFlags,
+		},
+	},
+}
+
+func planRebalance(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.PlanRebalance(context.Background(),
+		&gctrpc.PlanRebalanceRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getRebalanceProposals(c *cli.Context) error {
+	var status string
+	if c.IsSet("status") {
+		status = c.String("status")
+	} else {
+		status = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetRebalanceProposals(context.Background(),
+		&gctrpc.GetRebalanceProposalsRequest{Status: status})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func parseRebalanceProposalID(c *cli.Context) (string, error) {
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+	if id == "" {
+		return "", errProposalIDRequired
+	}
+	return id, nil
+}
+
+func approveRebalanceProposal(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "approve")
+	}
+
+	id, err := parseRebalanceProposalID(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ApproveRebalanceProposal(context.Background(),
+		&gctrpc.RebalanceProposalRequest{Id: id})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func rejectRebalanceProposal(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "reject")
+	}
+
+	id, err := parseRebalanceProposalID(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RejectRebalanceProposal(context.Background(),
+		&gctrpc.RebalanceProposalRequest{Id: id})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
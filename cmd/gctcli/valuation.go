@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/valuation"
+	"github.com/urfave/cli"
+)
+
+var valuationFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "fiat, f",
+		Usage: "the fiat currency the valuations were stored in, defaults to the configured valuation currency",
+	},
+	cli.StringFlag{
+		Name:        "start",
+		Usage:       "<start>",
+		Value:       time.Now().AddDate(0, 0, -30).Format(common.SimpleTimeFormat),
+		Destination: &startTime,
+	},
+	cli.StringFlag{
+		Name:        "end",
+		Usage:       "<end>",
+		Value:       time.Now().Format(common.SimpleTimeFormat),
+		Destination: &endTime,
+	},
+	cli.StringFlag{
+		Name:  "csv",
+		Usage: "optional filename to export the result to as CSV",
+	},
+}
+
+var valuationCommand = cli.Command{
+	Name:      "valuation",
+	Usage:     "execute portfolio valuation history commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "equitycurve",
+			Usage:     "gets the total fiat value of the portfolio over time",
+			ArgsUsage: "<fiat> <start> <end> <csv>",
+			Action:    getPortfolioEquityCurve,
+			Flags:     valuationFlags,
+		},
+		{
+			Name:      "allocation",
+			Usage:     "gets the per currency allocation of the portfolio over time",
+			ArgsUsage: "<fiat> <start> <end> <csv>",
+			Action:    getPortfolioAllocation,
+			Flags:     valuationFlags,
+		},
+	},
+}
+
+// parseValuationArgs parses the arguments shared by the valuation commands
+// and returns the request and CSV export filename
+func parseValuationArgs(c *cli.Context) (*gctrpc.GetPortfolioValuationRequest, string, error) {
+	var fiat string
+	if c.IsSet("fiat") {
+		fiat = c.String("fiat")
+	} else {
+		fiat = c.Args().First()
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
+	}
+
+	var csvFile string
+	if c.IsSet("csv") {
+		csvFile = c.String("csv")
+	} else {
+		csvFile = c.Args().Get(3)
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return nil, "", errors.New("start cannot be after end")
+	}
+
+	return &gctrpc.GetPortfolioValuationRequest{
+		FiatCurrency: fiat,
+		Start:        negateLocalOffset(s),
+		End:          negateLocalOffset(e),
+	}, csvFile, nil
+}
+
+func getPortfolioEquityCurve(c *cli.Context) error {
+	req, csvFile, err := parseValuationArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetPortfolioEquityCurve(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if csvFile == "" {
+		jsonOutput(result)
+		return nil
+	}
+
+	curve := make([]valuation.EquityPoint, len(result.Points))
+	for i := range result.Points {
+		curve[i].Timestamp, err = time.Parse(common.SimpleTimeFormatWithTimezone, result.Points[i].Timestamp)
+		if err != nil {
+			return err
+		}
+		curve[i].Value = result.Points[i].Value
+	}
+	err = valuation.ExportEquityCurveCSV(csvFile, curve)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d %s equity curve points to %s\n", len(curve), result.FiatCurrency, csvFile)
+	return nil
+}
+
+func getPortfolioAllocation(c *cli.Context) error {
+	req, csvFile, err := parseValuationArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetPortfolioAllocation(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if csvFile == "" {
+		jsonOutput(result)
+		return nil
+	}
+
+	series := make([]valuation.AllocationPoint, len(result.Points))
+	for i := range result.Points {
+		series[i].Timestamp, err = time.Parse(common.SimpleTimeFormatWithTimezone, result.Points[i].Timestamp)
+		if err != nil {
+			return err
+		}
+		series[i].Total = result.Points[i].Total
+		for _, a := range result.Points[i].Allocations {
+			series[i].Allocations = append(series[i].Allocations, valuation.Allocation{
+				Currency:   a.Currency,
+				Amount:     a.Amount,
+				Value:      a.Value,
+				Percentage: a.Percentage,
+			})
+		}
+	}
+	err = valuation.ExportAllocationCSV(csvFile, series)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d %s portfolio allocation snapshots to %s\n", len(series), result.FiatCurrency, csvFile)
+	return nil
+}
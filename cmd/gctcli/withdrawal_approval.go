@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var errWithdrawalApprovalArgs = errors.New("withdrawal id, operator and code required")
+
+var withdrawalApprovalFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "the pending withdrawal id",
+	},
+	cli.StringFlag{
+		Name:  "operator",
+		Usage: "the configured operator name",
+	},
+	cli.StringFlag{
+		Name:  "code",
+		Usage: "the operator one-time code",
+	},
+}
+
+var withdrawalApprovalCommand = cli.Command{
+	Name:      "withdrawalapproval",
+	Usage:     "execute withdrawal approval commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:   "getpending",
+			Usage:  "gets withdrawals awaiting operator approval",
+			Action: getPendingWithdrawals,
+		},
+		{
+			Name:      "approve",
+			Usage:     "approves a pending withdrawal, submitting it once enough operators have approved",
+			ArgsUsage: "<id> <operator> <code>",
+			Action:    approveWithdrawal,
+			Flags:     withdrawalApprovalFlags,
+		},
+		{
+			Name:      "reject",
+			Usage:     "rejects a pending withdrawal",
+			ArgsUsage: "<id> <operator> <code>",
+			Action:    rejectWithdrawal,
+			Flags:     withdrawalApprovalFlags,
+		},
+	},
+}
+
+func getPendingWithdrawals(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetPendingWithdrawals(context.Background(),
+		&gctrpc.GetPendingWithdrawalsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func parseWithdrawalApproval(c *cli.Context) (*gctrpc.WithdrawalApprovalRequest, error) {
+	req := &gctrpc.WithdrawalApprovalRequest{}
+	if c.IsSet("id") {
+		req.Id = c.String("id")
+	} else {
+		req.Id = c.Args().First()
+	}
+	if c.IsSet("operator") {
+		req.Operator = c.String("operator")
+	} else {
+		req.Operator = c.Args().Get(1)
+	}
+	if c.IsSet("code") {
+		req.Code = c.String("code")
+	} else {
+		req.Code = c.Args().Get(2)
+	}
+	if req.Id == "" || req.Operator == "" || req.Code == "" {
+		return nil, errWithdrawalApprovalArgs
+	}
+	return req, nil
+}
+
+func approveWithdrawal(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "approve")
+	}
+
+	req, err := parseWithdrawalApproval(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ApproveWithdrawal(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func rejectWithdrawal(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, "reject")
+	}
+
+	req, err := parseWithdrawalApproval(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RejectWithdrawal(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -32,6 +32,11 @@ var (
 	// ErrFunctionNotSupported defines a standardised error for an unsupported
 	// wrapper function by an API
 	ErrFunctionNotSupported = errors.New("unsupported wrapper function")
+
+	// ErrDateUnset is returned when a required start or end date is not set
+	ErrDateUnset = errors.New("date unset")
+	// ErrStartAfterEnd is returned when a start date is after the end date
+	ErrStartAfterEnd = errors.New("start date cannot be after end date")
 )
 
 // Const declarations for common.go operations
@@ -375,6 +380,21 @@ func InArray(val, array interface{}) (exists bool, index int) {
 	return
 }
 
+// StartEndTimeCheck provides some basic checks which occur
+// frequently in the codebase
+func StartEndTimeCheck(start, end time.Time) error {
+	if start.IsZero() {
+		return fmt.Errorf("start %w", ErrDateUnset)
+	}
+	if end.IsZero() {
+		return fmt.Errorf("end %w", ErrDateUnset)
+	}
+	if start.After(end) {
+		return ErrStartAfterEnd
+	}
+	return nil
+}
+
 // Errors defines multiple errors
 type Errors []error
 
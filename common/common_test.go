@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestIsEnabled(t *testing.T) {
@@ -563,3 +564,24 @@ func TestErrors(t *testing.T) {
 		t.Fatal("does not match error")
 	}
 }
+
+func TestStartEndTimeCheck(t *testing.T) {
+	t.Parallel()
+	tt := time.Now()
+	err := StartEndTimeCheck(time.Time{}, tt)
+	if !errors.Is(err, ErrDateUnset) {
+		t.Errorf("expected %v received %v", ErrDateUnset, err)
+	}
+	err = StartEndTimeCheck(tt, time.Time{})
+	if !errors.Is(err, ErrDateUnset) {
+		t.Errorf("expected %v received %v", ErrDateUnset, err)
+	}
+	err = StartEndTimeCheck(tt.Add(time.Minute), tt)
+	if !errors.Is(err, ErrStartAfterEnd) {
+		t.Errorf("expected %v received %v", ErrStartAfterEnd, err)
+	}
+	err = StartEndTimeCheck(tt, tt.Add(time.Minute))
+	if err != nil {
+		t.Error(err)
+	}
+}
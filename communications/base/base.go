@@ -18,10 +18,11 @@ type Base struct {
 	Connected bool
 }
 
-// Event is a generalise event type
+// Event is a generalise event type, events without a severity are info
 type Event struct {
-	Type    string
-	Message string
+	Type     string
+	Severity Severity
+	Message  string
 }
 
 // CommsStatus stores the status of a comms relayer
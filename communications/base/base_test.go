@@ -1,7 +1,12 @@
 package base
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
 )
 
 var (
@@ -127,3 +132,211 @@ func TestPushEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleCommand(t *testing.T) {
+	_, err := HandleCommand("tester", "")
+	if !errors.Is(err, ErrCommandNotFound) {
+		t.Errorf("expected %v received %v", ErrCommandNotFound, err)
+	}
+
+	RegisterCommand("Echo", func(sender string, args []string) (string, error) {
+		return sender + ": " + strings.Join(args, " "), nil
+	})
+	defer DeregisterCommand("echo")
+
+	reply, err := HandleCommand("tester", "echo hello   world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "tester: hello world" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	DeregisterCommand("ECHO")
+	_, err = HandleCommand("tester", "echo hello")
+	if !errors.Is(err, ErrCommandNotFound) {
+		t.Errorf("expected %v received %v", ErrCommandNotFound, err)
+	}
+
+	RegisterAuthorisedCommand("secret", func(sender string, args []string) (string, error) {
+		return "hidden", nil
+	})
+	defer DeregisterCommand("secret")
+	_, err = HandleCommand("tester", "secret")
+	if !errors.Is(err, ErrCommandUnauthorised) {
+		t.Errorf("expected %v received %v", ErrCommandUnauthorised, err)
+	}
+	reply, err = HandleAuthorisedCommand("tester", "SECRET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "hidden" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	r := Base{Name: "Tester"}
+	RegisterCommand("echo", func(sender string, args []string) (string, error) {
+		if len(args) == 0 {
+			return "", errors.New("nothing to echo")
+		}
+		return sender + ": " + strings.Join(args, " "), nil
+	})
+	defer DeregisterCommand("echo")
+
+	if reply := r.RunCommand("tester", "HELP", "", false); !strings.Contains(reply, "\necho") {
+		t.Errorf("expected registered commands in help, received %q", reply)
+	}
+	if reply := r.RunCommand("tester", "status", "", false); reply != r.GetStatus() {
+		t.Errorf("unexpected status reply %q", reply)
+	}
+	if reply := r.RunCommand("tester", "settings", "verbose: false", false); reply != "verbose: false" {
+		t.Errorf("unexpected settings reply %q", reply)
+	}
+	if reply := r.RunCommand("tester", "echo hi", "", false); reply != "tester: hi" {
+		t.Errorf("unexpected echo reply %q", reply)
+	}
+	if reply := r.RunCommand("tester", "echo", "", false); reply != "Error: nothing to echo" {
+		t.Errorf("unexpected error reply %q", reply)
+	}
+	if reply := r.RunCommand("tester", "missing", "", false); !strings.HasPrefix(reply, "Command missing not recognized") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	RegisterAuthorisedCommand("runsecret", func(string, []string) (string, error) {
+		return "hidden", nil
+	})
+	defer DeregisterCommand("runsecret")
+	if reply := r.RunCommand("tester", "runsecret", "", false); !strings.Contains(reply, ErrCommandUnauthorised.Error()) {
+		t.Errorf("expected unauthorised reply, received %q", reply)
+	}
+	if reply := r.RunCommand("tester", "runsecret", "", true); reply != "hidden" {
+		t.Errorf("unexpected authorised reply %q", reply)
+	}
+}
+
+func TestCommandAuditor(t *testing.T) {
+	type audited struct {
+		sender, name string
+		args         []string
+		err          error
+	}
+	var recorded []audited
+	SetCommandAuditor(func(sender, name string, args []string, err error) {
+		recorded = append(recorded, audited{sender, name, args, err})
+	})
+	defer SetCommandAuditor(nil)
+
+	RegisterAuthorisedCommand("guarded", func(string, []string) (string, error) {
+		return "ran", nil
+	})
+	defer DeregisterCommand("guarded")
+
+	r := Base{Name: "Tester"}
+	r.RunCommand("intruder", "guarded now", "", false)
+	r.RunCommand("tester", "status", "", false)
+	r.RunCommand("tester", "missing", "", false)
+	r.RunCommand("tester", "guarded", "", true)
+	AuditCommand("tester", "help", nil)
+	r.RunCommand("tester", "", "", false)
+
+	if len(recorded) != 5 {
+		t.Fatalf("expected every command audited, received %+v", recorded)
+	}
+	if recorded[0].sender != "intruder" ||
+		recorded[0].name != "guarded" ||
+		len(recorded[0].args) != 1 ||
+		!errors.Is(recorded[0].err, ErrCommandUnauthorised) {
+		t.Errorf("expected refused command audited, received %+v", recorded[0])
+	}
+	if recorded[1].name != "status" || recorded[1].err != nil {
+		t.Errorf("expected built in command audited, received %+v", recorded[1])
+	}
+	if !errors.Is(recorded[2].err, ErrCommandNotFound) {
+		t.Errorf("expected unknown command audited, received %+v", recorded[2])
+	}
+	if recorded[3].err != nil || recorded[4].name != "help" {
+		t.Errorf("unexpected audit records %+v", recorded[3:])
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []Severity{SeverityInfo, SeverityTrade, SeverityWarning, SeverityCritical} {
+		parsed, err := ParseSeverity(strings.ToUpper(s.String()))
+		if err != nil || parsed != s {
+			t.Errorf("expected %s, received %s %v", s, parsed, err)
+		}
+	}
+	if s, err := ParseSeverity(""); err != nil || s != SeverityInfo {
+		t.Errorf("expected an empty severity to be info, received %s %v", s, err)
+	}
+	if _, err := ParseSeverity("urgent"); !errors.Is(err, ErrInvalidSeverity) {
+		t.Errorf("expected %v received %v", ErrInvalidSeverity, err)
+	}
+}
+
+func TestRouter(t *testing.T) {
+	r, err := NewRouter(&config.EventRoutingConfig{
+		DeduplicationWindow: time.Minute,
+		Routes: []config.EventRouteConfig{
+			{Relayer: "SMS", EventTypes: []string{"order"}, MinSeverity: "trade"},
+			{Relayer: "Slack", DigestSeverity: "trade", MaxEvents: 1, RateLimitInterval: time.Minute},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	fill := Event{Type: "order", Severity: SeverityTrade, Message: "filled"}
+
+	if r.Route("sms", Event{Type: "deposit", Severity: SeverityCritical}, now) {
+		t.Error("expected unsubscribed event types to be dropped")
+	}
+	if r.Route("sms", Event{Type: "order", Message: "info"}, now) {
+		t.Error("expected events below the minimum severity to be dropped")
+	}
+	if !r.Route("sms", fill, now) {
+		t.Error("expected event to be routed")
+	}
+	if r.Route("sms", fill, now.Add(time.Second)) {
+		t.Error("expected repeated event to be deduplicated")
+	}
+	if !r.Route("sms", fill, now.Add(time.Minute)) {
+		t.Error("expected repeated event to be routed after the deduplication window")
+	}
+	if !r.Route("telegram", Event{Type: "config"}, now) {
+		t.Error("expected relayers without a route to receive every event")
+	}
+
+	if r.Route("slack", Event{Type: "config", Message: "reloaded"}, now) {
+		t.Error("expected info event to be batched for the digest")
+	}
+	if !r.Route("slack", fill, now) {
+		t.Error("expected event within the rate limit to be routed")
+	}
+	if r.Route("slack", Event{Type: "order", Severity: SeverityWarning, Message: "rejected"}, now) {
+		t.Error("expected event over the rate limit to be batched for the digest")
+	}
+	if !r.Route("slack", Event{Type: "order", Severity: SeverityCritical, Message: "kill"}, now) {
+		t.Error("expected critical event to bypass the rate limit")
+	}
+	if !r.Route("slack", Event{Type: "order", Severity: SeverityTrade, Message: "later"}, now.Add(time.Minute)) {
+		t.Error("expected event to be routed after the rate limit interval")
+	}
+
+	digest, ok := r.Digest("Slack")
+	if !ok {
+		t.Fatal("expected a digest")
+	}
+	if digest.Type != DigestEventType ||
+		digest.Message != "2 events since the last digest\n[info] config: reloaded\n[warning] order: rejected" {
+		t.Errorf("unexpected digest %+v", digest)
+	}
+	if _, ok = r.Digest("Slack"); ok {
+		t.Error("expected batched events to be cleared by the digest")
+	}
+	if _, ok = r.Digest("missing"); ok {
+		t.Error("expected no digest for an unknown relayer")
+	}
+}
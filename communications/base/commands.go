@@ -0,0 +1,196 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Built in commands answered by RunCommand for relayers which accept inbound
+// messages
+const (
+	CmdHelp     = "help"
+	CmdStatus   = "status"
+	CmdSettings = "settings"
+)
+
+var (
+	// ErrCommandNotFound is returned when no command is registered under a
+	// name
+	ErrCommandNotFound = errors.New("command not found")
+	// ErrCommandUnauthorised is returned when a sender the relayer has not
+	// authorised runs a command registered with RegisterAuthorisedCommand
+	ErrCommandUnauthorised = errors.New("sender is not authorised to run this command")
+)
+
+// Command handles a command received by a communication relayer. The sender
+// identifies who sent the command and args are the space separated words
+// following the command name. The returned string is replied to the sender
+type Command func(sender string, args []string) (string, error)
+
+// registeredCommand is a command handler and whether only authorised senders
+// may run it
+type registeredCommand struct {
+	handler    Command
+	authorised bool
+}
+
+// CommandAuditor records a command run by a sender. The name and args are
+// the words of the command and err is why it failed or was refused, nil when
+// it ran
+type CommandAuditor func(sender, name string, args []string, err error)
+
+var auditor = struct {
+	sync.RWMutex
+	a CommandAuditor
+}{}
+
+// SetCommandAuditor sets the auditor every command received by a relayer is
+// passed to, including built in, unknown and refused commands. A nil auditor
+// disables auditing
+func SetCommandAuditor(a CommandAuditor) {
+	auditor.Lock()
+	auditor.a = a
+	auditor.Unlock()
+}
+
+// AuditCommand passes a command, with the relayer's command prefix already
+// removed, to the auditor. Relayers call it for commands they answer
+// themselves
+func AuditCommand(sender, text string, err error) {
+	auditCommand(sender, strings.Fields(text), err)
+}
+
+func auditCommand(sender string, fields []string, err error) {
+	if len(fields) == 0 {
+		return
+	}
+	auditor.RLock()
+	a := auditor.a
+	auditor.RUnlock()
+	if a != nil {
+		a(sender, fields[0], fields[1:], err)
+	}
+}
+
+var commands = struct {
+	sync.RWMutex
+	m map[string]registeredCommand
+}{m: make(map[string]registeredCommand)}
+
+// RegisterCommand registers a command handler under a case insensitive name
+// so that communication relayers which support inbound messages can invoke it
+func RegisterCommand(name string, c Command) {
+	registerCommand(name, c, false)
+}
+
+// RegisterAuthorisedCommand registers a command handler which only runs for
+// senders the relayer has authorised, such as commands exposing balances or
+// placing orders
+func RegisterAuthorisedCommand(name string, c Command) {
+	registerCommand(name, c, true)
+}
+
+func registerCommand(name string, c Command, authorised bool) {
+	commands.Lock()
+	commands.m[strings.ToLower(name)] = registeredCommand{handler: c, authorised: authorised}
+	commands.Unlock()
+}
+
+// DeregisterCommand removes a registered command handler
+func DeregisterCommand(name string) {
+	commands.Lock()
+	delete(commands.m, strings.ToLower(name))
+	commands.Unlock()
+}
+
+// HandleCommand parses text as a command name followed by its arguments, with
+// the relayer's command prefix already removed, and runs the registered
+// command. Commands registered with RegisterAuthorisedCommand are refused.
+// Every command is passed to the command auditor, whether it ran or not
+func HandleCommand(sender, text string) (string, error) {
+	return handleCommand(sender, text, false)
+}
+
+// HandleAuthorisedCommand is HandleCommand for a sender the relayer has
+// authorised, any registered command may be run
+func HandleAuthorisedCommand(sender, text string) (string, error) {
+	return handleCommand(sender, text, true)
+}
+
+func handleCommand(sender, text string, authorised bool) (reply string, err error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", ErrCommandNotFound
+	}
+	defer func() { auditCommand(sender, fields, err) }()
+	commands.RLock()
+	c, ok := commands.m[strings.ToLower(fields[0])]
+	commands.RUnlock()
+	if !ok {
+		return "", ErrCommandNotFound
+	}
+	if c.authorised && !authorised {
+		return "", fmt.Errorf("%s %w", fields[0], ErrCommandUnauthorised)
+	}
+	return c.handler(sender, fields[1:])
+}
+
+// Commands returns the sorted names of the registered commands
+func Commands() []string {
+	commands.RLock()
+	names := make([]string, 0, len(commands.m))
+	for name := range commands.m {
+		names = append(names, name)
+	}
+	commands.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// RunCommand answers a command message with the relayer's command prefix
+// already removed. The built in help, status and settings commands are
+// answered here, settings describes the relayer, and any other command is
+// passed to HandleCommand, or HandleAuthorisedCommand when the relayer has
+// authorised the sender. Every command is audited. The returned string is
+// replied to the sender
+func (b *Base) RunCommand(sender, text, settings string, authorised bool) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "Command not recognized, use " + CmdHelp + " to list commands"
+	}
+	switch strings.ToLower(fields[0]) {
+	case CmdHelp:
+		auditCommand(sender, fields, nil)
+		return b.helpText()
+	case CmdStatus:
+		auditCommand(sender, fields, nil)
+		return b.GetStatus()
+	case CmdSettings:
+		auditCommand(sender, fields, nil)
+		return settings
+	}
+	reply, err := handleCommand(sender, text, authorised)
+	if errors.Is(err, ErrCommandNotFound) {
+		return fmt.Sprintf("Command %s not recognized, use %s to list commands", fields[0], CmdHelp)
+	}
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	return reply
+}
+
+// helpText lists the built in and registered commands
+func (b *Base) helpText() string {
+	var sb strings.Builder
+	sb.WriteString("GoCryptoTrader " + b.Name + " bot, current commands are:\n")
+	sb.WriteString(CmdStatus + " - Displays the status of the bot\n")
+	sb.WriteString(CmdHelp + " - Displays current command list\n")
+	sb.WriteString(CmdSettings + " - Displays current bot settings")
+	for _, name := range Commands() {
+		sb.WriteString("\n" + name)
+	}
+	return sb.String()
+}
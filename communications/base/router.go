@@ -0,0 +1,188 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+// Severity ranks events so relayers can subscribe to the events which matter
+// to them
+type Severity uint8
+
+// Event severities from least to most severe
+const (
+	SeverityInfo Severity = iota
+	SeverityTrade
+	SeverityWarning
+	SeverityCritical
+)
+
+// DigestEventType is the type of the summary of batched events
+const DigestEventType = "digest"
+
+// maxDigestLines limits the events listed in a digest
+const maxDigestLines = 50
+
+// ErrInvalidSeverity is returned when a severity name is not recognised
+var ErrInvalidSeverity = errors.New("invalid event severity")
+
+// String implements the stringer interface
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityTrade:
+		return "trade"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("severity(%d)", uint8(s))
+}
+
+// ParseSeverity converts a case insensitive severity name to a Severity, an
+// empty name is info
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "info":
+		return SeverityInfo, nil
+	case "trade":
+		return SeverityTrade, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "critical":
+		return SeverityCritical, nil
+	}
+	return SeverityInfo, fmt.Errorf("%w %q", ErrInvalidSeverity, s)
+}
+
+// Router applies the event routing configuration to the events pushed to each
+// relayer. Relayers without a configured route receive every event subject to
+// deduplication
+type Router struct {
+	m                   sync.Mutex
+	deduplicationWindow time.Duration
+	routes              map[string]*route
+}
+
+// route holds the rules and state of a single relayer
+type route struct {
+	eventTypes     []string
+	minSeverity    Severity
+	digestSeverity Severity
+	maxEvents      int
+	interval       time.Duration
+	sent           []time.Time
+	seen           map[string]time.Time
+	batched        []Event
+}
+
+// NewRouter returns a router for the event routing configuration
+func NewRouter(cfg *config.EventRoutingConfig) (*Router, error) {
+	r := &Router{
+		deduplicationWindow: cfg.DeduplicationWindow,
+		routes:              make(map[string]*route),
+	}
+	for i := range cfg.Routes {
+		minSeverity, err := ParseSeverity(cfg.Routes[i].MinSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("relayer %s minimum: %w", cfg.Routes[i].Relayer, err)
+		}
+		digestSeverity, err := ParseSeverity(cfg.Routes[i].DigestSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("relayer %s digest: %w", cfg.Routes[i].Relayer, err)
+		}
+		r.routes[strings.ToLower(cfg.Routes[i].Relayer)] = &route{
+			eventTypes:     cfg.Routes[i].EventTypes,
+			minSeverity:    minSeverity,
+			digestSeverity: digestSeverity,
+			maxEvents:      cfg.Routes[i].MaxEvents,
+			interval:       cfg.Routes[i].RateLimitInterval,
+			seen:           make(map[string]time.Time),
+		}
+	}
+	return r, nil
+}
+
+// Route returns whether the event should be pushed to the relayer now. Events
+// which are not pushed are either dropped or batched for the next digest
+func (r *Router) Route(relayer string, e Event, now time.Time) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	rt, ok := r.routes[strings.ToLower(relayer)]
+	if !ok {
+		rt = &route{seen: make(map[string]time.Time)}
+		r.routes[strings.ToLower(relayer)] = rt
+	}
+
+	if len(rt.eventTypes) > 0 && !common.StringDataCompareInsensitive(rt.eventTypes, e.Type) {
+		return false
+	}
+	if e.Severity < rt.minSeverity {
+		return false
+	}
+	if r.deduplicationWindow > 0 {
+		for k, v := range rt.seen {
+			if now.Sub(v) >= r.deduplicationWindow {
+				delete(rt.seen, k)
+			}
+		}
+		key := e.Type + "\x00" + e.Message
+		if _, ok = rt.seen[key]; ok {
+			return false
+		}
+		rt.seen[key] = now
+	}
+	if e.Severity < rt.digestSeverity {
+		rt.batched = append(rt.batched, e)
+		return false
+	}
+	if rt.maxEvents > 0 && e.Severity < SeverityCritical {
+		var i int
+		for i < len(rt.sent) && now.Sub(rt.sent[i]) >= rt.interval {
+			i++
+		}
+		rt.sent = rt.sent[i:]
+		if len(rt.sent) >= rt.maxEvents {
+			rt.batched = append(rt.batched, e)
+			return false
+		}
+		rt.sent = append(rt.sent, now)
+	}
+	return true
+}
+
+// Digest returns a summary of the events batched for the relayer since the
+// last digest, false is returned when no events were batched
+func (r *Router) Digest(relayer string) (Event, bool) {
+	r.m.Lock()
+	rt, ok := r.routes[strings.ToLower(relayer)]
+	if !ok || len(rt.batched) == 0 {
+		r.m.Unlock()
+		return Event{}, false
+	}
+	batched := rt.batched
+	rt.batched = nil
+	r.m.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d events since the last digest", len(batched)))
+	for i := range batched {
+		if i == maxDigestLines {
+			sb.WriteString(fmt.Sprintf("\n... and %d more", len(batched)-maxDigestLines))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("\n[%s] %s: %s",
+			batched[i].Severity,
+			batched[i].Type,
+			batched[i].Message))
+	}
+	return Event{Type: DigestEventType, Message: sb.String()}, true
+}
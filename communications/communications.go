@@ -2,18 +2,25 @@ package communications
 
 import (
 	"errors"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/discord"
+	"github.com/thrasher-corp/gocryptotrader/communications/matrix"
 	"github.com/thrasher-corp/gocryptotrader/communications/slack"
 	"github.com/thrasher-corp/gocryptotrader/communications/smsglobal"
 	"github.com/thrasher-corp/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-corp/gocryptotrader/communications/telegram"
+	"github.com/thrasher-corp/gocryptotrader/communications/webhook"
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // Communications is the overarching type across the communications packages
 type Communications struct {
 	base.IComm
+	router         *base.Router
+	digestInterval time.Duration
 }
 
 // NewComm sets up and returns a pointer to a Communications object
@@ -23,6 +30,15 @@ func NewComm(cfg *config.CommunicationsConfig) (*Communications, error) {
 	}
 
 	var comm Communications
+	if cfg.EventRouting.Enabled {
+		router, err := base.NewRouter(&cfg.EventRouting)
+		if err != nil {
+			return nil, err
+		}
+		comm.router = router
+		comm.digestInterval = cfg.EventRouting.DigestInterval
+	}
+
 	if cfg.TelegramConfig.Enabled {
 		Telegram := new(telegram.Telegram)
 		Telegram.Setup(cfg)
@@ -47,6 +63,81 @@ func NewComm(cfg *config.CommunicationsConfig) (*Communications, error) {
 		comm.IComm = append(comm.IComm, Slack)
 	}
 
+	if cfg.WebhookConfig.Enabled {
+		Webhook := new(webhook.Webhook)
+		Webhook.Setup(cfg)
+		comm.IComm = append(comm.IComm, Webhook)
+	}
+
+	if cfg.DiscordConfig.Enabled {
+		Discord := new(discord.Discord)
+		Discord.Setup(cfg)
+		comm.IComm = append(comm.IComm, Discord)
+	}
+
+	if cfg.MatrixConfig.Enabled {
+		Matrix := new(matrix.Matrix)
+		Matrix.Setup(cfg)
+		comm.IComm = append(comm.IComm, Matrix)
+	}
+
 	comm.Setup()
 	return &comm, nil
 }
+
+// PushEvent pushes an event to the enabled and connected relayers whose
+// routing rules accept it, every relayer receives it when routing is disabled
+func (c *Communications) PushEvent(event base.Event) {
+	if c.router == nil {
+		c.IComm.PushEvent(event)
+		return
+	}
+	now := time.Now()
+	for i := range c.IComm {
+		if !c.IComm[i].IsEnabled() || !c.IComm[i].IsConnected() {
+			continue
+		}
+		if !c.router.Route(c.IComm[i].GetName(), event, now) {
+			continue
+		}
+		pushEvent(c.IComm[i], event)
+	}
+}
+
+// PushDigests pushes each enabled and connected relayer a summary of the
+// events batched for it since the last digest
+func (c *Communications) PushDigests() {
+	if c.router == nil {
+		return
+	}
+	for i := range c.IComm {
+		if !c.IComm[i].IsEnabled() || !c.IComm[i].IsConnected() {
+			continue
+		}
+		if digest, ok := c.router.Digest(c.IComm[i].GetName()); ok {
+			pushEvent(c.IComm[i], digest)
+		}
+	}
+}
+
+// DigestInterval returns how often digests are pushed, zero when event
+// routing is disabled
+func (c *Communications) DigestInterval() time.Duration {
+	return c.digestInterval
+}
+
+// Shutdown stops the background workers of relayers which run them
+func (c *Communications) Shutdown() {
+	for i := range c.IComm {
+		if s, ok := c.IComm[i].(interface{ Shutdown() }); ok {
+			s.Shutdown()
+		}
+	}
+}
+
+func pushEvent(comm base.ICommunicate, event base.Event) {
+	if err := comm.PushEvent(event); err != nil {
+		log.Errorf(log.CommunicationMgr, "Communications error - PushEvent() in package %s with %v. Err %s",
+			comm.GetName(), event, err)
+	}
+}
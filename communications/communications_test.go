@@ -1,8 +1,10 @@
 package communications
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 )
 
@@ -27,3 +29,51 @@ func TestNewComm(t *testing.T) {
 			len(communications.IComm))
 	}
 }
+
+// fakeRelayer records the events pushed to it
+type fakeRelayer struct {
+	base.Base
+	events []base.Event
+}
+
+func (f *fakeRelayer) Setup(*config.CommunicationsConfig) {}
+func (f *fakeRelayer) Connect() error                     { return nil }
+func (f *fakeRelayer) PushEvent(e base.Event) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
+func TestPushEventRouting(t *testing.T) {
+	cfg := config.CommunicationsConfig{EventRouting: config.EventRoutingConfig{
+		Enabled: true,
+		Routes:  []config.EventRouteConfig{{Relayer: "sms", MinSeverity: "urgent"}},
+	}}
+	cfg.SMSGlobalConfig.Enabled = true
+	if _, err := NewComm(&cfg); !errors.Is(err, base.ErrInvalidSeverity) {
+		t.Errorf("expected %v, received %v", base.ErrInvalidSeverity, err)
+	}
+
+	router, err := base.NewRouter(&config.EventRoutingConfig{
+		Routes: []config.EventRouteConfig{{Relayer: "sms", MinSeverity: "critical", DigestSeverity: "critical"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sms := &fakeRelayer{Base: base.Base{Name: "SMS", Enabled: true, Connected: true}}
+	slack := &fakeRelayer{Base: base.Base{Name: "Slack", Enabled: true, Connected: true}}
+	comm := Communications{IComm: base.IComm{sms, slack}, router: router}
+
+	comm.PushEvent(base.Event{Type: "order", Severity: base.SeverityTrade, Message: "filled"})
+	comm.PushEvent(base.Event{Type: "order", Severity: base.SeverityCritical, Message: "kill switch"})
+	if len(sms.events) != 1 || sms.events[0].Message != "kill switch" {
+		t.Errorf("expected only the critical event to be pushed to SMS, received %+v", sms.events)
+	}
+	if len(slack.events) != 2 {
+		t.Errorf("expected every event to be pushed to Slack, received %+v", slack.events)
+	}
+
+	comm.PushDigests()
+	if len(sms.events) != 1 || len(slack.events) != 2 {
+		t.Error("expected no digests for events below the minimum severity")
+	}
+}
@@ -0,0 +1,213 @@
+// Package discord is used to push events to and run commands from a Discord
+// channel using the bot API defined in
+// https://discord.com/developers/docs/reference
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Setup takes in a Discord configuration and sets the bot token and channel
+func (d *Discord) Setup(cfg *config.CommunicationsConfig) {
+	d.Name = cfg.DiscordConfig.Name
+	d.Enabled = cfg.DiscordConfig.Enabled
+	d.Verbose = cfg.DiscordConfig.Verbose
+	d.Token = cfg.DiscordConfig.Token
+	d.ChannelID = cfg.DiscordConfig.ChannelID
+	d.AuthorisedUsers = cfg.DiscordConfig.AuthorisedUsers
+}
+
+// Connect verifies the bot token and starts polling the channel for commands
+func (d *Discord) Connect() error {
+	if err := d.initialise(); err != nil {
+		return err
+	}
+
+	log.Debugln(log.CommunicationMgr, "Discord: Connected successfully!")
+	d.Connected = true
+	go d.pollerStart()
+	return nil
+}
+
+// PushEvent sends an event to the channel
+func (d *Discord) PushEvent(event base.Event) error {
+	return d.SendMessage(fmt.Sprintf("Type: %s Message: %s",
+		event.Type, event.Message))
+}
+
+// SendMessage sends a message to the channel, messages over the Discord
+// length limit are truncated
+func (d *Discord) SendMessage(text string) error {
+	if r := []rune(text); len(r) > maxMessageLength {
+		text = string(r[:maxMessageLength-3]) + "..."
+	}
+	body, err := json.Marshal(map[string]string{"content": text})
+	if err != nil {
+		return err
+	}
+	err = d.SendHTTPRequest(http.MethodPost,
+		fmt.Sprintf(pathChannelMessages, url.PathEscape(d.ChannelID)),
+		body,
+		nil)
+	if err != nil {
+		return err
+	}
+	if d.Verbose {
+		log.Debugf(log.CommunicationMgr, "Discord: Sent '%s'\n", text)
+	}
+	return nil
+}
+
+// initialise resolves the bot token, checks it is valid and skips channel
+// messages sent before connecting
+func (d *Discord) initialise() error {
+	if d.apiURL == "" {
+		d.apiURL = apiURL
+	}
+	if d.client == nil {
+		d.client = &http.Client{Timeout: time.Second * 30}
+	}
+	token, err := config.ResolveSecret(d.Token)
+	if err != nil {
+		return err
+	}
+	d.token = token
+
+	var bot User
+	if err = d.SendHTTPRequest(http.MethodGet, pathCurrentUser, nil, &bot); err != nil {
+		return err
+	}
+	d.botID = bot.ID
+
+	messages, err := d.getMessages(url.Values{"limit": {"1"}})
+	if err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		d.lastMessageID = messages[0].ID
+	}
+	return nil
+}
+
+// pollerStart polls the channel for new commands
+func (d *Discord) pollerStart() {
+	for {
+		if err := d.poll(); err != nil {
+			log.Errorln(log.CommunicationMgr, err)
+			time.Sleep(ErrWaiter)
+			continue
+		}
+		time.Sleep(PollInterval)
+	}
+}
+
+// poll handles the messages sent to the channel since the last poll
+func (d *Discord) poll() error {
+	params := url.Values{"limit": {strconv.Itoa(maxMessagesLimit)}}
+	if d.lastMessageID != "" {
+		params.Set("after", d.lastMessageID)
+	}
+	messages, err := d.getMessages(params)
+	if err != nil {
+		return err
+	}
+	// Messages are returned newest first
+	for i := len(messages) - 1; i >= 0; i-- {
+		d.lastMessageID = messages[i].ID
+		if messages[i].Author.Bot || messages[i].Author.ID == d.botID ||
+			!strings.HasPrefix(messages[i].Content, commandPrefix) {
+			continue
+		}
+		if err = d.HandleMessage(&messages[i]); err != nil {
+			log.Errorf(log.CommunicationMgr, "Discord: Unable to handle message. Error: %s\n", err)
+		}
+	}
+	return nil
+}
+
+// HandleMessage runs the command in a message and replies with the result
+func (d *Discord) HandleMessage(m *Message) error {
+	if d.Verbose {
+		log.Debugf(log.CommunicationMgr, "Discord: Received message from %s: %s\n", m.Author.ID, m.Content)
+	}
+	return d.SendMessage(d.RunCommand(d.Name+":"+m.Author.ID,
+		strings.TrimPrefix(m.Content, commandPrefix),
+		d.settings(),
+		d.isAuthorised(m.Author.ID)))
+}
+
+// isAuthorised returns whether the user may run commands restricted to
+// authorised users, nobody is authorised when AuthorisedUsers is empty
+func (d *Discord) isAuthorised(userID string) bool {
+	for i := range d.AuthorisedUsers {
+		if d.AuthorisedUsers[i] == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// settings describes the relayer for the settings command
+func (d *Discord) settings() string {
+	return fmt.Sprintf("Name: %s\nChannel: %s\nVerbose: %v\nAuthorised users: %d",
+		d.Name, d.ChannelID, d.Verbose, len(d.AuthorisedUsers))
+}
+
+// getMessages returns channel messages matching the query parameters
+func (d *Discord) getMessages(params url.Values) ([]Message, error) {
+	var messages []Message
+	path := fmt.Sprintf(pathChannelMessages, url.PathEscape(d.ChannelID)) + "?" + params.Encode()
+	err := d.SendHTTPRequest(http.MethodGet, path, nil, &messages)
+	return messages, err
+}
+
+// SendHTTPRequest sends an authenticated HTTP request
+func (d *Discord) SendHTTPRequest(method, path string, data []byte, result interface{}) error {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, d.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+d.token)
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var e apiError
+		if json.Unmarshal(contents, &e) == nil && e.Message != "" {
+			return fmt.Errorf("%w %s: %s", errUnexpectedStatus, resp.Status, e.Message)
+		}
+		return fmt.Errorf("%w %s", errUnexpectedStatus, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(contents, result)
+}
@@ -0,0 +1,201 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+const (
+	testToken   = "token"
+	testChannel = "1234"
+	testBotID   = "1"
+)
+
+// fakeAPI serves the Discord endpoints used by the relayer
+type fakeAPI struct {
+	sync.Mutex
+	messages []Message
+	sent     []string
+	after    string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.Header.Get("Authorization") != "Bot "+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"401: Unauthorized","code":0}`))
+		return
+	}
+	switch {
+	case r.URL.Path == pathCurrentUser:
+		_ = json.NewEncoder(w).Encode(&User{ID: testBotID, Bot: true})
+	case r.URL.Path == "/channels/"+testChannel+"/messages" && r.Method == http.MethodGet:
+		f.after = r.URL.Query().Get("after")
+		_ = json.NewEncoder(w).Encode(f.messages)
+	case r.URL.Path == "/channels/"+testChannel+"/messages" && r.Method == http.MethodPost:
+		var m Message
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		f.sent = append(f.sent, m.Content)
+		_ = json.NewEncoder(w).Encode(&m)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeAPI) setMessages(m []Message) {
+	f.Lock()
+	f.messages = m
+	f.Unlock()
+}
+
+func (f *fakeAPI) sentMessages() []string {
+	f.Lock()
+	defer f.Unlock()
+	return append([]string(nil), f.sent...)
+}
+
+func newDiscord(srv *httptest.Server, token string) *Discord {
+	var d Discord
+	d.Setup(&config.CommunicationsConfig{DiscordConfig: config.DiscordConfig{
+		Name:            "Discord",
+		Enabled:         true,
+		Token:           token,
+		ChannelID:       testChannel,
+		AuthorisedUsers: []string{"10"},
+	}})
+	d.apiURL = srv.URL
+	return &d
+}
+
+func TestInitialise(t *testing.T) {
+	t.Parallel()
+	f := &fakeAPI{messages: []Message{{ID: "100", Content: "/status"}}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	d := newDiscord(srv, "bad")
+	if err := d.initialise(); !errors.Is(err, errUnexpectedStatus) {
+		t.Errorf("expected %v, received %v", errUnexpectedStatus, err)
+	}
+
+	d = newDiscord(srv, testToken)
+	if err := d.initialise(); err != nil {
+		t.Fatal(err)
+	}
+	if d.botID != testBotID || d.lastMessageID != "100" {
+		t.Errorf("unexpected bot %s and last message %s", d.botID, d.lastMessageID)
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	f := new(fakeAPI)
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	d := newDiscord(srv, testToken)
+	if err := d.initialise(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.PushEvent(base.Event{Type: "order", Message: "filled"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SendMessage(strings.Repeat("a", maxMessageLength+1)); err != nil {
+		t.Fatal(err)
+	}
+	sent := f.sentMessages()
+	if len(sent) != 2 || sent[0] != "Type: order Message: filled" {
+		t.Fatalf("unexpected messages sent %v", sent)
+	}
+	if len(sent[1]) != maxMessageLength || !strings.HasSuffix(sent[1], "...") {
+		t.Error("expected long message to be truncated")
+	}
+}
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+	f := new(fakeAPI)
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	d := newDiscord(srv, testToken)
+	if err := d.initialise(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.setMessages([]Message{
+		{ID: "105", Author: User{ID: "11"}, Content: "/status"},
+		{ID: "104", Author: User{ID: testBotID, Bot: true}, Content: "/help"},
+		{ID: "103", Author: User{ID: "10"}, Content: "hello"},
+		{ID: "102", Author: User{ID: "10"}, Content: "/settings"},
+	})
+	if err := d.poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.lastMessageID != "105" {
+		t.Errorf("expected the newest message to be recorded, received %s", d.lastMessageID)
+	}
+	if sent := f.sentMessages(); len(sent) != 2 || sent[0] != d.settings() || sent[1] != d.GetStatus() {
+		t.Errorf("expected replies to user commands only, received %v", sent)
+	}
+
+	f.setMessages(nil)
+	if err := d.poll(); err != nil {
+		t.Fatal(err)
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.after != "105" {
+		t.Errorf("expected messages after the last message, received %s", f.after)
+	}
+}
+
+func TestHandleMessageAuthorisation(t *testing.T) {
+	t.Parallel()
+	base.RegisterAuthorisedCommand("discordsecret", func(string, []string) (string, error) {
+		return "hidden", nil
+	})
+	defer base.DeregisterCommand("discordsecret")
+	f := new(fakeAPI)
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	d := newDiscord(srv, testToken)
+	if err := d.initialise(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []Message{
+		{Author: User{ID: "11"}, Content: "/discordsecret"},
+		{Author: User{ID: "10"}, Content: "/discordsecret"},
+	} {
+		if err := d.HandleMessage(&m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Nobody is authorised when no users are listed
+	d.AuthorisedUsers = nil
+	if err := d.HandleMessage(&Message{Author: User{ID: "10"}, Content: "/discordsecret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := f.sentMessages()
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 replies, received %v", sent)
+	}
+	if !strings.Contains(sent[0], base.ErrCommandUnauthorised.Error()) {
+		t.Errorf("expected %v for an unlisted user, received %q", base.ErrCommandUnauthorised, sent[0])
+	}
+	if sent[1] != "hidden" {
+		t.Errorf("expected the command to run for an authorised user, received %q", sent[1])
+	}
+	if !strings.Contains(sent[2], base.ErrCommandUnauthorised.Error()) {
+		t.Errorf("expected %v without authorised users, received %q", base.ErrCommandUnauthorised, sent[2])
+	}
+}
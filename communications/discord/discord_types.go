@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const (
+	apiURL = "https://discord.com/api/v10"
+
+	pathCurrentUser     = "/users/@me"
+	pathChannelMessages = "/channels/%s/messages"
+
+	commandPrefix    = "/"
+	maxMessageLength = 2000
+	maxMessagesLimit = 100
+)
+
+var (
+	// ErrWaiter is the default timer to wait if an err occurs
+	// before retrying after successfully connecting
+	ErrWaiter = time.Second * 30
+	// PollInterval is the time between checks of the channel for commands
+	PollInterval = time.Second * 2
+
+	errUnexpectedStatus = errors.New("unexpected Discord response status")
+)
+
+// Discord is the overarching type across this package
+type Discord struct {
+	base.Base
+	Token           string
+	ChannelID       string
+	AuthorisedUsers []string
+	apiURL          string
+	token           string
+	client          *http.Client
+	botID           string
+	lastMessageID   string
+}
+
+// User holds Discord user information
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+// Message holds a Discord channel message
+type Message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	Author    User   `json:"author"`
+	Content   string `json:"content"`
+}
+
+// apiError is returned by the API when a request fails
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
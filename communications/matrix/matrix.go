@@ -0,0 +1,231 @@
+// Package matrix is used to push events to and run commands from a Matrix
+// room using the client-server API defined in
+// https://spec.matrix.org/latest/client-server-api/
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Setup takes in a Matrix configuration and sets the homeserver, access token
+// and room
+func (m *Matrix) Setup(cfg *config.CommunicationsConfig) {
+	m.Name = cfg.MatrixConfig.Name
+	m.Enabled = cfg.MatrixConfig.Enabled
+	m.Verbose = cfg.MatrixConfig.Verbose
+	m.HomeserverURL = strings.TrimSuffix(cfg.MatrixConfig.HomeserverURL, "/")
+	m.AccessToken = cfg.MatrixConfig.AccessToken
+	m.RoomID = cfg.MatrixConfig.RoomID
+	m.AuthorisedUsers = cfg.MatrixConfig.AuthorisedUsers
+}
+
+// Connect verifies the access token and starts syncing the room for commands
+func (m *Matrix) Connect() error {
+	if err := m.initialise(); err != nil {
+		return err
+	}
+
+	log.Debugln(log.CommunicationMgr, "Matrix: Connected successfully!")
+	m.Connected = true
+	go m.pollerStart()
+	return nil
+}
+
+// PushEvent sends an event to the room
+func (m *Matrix) PushEvent(event base.Event) error {
+	return m.SendMessage(fmt.Sprintf("Type: %s Message: %s",
+		event.Type, event.Message))
+}
+
+// SendMessage sends a notice to the room
+func (m *Matrix) SendMessage(text string) error {
+	body, err := json.Marshal(&EventContent{MsgType: msgTypeNotice, Body: text})
+	if err != nil {
+		return err
+	}
+	txnID := strconv.FormatInt(atomic.AddInt64(&m.txnID, 1), 10)
+	err = m.SendHTTPRequest(http.MethodPut,
+		fmt.Sprintf(pathSendMessage, url.PathEscape(m.RoomID), txnID),
+		body,
+		nil)
+	if err != nil {
+		return err
+	}
+	if m.Verbose {
+		log.Debugf(log.CommunicationMgr, "Matrix: Sent '%s'\n", text)
+	}
+	return nil
+}
+
+// initialise resolves the access token, checks it is valid and skips room
+// events sent before connecting so earlier commands are not run again
+func (m *Matrix) initialise() error {
+	if m.client == nil {
+		m.client = &http.Client{Timeout: SyncTimeout * 2}
+	}
+	// Transaction IDs must be unique per access token across restarts
+	atomic.StoreInt64(&m.txnID, time.Now().UnixNano())
+	token, err := config.ResolveSecret(m.AccessToken)
+	if err != nil {
+		return err
+	}
+	m.token = token
+
+	var user WhoAmI
+	if err = m.SendHTTPRequest(http.MethodGet, pathWhoAmI, nil, &user); err != nil {
+		return err
+	}
+	m.userID = user.UserID
+
+	resp, err := m.sync(0)
+	if err != nil {
+		return err
+	}
+	m.nextBatch = resp.NextBatch
+	return nil
+}
+
+// pollerStart long polls the homeserver for new commands
+func (m *Matrix) pollerStart() {
+	for {
+		if err := m.poll(); err != nil {
+			log.Errorln(log.CommunicationMgr, err)
+			time.Sleep(ErrWaiter)
+		}
+	}
+}
+
+// poll handles the room messages received since the previous sync
+func (m *Matrix) poll() error {
+	resp, err := m.sync(SyncTimeout)
+	if err != nil {
+		return err
+	}
+	m.nextBatch = resp.NextBatch
+	room, ok := resp.Rooms.Join[m.RoomID]
+	if !ok {
+		return nil
+	}
+	for i := range room.Timeline.Events {
+		e := &room.Timeline.Events[i]
+		if e.Type != eventTypeMessage ||
+			e.Sender == m.userID ||
+			e.Content.MsgType != msgTypeText ||
+			!strings.HasPrefix(e.Content.Body, commandPrefix) {
+			continue
+		}
+		if err = m.HandleMessage(e); err != nil {
+			log.Errorf(log.CommunicationMgr, "Matrix: Unable to handle message. Error: %s\n", err)
+		}
+	}
+	return nil
+}
+
+// HandleMessage runs the command in a message and replies with the result
+func (m *Matrix) HandleMessage(e *Event) error {
+	if m.Verbose {
+		log.Debugf(log.CommunicationMgr, "Matrix: Received message from %s: %s\n", e.Sender, e.Content.Body)
+	}
+	return m.SendMessage(m.RunCommand(m.Name+":"+e.Sender,
+		strings.TrimPrefix(e.Content.Body, commandPrefix),
+		m.settings(),
+		m.isAuthorised(e.Sender)))
+}
+
+// isAuthorised returns whether the user may run commands restricted to
+// authorised users, nobody is authorised when AuthorisedUsers is empty
+func (m *Matrix) isAuthorised(userID string) bool {
+	for i := range m.AuthorisedUsers {
+		if m.AuthorisedUsers[i] == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// settings describes the relayer for the settings command
+func (m *Matrix) settings() string {
+	return fmt.Sprintf("Name: %s\nHomeserver: %s\nRoom: %s\nVerbose: %v\nAuthorised users: %d",
+		m.Name, m.HomeserverURL, m.RoomID, m.Verbose, len(m.AuthorisedUsers))
+}
+
+// sync returns the room messages since the previous sync, waiting up to
+// timeout for new events
+func (m *Matrix) sync(timeout time.Duration) (*SyncResponse, error) {
+	filter, err := json.Marshal(map[string]interface{}{
+		"room": map[string]interface{}{
+			"rooms":    []string{m.RoomID},
+			"timeline": map[string]interface{}{"types": []string{eventTypeMessage}},
+		},
+		"presence":     map[string]interface{}{"types": []string{}},
+		"account_data": map[string]interface{}{"types": []string{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("filter", string(filter))
+	params.Set("timeout", strconv.FormatInt(timeout.Milliseconds(), 10))
+	if m.nextBatch != "" {
+		params.Set("since", m.nextBatch)
+	}
+
+	var resp SyncResponse
+	err = m.SendHTTPRequest(http.MethodGet, pathSync+"?"+params.Encode(), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SendHTTPRequest sends an authenticated HTTP request to the homeserver
+func (m *Matrix) SendHTTPRequest(method, path string, data []byte, result interface{}) error {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, m.HomeserverURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.token)
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var e apiError
+		if json.Unmarshal(contents, &e) == nil && e.ErrCode != "" {
+			return fmt.Errorf("%w %s: %s %s", errUnexpectedStatus, resp.Status, e.ErrCode, e.Error)
+		}
+		return fmt.Errorf("%w %s", errUnexpectedStatus, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(contents, result)
+}
@@ -0,0 +1,213 @@
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+const (
+	testToken  = "token"
+	testRoomID = "!room:localhost"
+	testUserID = "@gct:localhost"
+)
+
+// fakeHomeserver serves the Matrix endpoints used by the relayer
+type fakeHomeserver struct {
+	sync.Mutex
+	events []Event
+	since  string
+	sent   []EventContent
+	txnIDs map[string]bool
+}
+
+func (f *fakeHomeserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"Invalid access token"}`))
+		return
+	}
+	sendPrefix := "/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/"
+	switch {
+	case r.URL.Path == pathWhoAmI:
+		_ = json.NewEncoder(w).Encode(&WhoAmI{UserID: testUserID})
+	case r.URL.Path == pathSync:
+		f.since = r.URL.Query().Get("since")
+		var resp SyncResponse
+		resp.NextBatch = "batch" + f.since
+		resp.Rooms.Join = map[string]struct {
+			Timeline struct {
+				Events []Event `json:"events"`
+			} `json:"timeline"`
+		}{}
+		room := resp.Rooms.Join[testRoomID]
+		room.Timeline.Events = f.events
+		resp.Rooms.Join[testRoomID] = room
+		_ = json.NewEncoder(w).Encode(&resp)
+	case strings.HasPrefix(r.URL.Path, sendPrefix) && r.Method == http.MethodPut:
+		txnID := strings.TrimPrefix(r.URL.Path, sendPrefix)
+		if f.txnIDs[txnID] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.txnIDs[txnID] = true
+		var c EventContent
+		_ = json.NewDecoder(r.Body).Decode(&c)
+		f.sent = append(f.sent, c)
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeHomeserver) setEvents(e []Event) {
+	f.Lock()
+	f.events = e
+	f.Unlock()
+}
+
+func (f *fakeHomeserver) sentMessages() []EventContent {
+	f.Lock()
+	defer f.Unlock()
+	return append([]EventContent(nil), f.sent...)
+}
+
+func newMatrix(srv *httptest.Server, token string) *Matrix {
+	var m Matrix
+	m.Setup(&config.CommunicationsConfig{MatrixConfig: config.MatrixConfig{
+		Name:            "Matrix",
+		Enabled:         true,
+		HomeserverURL:   srv.URL + "/",
+		AccessToken:     token,
+		RoomID:          testRoomID,
+		AuthorisedUsers: []string{"@trader:localhost"},
+	}})
+	return &m
+}
+
+func TestInitialise(t *testing.T) {
+	t.Parallel()
+	f := &fakeHomeserver{
+		txnIDs: make(map[string]bool),
+		events: []Event{{Type: eventTypeMessage, Sender: "@trader:localhost", Content: EventContent{MsgType: msgTypeText, Body: "/status"}}},
+	}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	m := newMatrix(srv, "bad")
+	if err := m.initialise(); !errors.Is(err, errUnexpectedStatus) {
+		t.Errorf("expected %v, received %v", errUnexpectedStatus, err)
+	}
+
+	m = newMatrix(srv, testToken)
+	if err := m.initialise(); err != nil {
+		t.Fatal(err)
+	}
+	if m.userID != testUserID || m.nextBatch != "batch" {
+		t.Errorf("unexpected user %s and next batch %s", m.userID, m.nextBatch)
+	}
+	if len(f.sentMessages()) != 0 {
+		t.Error("commands sent before connecting should not be run")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	f := &fakeHomeserver{txnIDs: make(map[string]bool)}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	m := newMatrix(srv, testToken)
+	if err := m.initialise(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := m.PushEvent(base.Event{Type: "order", Message: "filled"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sent := f.sentMessages()
+	if len(sent) != 2 || sent[0].Body != "Type: order Message: filled" || sent[0].MsgType != msgTypeNotice {
+		t.Errorf("unexpected messages sent %+v", sent)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+	f := &fakeHomeserver{txnIDs: make(map[string]bool)}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	m := newMatrix(srv, testToken)
+	if err := m.initialise(); err != nil {
+		t.Fatal(err)
+	}
+
+	f.setEvents([]Event{
+		{Type: eventTypeMessage, Sender: "@trader:localhost", Content: EventContent{MsgType: msgTypeText, Body: "/settings"}},
+		{Type: eventTypeMessage, Sender: testUserID, Content: EventContent{MsgType: msgTypeText, Body: "/help"}},
+		{Type: eventTypeMessage, Sender: "@other:localhost", Content: EventContent{MsgType: msgTypeText, Body: "/status"}},
+		{Type: eventTypeMessage, Sender: "@trader:localhost", Content: EventContent{MsgType: msgTypeNotice, Body: "/status"}},
+		{Type: eventTypeMessage, Sender: "@trader:localhost", Content: EventContent{MsgType: msgTypeText, Body: "hello"}},
+	})
+	if err := m.poll(); err != nil {
+		t.Fatal(err)
+	}
+	if m.nextBatch != "batchbatch" {
+		t.Errorf("expected the next batch to be recorded, received %s", m.nextBatch)
+	}
+	if sent := f.sentMessages(); len(sent) != 2 || sent[0].Body != m.settings() || sent[1].Body != m.GetStatus() {
+		t.Errorf("expected replies to user commands only, received %+v", sent)
+	}
+}
+
+func TestHandleMessageAuthorisation(t *testing.T) {
+	t.Parallel()
+	base.RegisterAuthorisedCommand("matrixsecret", func(string, []string) (string, error) {
+		return "hidden", nil
+	})
+	defer base.DeregisterCommand("matrixsecret")
+	f := &fakeHomeserver{txnIDs: make(map[string]bool)}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	m := newMatrix(srv, testToken)
+	if err := m.initialise(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sender := range []string{"@other:localhost", "@trader:localhost"} {
+		err := m.HandleMessage(&Event{Sender: sender, Content: EventContent{MsgType: msgTypeText, Body: "/matrixsecret"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Nobody is authorised when no users are listed
+	m.AuthorisedUsers = nil
+	err := m.HandleMessage(&Event{Sender: "@trader:localhost", Content: EventContent{MsgType: msgTypeText, Body: "/matrixsecret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := f.sentMessages()
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 replies, received %+v", sent)
+	}
+	if !strings.Contains(sent[0].Body, base.ErrCommandUnauthorised.Error()) {
+		t.Errorf("expected %v for an unlisted user, received %q", base.ErrCommandUnauthorised, sent[0].Body)
+	}
+	if sent[1].Body != "hidden" {
+		t.Errorf("expected the command to run for an authorised user, received %q", sent[1].Body)
+	}
+	if !strings.Contains(sent[2].Body, base.ErrCommandUnauthorised.Error()) {
+		t.Errorf("expected %v without authorised users, received %q", base.ErrCommandUnauthorised, sent[2].Body)
+	}
+}
@@ -0,0 +1,83 @@
+package matrix
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const (
+	pathWhoAmI      = "/_matrix/client/v3/account/whoami"
+	pathSync        = "/_matrix/client/v3/sync"
+	pathSendMessage = "/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
+
+	eventTypeMessage = "m.room.message"
+	msgTypeText      = "m.text"
+	msgTypeNotice    = "m.notice"
+
+	commandPrefix = "/"
+)
+
+var (
+	// ErrWaiter is the default timer to wait if an err occurs
+	// before retrying after successfully connecting
+	ErrWaiter = time.Second * 30
+	// SyncTimeout is how long the homeserver holds a sync request open
+	// waiting for new events
+	SyncTimeout = time.Second * 30
+
+	errUnexpectedStatus = errors.New("unexpected Matrix response status")
+)
+
+// Matrix is the overarching type across this package
+type Matrix struct {
+	base.Base
+	HomeserverURL   string
+	AccessToken     string
+	RoomID          string
+	AuthorisedUsers []string
+	token           string
+	client          *http.Client
+	userID          string
+	nextBatch       string
+	txnID           int64
+}
+
+// WhoAmI holds the user the access token belongs to
+type WhoAmI struct {
+	UserID string `json:"user_id"`
+}
+
+// SyncResponse holds the events received since the previous sync
+type SyncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []Event `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+// Event is a room event
+type Event struct {
+	Type    string       `json:"type"`
+	EventID string       `json:"event_id"`
+	Sender  string       `json:"sender"`
+	Content EventContent `json:"content"`
+}
+
+// EventContent holds the content of a room message event
+type EventContent struct {
+	MsgType string `json:"msgtype"`
+	Body    string `json:"body"`
+}
+
+// apiError is returned by the homeserver when a request fails
+type apiError struct {
+	ErrCode string `json:"errcode"`
+	Error   string `json:"error"`
+}
@@ -40,6 +40,7 @@ type Slack struct {
 
 	TargetChannel     string
 	VerificationToken string
+	AuthorisedUsers   []string
 
 	TargetChannelID string
 	Details         Response
@@ -63,6 +64,7 @@ func (s *Slack) Setup(cfg *config.CommunicationsConfig) {
 	s.Verbose = cfg.SlackConfig.Verbose
 	s.TargetChannel = cfg.SlackConfig.TargetChannel
 	s.VerificationToken = cfg.SlackConfig.VerificationToken
+	s.AuthorisedUsers = cfg.SlackConfig.AuthorisedUsers
 }
 
 // Connect connects to the service
@@ -375,15 +377,42 @@ func (s *Slack) HandleMessage(msg *Message) error {
 		return errors.New("slack msg is nil")
 	}
 
-	msg.Text = strings.ToLower(msg.Text)
+	// Only the command is case insensitive, arguments such as order IDs are
+	// passed on as sent
+	text := strings.ToLower(msg.Text)
+	sender := s.Name + ":" + msg.User
 	switch {
-	case strings.Contains(msg.Text, cmdStatus):
+	case strings.Contains(text, cmdStatus):
+		base.AuditCommand(sender, strings.TrimPrefix(msg.Text, "!"), nil)
 		return s.WebsocketSend("message", s.GetStatus())
 
-	case strings.Contains(msg.Text, cmdHelp):
+	case strings.Contains(text, cmdHelp):
+		base.AuditCommand(sender, strings.TrimPrefix(msg.Text, "!"), nil)
 		return s.WebsocketSend("message", getHelp)
 
 	default:
-		return s.WebsocketSend("message", "GoCryptoTrader SlackBot - Command Unknown!")
+		handle := base.HandleCommand
+		if s.isAuthorised(msg.User) {
+			handle = base.HandleAuthorisedCommand
+		}
+		reply, err := handle(sender, strings.TrimPrefix(msg.Text, "!"))
+		if errors.Is(err, base.ErrCommandNotFound) {
+			return s.WebsocketSend("message", "GoCryptoTrader SlackBot - Command Unknown!")
+		}
+		if err != nil {
+			return s.WebsocketSend("message", "GoCryptoTrader SlackBot - "+err.Error())
+		}
+		return s.WebsocketSend("message", "GoCryptoTrader SlackBot - "+reply)
+	}
+}
+
+// isAuthorised returns whether the user may run commands restricted to
+// authorised users
+func (s *Slack) isAuthorised(userID string) bool {
+	for i := range s.AuthorisedUsers {
+		if s.AuthorisedUsers[i] == userID {
+			return true
+		}
 	}
+	return false
 }
@@ -303,3 +303,14 @@ func TestHandleMessage(t *testing.T) {
 		t.Error("slack HandleMessage(), Sent message through nil websocket")
 	}
 }
+
+func TestIsAuthorised(t *testing.T) {
+	t.Parallel()
+	var sl Slack
+	sl.Setup(&config.CommunicationsConfig{SlackConfig: config.SlackConfig{
+		AuthorisedUsers: []string{"U1337"},
+	}})
+	if !sl.isAuthorised("U1337") || sl.isAuthorised("U1338") {
+		t.Error("slack isAuthorised() unexpected result")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -64,6 +65,7 @@ func (t *Telegram) Setup(cfg *config.CommunicationsConfig) {
 	t.Enabled = cfg.TelegramConfig.Enabled
 	t.Token = cfg.TelegramConfig.VerificationToken
 	t.Verbose = cfg.TelegramConfig.Verbose
+	t.AuthorisedClients = cfg.TelegramConfig.AuthorisedUsers
 }
 
 // Connect starts an initial connection
@@ -170,19 +172,45 @@ func (t *Telegram) HandleMessages(text string, chatID int64) error {
 		log.Debugf(log.CommunicationMgr, "Telegram: Received message: %s\n", text)
 	}
 
+	sender := t.Name + ":" + strconv.FormatInt(chatID, 10)
 	switch {
 	case strings.Contains(text, cmdHelp):
+		base.AuditCommand(sender, strings.TrimPrefix(text, "/"), nil)
 		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
 
 	case strings.Contains(text, cmdStart):
+		base.AuditCommand(sender, strings.TrimPrefix(text, "/"), nil)
 		return t.SendMessage(fmt.Sprintf("%s: START COMMANDS HERE", talkRoot), chatID)
 
 	case strings.Contains(text, cmdStatus):
+		base.AuditCommand(sender, strings.TrimPrefix(text, "/"), nil)
 		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetStatus()), chatID)
 
 	default:
-		return t.SendMessage(fmt.Sprintf("Command %s not recognized", text), chatID)
+		handle := base.HandleCommand
+		if t.isAuthorised(chatID) {
+			handle = base.HandleAuthorisedCommand
+		}
+		reply, err := handle(sender, strings.TrimPrefix(text, "/"))
+		if errors.Is(err, base.ErrCommandNotFound) {
+			return t.SendMessage(fmt.Sprintf("Command %s not recognized", text), chatID)
+		}
+		if err != nil {
+			return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, err), chatID)
+		}
+		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, reply), chatID)
+	}
+}
+
+// isAuthorised returns whether the user may run commands restricted to
+// authorised users
+func (t *Telegram) isAuthorised(chatID int64) bool {
+	for i := range t.AuthorisedClients {
+		if t.AuthorisedClients[i] == chatID {
+			return true
+		}
 	}
+	return false
 }
 
 // GetUpdates gets new updates via a long poll connection
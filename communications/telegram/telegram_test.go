@@ -113,3 +113,14 @@ func TestSendHTTPRequest(t *testing.T) {
 		t.Error("telegram SendHTTPRequest() error")
 	}
 }
+
+func TestIsAuthorised(t *testing.T) {
+	t.Parallel()
+	var tg Telegram
+	tg.Setup(&config.CommunicationsConfig{TelegramConfig: config.TelegramConfig{
+		AuthorisedUsers: []int64{1337},
+	}})
+	if !tg.isAuthorised(1337) || tg.isAuthorised(1338) {
+		t.Error("telegram isAuthorised() unexpected result")
+	}
+}
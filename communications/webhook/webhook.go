@@ -0,0 +1,249 @@
+// Package webhook posts events as JSON to configurable HTTP endpoints
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// templateFuncs are available to payload templates, json encodes a value so
+// messages can be embedded in JSON payloads
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Setup takes in a webhook configuration and sets the endpoints events are
+// posted to
+func (w *Webhook) Setup(cfg *config.CommunicationsConfig) {
+	w.Name = cfg.WebhookConfig.Name
+	w.Enabled = cfg.WebhookConfig.Enabled
+	w.Verbose = cfg.WebhookConfig.Verbose
+	w.Endpoints = cfg.WebhookConfig.Endpoints
+}
+
+// Connect resolves the endpoint secrets, parses the payload templates and
+// starts a worker per endpoint which posts its queued events
+func (w *Webhook) Connect() error {
+	if len(w.Endpoints) == 0 {
+		return errNoEndpoints
+	}
+
+	endpoints := make([]*endpoint, len(w.Endpoints))
+	for i := range w.Endpoints {
+		e, err := newEndpoint(&w.Endpoints[i])
+		if err != nil {
+			return err
+		}
+		endpoints[i] = e
+	}
+
+	if w.client == nil {
+		w.client = new(http.Client)
+	}
+	w.Shutdown()
+	w.endpoints = endpoints
+	w.shutdown = make(chan struct{})
+	for i := range endpoints {
+		w.wg.Add(1)
+		go w.worker(endpoints[i], w.shutdown)
+	}
+	w.Connected = true
+	return nil
+}
+
+// Shutdown stops the endpoint workers, events still queued are dropped
+func (w *Webhook) Shutdown() {
+	if w.shutdown == nil {
+		return
+	}
+	close(w.shutdown)
+	w.wg.Wait()
+	w.shutdown = nil
+	w.Connected = false
+}
+
+// worker posts the events queued for the endpoint until shutdown, so a slow
+// or failing endpoint does not hold up events for other endpoints or relayers
+func (w *Webhook) worker(e *endpoint, shutdown <-chan struct{}) {
+	defer w.wg.Done()
+	for {
+		select {
+		case p := <-e.queue:
+			if err := w.send(e, p, shutdown); err != nil {
+				log.Errorf(log.CommunicationMgr, "Webhook: %s event not posted. Err: %s", p.Type, err)
+			}
+		case <-shutdown:
+			return
+		}
+	}
+}
+
+// PushEvent queues the event for every endpoint routed its event type, the
+// event is dropped for endpoints whose queue is full
+func (w *Webhook) PushEvent(event base.Event) error {
+	p := Payload{
+		Name:      w.Name,
+		Type:      event.Type,
+		Severity:  event.Severity.String(),
+		Message:   event.Message,
+		Timestamp: time.Now().UTC(),
+	}
+	var errs common.Errors
+	for i := range w.endpoints {
+		if !w.endpoints[i].routes(event.Type) {
+			continue
+		}
+		select {
+		case w.endpoints[i].queue <- &p:
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", w.endpoints[i].url, errQueueFull))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// send posts the payload to the endpoint, retrying failed requests with an
+// exponential backoff, retries stop at shutdown
+func (w *Webhook) send(e *endpoint, p *Payload, shutdown <-chan struct{}) error {
+	body, err := e.payload(p)
+	if err != nil {
+		return fmt.Errorf("%s payload: %w", e.url, err)
+	}
+
+	delay := e.retryDelay
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = w.post(e, p.Type, body)
+		if err == nil {
+			if w.Verbose {
+				log.Debugf(log.CommunicationMgr, "Webhook: %s event posted to %s", p.Type, e.url)
+			}
+			return nil
+		}
+		if !retry || attempt >= e.maxRetries {
+			return fmt.Errorf("%s: %w", e.url, err)
+		}
+		if w.Verbose {
+			log.Debugf(log.CommunicationMgr, "Webhook: %s post failed, retrying in %s. Err: %v", e.url, delay, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-shutdown:
+			return fmt.Errorf("%s: %w", e.url, err)
+		}
+		delay *= 2
+	}
+}
+
+// post sends a single request and returns whether a failure may succeed if
+// retried
+func (w *Webhook) post(e *endpoint, eventType string, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	if common.HTTPUserAgent != "" {
+		req.Header.Set("User-Agent", common.HTTPUserAgent)
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(EventTypeHeader, eventType)
+	if len(e.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(body, e.secret))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		retry := resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError
+		return retry, fmt.Errorf("%w %s", errUnexpectedStatus, resp.Status)
+	}
+	return false, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the payload which
+// receivers compare against the SignatureHeader value
+func Sign(payload, secret []byte) string {
+	return crypto.HexEncodeToString(crypto.GetHMAC(crypto.HashSHA256, payload, secret))
+}
+
+// newEndpoint validates the endpoint configuration, resolves its secret and
+// parses its payload template
+func newEndpoint(cfg *config.WebhookEndpointConfig) (*endpoint, error) {
+	if cfg.URL == "" {
+		return nil, errEndpointURLEmpty
+	}
+	secret, err := config.ResolveSecret(cfg.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("%s secret: %w", cfg.URL, err)
+	}
+	e := &endpoint{
+		url:        cfg.URL,
+		secret:     []byte(secret),
+		headers:    cfg.Headers,
+		timeout:    cfg.Timeout,
+		maxRetries: cfg.MaxRetries,
+		retryDelay: cfg.RetryDelay,
+		queue:      make(chan *Payload, queueSize),
+	}
+	if cfg.Template != "" {
+		e.template, err = template.New(cfg.URL).Funcs(templateFuncs).Parse(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("%s template: %w", cfg.URL, err)
+		}
+	}
+	if len(cfg.EventTypes) > 0 {
+		e.eventTypes = make(map[string]bool, len(cfg.EventTypes))
+		for i := range cfg.EventTypes {
+			e.eventTypes[strings.ToLower(cfg.EventTypes[i])] = true
+		}
+	}
+	return e, nil
+}
+
+// routes returns whether events of the type are posted to the endpoint
+func (e *endpoint) routes(eventType string) bool {
+	return e.eventTypes == nil || e.eventTypes[strings.ToLower(eventType)]
+}
+
+// payload returns the request body for the event, either the templated
+// payload or the default JSON encoding
+func (e *endpoint) payload(p *Payload) ([]byte, error) {
+	if e.template == nil {
+		return json.Marshal(p)
+	}
+	var buf bytes.Buffer
+	err := e.template.Execute(&buf, p)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+// received is a request received by the test server
+type received struct {
+	path      string
+	body      []byte
+	signature string
+	eventType string
+	header    string
+}
+
+// testServer records requests, fails the first failures requests to the
+// /flaky path and holds requests to the /slow path until release is closed
+type testServer struct {
+	sync.Mutex
+	requests []received
+	failures int
+	release  chan struct{}
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	if r.URL.Path == "/slow" {
+		<-s.release
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.requests = append(s.requests, received{
+		path:      r.URL.Path,
+		body:      body,
+		signature: r.Header.Get(SignatureHeader),
+		eventType: r.Header.Get(EventTypeHeader),
+		header:    r.Header.Get("X-Custom"),
+	})
+	switch r.URL.Path {
+	case "/flaky":
+		if s.failures > 0 {
+			s.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	case "/rejected":
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *testServer) received(path string) []received {
+	s.Lock()
+	defer s.Unlock()
+	var r []received
+	for i := range s.requests {
+		if s.requests[i].path == path {
+			r = append(r, s.requests[i])
+		}
+	}
+	return r
+}
+
+// waitFor waits until at least n requests to the path are received
+func (s *testServer) waitFor(t *testing.T, path string, n int) []received {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r := s.received(path)
+		if len(r) >= n || time.Now().After(deadline) {
+			return r
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+}
+
+func newWebhook(t *testing.T, endpoints ...config.WebhookEndpointConfig) *Webhook {
+	t.Helper()
+	for i := range endpoints {
+		endpoints[i].Timeout = time.Second
+		endpoints[i].RetryDelay = time.Millisecond
+	}
+	var w Webhook
+	w.Setup(&config.CommunicationsConfig{WebhookConfig: config.WebhookConfig{
+		Name:      "Webhook",
+		Enabled:   true,
+		Endpoints: endpoints,
+	}})
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	return &w
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var w Webhook
+	if err := w.Connect(); !errors.Is(err, errNoEndpoints) {
+		t.Errorf("expected %v, received %v", errNoEndpoints, err)
+	}
+	w.Endpoints = []config.WebhookEndpointConfig{{}}
+	if err := w.Connect(); !errors.Is(err, errEndpointURLEmpty) {
+		t.Errorf("expected %v, received %v", errEndpointURLEmpty, err)
+	}
+	w.Endpoints[0] = config.WebhookEndpointConfig{URL: "http://localhost", Template: "{{"}
+	if err := w.Connect(); err == nil {
+		t.Error("expected invalid template error")
+	}
+	if w.IsConnected() {
+		t.Error("webhook should not be connected")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	s := new(testServer)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w := newWebhook(t,
+		config.WebhookEndpointConfig{
+			URL:     srv.URL + "/all",
+			Secret:  "secret",
+			Headers: map[string]string{"X-Custom": "custom"},
+		},
+		config.WebhookEndpointConfig{
+			URL:        srv.URL + "/orders",
+			Template:   `{"text":{{json .Message}}}`,
+			EventTypes: []string{"Order"},
+		})
+
+	err := w.PushEvent(base.Event{Type: "order", Message: `order "1" filled`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Shutdown()
+	err = w.PushEvent(base.Event{Type: "withdrawal", Message: "withdrawal sent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := s.waitFor(t, "/all", 2)
+	if len(all) != 2 {
+		t.Fatalf("expected every event posted, received %d", len(all))
+	}
+	var p Payload
+	if err = json.Unmarshal(all[0].body, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Webhook" || p.Type != "order" || p.Message != `order "1" filled` || p.Timestamp.IsZero() {
+		t.Errorf("unexpected payload %+v", p)
+	}
+	if all[0].signature != Sign(all[0].body, []byte("secret")) ||
+		all[0].eventType != "order" ||
+		all[0].header != "custom" {
+		t.Errorf("unexpected headers %+v", all[0])
+	}
+
+	orders := s.waitFor(t, "/orders", 1)
+	if len(orders) != 1 {
+		t.Fatalf("expected only order events routed, received %d", len(orders))
+	}
+	if string(orders[0].body) != `{"text":"order \"1\" filled"}` {
+		t.Errorf("unexpected templated payload %s", orders[0].body)
+	}
+	if orders[0].signature != "" {
+		t.Error("expected unsigned payload without a secret")
+	}
+}
+
+func TestPushEventRetry(t *testing.T) {
+	t.Parallel()
+	s := &testServer{failures: 2}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w := newWebhook(t,
+		config.WebhookEndpointConfig{URL: srv.URL + "/flaky", MaxRetries: 2},
+		config.WebhookEndpointConfig{URL: srv.URL + "/rejected", MaxRetries: 2})
+	defer w.Shutdown()
+
+	if err := w.PushEvent(base.Event{Type: "order"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.waitFor(t, "/flaky", 3)); n != 3 {
+		t.Errorf("expected failed posts to be retried, received %d attempts", n)
+	}
+	if n := len(s.waitFor(t, "/rejected", 1)); n != 1 {
+		t.Errorf("expected client errors not to be retried, received %d attempts", n)
+	}
+
+	s.Lock()
+	s.failures = 3
+	s.Unlock()
+	if err := w.PushEvent(base.Event{Type: "order"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.waitFor(t, "/flaky", 6)); n != 6 {
+		t.Errorf("expected retries to stop once exhausted, received %d attempts", n)
+	}
+}
+
+func TestPushEventSlowEndpoint(t *testing.T) {
+	t.Parallel()
+	s := &testServer{release: make(chan struct{})}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w := newWebhook(t,
+		config.WebhookEndpointConfig{URL: srv.URL + "/slow", EventTypes: []string{"order"}},
+		config.WebhookEndpointConfig{URL: srv.URL + "/all"})
+	defer w.Shutdown()
+	defer close(s.release)
+
+	start := time.Now()
+	if err := w.PushEvent(base.Event{Type: "order"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PushEvent(base.Event{Type: "withdrawal"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.waitFor(t, "/all", 2)); n != 2 {
+		t.Errorf("expected other endpoints not to wait on a slow endpoint, received %d", n)
+	}
+
+	// the slow endpoint holds one event in flight and queueSize queued
+	var err error
+	for i := 0; i < queueSize+1; i++ {
+		err = w.PushEvent(base.Event{Type: "order"})
+	}
+	errs, ok := err.(common.Errors)
+	if !ok || len(errs) != 1 || !errors.Is(errs[0], errQueueFull) {
+		t.Errorf("expected %v, received %v", errQueueFull, err)
+	}
+	if time.Since(start) > time.Second/2 {
+		t.Error("expected PushEvent not to wait on the endpoint")
+	}
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 signature of the
+	// payload when an endpoint secret is set
+	SignatureHeader = "X-GCT-Signature"
+	// EventTypeHeader holds the type of the event posted
+	EventTypeHeader = "X-GCT-Event"
+
+	contentTypeJSON = "application/json"
+	// queueSize is the number of events buffered per endpoint while earlier
+	// events are being posted or retried
+	queueSize = 100
+)
+
+var (
+	errNoEndpoints      = errors.New("webhook endpoints not set")
+	errEndpointURLEmpty = errors.New("webhook endpoint URL not set")
+	errUnexpectedStatus = errors.New("unexpected webhook response status")
+	errQueueFull        = errors.New("webhook endpoint queue full, event dropped")
+)
+
+// Webhook is the overarching type across this package
+type Webhook struct {
+	base.Base
+	Endpoints []config.WebhookEndpointConfig
+	client    *http.Client
+	endpoints []*endpoint
+	shutdown  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// endpoint is a configured URL events are posted to
+type endpoint struct {
+	url        string
+	secret     []byte
+	headers    map[string]string
+	template   *template.Template
+	eventTypes map[string]bool
+	timeout    time.Duration
+	maxRetries int
+	retryDelay time.Duration
+	queue      chan *Payload
+}
+
+// Payload is the default JSON body posted for an event and the data passed
+// to payload templates
+type Payload struct {
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
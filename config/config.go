@@ -23,9 +23,11 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/rebalance"
 )
 
 // GetCurrencyConfig returns currency configurations
@@ -201,6 +203,47 @@ func (c *Config) UpdateCryptocurrencyProviderConfig(config CryptocurrencyProvide
 	m.Unlock()
 }
 
+// GetRemoteControlConfig returns the remote control configuration
+func (c *Config) GetRemoteControlConfig() RemoteControlConfig {
+	m.Lock()
+	rc := c.RemoteControl
+	m.Unlock()
+	return rc
+}
+
+// GetDatabaseConfig returns the database configuration
+func (c *Config) GetDatabaseConfig() database.Config {
+	m.Lock()
+	db := c.Database
+	m.Unlock()
+	return db
+}
+
+// GetPortfolioValuationConfig returns the portfolio valuation configuration
+func (c *Config) GetPortfolioValuationConfig() PortfolioValuationConfig {
+	m.Lock()
+	valuation := c.PortfolioValuation
+	m.Unlock()
+	return valuation
+}
+
+// GetRebalancerConfig returns the rebalancer configuration
+func (c *Config) GetRebalancerConfig() RebalancerConfig {
+	m.Lock()
+	rebalancer := c.Rebalancer
+	m.Unlock()
+	return rebalancer
+}
+
+// UpdateSettings runs update while holding the config lock so readers using
+// the config getters never see a partially applied change. update must only
+// assign config fields, calling a config method from it deadlocks
+func (c *Config) UpdateSettings(update func()) {
+	m.Lock()
+	update()
+	m.Unlock()
+}
+
 // CheckCommunicationsConfig checks to see if the variables are set correctly
 // from config.json
 func (c *Config) CheckCommunicationsConfig() {
@@ -296,10 +339,32 @@ func (c *Config) CheckCommunicationsConfig() {
 		}
 	}
 
+	if c.Communications.WebhookConfig.Name == "" {
+		c.Communications.WebhookConfig = WebhookConfig{
+			Name: "Webhook",
+		}
+	}
+
+	if c.Communications.DiscordConfig.Name == "" {
+		c.Communications.DiscordConfig = DiscordConfig{
+			Name: "Discord",
+		}
+	}
+
+	if c.Communications.MatrixConfig.Name == "" {
+		c.Communications.MatrixConfig = MatrixConfig{
+			Name:          "Matrix",
+			HomeserverURL: "https://matrix.org",
+		}
+	}
+
 	if c.Communications.SlackConfig.Name != "Slack" ||
 		c.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		c.Communications.SMTPConfig.Name != "SMTP" ||
-		c.Communications.TelegramConfig.Name != "Telegram" {
+		c.Communications.TelegramConfig.Name != "Telegram" ||
+		c.Communications.WebhookConfig.Name != "Webhook" ||
+		c.Communications.DiscordConfig.Name != "Discord" ||
+		c.Communications.MatrixConfig.Name != "Matrix" {
 		log.Warnln(log.ConfigMgr, "Communications config name/s not set correctly")
 	}
 	if c.Communications.SlackConfig.Enabled {
@@ -333,6 +398,63 @@ func (c *Config) CheckCommunicationsConfig() {
 			log.Warnln(log.ConfigMgr, "Telegram enabled in config but variable data not set, disabling.")
 		}
 	}
+	endpoints := c.Communications.WebhookConfig.Endpoints
+	for i := range endpoints {
+		if endpoints[i].Timeout <= 0 {
+			endpoints[i].Timeout = defaultWebhookTimeout
+		}
+		if endpoints[i].MaxRetries <= 0 {
+			endpoints[i].MaxRetries = defaultWebhookMaxRetries
+		}
+		if endpoints[i].RetryDelay <= 0 {
+			endpoints[i].RetryDelay = defaultWebhookRetryDelay
+		}
+	}
+	if c.Communications.WebhookConfig.Enabled {
+		valid := len(endpoints) > 0
+		for i := range endpoints {
+			if endpoints[i].URL == "" {
+				valid = false
+			}
+		}
+		if !valid {
+			c.Communications.WebhookConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Webhook enabled in config but endpoint URLs not set, disabling.")
+		}
+	}
+	if c.Communications.DiscordConfig.Enabled {
+		if c.Communications.DiscordConfig.Token == "" ||
+			c.Communications.DiscordConfig.ChannelID == "" {
+			c.Communications.DiscordConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Discord enabled in config but variable data not set, disabling.")
+		}
+	}
+	if c.Communications.MatrixConfig.Enabled {
+		if c.Communications.MatrixConfig.HomeserverURL == "" ||
+			c.Communications.MatrixConfig.AccessToken == "" ||
+			c.Communications.MatrixConfig.RoomID == "" {
+			c.Communications.MatrixConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Matrix enabled in config but variable data not set, disabling.")
+		}
+	}
+	routing := &c.Communications.EventRouting
+	if routing.DeduplicationWindow < 0 {
+		routing.DeduplicationWindow = 0
+	}
+	if routing.DigestInterval <= 0 {
+		routing.DigestInterval = defaultEventDigestInterval
+	}
+	for i := range routing.Routes {
+		r := &routing.Routes[i]
+		r.MinSeverity = strings.ToLower(r.MinSeverity)
+		r.DigestSeverity = strings.ToLower(r.DigestSeverity)
+		if r.MaxEvents < 0 {
+			r.MaxEvents = 0
+		}
+		if r.MaxEvents > 0 && r.RateLimitInterval <= 0 {
+			r.RateLimitInterval = defaultEventRateLimitInterval
+		}
+	}
 }
 
 // GetExchangeAssetTypes returns the exchanges supported asset types
@@ -797,6 +919,33 @@ func (c *Config) UpdateExchangeConfig(e *ExchangeConfig) error {
 	return fmt.Errorf(ErrExchangeNotFound, e.Name)
 }
 
+// checkAccounts removes named accounts which are unnamed or share a name with
+// another account and resolves their credential references
+func (a *APIConfig) checkAccounts(exchName string) {
+	seen := make(map[string]bool)
+	accounts := a.Accounts[:0]
+	for i := range a.Accounts {
+		name := strings.ToLower(a.Accounts[i].Name)
+		if name == "" || seen[name] {
+			log.Warnf(log.ConfigMgr,
+				"Exchange %s account %q removed, accounts require a unique name\n",
+				exchName,
+				a.Accounts[i].Name)
+			continue
+		}
+		seen[name] = true
+		err := a.Accounts[i].Credentials.resolveReferences()
+		if err != nil {
+			log.Errorf(log.ConfigMgr, "Exchange %s account %s %v\n",
+				exchName,
+				a.Accounts[i].Name,
+				err)
+		}
+		accounts = append(accounts, a.Accounts[i])
+	}
+	a.Accounts = accounts
+}
+
 // CheckExchangeConfigValues returns configuation values for all enabled
 // exchanges
 func (c *Config) CheckExchangeConfigValues() error {
@@ -852,6 +1001,12 @@ func (c *Config) CheckExchangeConfigValues() error {
 			c.Exchanges[i].WebsocketURL = nil
 		}
 
+		err := c.Exchanges[i].API.Credentials.resolveReferences()
+		if err != nil {
+			log.Errorf(log.ConfigMgr, "Exchange %s %v\n", c.Exchanges[i].Name, err)
+		}
+		c.Exchanges[i].API.checkAccounts(c.Exchanges[i].Name)
+
 		if c.Exchanges[i].Features == nil {
 			c.Exchanges[i].Features = &FeaturesConfig{}
 		}
@@ -1276,6 +1431,12 @@ func (c *Config) RetrieveConfigCurrencyPairs(enabledOnly bool, assetType asset.I
 // CheckLoggerConfig checks to see logger values are present and valid in config
 // if not creates a default instance of the logger
 func (c *Config) CheckLoggerConfig() error {
+	return c.checkLoggerConfig(true)
+}
+
+// checkLoggerConfig checks the logger values, setup points the logger at this
+// config and creates the log directory
+func (c *Config) checkLoggerConfig(setup bool) error {
 	m.Lock()
 	defer m.Unlock()
 
@@ -1287,6 +1448,35 @@ func (c *Config) CheckLoggerConfig() error {
 		c.Logging.AdvancedSettings.ShowLogSystemName = convert.BoolPtr(false)
 	}
 
+	if !log.ValidFormat(c.Logging.Format) {
+		log.Warnf(log.Global, "Logger format %s invalid, defaulting to %s", c.Logging.Format, log.FormatText)
+		c.Logging.Format = log.FormatText
+	}
+	for i := range c.Logging.SubLoggers {
+		if !log.ValidFormat(c.Logging.SubLoggers[i].Format) {
+			log.Warnf(log.Global, "Logger %s format %s invalid, using the global format",
+				c.Logging.SubLoggers[i].Name,
+				c.Logging.SubLoggers[i].Format)
+			c.Logging.SubLoggers[i].Format = ""
+		}
+		fileSettings := c.Logging.SubLoggers[i].FileSettings
+		if fileSettings == nil {
+			continue
+		}
+		if fileSettings.FileName == "" {
+			fileSettings.FileName = strings.ToLower(c.Logging.SubLoggers[i].Name) + ".log"
+		}
+		if fileSettings.Rotate == nil {
+			fileSettings.Rotate = convert.BoolPtr(false)
+		}
+		if fileSettings.MaxSize <= 0 {
+			fileSettings.MaxSize = log.DefaultMaxFileSize
+		}
+		if fileSettings.MaxBackups < 0 {
+			fileSettings.MaxBackups = 0
+		}
+	}
+
 	if c.Logging.LoggerFileConfig != nil {
 		if c.Logging.LoggerFileConfig.FileName == "" {
 			c.Logging.LoggerFileConfig.FileName = "log.txt"
@@ -1298,6 +1488,15 @@ func (c *Config) CheckLoggerConfig() error {
 			log.Warnf(log.Global, "Logger rotation size invalid, defaulting to %v", log.DefaultMaxFileSize)
 			c.Logging.LoggerFileConfig.MaxSize = log.DefaultMaxFileSize
 		}
+		if c.Logging.LoggerFileConfig.MaxBackups < 0 {
+			c.Logging.LoggerFileConfig.MaxBackups = 0
+		}
+	}
+
+	if !setup {
+		return nil
+	}
+	if c.Logging.LoggerFileConfig != nil {
 		log.FileLoggingConfiguredCorrectly = true
 	}
 	log.RWM.Lock()
@@ -1314,7 +1513,9 @@ func (c *Config) CheckLoggerConfig() error {
 	return nil
 }
 
-func (c *Config) checkGCTScriptConfig() error {
+// checkGCTScriptConfig checks the gctscript values, setup creates the script
+// directories and sets the gctscript script path
+func (c *Config) checkGCTScriptConfig(setup bool) error {
 	m.Lock()
 	defer m.Unlock()
 
@@ -1326,6 +1527,10 @@ func (c *Config) checkGCTScriptConfig() error {
 		c.GCTScript.MaxVirtualMachines = gctscript.DefaultMaxVirtualMachines
 	}
 
+	if !setup {
+		return nil
+	}
+
 	scriptPath := c.GetDataPath("scripts")
 	err := common.CreateDir(scriptPath)
 	if err != nil {
@@ -1343,7 +1548,9 @@ func (c *Config) checkGCTScriptConfig() error {
 	return nil
 }
 
-func (c *Config) checkDatabaseConfig() error {
+// checkDatabaseConfig checks the database values, setup creates the sqlite
+// directory and points the database package at this config
+func (c *Config) checkDatabaseConfig(setup bool) error {
 	m.Lock()
 	defer m.Unlock()
 
@@ -1361,6 +1568,10 @@ func (c *Config) checkDatabaseConfig() error {
 		return fmt.Errorf("unsupported database driver %v, database disabled", c.Database.Driver)
 	}
 
+	if !setup {
+		return nil
+	}
+
 	if c.Database.Driver == database.DBSQLite || c.Database.Driver == database.DBSQLite3 {
 		databaseDir := c.GetDataPath("database")
 		err := common.CreateDir(databaseDir)
@@ -1453,6 +1664,174 @@ func (c *Config) CheckConnectionMonitorConfig() {
 	}
 }
 
+// CheckPortfolioValuationConfig checks and sets the default values for the
+// portfolio valuation config
+func (c *Config) CheckPortfolioValuationConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.PortfolioValuation.FiatCurrency.IsEmpty() {
+		c.PortfolioValuation.FiatCurrency = c.Currency.FiatDisplayCurrency
+	}
+
+	if c.PortfolioValuation.SnapshotInterval <= 0 {
+		c.PortfolioValuation.SnapshotInterval = defaultPortfolioValuationInterval
+	}
+}
+
+// CheckRebalancerConfig checks and sets the default values for the rebalancer
+// config, disabling the rebalancer if its targets are invalid
+func (c *Config) CheckRebalancerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Rebalancer.CheckInterval <= 0 {
+		c.Rebalancer.CheckInterval = defaultRebalancerCheckInterval
+	}
+
+	if !c.Rebalancer.Enabled {
+		return
+	}
+
+	err := rebalance.ValidateTargets(c.Rebalancer.Targets)
+	if err != nil {
+		log.Errorf(log.ConfigMgr,
+			"Rebalancer targets invalid, rebalancer disabled: %s\n",
+			err)
+		c.Rebalancer.Enabled = false
+	}
+}
+
+// CheckWithdrawalApprovalConfig checks and sets the default values for the
+// withdrawal approval config. Misconfigured operators are reported but the
+// approval requirement remains so withdrawals are never sent unapproved
+func (c *Config) CheckWithdrawalApprovalConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.WithdrawalApproval.RequiredApprovals <= 0 {
+		c.WithdrawalApproval.RequiredApprovals = 1
+	}
+
+	if c.WithdrawalApproval.Expiry <= 0 {
+		c.WithdrawalApproval.Expiry = defaultWithdrawalApprovalExpiry
+	}
+
+	if !c.WithdrawalApproval.Enabled {
+		return
+	}
+
+	var valid int
+	seen := make(map[string]bool)
+	for i := range c.WithdrawalApproval.Operators {
+		name := strings.ToLower(c.WithdrawalApproval.Operators[i].Name)
+		if name == "" || c.WithdrawalApproval.Operators[i].OTPSecret == "" {
+			log.Warnf(log.ConfigMgr,
+				"Withdrawal approval operator %d requires a name and OTP secret\n",
+				i)
+			continue
+		}
+		if seen[name] {
+			log.Warnf(log.ConfigMgr,
+				"Withdrawal approval operator %s is duplicated\n",
+				c.WithdrawalApproval.Operators[i].Name)
+			continue
+		}
+		seen[name] = true
+		valid++
+	}
+	if valid < c.WithdrawalApproval.RequiredApprovals {
+		log.Errorf(log.ConfigMgr,
+			"Withdrawal approval requires %d approvals but only %d valid operators are configured, withdrawals above threshold cannot be approved\n",
+			c.WithdrawalApproval.RequiredApprovals,
+			valid)
+	}
+}
+
+// CheckWithdrawalTrackerConfig checks and sets the default values for the
+// withdrawal tracker config
+func (c *Config) CheckWithdrawalTrackerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.WithdrawalTracker.CheckInterval <= 0 {
+		c.WithdrawalTracker.CheckInterval = defaultWithdrawalTrackerInterval
+	}
+
+	if c.WithdrawalTracker.StallTimeout <= 0 {
+		c.WithdrawalTracker.StallTimeout = defaultWithdrawalStallTimeout
+	}
+}
+
+// CheckDepositMonitorConfig checks and sets the default values for the
+// deposit monitor config
+func (c *Config) CheckDepositMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DepositMonitor.CheckInterval <= 0 {
+		c.DepositMonitor.CheckInterval = defaultDepositMonitorInterval
+	}
+
+	if c.DepositMonitor.MatchWindow <= 0 {
+		c.DepositMonitor.MatchWindow = defaultDepositMatchWindow
+	}
+}
+
+// CheckBalanceAlertConfig checks and sets the default values for the balance
+// alert config, removing rules which cannot trigger
+func (c *Config) CheckBalanceAlertConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.BalanceAlerts.Cooldown <= 0 {
+		c.BalanceAlerts.Cooldown = defaultBalanceAlertCooldown
+	}
+
+	var rules []BalanceAlertRule
+	for i := range c.BalanceAlerts.Rules {
+		rule := c.BalanceAlerts.Rules[i]
+		if rule.Below < 0 || rule.ChangePercent < 0 {
+			log.Warnf(log.ConfigMgr,
+				"Balance alert rule %d has a negative threshold, removing.\n",
+				i)
+			continue
+		}
+		if rule.Below == 0 && rule.ChangePercent == 0 && !rule.NewCurrency {
+			log.Warnf(log.ConfigMgr,
+				"Balance alert rule %d has no conditions set, removing.\n",
+				i)
+			continue
+		}
+		if rule.ChangePercent > 0 && rule.ChangeWindow <= 0 {
+			rule.ChangeWindow = defaultBalanceAlertChangeWindow
+		}
+		rules = append(rules, rule)
+	}
+	c.BalanceAlerts.Rules = rules
+}
+
+// CheckMetricsConfig checks and sets the default values for the metrics
+// config
+func (c *Config) CheckMetricsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Metrics.ListenAddress == "" {
+		c.Metrics.ListenAddress = defaultMetricsListenAddress
+	}
+}
+
+// CheckConfigReloadConfig checks the config file watcher settings
+func (c *Config) CheckConfigReloadConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ConfigReload.WatchInterval <= 0 {
+		c.ConfigReload.WatchInterval = defaultConfigWatchInterval
+	}
+}
+
 // DefaultFilePath returns the default config file path
 // MacOS/Linux: $HOME/.gocryptotrader/config.json or config.dat
 // Windows: %APPDATA%\GoCryptoTrader\config.json or config.dat
@@ -1747,30 +2126,69 @@ func (c *Config) CheckRemoteControlConfig() {
 		// Then flush the old webserver settings
 		c.Webserver = nil
 	}
+
+	usernames := map[string]bool{strings.ToLower(c.RemoteControl.Username): true}
+	tokens := make(map[string]bool)
+	var users []RemoteControlUser
+	for i := range c.RemoteControl.Users {
+		user := c.RemoteControl.Users[i]
+		user.Role = strings.ToLower(user.Role)
+		switch {
+		case user.Username == "":
+			log.Warnf(log.ConfigMgr, "Remote control user %d has no username, removing.\n", i)
+			continue
+		case user.Password == "" && user.APIToken == "":
+			log.Warnf(log.ConfigMgr, "Remote control user %s has no password or API token, removing.\n", user.Username)
+			continue
+		case !auth.IsValidRole(user.Role):
+			log.Warnf(log.ConfigMgr, "Remote control user %s has invalid role %q, removing.\n", user.Username, user.Role)
+			continue
+		case usernames[strings.ToLower(user.Username)]:
+			log.Warnf(log.ConfigMgr, "Remote control user %s is a duplicate, removing.\n", user.Username)
+			continue
+		case user.APIToken != "" && tokens[user.APIToken]:
+			log.Warnf(log.ConfigMgr, "Remote control user %s API token is already in use, removing.\n", user.Username)
+			continue
+		}
+		usernames[strings.ToLower(user.Username)] = true
+		if user.APIToken != "" {
+			tokens[user.APIToken] = true
+		}
+		users = append(users, user)
+	}
+	c.RemoteControl.Users = users
 }
 
 // CheckConfig checks all config settings
 func (c *Config) CheckConfig() error {
-	err := c.CheckLoggerConfig()
+	return c.checkConfig(true)
+}
+
+// checkConfig checks all config values, setup points the logger, database and
+// gctscript packages at this config. A config checked without setup leaves the
+// running package state untouched
+func (c *Config) checkConfig(setup bool) error {
+	err := c.checkLoggerConfig(setup)
 	if err != nil {
 		log.Errorf(log.ConfigMgr,
 			"Failed to configure logger, some logging features unavailable: %s\n",
 			err)
 	}
 
-	err = c.checkDatabaseConfig()
+	err = c.checkDatabaseConfig(setup)
 	if err != nil {
 		log.Errorf(log.DatabaseMgr,
 			"Failed to configure database: %v",
 			err)
 	}
 
+	c.CheckSecretsConfig()
 	err = c.CheckExchangeConfigValues()
 	if err != nil {
 		return fmt.Errorf(ErrCheckingConfigValues, err)
 	}
 
-	err = c.checkGCTScriptConfig()
+	err = c.checkGCTScriptConfig(setup)
 	if err != nil {
 		log.Errorf(log.Global,
 			"Failed to configure gctscript, feature has been disabled: %s\n",
@@ -1787,6 +2205,14 @@ func (c *Config) CheckConfig() error {
 	if err != nil {
 		return err
 	}
+	c.CheckPortfolioValuationConfig()
+	c.CheckRebalancerConfig()
+	c.CheckWithdrawalApprovalConfig()
+	c.CheckWithdrawalTrackerConfig()
+	c.CheckDepositMonitorConfig()
+	c.CheckBalanceAlertConfig()
+	c.CheckMetricsConfig()
+	c.CheckConfigReloadConfig()
 
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Warnf(log.ConfigMgr,
@@ -1812,6 +2238,29 @@ func (c *Config) LoadConfig(configPath string, dryrun bool) error {
 	return c.CheckConfig()
 }
 
+// ReadReloadConfig reads and checks the config file for reloading into a
+// running engine, it does not prompt so encrypted config files are rejected.
+// The logger, database and gctscript packages keep using the running config
+func ReadReloadConfig(configPath string) (*Config, error) {
+	defaultPath, _, err := GetFilePath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(defaultPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) >= len(EncryptConfirmString) &&
+		ConfirmECS(data[:len(EncryptConfirmString)]) {
+		return nil, errReloadEncryptedConfig
+	}
+	result, _, err := ReadConfig(bytes.NewReader(data), nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrFailureOpeningConfig, defaultPath, err)
+	}
+	return result, result.checkConfig(false)
+}
+
 // UpdateConfig updates the config with a supplied config file
 func (c *Config) UpdateConfig(configPath string, newCfg *Config, dryrun bool) error {
 	err := newCfg.CheckConfig()
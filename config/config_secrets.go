@@ -0,0 +1,287 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	// SecretSchemeEnv resolves references from environment variables
+	SecretSchemeEnv = "env"
+	// SecretSchemeFile resolves references from files such as Docker or
+	// Kubernetes secrets
+	SecretSchemeFile = "file"
+	// SecretSchemeVault resolves references from a Vault key-value store
+	SecretSchemeVault = "vault"
+)
+
+var (
+	errSecretNotFound        = errors.New("secret not found")
+	errSecretProviderNil     = errors.New("secret provider is nil")
+	errSecretSchemeEmpty     = errors.New("secret scheme is empty")
+	errVaultReferenceNoField = errors.New("vault reference requires a #field suffix")
+	errVaultAddressUnset     = errors.New("vault address is not set")
+	errVaultUnexpectedStatus = errors.New("unexpected vault response status")
+	errVaultFieldNotString   = errors.New("vault secret field is not a string")
+	errCredentialsUnresolved = errors.New("unable to resolve credential references")
+	secretProvidersMtx       sync.RWMutex
+	secretProviders          = map[string]SecretProvider{
+		SecretSchemeEnv:  envSecretProvider{},
+		SecretSchemeFile: fileSecretProvider{},
+	}
+)
+
+// SecretProvider resolves a secret reference, such as an environment variable
+// name or a file path, to the secret value
+type SecretProvider interface {
+	GetSecret(reference string) (string, error)
+}
+
+// RegisterSecretProvider registers a provider for values prefixed with the
+// scheme, for example "env:BINANCE_API_KEY" is resolved by the "env" provider
+func RegisterSecretProvider(scheme string, p SecretProvider) error {
+	if scheme == "" {
+		return errSecretSchemeEmpty
+	}
+	if p == nil {
+		return errSecretProviderNil
+	}
+	secretProvidersMtx.Lock()
+	secretProviders[strings.ToLower(scheme)] = p
+	secretProvidersMtx.Unlock()
+	return nil
+}
+
+// unregisterSecretProvider removes the provider for the scheme
+func unregisterSecretProvider(scheme string) {
+	secretProvidersMtx.Lock()
+	delete(secretProviders, strings.ToLower(scheme))
+	secretProvidersMtx.Unlock()
+}
+
+// secretProviderFor returns the provider and reference of a value prefixed
+// with a registered scheme
+func secretProviderFor(value string) (SecretProvider, string, bool) {
+	i := strings.Index(value, ":")
+	if i <= 0 {
+		return nil, "", false
+	}
+	secretProvidersMtx.RLock()
+	p, ok := secretProviders[strings.ToLower(value[:i])]
+	secretProvidersMtx.RUnlock()
+	if !ok {
+		return nil, "", false
+	}
+	return p, value[i+1:], true
+}
+
+// IsSecretReference returns whether the value is prefixed with a registered
+// secret scheme
+func IsSecretReference(value string) bool {
+	_, _, ok := secretProviderFor(value)
+	return ok
+}
+
+// ResolveSecret returns the secret a reference resolves to, values which are
+// not prefixed with a registered scheme are returned unchanged
+func ResolveSecret(value string) (string, error) {
+	p, reference, ok := secretProviderFor(value)
+	if !ok {
+		return value, nil
+	}
+	return p.GetSecret(reference)
+}
+
+// envSecretProvider resolves environment variables
+type envSecretProvider struct{}
+
+func (envSecretProvider) GetSecret(reference string) (string, error) {
+	v, ok := os.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s %w", reference, errSecretNotFound)
+	}
+	return v, nil
+}
+
+// fileSecretProvider resolves file contents, trailing line breaks are removed
+type fileSecretProvider struct{}
+
+func (fileSecretProvider) GetSecret(reference string) (string, error) {
+	data, err := ioutil.ReadFile(reference)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// VaultSecretProvider resolves references in the form path#field from a
+// HashiCorp Vault compatible key-value store. The path is relative to /v1/
+// so a KV version 2 secret is referenced as "secret/data/gct#key"
+type VaultSecretProvider struct {
+	Address   string
+	Token     string
+	Namespace string
+	Client    *http.Client
+}
+
+// GetSecret fetches the path and returns the field value
+func (v *VaultSecretProvider) GetSecret(reference string) (string, error) {
+	i := strings.LastIndex(reference, "#")
+	if i <= 0 || i == len(reference)-1 {
+		return "", errVaultReferenceNoField
+	}
+	path, field := strings.TrimLeft(reference[:i], "/"), reference[i+1:]
+
+	req, err := http.NewRequest(http.MethodGet,
+		strings.TrimRight(v.Address, "/")+"/v1/"+path,
+		nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s %w %d", path, errVaultUnexpectedStatus, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	if err != nil {
+		return "", err
+	}
+	values := secret.Data
+	// KV version 2 nests the secret beneath data alongside its metadata
+	if nested, ok := values["data"].(map[string]interface{}); ok {
+		values = nested
+	}
+	value, ok := values[field]
+	if !ok {
+		return "", fmt.Errorf("%s#%s %w", path, field, errSecretNotFound)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s#%s %w", path, field, errVaultFieldNotString)
+	}
+	return s, nil
+}
+
+// CheckSecretsConfig checks the external secret store settings and registers
+// the vault provider when enabled
+func (c *Config) CheckSecretsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	vault := &c.Secrets.Vault
+	if !vault.Enabled {
+		unregisterSecretProvider(SecretSchemeVault)
+		return
+	}
+	if vault.Timeout <= 0 {
+		vault.Timeout = defaultVaultTimeout
+	}
+	if vault.Address == "" {
+		log.Warnf(log.ConfigMgr, "Vault secret provider disabled: %v\n", errVaultAddressUnset)
+		vault.Enabled = false
+		unregisterSecretProvider(SecretSchemeVault)
+		return
+	}
+	// The token may itself reference an environment variable or file
+	token, err := ResolveSecret(vault.Token)
+	if err != nil {
+		log.Warnf(log.ConfigMgr, "Vault secret provider disabled, unable to resolve token: %v\n", err)
+		vault.Enabled = false
+		unregisterSecretProvider(SecretSchemeVault)
+		return
+	}
+	err = RegisterSecretProvider(SecretSchemeVault, &VaultSecretProvider{
+		Address:   vault.Address,
+		Token:     token,
+		Namespace: vault.Namespace,
+		Client:    &http.Client{Timeout: vault.Timeout},
+	})
+	if err != nil {
+		log.Errorf(log.ConfigMgr, "Vault secret provider failed to register: %v\n", err)
+	}
+}
+
+// resolveReferences replaces credentials which reference a secret with the
+// secret. The references are kept and saved in place of the secrets, a
+// credential which fails to resolve is left empty
+func (a *APICredentialsConfig) resolveReferences() error {
+	fields := []struct {
+		name       string
+		value, ref *string
+	}{
+		{"key", &a.Key, &a.references.Key},
+		{"secret", &a.Secret, &a.references.Secret},
+		{"clientID", &a.ClientID, &a.references.ClientID},
+		{"pemKey", &a.PEMKey, &a.references.PEMKey},
+		{"otpSecret", &a.OTPSecret, &a.references.OTPSecret},
+	}
+	var failed []string
+	for i := range fields {
+		if *fields[i].ref != "" {
+			continue
+		}
+		p, reference, ok := secretProviderFor(*fields[i].value)
+		if !ok {
+			continue
+		}
+		*fields[i].ref = *fields[i].value
+		secret, err := p.GetSecret(reference)
+		if err != nil {
+			*fields[i].value = ""
+			failed = append(failed, fmt.Sprintf("%s: %v", fields[i].name, err))
+			continue
+		}
+		*fields[i].value = secret
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%w %s", errCredentialsUnresolved, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// MarshalJSON writes secret references in place of the secrets they were
+// resolved to so secrets are never saved
+func (a APICredentialsConfig) MarshalJSON() ([]byte, error) {
+	type credentials APICredentialsConfig
+	saved := credentials(a)
+	if a.references.Key != "" {
+		saved.Key = a.references.Key
+	}
+	if a.references.Secret != "" {
+		saved.Secret = a.references.Secret
+	}
+	if a.references.ClientID != "" {
+		saved.ClientID = a.references.ClientID
+	}
+	if a.references.PEMKey != "" {
+		saved.PEMKey = a.references.PEMKey
+	}
+	if a.references.OTPSecret != "" {
+		saved.OTPSecret = a.references.OTPSecret
+	}
+	return json.Marshal(saved)
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testSecretEnv = "GCT_TEST_SECRET"
+
+func TestResolveSecret(t *testing.T) {
+	err := os.Setenv(testSecretEnv, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(testSecretEnv)
+
+	v, err := ResolveSecret("env:" + testSecretEnv)
+	if err != nil || v != "hunter2" {
+		t.Errorf("expected env secret, received %v %v", v, err)
+	}
+
+	f, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("filesecret\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	v, err = ResolveSecret("file:" + f.Name())
+	if err != nil || v != "filesecret" {
+		t.Errorf("expected file secret, received %v %v", v, err)
+	}
+
+	v, err = ResolveSecret("plain:value")
+	if err != nil || v != "plain:value" {
+		t.Errorf("expected value without a registered scheme unchanged, received %v %v", v, err)
+	}
+
+	_, err = ResolveSecret("env:GCT_TEST_SECRET_MISSING")
+	if !errors.Is(err, errSecretNotFound) {
+		t.Errorf("expected %v, received %v", errSecretNotFound, err)
+	}
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	t.Parallel()
+	if err := RegisterSecretProvider("", envSecretProvider{}); err != errSecretSchemeEmpty {
+		t.Errorf("expected %v, received %v", errSecretSchemeEmpty, err)
+	}
+	if err := RegisterSecretProvider("test", nil); err != errSecretProviderNil {
+		t.Errorf("expected %v, received %v", errSecretProviderNil, err)
+	}
+}
+
+func TestVaultSecretProvider(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gct":
+			_, _ = w.Write([]byte(`{"data":{"data":{"key":"v2key","number":1},"metadata":{}}}`))
+		case "/v1/kv/gct":
+			_, _ = w.Write([]byte(`{"data":{"key":"v1key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	v := &VaultSecretProvider{Address: server.URL, Token: "token"}
+	for reference, expected := range map[string]string{
+		"secret/data/gct#key": "v2key",
+		"/kv/gct#key":         "v1key",
+	} {
+		s, err := v.GetSecret(reference)
+		if err != nil || s != expected {
+			t.Errorf("%s expected %s, received %v %v", reference, expected, s, err)
+		}
+	}
+
+	for reference, expected := range map[string]error{
+		"secret/data/gct":        errVaultReferenceNoField,
+		"secret/data/gct#":       errVaultReferenceNoField,
+		"secret/data/gct#absent": errSecretNotFound,
+		"secret/data/gct#number": errVaultFieldNotString,
+		"secret/data/none#key":   errVaultUnexpectedStatus,
+	} {
+		if _, err := v.GetSecret(reference); !errors.Is(err, expected) {
+			t.Errorf("%s expected %v, received %v", reference, expected, err)
+		}
+	}
+
+	v.Token = "wrong"
+	if _, err := v.GetSecret("secret/data/gct#key"); !errors.Is(err, errVaultUnexpectedStatus) {
+		t.Errorf("expected %v, received %v", errVaultUnexpectedStatus, err)
+	}
+}
+
+func TestCredentialReferences(t *testing.T) {
+	err := os.Setenv(testSecretEnv, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(testSecretEnv)
+
+	creds := APICredentialsConfig{
+		Key:      "plainkey",
+		Secret:   "env:" + testSecretEnv,
+		ClientID: "env:GCT_TEST_SECRET_MISSING",
+	}
+	err = creds.resolveReferences()
+	if !errors.Is(err, errCredentialsUnresolved) {
+		t.Errorf("expected %v, received %v", errCredentialsUnresolved, err)
+	}
+	if creds.Key != "plainkey" || creds.Secret != "hunter2" || creds.ClientID != "" {
+		t.Errorf("unexpected resolved credentials %+v", creds)
+	}
+
+	// Resolving again does not treat the secrets as references
+	if err = creds.resolveReferences(); err != nil {
+		t.Error(err)
+	}
+
+	data, err := json.Marshal(APIConfig{Credentials: creds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") ||
+		!strings.Contains(string(data), `"secret":"env:`+testSecretEnv+`"`) ||
+		!strings.Contains(string(data), `"clientID":"env:GCT_TEST_SECRET_MISSING"`) ||
+		!strings.Contains(string(data), `"key":"plainkey"`) {
+		t.Errorf("unexpected saved credentials %s", data)
+	}
+}
+
+func TestCheckSecretsConfig(t *testing.T) {
+	err := os.Setenv(testSecretEnv, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(testSecretEnv)
+
+	var c Config
+	c.Secrets.Vault.Enabled = true
+	c.CheckSecretsConfig()
+	if c.Secrets.Vault.Enabled || IsSecretReference("vault:secret/data/gct#key") {
+		t.Error("expected vault to be disabled without an address")
+	}
+
+	c.Secrets.Vault = VaultSecretsConfig{
+		Enabled: true,
+		Address: "http://localhost:8200",
+		Token:   "env:" + testSecretEnv,
+	}
+	c.CheckSecretsConfig()
+	if !IsSecretReference("vault:secret/data/gct#key") {
+		t.Error("expected vault provider to be registered")
+	}
+	if c.Secrets.Vault.Timeout != defaultVaultTimeout || c.Secrets.Vault.Token != "env:"+testSecretEnv {
+		t.Errorf("unexpected vault config %+v", c.Secrets.Vault)
+	}
+
+	c.Secrets.Vault.Enabled = false
+	c.CheckSecretsConfig()
+	if IsSecretReference("vault:secret/data/gct#key") {
+		t.Error("expected vault provider to be removed")
+	}
+}
@@ -1,12 +1,14 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
@@ -19,6 +21,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/ntpclient"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/rebalance"
 )
 
 const (
@@ -345,7 +348,9 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	if cfg.Communications.SlackConfig.Name != "Slack" ||
 		cfg.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		cfg.Communications.SMTPConfig.Name != "SMTP" ||
-		cfg.Communications.TelegramConfig.Name != "Telegram" {
+		cfg.Communications.TelegramConfig.Name != "Telegram" ||
+		cfg.Communications.DiscordConfig.Name != "Discord" ||
+		cfg.Communications.MatrixConfig.Name != "Matrix" {
 		t.Error("CheckCommunicationsConfig unexpected data:",
 			cfg.Communications)
 	}
@@ -420,6 +425,53 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	if cfg.Communications.TelegramConfig.Enabled {
 		t.Error("CheckCommunicationsConfig TelegramConfig is enabled when it shouldn't be.")
 	}
+
+	cfg.Communications.TelegramConfig.Enabled = false
+	cfg.Communications.WebhookConfig.Enabled = true
+	cfg.Communications.WebhookConfig.Endpoints = []WebhookEndpointConfig{{}}
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.WebhookConfig.Enabled {
+		t.Error("CheckCommunicationsConfig WebhookConfig is enabled when it shouldn't be.")
+	}
+	endpoint := cfg.Communications.WebhookConfig.Endpoints[0]
+	if endpoint.Timeout != defaultWebhookTimeout ||
+		endpoint.MaxRetries != defaultWebhookMaxRetries ||
+		endpoint.RetryDelay != defaultWebhookRetryDelay {
+		t.Errorf("CheckCommunicationsConfig unexpected webhook endpoint defaults %+v", endpoint)
+	}
+
+	cfg.Communications.WebhookConfig.Enabled = false
+	cfg.Communications.DiscordConfig.Enabled = true
+	cfg.Communications.MatrixConfig.Enabled = true
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.DiscordConfig.Enabled {
+		t.Error("CheckCommunicationsConfig DiscordConfig is enabled when it shouldn't be.")
+	}
+	if cfg.Communications.MatrixConfig.Enabled {
+		t.Error("CheckCommunicationsConfig MatrixConfig is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.EventRouting = EventRoutingConfig{
+		DeduplicationWindow: -1,
+		Routes: []EventRouteConfig{
+			{Relayer: "SMSGlobal", MinSeverity: "CRITICAL", MaxEvents: 5},
+			{Relayer: "Slack", DigestSeverity: "Warning", MaxEvents: -1},
+		},
+	}
+	cfg.CheckCommunicationsConfig()
+	routing := cfg.Communications.EventRouting
+	if routing.DeduplicationWindow != 0 || routing.DigestInterval != defaultEventDigestInterval {
+		t.Errorf("unexpected event routing defaults %+v", routing)
+	}
+	if routing.Routes[0].MinSeverity != "critical" ||
+		routing.Routes[0].RateLimitInterval != defaultEventRateLimitInterval {
+		t.Errorf("unexpected event route defaults %+v", routing.Routes[0])
+	}
+	if routing.Routes[1].DigestSeverity != "warning" ||
+		routing.Routes[1].MaxEvents != 0 ||
+		routing.Routes[1].RateLimitInterval != 0 {
+		t.Errorf("unexpected event route defaults %+v", routing.Routes[1])
+	}
 }
 
 func TestGetExchangeAssetTypes(t *testing.T) {
@@ -1727,6 +1779,212 @@ func TestCheckConnectionMonitorConfig(t *testing.T) {
 	}
 }
 
+func TestCheckPortfolioValuationConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.CheckPortfolioValuationConfig()
+	if c.PortfolioValuation.FiatCurrency != currency.AUD ||
+		c.PortfolioValuation.SnapshotInterval != defaultPortfolioValuationInterval {
+		t.Error("unexpected values")
+	}
+
+	c.PortfolioValuation.FiatCurrency = currency.EUR
+	c.PortfolioValuation.SnapshotInterval = time.Minute
+	c.CheckPortfolioValuationConfig()
+	if c.PortfolioValuation.FiatCurrency != currency.EUR ||
+		c.PortfolioValuation.SnapshotInterval != time.Minute {
+		t.Error("configured values should not be overridden")
+	}
+}
+
+func TestCheckRebalancerConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Rebalancer.Enabled = true
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.CheckInterval != defaultRebalancerCheckInterval {
+		t.Error("unexpected check interval")
+	}
+	if c.Rebalancer.Enabled {
+		t.Error("rebalancer should be disabled without valid targets")
+	}
+
+	c.Rebalancer.Enabled = true
+	c.Rebalancer.Targets = []rebalance.Target{
+		{
+			Currency: currency.BTC,
+			Allocations: []rebalance.Allocation{
+				{Exchange: "Binance", Weight: 1},
+				{Exchange: "Bitstamp", Weight: 1},
+			},
+		},
+	}
+	c.CheckRebalancerConfig()
+	if !c.Rebalancer.Enabled {
+		t.Error("rebalancer should remain enabled with valid targets")
+	}
+}
+
+func TestCheckWithdrawalApprovalConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.WithdrawalApproval.Enabled = true
+	c.WithdrawalApproval.Operators = []WithdrawalOperator{
+		{Name: "alice", OTPSecret: "secret"},
+		{Name: "ALICE", OTPSecret: "secret"},
+		{Name: "bob"},
+	}
+	c.CheckWithdrawalApprovalConfig()
+	if c.WithdrawalApproval.RequiredApprovals != 1 ||
+		c.WithdrawalApproval.Expiry != defaultWithdrawalApprovalExpiry {
+		t.Error("unexpected values")
+	}
+	if !c.WithdrawalApproval.Enabled {
+		t.Error("withdrawal approval should never be disabled by config checks")
+	}
+}
+
+func TestCheckWithdrawalTrackerConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckWithdrawalTrackerConfig()
+	if c.WithdrawalTracker.CheckInterval != defaultWithdrawalTrackerInterval ||
+		c.WithdrawalTracker.StallTimeout != defaultWithdrawalStallTimeout {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckDepositMonitorConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckDepositMonitorConfig()
+	if c.DepositMonitor.CheckInterval != defaultDepositMonitorInterval ||
+		c.DepositMonitor.MatchWindow != defaultDepositMatchWindow {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckBalanceAlertConfig(t *testing.T) {
+	t.Parallel()
+
+	c := Config{
+		BalanceAlerts: BalanceAlertConfig{
+			Rules: []BalanceAlertRule{
+				{Exchange: testFakeExchangeName},
+				{Below: -1},
+				{ChangePercent: 10},
+				{Below: 1, NewCurrency: true},
+			},
+		},
+	}
+	c.CheckBalanceAlertConfig()
+	if c.BalanceAlerts.Cooldown != defaultBalanceAlertCooldown {
+		t.Error("unexpected cooldown")
+	}
+	if len(c.BalanceAlerts.Rules) != 2 {
+		t.Fatalf("expected 2 rules, received %d", len(c.BalanceAlerts.Rules))
+	}
+	if c.BalanceAlerts.Rules[0].ChangeWindow != defaultBalanceAlertChangeWindow {
+		t.Error("expected default change window")
+	}
+	if c.BalanceAlerts.Rules[1].ChangeWindow != 0 {
+		t.Error("unexpected change window")
+	}
+}
+
+func TestCheckMetricsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckMetricsConfig()
+	if c.Metrics.ListenAddress != defaultMetricsListenAddress {
+		t.Error("expected default listen address")
+	}
+}
+
+func TestCheckConfigReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckConfigReloadConfig()
+	if c.ConfigReload.WatchInterval != defaultConfigWatchInterval {
+		t.Error("expected default watch interval")
+	}
+}
+
+func TestReadReloadConfig(t *testing.T) {
+	log.RWM.RLock()
+	logCfg := log.GlobalLogConfig
+	log.RWM.RUnlock()
+	c, err := ReadReloadConfig(TestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.RWM.RLock()
+	if log.GlobalLogConfig != logCfg {
+		t.Error("expected reading a reload config to leave the logger untouched")
+	}
+	log.RWM.RUnlock()
+	if len(c.Exchanges) == 0 {
+		t.Error("expected exchanges to be read")
+	}
+	if c == GetConfig() {
+		t.Error("expected a separate config")
+	}
+
+	_, err = ReadReloadConfig("bla")
+	if err == nil {
+		t.Error("expected error on missing file")
+	}
+
+	f, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(EncryptConfirmString + "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	_, err = ReadReloadConfig(f.Name())
+	if !errors.Is(err, errReloadEncryptedConfig) {
+		t.Errorf("expected %v, received %v", errReloadEncryptedConfig, err)
+	}
+}
+
+func TestCheckAccounts(t *testing.T) {
+	err := os.Setenv("GCT_TEST_ACCOUNT_KEY", "subkey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("GCT_TEST_ACCOUNT_KEY")
+
+	a := APIConfig{
+		Accounts: []APIAccountConfig{
+			{Name: "sub", Credentials: APICredentialsConfig{Key: "env:GCT_TEST_ACCOUNT_KEY"}},
+			{Name: "SUB"},
+			{},
+			{Name: "other"},
+		},
+	}
+	a.checkAccounts("Bitstamp")
+	if len(a.Accounts) != 2 ||
+		a.Accounts[0].Name != "sub" ||
+		a.Accounts[1].Name != "other" {
+		t.Fatalf("expected unnamed and duplicate accounts to be removed, received %+v", a.Accounts)
+	}
+	if a.Accounts[0].Credentials.Key != "subkey" {
+		t.Errorf("expected account credentials to be resolved, received %s", a.Accounts[0].Credentials.Key)
+	}
+}
+
 func TestDefaultFilePath(t *testing.T) {
 	// This is tricky to test because we're dealing with a config file stored
 	// in a persons default directory and to properly test it, it would
@@ -1803,6 +2061,30 @@ func TestCheckRemoteControlConfig(t *testing.T) {
 	}
 }
 
+func TestCheckRemoteControlUsers(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.RemoteControl.Username = "admin"
+	c.RemoteControl.Users = []RemoteControlUser{
+		{Username: "reader", Password: "pw", Role: "ReadOnly"},
+		{Username: "bot", APIToken: "token", Role: "trader"},
+		{Username: "", Password: "pw", Role: "trader"},
+		{Username: "nocreds", Role: "trader"},
+		{Username: "badrole", Password: "pw", Role: "superuser"},
+		{Username: "Admin", Password: "pw", Role: "admin"},
+		{Username: "bot2", APIToken: "token", Role: "trader"},
+	}
+	c.CheckRemoteControlConfig()
+	if len(c.RemoteControl.Users) != 2 {
+		t.Fatalf("expected 2 users, received %d", len(c.RemoteControl.Users))
+	}
+	if c.RemoteControl.Users[0].Role != "readonly" ||
+		c.RemoteControl.Users[1].Username != "bot" {
+		t.Errorf("unexpected users %+v", c.RemoteControl.Users)
+	}
+}
+
 func TestCheckConfig(t *testing.T) {
 	var c Config
 	err := c.LoadConfig(TestFile, true)
@@ -1888,6 +2170,29 @@ func TestCheckLoggerConfig(t *testing.T) {
 		*c.Logging.AdvancedSettings.ShowLogSystemName {
 		t.Error("unexpected result")
 	}
+
+	c.Logging.Format = "xml"
+	c.Logging.SubLoggers = []log.SubLoggerConfig{{Name: "order", Format: "yaml"}}
+	err = c.CheckLoggerConfig()
+	if err != nil {
+		t.Error(err)
+	}
+	if c.Logging.Format != log.FormatText || c.Logging.SubLoggers[0].Format != "" {
+		t.Error("expected invalid logger formats to be reset")
+	}
+
+	c.Logging.SubLoggers[0].FileSettings = &log.FileConfig{MaxBackups: -1}
+	err = c.CheckLoggerConfig()
+	if err != nil {
+		t.Error(err)
+	}
+	fileSettings := c.Logging.SubLoggers[0].FileSettings
+	if fileSettings.FileName != "order.log" ||
+		fileSettings.Rotate == nil ||
+		fileSettings.MaxSize != log.DefaultMaxFileSize ||
+		fileSettings.MaxBackups != 0 {
+		t.Errorf("unexpected sublogger file settings %+v", fileSettings)
+	}
 }
 
 func TestDisableNTPCheck(t *testing.T) {
@@ -1923,7 +2228,7 @@ func TestCheckGCTScriptConfig(t *testing.T) {
 	t.Parallel()
 
 	var c Config
-	if err := c.checkGCTScriptConfig(); err != nil {
+	if err := c.checkGCTScriptConfig(true); err != nil {
 		t.Error(err)
 	}
 
@@ -1940,7 +2245,7 @@ func TestCheckDatabaseConfig(t *testing.T) {
 	t.Parallel()
 
 	var c Config
-	if err := c.checkDatabaseConfig(); err != nil {
+	if err := c.checkDatabaseConfig(true); err != nil {
 		t.Error(err)
 	}
 
@@ -1952,13 +2257,13 @@ func TestCheckDatabaseConfig(t *testing.T) {
 
 	c.Database.Enabled = true
 	c.Database.Driver = "mssqlisthebest"
-	if err := c.checkDatabaseConfig(); err == nil {
+	if err := c.checkDatabaseConfig(true); err == nil {
 		t.Error("unexpected result")
 	}
 
 	c.Database.Driver = database.DBSQLite3
 	c.Database.Enabled = true
-	if err := c.checkDatabaseConfig(); err != nil {
+	if err := c.checkDatabaseConfig(true); err != nil {
 		t.Error(err)
 	}
 }
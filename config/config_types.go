@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/rebalance"
 )
 
 // Constants declared here are filename strings and test strings
@@ -30,6 +32,23 @@ const (
 	defaultWebsocketTrafficTimeout       = time.Second * 30
 	maxAuthFailures                      = 3
 	defaultNTPAllowedDifference          = 50000000
+	defaultPortfolioValuationInterval    = time.Hour
+	defaultRebalancerCheckInterval       = time.Hour
+	defaultWithdrawalApprovalExpiry      = time.Hour
+	defaultWithdrawalTrackerInterval     = time.Minute * 5
+	defaultWithdrawalStallTimeout        = time.Hour * 24
+	defaultDepositMonitorInterval        = time.Minute * 5
+	defaultDepositMatchWindow            = time.Hour * 24 * 7
+	defaultBalanceAlertChangeWindow      = time.Hour
+	defaultBalanceAlertCooldown          = time.Minute * 15
+	defaultMetricsListenAddress          = "localhost:9054"
+	defaultConfigWatchInterval           = time.Second * 10
+	defaultVaultTimeout                  = time.Second * 10
+	defaultWebhookTimeout                = time.Second * 10
+	defaultWebhookMaxRetries             = 3
+	defaultWebhookRetryDelay             = time.Second
+	defaultEventDigestInterval           = time.Minute * 15
+	defaultEventRateLimitInterval        = time.Minute
 	defaultNTPAllowedNegativeDifference  = 50000000
 	DefaultAPIKey                        = "Key"
 	DefaultAPISecret                     = "Secret"
@@ -69,28 +88,39 @@ const (
 var (
 	Cfg Config
 	m   sync.Mutex
+
+	errReloadEncryptedConfig = errors.New("encrypted config files cannot be reloaded as the key is not retained")
 )
 
 // Config is the overarching object that holds all the information for
 // prestart management of Portfolio, Communications, Webserver and Enabled
 // Exchanges
 type Config struct {
-	Name              string                  `json:"name"`
-	DataDirectory     string                  `json:"dataDirectory"`
-	EncryptConfig     int                     `json:"encryptConfig"`
-	GlobalHTTPTimeout time.Duration           `json:"globalHTTPTimeout"`
-	Database          database.Config         `json:"database"`
-	Logging           log.Config              `json:"logging"`
-	ConnectionMonitor ConnectionMonitorConfig `json:"connectionMonitor"`
-	Profiler          Profiler                `json:"profiler"`
-	NTPClient         NTPClientConfig         `json:"ntpclient"`
-	GCTScript         gctscript.Config        `json:"gctscript"`
-	Currency          CurrencyConfig          `json:"currencyConfig"`
-	Communications    CommunicationsConfig    `json:"communications"`
-	RemoteControl     RemoteControlConfig     `json:"remoteControl"`
-	Portfolio         portfolio.Base          `json:"portfolioAddresses"`
-	Exchanges         []ExchangeConfig        `json:"exchanges"`
-	BankAccounts      []banking.Account       `json:"bankAccounts"`
+	Name               string                   `json:"name"`
+	DataDirectory      string                   `json:"dataDirectory"`
+	EncryptConfig      int                      `json:"encryptConfig"`
+	GlobalHTTPTimeout  time.Duration            `json:"globalHTTPTimeout"`
+	Database           database.Config          `json:"database"`
+	Logging            log.Config               `json:"logging"`
+	ConnectionMonitor  ConnectionMonitorConfig  `json:"connectionMonitor"`
+	Profiler           Profiler                 `json:"profiler"`
+	NTPClient          NTPClientConfig          `json:"ntpclient"`
+	GCTScript          gctscript.Config         `json:"gctscript"`
+	Currency           CurrencyConfig           `json:"currencyConfig"`
+	Communications     CommunicationsConfig     `json:"communications"`
+	RemoteControl      RemoteControlConfig      `json:"remoteControl"`
+	Portfolio          portfolio.Base           `json:"portfolioAddresses"`
+	PortfolioValuation PortfolioValuationConfig `json:"portfolioValuation"`
+	Rebalancer         RebalancerConfig         `json:"rebalancer"`
+	WithdrawalApproval WithdrawalApprovalConfig `json:"withdrawalApproval"`
+	WithdrawalTracker  WithdrawalTrackerConfig  `json:"withdrawalTracker"`
+	DepositMonitor     DepositMonitorConfig     `json:"depositMonitor"`
+	BalanceAlerts      BalanceAlertConfig       `json:"balanceAlerts"`
+	Metrics            MetricsConfig            `json:"metrics"`
+	ConfigReload       ConfigReloadConfig       `json:"configReload"`
+	Secrets            SecretsConfig            `json:"secrets"`
+	Exchanges          []ExchangeConfig         `json:"exchanges"`
+	BankAccounts       []banking.Account        `json:"bankAccounts"`
 
 	// Deprecated config settings, will be removed at a future date
 	Webserver           *WebserverConfig          `json:"webserver,omitempty"`
@@ -111,6 +141,118 @@ type ConnectionMonitorConfig struct {
 	CheckInterval    time.Duration `json:"checkInterval"`
 }
 
+// PortfolioValuationConfig defines how often portfolio holdings are valued
+// and stored and which fiat currency they are valued in
+type PortfolioValuationConfig struct {
+	Enabled          bool          `json:"enabled"`
+	FiatCurrency     currency.Code `json:"fiatCurrency"`
+	SnapshotInterval time.Duration `json:"snapshotInterval"`
+}
+
+// RebalancerConfig defines the target exchange allocations per currency for
+// the inter-exchange rebalancer. In dry run mode transfers are proposed but
+// can never be approved for withdrawal
+type RebalancerConfig struct {
+	Enabled       bool               `json:"enabled"`
+	DryRun        bool               `json:"dryRun"`
+	CheckInterval time.Duration      `json:"checkInterval"`
+	Targets       []rebalance.Target `json:"targets"`
+}
+
+// WithdrawalApprovalConfig defines which withdrawals are held for operator
+// sign-off before being submitted to an exchange. A withdrawal is held when
+// its amount is above the threshold configured for its currency
+type WithdrawalApprovalConfig struct {
+	Enabled           bool                  `json:"enabled"`
+	RequiredApprovals int                   `json:"requiredApprovals"`
+	Expiry            time.Duration         `json:"expiry"`
+	Thresholds        []WithdrawalThreshold `json:"thresholds"`
+	Operators         []WithdrawalOperator  `json:"operators"`
+}
+
+// WithdrawalThreshold is the amount of a currency above which a withdrawal
+// requires approval
+type WithdrawalThreshold struct {
+	Currency currency.Code `json:"currency"`
+	Amount   float64       `json:"amount"`
+}
+
+// WithdrawalOperator is a person allowed to approve withdrawals. Approvals
+// are authenticated with a one-time code generated from the OTP secret
+type WithdrawalOperator struct {
+	Name      string `json:"name"`
+	OTPSecret string `json:"otpSecret"`
+}
+
+// WithdrawalTrackerConfig defines how often submitted withdrawals are matched
+// against exchange withdrawal history and when they are reported as stalled
+type WithdrawalTrackerConfig struct {
+	Enabled       bool          `json:"enabled"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	StallTimeout  time.Duration `json:"stallTimeout"`
+}
+
+// DepositMonitorConfig defines how often exchange funding history is checked
+// for new deposits and how far back deposits are matched to withdrawals.
+// ReportBalanceIncreases reports balance increases on exchanges without
+// funding history support, which may also be caused by trading
+type DepositMonitorConfig struct {
+	Enabled                bool          `json:"enabled"`
+	CheckInterval          time.Duration `json:"checkInterval"`
+	MatchWindow            time.Duration `json:"matchWindow"`
+	ReportBalanceIncreases bool          `json:"reportBalanceIncreases"`
+}
+
+// BalanceAlertConfig holds the rules evaluated against every exchange account
+// update. Cooldown is the minimum time between repeated change alerts for the
+// same exchange and currency
+type BalanceAlertConfig struct {
+	Enabled  bool               `json:"enabled"`
+	Cooldown time.Duration      `json:"cooldown"`
+	Rules    []BalanceAlertRule `json:"rules"`
+}
+
+// BalanceAlertRule alerts when a balance falls below an amount, changes by
+// more than a percentage within a window or when a currency not previously
+// held appears. An empty exchange or currency matches all
+type BalanceAlertRule struct {
+	Exchange      string        `json:"exchange"`
+	Currency      currency.Code `json:"currency"`
+	Below         float64       `json:"below,omitempty"`
+	ChangePercent float64       `json:"changePercent,omitempty"`
+	ChangeWindow  time.Duration `json:"changeWindow,omitempty"`
+	NewCurrency   bool          `json:"newCurrency,omitempty"`
+}
+
+// MetricsConfig defines the listen address of the Prometheus metrics endpoint
+type MetricsConfig struct {
+	Enabled       bool   `json:"enabled"`
+	ListenAddress string `json:"listenAddress"`
+}
+
+// ConfigReloadConfig defines whether the config file is watched for changes
+// and reloaded into the running engine
+type ConfigReloadConfig struct {
+	WatchFile     bool          `json:"watchFile"`
+	WatchInterval time.Duration `json:"watchInterval"`
+}
+
+// SecretsConfig defines the external stores exchange API credentials can be
+// resolved from
+type SecretsConfig struct {
+	Vault VaultSecretsConfig `json:"vault"`
+}
+
+// VaultSecretsConfig defines the Vault key-value store connection, the token
+// may reference an environment variable or file
+type VaultSecretsConfig struct {
+	Enabled   bool          `json:"enabled"`
+	Address   string        `json:"address"`
+	Token     string        `json:"token"`
+	Namespace string        `json:"namespace,omitempty"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
 	Name                          string                 `json:"name"`
@@ -190,10 +332,22 @@ type WebsocketRPCConfig struct {
 	AllowInsecureOrigin bool   `json:"allowInsecureOrigin"`
 }
 
-// RemoteControlConfig stores the RPC services config
-type RemoteControlConfig struct {
+// RemoteControlUser is a remote control identity authenticated by username
+// and password or by API token, granted the permissions of its role
+type RemoteControlUser struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"password,omitempty"`
+	APIToken string `json:"apiToken,omitempty"`
+	Role     string `json:"role"`
+}
+
+// RemoteControlConfig stores the RPC services config. Username and Password
+// are the administrator credentials, Users holds additional identities with
+// restricted roles
+type RemoteControlConfig struct {
+	Username string              `json:"username"`
+	Password string              `json:"password"`
+	Users    []RemoteControlUser `json:"users,omitempty"`
 
 	GRPC          GRPCConfig           `json:"gRPC"`
 	DeprecatedRPC DepcrecatedRPCConfig `json:"deprecatedRPC"`
@@ -254,10 +408,14 @@ type CryptocurrencyProvider struct {
 // CommunicationsConfig holds all the information needed for each
 // enabled communication package
 type CommunicationsConfig struct {
-	SlackConfig     SlackConfig     `json:"slack"`
-	SMSGlobalConfig SMSGlobalConfig `json:"smsGlobal"`
-	SMTPConfig      SMTPConfig      `json:"smtp"`
-	TelegramConfig  TelegramConfig  `json:"telegram"`
+	SlackConfig     SlackConfig        `json:"slack"`
+	SMSGlobalConfig SMSGlobalConfig    `json:"smsGlobal"`
+	SMTPConfig      SMTPConfig         `json:"smtp"`
+	TelegramConfig  TelegramConfig     `json:"telegram"`
+	WebhookConfig   WebhookConfig      `json:"webhook"`
+	DiscordConfig   DiscordConfig      `json:"discord"`
+	MatrixConfig    MatrixConfig       `json:"matrix"`
+	EventRouting    EventRoutingConfig `json:"eventRouting"`
 }
 
 // IsAnyEnabled returns whether or any any comms relayers
@@ -266,19 +424,52 @@ func (c *CommunicationsConfig) IsAnyEnabled() bool {
 	if c.SMSGlobalConfig.Enabled ||
 		c.SMTPConfig.Enabled ||
 		c.SlackConfig.Enabled ||
-		c.TelegramConfig.Enabled {
+		c.TelegramConfig.Enabled ||
+		c.WebhookConfig.Enabled ||
+		c.DiscordConfig.Enabled ||
+		c.MatrixConfig.Enabled {
 		return true
 	}
 	return false
 }
 
-// SlackConfig holds all variables to start and run the Slack package
+// EventRoutingConfig filters, rate limits, deduplicates and batches the events
+// pushed to communication relayers. Relayers without a route receive every
+// event. Repeated events within DeduplicationWindow are dropped, zero disables
+// deduplication, and DigestInterval sets how often batched events are sent as
+// a summary
+type EventRoutingConfig struct {
+	Enabled             bool               `json:"enabled"`
+	DeduplicationWindow time.Duration      `json:"deduplicationWindow"`
+	DigestInterval      time.Duration      `json:"digestInterval"`
+	Routes              []EventRouteConfig `json:"routes"`
+}
+
+// EventRouteConfig holds the subscription rules of a relayer by name.
+// Severities are info, trade, warning or critical. EventTypes restricts the
+// event types sent and MinSeverity drops less severe events. Up to MaxEvents
+// non critical events are sent per RateLimitInterval, zero is unlimited.
+// Events less severe than DigestSeverity, or over the rate limit, are batched
+// into the digest instead of being sent immediately
+type EventRouteConfig struct {
+	Relayer           string        `json:"relayer"`
+	EventTypes        []string      `json:"eventTypes,omitempty"`
+	MinSeverity       string        `json:"minSeverity,omitempty"`
+	MaxEvents         int           `json:"maxEvents,omitempty"`
+	RateLimitInterval time.Duration `json:"rateLimitInterval,omitempty"`
+	DigestSeverity    string        `json:"digestSeverity,omitempty"`
+}
+
+// SlackConfig holds all variables to start and run the Slack package.
+// AuthorisedUsers lists the Slack user IDs which may run commands restricted
+// to authorised users, such as trading commands
 type SlackConfig struct {
-	Name              string `json:"name"`
-	Enabled           bool   `json:"enabled"`
-	Verbose           bool   `json:"verbose"`
-	TargetChannel     string `json:"targetChannel"`
-	VerificationToken string `json:"verificationToken"`
+	Name              string   `json:"name"`
+	Enabled           bool     `json:"enabled"`
+	Verbose           bool     `json:"verbose"`
+	TargetChannel     string   `json:"targetChannel"`
+	VerificationToken string   `json:"verificationToken"`
+	AuthorisedUsers   []string `json:"authorisedUsers,omitempty"`
 }
 
 // SMSContact stores the SMS contact info
@@ -313,12 +504,65 @@ type SMTPConfig struct {
 	RecipientList   string `json:"recipientList"`
 }
 
-// TelegramConfig holds all variables to start and run the Telegram package
+// TelegramConfig holds all variables to start and run the Telegram package.
+// AuthorisedUsers lists the Telegram user IDs which receive events and may
+// run commands restricted to authorised users, such as trading commands
 type TelegramConfig struct {
-	Name              string `json:"name"`
-	Enabled           bool   `json:"enabled"`
-	Verbose           bool   `json:"verbose"`
-	VerificationToken string `json:"verificationToken"`
+	Name              string  `json:"name"`
+	Enabled           bool    `json:"enabled"`
+	Verbose           bool    `json:"verbose"`
+	VerificationToken string  `json:"verificationToken"`
+	AuthorisedUsers   []int64 `json:"authorisedUsers,omitempty"`
+}
+
+// WebhookConfig holds all variables to start and run the webhook package
+type WebhookConfig struct {
+	Name      string                  `json:"name"`
+	Enabled   bool                    `json:"enabled"`
+	Verbose   bool                    `json:"verbose"`
+	Endpoints []WebhookEndpointConfig `json:"endpoints"`
+}
+
+// WebhookEndpointConfig defines a URL events are posted to. Secret signs each
+// payload and may be a secret reference, Template overrides the default JSON
+// payload and EventTypes restricts the events posted, all events are posted
+// when it is empty
+type WebhookEndpointConfig struct {
+	URL        string            `json:"url"`
+	Secret     string            `json:"secret,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Template   string            `json:"template,omitempty"`
+	EventTypes []string          `json:"eventTypes,omitempty"`
+	Timeout    time.Duration     `json:"timeout"`
+	MaxRetries int               `json:"maxRetries"`
+	RetryDelay time.Duration     `json:"retryDelay"`
+}
+
+// DiscordConfig holds all variables to start and run the Discord package.
+// Token may be a secret reference and AuthorisedUsers lists the user IDs
+// which may run commands restricted to authorised users, such as trading
+// commands, nobody may when it is empty
+type DiscordConfig struct {
+	Name            string   `json:"name"`
+	Enabled         bool     `json:"enabled"`
+	Verbose         bool     `json:"verbose"`
+	Token           string   `json:"token"`
+	ChannelID       string   `json:"channelID"`
+	AuthorisedUsers []string `json:"authorisedUsers,omitempty"`
+}
+
+// MatrixConfig holds all variables to start and run the Matrix package.
+// AccessToken may be a secret reference and AuthorisedUsers lists the user
+// IDs which may run commands restricted to authorised users, such as trading
+// commands, nobody may when it is empty
+type MatrixConfig struct {
+	Name            string   `json:"name"`
+	Enabled         bool     `json:"enabled"`
+	Verbose         bool     `json:"verbose"`
+	HomeserverURL   string   `json:"homeserverURL"`
+	AccessToken     string   `json:"accessToken"`
+	RoomID          string   `json:"roomID"`
+	AuthorisedUsers []string `json:"authorisedUsers,omitempty"`
 }
 
 // FeaturesSupportedConfig stores the exchanges supported features
@@ -356,6 +600,19 @@ type APICredentialsConfig struct {
 	ClientID  string `json:"clientID,omitempty"`
 	PEMKey    string `json:"pemKey,omitempty"`
 	OTPSecret string `json:"otpSecret,omitempty"`
+
+	// references holds the secret references the credentials were resolved
+	// from, they are saved instead of the secrets
+	references apiCredentialReferences
+}
+
+// apiCredentialReferences stores the secret reference of each credential
+type apiCredentialReferences struct {
+	Key       string
+	Secret    string
+	ClientID  string
+	PEMKey    string
+	OTPSecret string
 }
 
 // APICredentialsValidatorConfig stores the API credentials validator settings
@@ -378,6 +635,25 @@ type APIConfig struct {
 	Endpoints            APIEndpointsConfig             `json:"endpoints"`
 	Credentials          APICredentialsConfig           `json:"credentials"`
 	CredentialsValidator *APICredentialsValidatorConfig `json:"credentialsValidator,omitempty"`
+	Accounts             []APIAccountConfig             `json:"accounts,omitempty"`
+	Permissions          *APIPermissionsConfig          `json:"permissions,omitempty"`
+}
+
+// APIPermissionsConfig declares the rights the exchange API keys are expected
+// to hold, with RefuseExcess set the engine refuses to start when a key holds
+// more rights than declared or its permissions cannot be checked
+type APIPermissionsConfig struct {
+	Trade                bool `json:"trade"`
+	Withdraw             bool `json:"withdraw"`
+	RequireIPRestriction bool `json:"requireIPRestriction"`
+	RefuseExcess         bool `json:"refuseExcess"`
+}
+
+// APIAccountConfig stores a named credential set for an additional account or
+// sub-account on the same exchange
+type APIAccountConfig struct {
+	Name        string               `json:"name"`
+	Credentials APICredentialsConfig `json:"credentials"`
 }
 
 // OrderbookConfig stores the orderbook configuration variables
@@ -41,6 +41,52 @@ func (p *PairsManager) Store(a asset.Item, ps PairStore) {
 	p.m.Unlock()
 }
 
+// Copy returns a deep copy of the pairs manager, changes to the pairs,
+// formats or enabled assets of either do not affect the other
+func (p *PairsManager) Copy() *PairsManager {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	c := &PairsManager{
+		RequestFormat:   copyFormat(p.RequestFormat),
+		ConfigFormat:    copyFormat(p.ConfigFormat),
+		UseGlobalFormat: p.UseGlobalFormat,
+		LastUpdated:     p.LastUpdated,
+	}
+	if p.Pairs == nil {
+		return c
+	}
+	c.Pairs = make(map[asset.Item]*PairStore, len(p.Pairs))
+	for a, ps := range p.Pairs {
+		if ps == nil {
+			c.Pairs[a] = nil
+			continue
+		}
+		store := &PairStore{
+			RequestFormat: copyFormat(ps.RequestFormat),
+			ConfigFormat:  copyFormat(ps.ConfigFormat),
+		}
+		if ps.AssetEnabled != nil {
+			store.AssetEnabled = convert.BoolPtr(*ps.AssetEnabled)
+		}
+		if ps.Enabled != nil {
+			store.Enabled = append(Pairs{}, ps.Enabled...)
+		}
+		if ps.Available != nil {
+			store.Available = append(Pairs{}, ps.Available...)
+		}
+		c.Pairs[a] = store
+	}
+	return c
+}
+
+func copyFormat(f *PairFormat) *PairFormat {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
+
 // Delete deletes a map entry based on the supplied asset type
 func (p *PairsManager) Delete(a asset.Item) {
 	p.m.Lock()
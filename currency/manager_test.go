@@ -347,3 +347,34 @@ func TestIsAssetEnabled_SetAssetEnabled(t *testing.T) {
 		t.Error("unexpected result")
 	}
 }
+
+func TestCopy(t *testing.T) {
+	initTest(t)
+	if err := p.SetAssetEnabled(asset.Spot, true); err != nil {
+		t.Fatal(err)
+	}
+
+	c := p.Copy()
+	pair := NewPair(BTC, USD)
+	if err := c.DisablePair(asset.Spot, pair); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetAssetEnabled(asset.Spot, false); err != nil {
+		t.Fatal(err)
+	}
+	c.Pairs[asset.Spot].ConfigFormat.Delimiter = "_"
+
+	enabled, err := p.GetPairs(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !enabled.Contains(pair, true) {
+		t.Error("disabling a pair on the copy should not affect the original")
+	}
+	if err = p.IsAssetEnabled(asset.Spot); err != nil {
+		t.Error("disabling an asset on the copy should not affect the original")
+	}
+	if p.Pairs[asset.Spot].ConfigFormat.Delimiter != "-" {
+		t.Error("changing a format on the copy should not affect the original")
+	}
+}
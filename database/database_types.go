@@ -18,11 +18,13 @@ type Instance struct {
 	Mu        sync.RWMutex
 }
 
-// Config holds all database configurable options including enable/disabled & DSN settings
+// Config holds all database configurable options including enable/disabled & DSN settings.
+// AuditSigningKey signs audit event exports and may be a secret reference
 type Config struct {
 	Enabled                   bool   `json:"enabled"`
 	Verbose                   bool   `json:"verbose"`
 	Driver                    string `json:"driver"`
+	AuditSigningKey           string `json:"auditSigningKey,omitempty"`
 	drivers.ConnectionDetails `json:"connectionDetails"`
 }
 
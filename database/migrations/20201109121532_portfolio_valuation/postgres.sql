@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS portfolio_valuation
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    source varchar(128) NOT NULL,
+    currency varchar(30) NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    fiat_currency varchar(30) NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniqueportfoliovaluation
+        unique(source, currency, fiat_currency, timestamp)
+);
+-- +goose Down
+DROP TABLE portfolio_valuation;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS portfolio_valuation
+(
+    id text not null primary key,
+    source text NOT NULL,
+    currency text NOT NULL,
+    amount REAL NOT NULL,
+    price REAL NOT NULL,
+    value REAL NOT NULL,
+    fiat_currency text NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    CONSTRAINT uniqueportfoliovaluation
+        unique(source, currency, fiat_currency, timestamp) ON CONFLICT IGNORE
+);
+-- +goose Down
+DROP TABLE portfolio_valuation;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE withdrawal_crypto ADD COLUMN tx_id text NULL;
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+CREATE TABLE IF NOT EXISTS withdrawal_crypto_new
+(
+    id	        integer not null primary key,
+    address                   text NOT NULL,
+    address_tag               text NULL,
+    fee                       real NOT NULL,
+    withdrawal_history_id  text NOT NULL,
+    FOREIGN KEY(withdrawal_history_id) REFERENCES withdrawal_history(id) ON DELETE RESTRICT
+);
+INSERT INTO
+    withdrawal_crypto_new (id, address, address_tag, fee, withdrawal_history_id)
+SELECT
+    id, address, address_tag, fee, withdrawal_history_id
+FROM
+    withdrawal_crypto;
+
+DROP TABLE withdrawal_crypto;
+
+ALTER TABLE withdrawal_crypto_new RENAME TO withdrawal_crypto;
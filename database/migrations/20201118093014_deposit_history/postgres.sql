@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS deposit_history
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange_name_id uuid NOT NULL REFERENCES exchange(id),
+    exchange_id text NOT NULL,
+    status varchar(255) NOT NULL,
+    currency text NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    fee DOUBLE PRECISION NOT NULL DEFAULT 0,
+    address text NULL,
+    tx_id text NULL,
+    withdrawal_history_id uuid NULL REFERENCES withdrawal_history(id),
+    deposited_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT uniquedeposithistory
+        unique(exchange_name_id, exchange_id)
+);
+-- +goose Down
+DROP TABLE deposit_history;
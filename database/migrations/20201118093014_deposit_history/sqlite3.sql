@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS deposit_history
+(
+    id text not null primary key,
+    exchange_name_id text NOT NULL,
+    exchange_id text NOT NULL,
+    status text NOT NULL,
+    currency text NOT NULL,
+    amount REAL NOT NULL,
+    fee REAL NOT NULL DEFAULT 0,
+    address text NULL,
+    tx_id text NULL,
+    withdrawal_history_id text NULL,
+    deposited_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(exchange_name_id) REFERENCES exchange(id) ON DELETE RESTRICT,
+    FOREIGN KEY(withdrawal_history_id) REFERENCES withdrawal_history(id) ON DELETE RESTRICT,
+    CONSTRAINT uniquedeposithistory
+        unique(exchange_name_id, exchange_id) ON CONFLICT IGNORE
+);
+-- +goose Down
+DROP TABLE deposit_history;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE audit_event ADD COLUMN prev_hash text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN hash text NOT NULL DEFAULT '';
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+CREATE TABLE "audit_event_new" (
+    id	        integer not null primary key,
+    type    	text not null,
+    identifier	text not null,
+    message	    text not null,
+    created_at  timestamp not null default CURRENT_TIMESTAMP
+);
+INSERT INTO
+    audit_event_new (id, type, identifier, message, created_at)
+SELECT
+    id, type, identifier, message, created_at
+FROM
+    audit_event;
+
+DROP TABLE audit_event;
+
+ALTER TABLE audit_event_new RENAME TO audit_event;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE withdrawal_history ADD COLUMN account_id text NULL;
+ALTER TABLE withdrawal_history ADD COLUMN approvers text NULL;
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE withdrawal_history DROP COLUMN approvers;
+ALTER TABLE withdrawal_history DROP COLUMN account_id;
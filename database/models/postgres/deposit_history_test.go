@@ -0,0 +1,1000 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testDepositHistories(t *testing.T) {
+	t.Parallel()
+
+	query := DepositHistories()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testDepositHistoriesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testDepositHistoriesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := DepositHistories().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testDepositHistoriesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := DepositHistorySlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testDepositHistoriesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := DepositHistoryExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if DepositHistory exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected DepositHistoryExists to return true, but got false.")
+	}
+}
+
+func testDepositHistoriesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	depositHistoryFound, err := FindDepositHistory(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if depositHistoryFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testDepositHistoriesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = DepositHistories().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testDepositHistoriesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := DepositHistories().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testDepositHistoriesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	depositHistoryOne := &DepositHistory{}
+	depositHistoryTwo := &DepositHistory{}
+	if err = randomize.Struct(seed, depositHistoryOne, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+	if err = randomize.Struct(seed, depositHistoryTwo, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = depositHistoryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = depositHistoryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := DepositHistories().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testDepositHistoriesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	depositHistoryOne := &DepositHistory{}
+	depositHistoryTwo := &DepositHistory{}
+	if err = randomize.Struct(seed, depositHistoryOne, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+	if err = randomize.Struct(seed, depositHistoryTwo, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = depositHistoryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = depositHistoryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func depositHistoryBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func depositHistoryAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *DepositHistory) error {
+	*o = DepositHistory{}
+	return nil
+}
+
+func testDepositHistoriesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &DepositHistory{}
+	o := &DepositHistory{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize DepositHistory object: %s", err)
+	}
+
+	AddDepositHistoryHook(boil.BeforeInsertHook, depositHistoryBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryBeforeInsertHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.AfterInsertHook, depositHistoryAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryAfterInsertHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.AfterSelectHook, depositHistoryAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryAfterSelectHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.BeforeUpdateHook, depositHistoryBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryBeforeUpdateHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.AfterUpdateHook, depositHistoryAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryAfterUpdateHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.BeforeDeleteHook, depositHistoryBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryBeforeDeleteHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.AfterDeleteHook, depositHistoryAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryAfterDeleteHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.BeforeUpsertHook, depositHistoryBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryBeforeUpsertHooks = []DepositHistoryHook{}
+
+	AddDepositHistoryHook(boil.AfterUpsertHook, depositHistoryAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	depositHistoryAfterUpsertHooks = []DepositHistoryHook{}
+}
+
+func testDepositHistoriesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testDepositHistoriesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(depositHistoryColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testDepositHistoryToOneExchangeUsingExchangeName(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local DepositHistory
+	var foreign Exchange
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, exchangeDBTypes, false, exchangeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Exchange struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.ExchangeNameID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.ExchangeName().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := DepositHistorySlice{&local}
+	if err = local.L.LoadExchangeName(ctx, tx, false, (*[]*DepositHistory)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeName == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.ExchangeName = nil
+	if err = local.L.LoadExchangeName(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeName == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testDepositHistoryToOneWithdrawalHistoryUsingWithdrawalHistory(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local DepositHistory
+	var foreign WithdrawalHistory
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, withdrawalHistoryDBTypes, false, withdrawalHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WithdrawalHistory struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	queries.Assign(&local.WithdrawalHistoryID, foreign.ID)
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.WithdrawalHistory().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !queries.Equal(check.ID, foreign.ID) {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := DepositHistorySlice{&local}
+	if err = local.L.LoadWithdrawalHistory(ctx, tx, false, (*[]*DepositHistory)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.WithdrawalHistory == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.WithdrawalHistory = nil
+	if err = local.L.LoadWithdrawalHistory(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.WithdrawalHistory == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testDepositHistoryToOneSetOpExchangeUsingExchangeName(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a DepositHistory
+	var b, c Exchange
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, exchangeDBTypes, false, strmangle.SetComplement(exchangePrimaryKeyColumns, exchangeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, exchangeDBTypes, false, strmangle.SetComplement(exchangePrimaryKeyColumns, exchangeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Exchange{&b, &c} {
+		err = a.SetExchangeName(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.ExchangeName != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.ExchangeNameDepositHistories[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.ExchangeNameID != x.ID {
+			t.Error("foreign key was wrong value", a.ExchangeNameID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ExchangeNameID))
+		reflect.Indirect(reflect.ValueOf(&a.ExchangeNameID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ExchangeNameID != x.ID {
+			t.Error("foreign key was wrong value", a.ExchangeNameID, x.ID)
+		}
+	}
+}
+func testDepositHistoryToOneSetOpWithdrawalHistoryUsingWithdrawalHistory(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a DepositHistory
+	var b, c WithdrawalHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*WithdrawalHistory{&b, &c} {
+		err = a.SetWithdrawalHistory(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.WithdrawalHistory != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.DepositHistories[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if !queries.Equal(a.WithdrawalHistoryID, x.ID) {
+			t.Error("foreign key was wrong value", a.WithdrawalHistoryID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.WithdrawalHistoryID))
+		reflect.Indirect(reflect.ValueOf(&a.WithdrawalHistoryID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if !queries.Equal(a.WithdrawalHistoryID, x.ID) {
+			t.Error("foreign key was wrong value", a.WithdrawalHistoryID, x.ID)
+		}
+	}
+}
+
+func testDepositHistoryToOneRemoveOpWithdrawalHistoryUsingWithdrawalHistory(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a DepositHistory
+	var b WithdrawalHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.SetWithdrawalHistory(ctx, tx, true, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.RemoveWithdrawalHistory(ctx, tx, &b); err != nil {
+		t.Error("failed to remove relationship")
+	}
+
+	count, err := a.WithdrawalHistory().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Error("want no relationships remaining")
+	}
+
+	if a.R.WithdrawalHistory != nil {
+		t.Error("R struct entry should be nil")
+	}
+
+	if !queries.IsValuerNil(a.WithdrawalHistoryID) {
+		t.Error("foreign key value should be nil")
+	}
+
+	if len(b.R.DepositHistories) != 0 {
+		t.Error("failed to remove a from b's relationships")
+	}
+}
+
+func testDepositHistoriesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testDepositHistoriesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := DepositHistorySlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testDepositHistoriesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := DepositHistories().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	depositHistoryDBTypes = map[string]string{`ID`: `uuid`, `ExchangeNameID`: `uuid`, `ExchangeID`: `text`, `Status`: `character varying`, `Currency`: `text`, `Amount`: `double precision`, `Fee`: `double precision`, `Address`: `text`, `TXID`: `text`, `WithdrawalHistoryID`: `uuid`, `DepositedAt`: `timestamp with time zone`, `CreatedAt`: `timestamp without time zone`}
+	_                     = bytes.MinRead
+)
+
+func testDepositHistoriesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(depositHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(depositHistoryAllColumns) == len(depositHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testDepositHistoriesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(depositHistoryAllColumns) == len(depositHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &DepositHistory{}
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, depositHistoryDBTypes, true, depositHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(depositHistoryAllColumns, depositHistoryPrimaryKeyColumns) {
+		fields = depositHistoryAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			depositHistoryAllColumns,
+			depositHistoryPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := DepositHistorySlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testDepositHistoriesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(depositHistoryAllColumns) == len(depositHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := DepositHistory{}
+	if err = randomize.Struct(seed, &o, depositHistoryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert DepositHistory: %s", err)
+	}
+
+	count, err := DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, depositHistoryDBTypes, false, depositHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize DepositHistory struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert DepositHistory: %s", err)
+	}
+
+	count, err = DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testPortfolioValuations(t *testing.T) {
+	t.Parallel()
+
+	query := PortfolioValuations()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testPortfolioValuationsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPortfolioValuationsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := PortfolioValuations().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPortfolioValuationsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := PortfolioValuationSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPortfolioValuationsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := PortfolioValuationExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if PortfolioValuation exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected PortfolioValuationExists to return true, but got false.")
+	}
+}
+
+func testPortfolioValuationsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	portfolioValuationFound, err := FindPortfolioValuation(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if portfolioValuationFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testPortfolioValuationsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = PortfolioValuations().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPortfolioValuationsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := PortfolioValuations().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testPortfolioValuationsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	portfolioValuationOne := &PortfolioValuation{}
+	portfolioValuationTwo := &PortfolioValuation{}
+	if err = randomize.Struct(seed, portfolioValuationOne, portfolioValuationDBTypes, false, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+	if err = randomize.Struct(seed, portfolioValuationTwo, portfolioValuationDBTypes, false, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = portfolioValuationOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = portfolioValuationTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := PortfolioValuations().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testPortfolioValuationsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	portfolioValuationOne := &PortfolioValuation{}
+	portfolioValuationTwo := &PortfolioValuation{}
+	if err = randomize.Struct(seed, portfolioValuationOne, portfolioValuationDBTypes, false, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+	if err = randomize.Struct(seed, portfolioValuationTwo, portfolioValuationDBTypes, false, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = portfolioValuationOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = portfolioValuationTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func portfolioValuationBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func portfolioValuationAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *PortfolioValuation) error {
+	*o = PortfolioValuation{}
+	return nil
+}
+
+func testPortfolioValuationsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &PortfolioValuation{}
+	o := &PortfolioValuation{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation object: %s", err)
+	}
+
+	AddPortfolioValuationHook(boil.BeforeInsertHook, portfolioValuationBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationBeforeInsertHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.AfterInsertHook, portfolioValuationAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationAfterInsertHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.AfterSelectHook, portfolioValuationAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationAfterSelectHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.BeforeUpdateHook, portfolioValuationBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationBeforeUpdateHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.AfterUpdateHook, portfolioValuationAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationAfterUpdateHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.BeforeDeleteHook, portfolioValuationBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationBeforeDeleteHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.AfterDeleteHook, portfolioValuationAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationAfterDeleteHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.BeforeUpsertHook, portfolioValuationBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationBeforeUpsertHooks = []PortfolioValuationHook{}
+
+	AddPortfolioValuationHook(boil.AfterUpsertHook, portfolioValuationAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	portfolioValuationAfterUpsertHooks = []PortfolioValuationHook{}
+}
+
+func testPortfolioValuationsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testPortfolioValuationsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(portfolioValuationColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testPortfolioValuationsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPortfolioValuationsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := PortfolioValuationSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPortfolioValuationsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := PortfolioValuations().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	portfolioValuationDBTypes = map[string]string{`ID`: `uuid`, `Source`: `character varying`, `Currency`: `character varying`, `Amount`: `double precision`, `Price`: `double precision`, `Value`: `double precision`, `FiatCurrency`: `character varying`, `Timestamp`: `timestamp with time zone`}
+	_                         = bytes.MinRead
+)
+
+func testPortfolioValuationsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(portfolioValuationPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(portfolioValuationAllColumns) == len(portfolioValuationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testPortfolioValuationsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(portfolioValuationAllColumns) == len(portfolioValuationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PortfolioValuation{}
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, portfolioValuationDBTypes, true, portfolioValuationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(portfolioValuationAllColumns, portfolioValuationPrimaryKeyColumns) {
+		fields = portfolioValuationAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			portfolioValuationAllColumns,
+			portfolioValuationPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := PortfolioValuationSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testPortfolioValuationsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(portfolioValuationAllColumns) == len(portfolioValuationPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := PortfolioValuation{}
+	if err = randomize.Struct(seed, &o, portfolioValuationDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert PortfolioValuation: %s", err)
+	}
+
+	count, err := PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, portfolioValuationDBTypes, false, portfolioValuationPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PortfolioValuation struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert PortfolioValuation: %s", err)
+	}
+
+	count, err = PortfolioValuations().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -29,6 +29,7 @@ type WithdrawalCrypto struct {
 	Address            string      `boil:"address" json:"address" toml:"address" yaml:"address"`
 	AddressTag         null.String `boil:"address_tag" json:"address_tag,omitempty" toml:"address_tag" yaml:"address_tag,omitempty"`
 	Fee                float64     `boil:"fee" json:"fee" toml:"fee" yaml:"fee"`
+	TXID               null.String `boil:"tx_id" json:"tx_id,omitempty" toml:"tx_id" yaml:"tx_id,omitempty"`
 
 	R *withdrawalCryptoR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L withdrawalCryptoL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -40,12 +41,14 @@ var WithdrawalCryptoColumns = struct {
 	Address            string
 	AddressTag         string
 	Fee                string
+	TXID               string
 }{
 	ID:                 "id",
 	WithdrawalCryptoID: "withdrawal_crypto_id",
 	Address:            "address",
 	AddressTag:         "address_tag",
 	Fee:                "fee",
+	TXID:               "tx_id",
 }
 
 // Generated where
@@ -56,12 +59,14 @@ var WithdrawalCryptoWhere = struct {
 	Address            whereHelperstring
 	AddressTag         whereHelpernull_String
 	Fee                whereHelperfloat64
+	TXID               whereHelpernull_String
 }{
 	ID:                 whereHelperint64{field: "\"withdrawal_crypto\".\"id\""},
 	WithdrawalCryptoID: whereHelpernull_String{field: "\"withdrawal_crypto\".\"withdrawal_crypto_id\""},
 	Address:            whereHelperstring{field: "\"withdrawal_crypto\".\"address\""},
 	AddressTag:         whereHelpernull_String{field: "\"withdrawal_crypto\".\"address_tag\""},
 	Fee:                whereHelperfloat64{field: "\"withdrawal_crypto\".\"fee\""},
+	TXID:               whereHelpernull_String{field: "\"withdrawal_crypto\".\"tx_id\""},
 }
 
 // WithdrawalCryptoRels is where relationship names are stored.
@@ -85,8 +90,8 @@ func (*withdrawalCryptoR) NewStruct() *withdrawalCryptoR {
 type withdrawalCryptoL struct{}
 
 var (
-	withdrawalCryptoAllColumns            = []string{"id", "withdrawal_crypto_id", "address", "address_tag", "fee"}
-	withdrawalCryptoColumnsWithoutDefault = []string{"withdrawal_crypto_id", "address", "address_tag", "fee"}
+	withdrawalCryptoAllColumns            = []string{"id", "withdrawal_crypto_id", "address", "address_tag", "fee", "tx_id"}
+	withdrawalCryptoColumnsWithoutDefault = []string{"withdrawal_crypto_id", "address", "address_tag", "fee", "tx_id"}
 	withdrawalCryptoColumnsWithDefault    = []string{"id"}
 	withdrawalCryptoPrimaryKeyColumns     = []string{"id"}
 )
@@ -728,7 +728,7 @@ func testWithdrawalCryptosSelect(t *testing.T) {
 }
 
 var (
-	withdrawalCryptoDBTypes = map[string]string{`ID`: `bigint`, `WithdrawalCryptoID`: `uuid`, `Address`: `text`, `AddressTag`: `text`, `Fee`: `double precision`}
+	withdrawalCryptoDBTypes = map[string]string{`ID`: `bigint`, `WithdrawalCryptoID`: `uuid`, `Address`: `text`, `AddressTag`: `text`, `Fee`: `double precision`, `TXID`: `text`}
 	_                       = bytes.MinRead
 )
 
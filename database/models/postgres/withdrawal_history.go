@@ -34,6 +34,8 @@ type WithdrawalHistory struct {
 	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 	ExchangeNameID string      `boil:"exchange_name_id" json:"exchange_name_id" toml:"exchange_name_id" yaml:"exchange_name_id"`
+	AccountID      null.String `boil:"account_id" json:"account_id,omitempty" toml:"account_id" yaml:"account_id,omitempty"`
+	Approvers      null.String `boil:"approvers" json:"approvers,omitempty" toml:"approvers" yaml:"approvers,omitempty"`
 
 	R *withdrawalHistoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L withdrawalHistoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -50,6 +52,8 @@ var WithdrawalHistoryColumns = struct {
 	CreatedAt      string
 	UpdatedAt      string
 	ExchangeNameID string
+	AccountID      string
+	Approvers      string
 }{
 	ID:             "id",
 	ExchangeID:     "exchange_id",
@@ -61,6 +65,8 @@ var WithdrawalHistoryColumns = struct {
 	CreatedAt:      "created_at",
 	UpdatedAt:      "updated_at",
 	ExchangeNameID: "exchange_name_id",
+	AccountID:      "account_id",
+	Approvers:      "approvers",
 }
 
 // Generated where
@@ -92,6 +98,8 @@ var WithdrawalHistoryWhere = struct {
 	CreatedAt      whereHelpertime_Time
 	UpdatedAt      whereHelpertime_Time
 	ExchangeNameID whereHelperstring
+	AccountID      whereHelpernull_String
+	Approvers      whereHelpernull_String
 }{
 	ID:             whereHelperstring{field: "\"withdrawal_history\".\"id\""},
 	ExchangeID:     whereHelperstring{field: "\"withdrawal_history\".\"exchange_id\""},
@@ -103,15 +111,19 @@ var WithdrawalHistoryWhere = struct {
 	CreatedAt:      whereHelpertime_Time{field: "\"withdrawal_history\".\"created_at\""},
 	UpdatedAt:      whereHelpertime_Time{field: "\"withdrawal_history\".\"updated_at\""},
 	ExchangeNameID: whereHelperstring{field: "\"withdrawal_history\".\"exchange_name_id\""},
+	AccountID:      whereHelpernull_String{field: "\"withdrawal_history\".\"account_id\""},
+	Approvers:      whereHelpernull_String{field: "\"withdrawal_history\".\"approvers\""},
 }
 
 // WithdrawalHistoryRels is where relationship names are stored.
 var WithdrawalHistoryRels = struct {
 	ExchangeName                      string
+	DepositHistories                  string
 	WithdrawalCryptoWithdrawalCryptos string
 	WithdrawalFiatWithdrawalFiats     string
 }{
 	ExchangeName:                      "ExchangeName",
+	DepositHistories:                  "DepositHistories",
 	WithdrawalCryptoWithdrawalCryptos: "WithdrawalCryptoWithdrawalCryptos",
 	WithdrawalFiatWithdrawalFiats:     "WithdrawalFiatWithdrawalFiats",
 }
@@ -119,6 +131,7 @@ var WithdrawalHistoryRels = struct {
 // withdrawalHistoryR is where relationships are stored.
 type withdrawalHistoryR struct {
 	ExchangeName                      *Exchange
+	DepositHistories                  DepositHistorySlice
 	WithdrawalCryptoWithdrawalCryptos WithdrawalCryptoSlice
 	WithdrawalFiatWithdrawalFiats     WithdrawalFiatSlice
 }
@@ -132,8 +145,8 @@ func (*withdrawalHistoryR) NewStruct() *withdrawalHistoryR {
 type withdrawalHistoryL struct{}
 
 var (
-	withdrawalHistoryAllColumns            = []string{"id", "exchange_id", "status", "currency", "amount", "description", "withdraw_type", "created_at", "updated_at", "exchange_name_id"}
-	withdrawalHistoryColumnsWithoutDefault = []string{"exchange_id", "status", "currency", "amount", "description", "withdraw_type", "exchange_name_id"}
+	withdrawalHistoryAllColumns            = []string{"id", "exchange_id", "status", "currency", "amount", "description", "withdraw_type", "created_at", "updated_at", "exchange_name_id", "account_id", "approvers"}
+	withdrawalHistoryColumnsWithoutDefault = []string{"exchange_id", "status", "currency", "amount", "description", "withdraw_type", "exchange_name_id", "account_id", "approvers"}
 	withdrawalHistoryColumnsWithDefault    = []string{"id", "created_at", "updated_at"}
 	withdrawalHistoryPrimaryKeyColumns     = []string{"id"}
 )
@@ -427,6 +440,27 @@ func (o *WithdrawalHistory) ExchangeName(mods ...qm.QueryMod) exchangeQuery {
 	return query
 }
 
+// DepositHistories retrieves all the deposit_history's DepositHistories with an executor.
+func (o *WithdrawalHistory) DepositHistories(mods ...qm.QueryMod) depositHistoryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"deposit_history\".\"withdrawal_history_id\"=?", o.ID),
+	)
+
+	query := DepositHistories(queryMods...)
+	queries.SetFrom(query.Query, "\"deposit_history\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"deposit_history\".*"})
+	}
+
+	return query
+}
+
 // WithdrawalCryptoWithdrawalCryptos retrieves all the withdrawal_crypto's WithdrawalCryptos with an executor via withdrawal_crypto_id column.
 func (o *WithdrawalHistory) WithdrawalCryptoWithdrawalCryptos(mods ...qm.QueryMod) withdrawalCryptoQuery {
 	var queryMods []qm.QueryMod
@@ -570,6 +604,101 @@ func (withdrawalHistoryL) LoadExchangeName(ctx context.Context, e boil.ContextEx
 	return nil
 }
 
+// LoadDepositHistories allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (withdrawalHistoryL) LoadDepositHistories(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWithdrawalHistory interface{}, mods queries.Applicator) error {
+	var slice []*WithdrawalHistory
+	var object *WithdrawalHistory
+
+	if singular {
+		object = maybeWithdrawalHistory.(*WithdrawalHistory)
+	} else {
+		slice = *maybeWithdrawalHistory.(*[]*WithdrawalHistory)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &withdrawalHistoryR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &withdrawalHistoryR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`deposit_history`), qm.WhereIn(`deposit_history.withdrawal_history_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load deposit_history")
+	}
+
+	var resultSlice []*DepositHistory
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice deposit_history")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on deposit_history")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for deposit_history")
+	}
+
+	if len(depositHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.DepositHistories = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &depositHistoryR{}
+			}
+			foreign.R.WithdrawalHistory = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.WithdrawalHistoryID) {
+				local.R.DepositHistories = append(local.R.DepositHistories, foreign)
+				if foreign.R == nil {
+					foreign.R = &depositHistoryR{}
+				}
+				foreign.R.WithdrawalHistory = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadWithdrawalCryptoWithdrawalCryptos allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (withdrawalHistoryL) LoadWithdrawalCryptoWithdrawalCryptos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWithdrawalHistory interface{}, mods queries.Applicator) error {
@@ -807,6 +936,129 @@ func (o *WithdrawalHistory) SetExchangeName(ctx context.Context, exec boil.Conte
 	return nil
 }
 
+// AddDepositHistories adds the given related objects to the existing relationships
+// of the withdrawal_history, optionally inserting them as new records.
+// Appends related to o.R.DepositHistories.
+// Sets related.R.WithdrawalHistory appropriately.
+func (o *WithdrawalHistory) AddDepositHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*DepositHistory) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.WithdrawalHistoryID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"deposit_history\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"withdrawal_history_id"}),
+				strmangle.WhereClause("\"", "\"", 2, depositHistoryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.WithdrawalHistoryID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &withdrawalHistoryR{
+			DepositHistories: related,
+		}
+	} else {
+		o.R.DepositHistories = append(o.R.DepositHistories, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &depositHistoryR{
+				WithdrawalHistory: o,
+			}
+		} else {
+			rel.R.WithdrawalHistory = o
+		}
+	}
+	return nil
+}
+
+// SetDepositHistories removes all previously related items of the
+// withdrawal_history replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.WithdrawalHistory's DepositHistories accordingly.
+// Replaces o.R.DepositHistories with related.
+// Sets related.R.WithdrawalHistory's DepositHistories accordingly.
+func (o *WithdrawalHistory) SetDepositHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*DepositHistory) error {
+	query := "update \"deposit_history\" set \"withdrawal_history_id\" = null where \"withdrawal_history_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.DepositHistories {
+			queries.SetScanner(&rel.WithdrawalHistoryID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.WithdrawalHistory = nil
+		}
+
+		o.R.DepositHistories = nil
+	}
+	return o.AddDepositHistories(ctx, exec, insert, related...)
+}
+
+// RemoveDepositHistories relationships from objects passed in.
+// Removes related items from R.DepositHistories (uses pointer comparison, removal does not keep order)
+// Sets related.R.WithdrawalHistory.
+func (o *WithdrawalHistory) RemoveDepositHistories(ctx context.Context, exec boil.ContextExecutor, related ...*DepositHistory) error {
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.WithdrawalHistoryID, nil)
+		if rel.R != nil {
+			rel.R.WithdrawalHistory = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("withdrawal_history_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.DepositHistories {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.DepositHistories)
+			if ln > 1 && i < ln-1 {
+				o.R.DepositHistories[i] = o.R.DepositHistories[ln-1]
+			}
+			o.R.DepositHistories = o.R.DepositHistories[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // AddWithdrawalCryptoWithdrawalCryptos adds the given related objects to the existing relationships
 // of the withdrawal_history, optionally inserting them as new records.
 // Appends related to o.R.WithdrawalCryptoWithdrawalCryptos.
@@ -494,6 +494,83 @@ func testWithdrawalHistoriesInsertWhitelist(t *testing.T) {
 	}
 }
 
+func testWithdrawalHistoryToManyDepositHistories(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a WithdrawalHistory
+	var b, c DepositHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, withdrawalHistoryDBTypes, true, withdrawalHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WithdrawalHistory struct: %s", err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = randomize.Struct(seed, &b, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, depositHistoryDBTypes, false, depositHistoryColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+
+	queries.Assign(&b.WithdrawalHistoryID, a.ID)
+	queries.Assign(&c.WithdrawalHistoryID, a.ID)
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := a.DepositHistories().All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bFound, cFound := false, false
+	for _, v := range check {
+		if queries.Equal(v.WithdrawalHistoryID, b.WithdrawalHistoryID) {
+			bFound = true
+		}
+		if queries.Equal(v.WithdrawalHistoryID, c.WithdrawalHistoryID) {
+			cFound = true
+		}
+	}
+
+	if !bFound {
+		t.Error("expected to find b")
+	}
+	if !cFound {
+		t.Error("expected to find c")
+	}
+
+	slice := WithdrawalHistorySlice{&a}
+	if err = a.L.LoadDepositHistories(ctx, tx, false, (*[]*WithdrawalHistory)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.DepositHistories); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	a.R.DepositHistories = nil
+	if err = a.L.LoadDepositHistories(ctx, tx, true, &a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.DepositHistories); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	if t.Failed() {
+		t.Logf("%#v", check)
+	}
+}
+
 func testWithdrawalHistoryToManyWithdrawalCryptoWithdrawalCryptos(t *testing.T) {
 	var err error
 	ctx := context.Background()
@@ -648,6 +725,257 @@ func testWithdrawalHistoryToManyWithdrawalFiatWithdrawalFiats(t *testing.T) {
 	}
 }
 
+func testWithdrawalHistoryToManyAddOpDepositHistories(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a WithdrawalHistory
+	var b, c, d, e DepositHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*DepositHistory{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	foreignersSplitByInsertion := [][]*DepositHistory{
+		{&b, &c},
+		{&d, &e},
+	}
+
+	for i, x := range foreignersSplitByInsertion {
+		err = a.AddDepositHistories(ctx, tx, i != 0, x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := x[0]
+		second := x[1]
+
+		if !queries.Equal(a.ID, first.WithdrawalHistoryID) {
+			t.Error("foreign key was wrong value", a.ID, first.WithdrawalHistoryID)
+		}
+		if !queries.Equal(a.ID, second.WithdrawalHistoryID) {
+			t.Error("foreign key was wrong value", a.ID, second.WithdrawalHistoryID)
+		}
+
+		if first.R.WithdrawalHistory != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+		if second.R.WithdrawalHistory != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+
+		if a.R.DepositHistories[i*2] != first {
+			t.Error("relationship struct slice not set to correct value")
+		}
+		if a.R.DepositHistories[i*2+1] != second {
+			t.Error("relationship struct slice not set to correct value")
+		}
+
+		count, err := a.DepositHistories().Count(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64((i + 1) * 2); count != want {
+			t.Error("want", want, "got", count)
+		}
+	}
+}
+
+func testWithdrawalHistoryToManySetOpDepositHistories(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a WithdrawalHistory
+	var b, c, d, e DepositHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*DepositHistory{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.SetDepositHistories(ctx, tx, false, &b, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.SetDepositHistories(ctx, tx, true, &d, &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	if !queries.IsValuerNil(b.WithdrawalHistoryID) {
+		t.Error("want b's foreign key value to be nil")
+	}
+	if !queries.IsValuerNil(c.WithdrawalHistoryID) {
+		t.Error("want c's foreign key value to be nil")
+	}
+	if !queries.Equal(a.ID, d.WithdrawalHistoryID) {
+		t.Error("foreign key was wrong value", a.ID, d.WithdrawalHistoryID)
+	}
+	if !queries.Equal(a.ID, e.WithdrawalHistoryID) {
+		t.Error("foreign key was wrong value", a.ID, e.WithdrawalHistoryID)
+	}
+
+	if b.R.WithdrawalHistory != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if c.R.WithdrawalHistory != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if d.R.WithdrawalHistory != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+	if e.R.WithdrawalHistory != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+
+	if a.R.DepositHistories[0] != &d {
+		t.Error("relationship struct slice not set to correct value")
+	}
+	if a.R.DepositHistories[1] != &e {
+		t.Error("relationship struct slice not set to correct value")
+	}
+}
+
+func testWithdrawalHistoryToManyRemoveOpDepositHistories(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a WithdrawalHistory
+	var b, c, d, e DepositHistory
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, withdrawalHistoryDBTypes, false, strmangle.SetComplement(withdrawalHistoryPrimaryKeyColumns, withdrawalHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*DepositHistory{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, depositHistoryDBTypes, false, strmangle.SetComplement(depositHistoryPrimaryKeyColumns, depositHistoryColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.AddDepositHistories(ctx, tx, true, foreigners...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.RemoveDepositHistories(ctx, tx, foreigners[:2]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.DepositHistories().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	if !queries.IsValuerNil(b.WithdrawalHistoryID) {
+		t.Error("want b's foreign key value to be nil")
+	}
+	if !queries.IsValuerNil(c.WithdrawalHistoryID) {
+		t.Error("want c's foreign key value to be nil")
+	}
+
+	if b.R.WithdrawalHistory != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if c.R.WithdrawalHistory != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if d.R.WithdrawalHistory != &a {
+		t.Error("relationship to a should have been preserved")
+	}
+	if e.R.WithdrawalHistory != &a {
+		t.Error("relationship to a should have been preserved")
+	}
+
+	if len(a.R.DepositHistories) != 2 {
+		t.Error("should have preserved two relationships")
+	}
+
+	// Removal doesn't do a stable deletion for performance so we have to flip the order
+	if a.R.DepositHistories[1] != &d {
+		t.Error("relationship to d should have been preserved")
+	}
+	if a.R.DepositHistories[0] != &e {
+		t.Error("relationship to e should have been preserved")
+	}
+}
+
 func testWithdrawalHistoryToManyAddOpWithdrawalCryptoWithdrawalCryptos(t *testing.T) {
 	var err error
 
@@ -1333,7 +1661,7 @@ func testWithdrawalHistoriesSelect(t *testing.T) {
 }
 
 var (
-	withdrawalHistoryDBTypes = map[string]string{`ID`: `uuid`, `ExchangeID`: `text`, `Status`: `character varying`, `Currency`: `text`, `Amount`: `double precision`, `Description`: `text`, `WithdrawType`: `integer`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`, `ExchangeNameID`: `uuid`}
+	withdrawalHistoryDBTypes = map[string]string{`ID`: `uuid`, `ExchangeID`: `text`, `Status`: `character varying`, `Currency`: `text`, `Amount`: `double precision`, `Description`: `text`, `WithdrawType`: `integer`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`, `ExchangeNameID`: `uuid`, `AccountID`: `text`, `Approvers`: `text`}
 	_                        = bytes.MinRead
 )
 
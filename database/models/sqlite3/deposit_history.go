@@ -0,0 +1,1205 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+	"github.com/volatiletech/null"
+)
+
+// DepositHistory is an object representing the database table.
+type DepositHistory struct {
+	ID                  string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ExchangeNameID      string      `boil:"exchange_name_id" json:"exchange_name_id" toml:"exchange_name_id" yaml:"exchange_name_id"`
+	ExchangeID          string      `boil:"exchange_id" json:"exchange_id" toml:"exchange_id" yaml:"exchange_id"`
+	Status              string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	Currency            string      `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	Amount              float64     `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Fee                 float64     `boil:"fee" json:"fee" toml:"fee" yaml:"fee"`
+	Address             null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	TXID                null.String `boil:"tx_id" json:"tx_id,omitempty" toml:"tx_id" yaml:"tx_id,omitempty"`
+	WithdrawalHistoryID null.String `boil:"withdrawal_history_id" json:"withdrawal_history_id,omitempty" toml:"withdrawal_history_id" yaml:"withdrawal_history_id,omitempty"`
+	DepositedAt         string      `boil:"deposited_at" json:"deposited_at" toml:"deposited_at" yaml:"deposited_at"`
+	CreatedAt           string      `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *depositHistoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L depositHistoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var DepositHistoryColumns = struct {
+	ID                  string
+	ExchangeNameID      string
+	ExchangeID          string
+	Status              string
+	Currency            string
+	Amount              string
+	Fee                 string
+	Address             string
+	TXID                string
+	WithdrawalHistoryID string
+	DepositedAt         string
+	CreatedAt           string
+}{
+	ID:                  "id",
+	ExchangeNameID:      "exchange_name_id",
+	ExchangeID:          "exchange_id",
+	Status:              "status",
+	Currency:            "currency",
+	Amount:              "amount",
+	Fee:                 "fee",
+	Address:             "address",
+	TXID:                "tx_id",
+	WithdrawalHistoryID: "withdrawal_history_id",
+	DepositedAt:         "deposited_at",
+	CreatedAt:           "created_at",
+}
+
+// Generated where
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var DepositHistoryWhere = struct {
+	ID                  whereHelperstring
+	ExchangeNameID      whereHelperstring
+	ExchangeID          whereHelperstring
+	Status              whereHelperstring
+	Currency            whereHelperstring
+	Amount              whereHelperfloat64
+	Fee                 whereHelperfloat64
+	Address             whereHelpernull_String
+	TXID                whereHelpernull_String
+	WithdrawalHistoryID whereHelpernull_String
+	DepositedAt         whereHelperstring
+	CreatedAt           whereHelperstring
+}{
+	ID:                  whereHelperstring{field: "\"deposit_history\".\"id\""},
+	ExchangeNameID:      whereHelperstring{field: "\"deposit_history\".\"exchange_name_id\""},
+	ExchangeID:          whereHelperstring{field: "\"deposit_history\".\"exchange_id\""},
+	Status:              whereHelperstring{field: "\"deposit_history\".\"status\""},
+	Currency:            whereHelperstring{field: "\"deposit_history\".\"currency\""},
+	Amount:              whereHelperfloat64{field: "\"deposit_history\".\"amount\""},
+	Fee:                 whereHelperfloat64{field: "\"deposit_history\".\"fee\""},
+	Address:             whereHelpernull_String{field: "\"deposit_history\".\"address\""},
+	TXID:                whereHelpernull_String{field: "\"deposit_history\".\"tx_id\""},
+	WithdrawalHistoryID: whereHelpernull_String{field: "\"deposit_history\".\"withdrawal_history_id\""},
+	DepositedAt:         whereHelperstring{field: "\"deposit_history\".\"deposited_at\""},
+	CreatedAt:           whereHelperstring{field: "\"deposit_history\".\"created_at\""},
+}
+
+// DepositHistoryRels is where relationship names are stored.
+var DepositHistoryRels = struct {
+	WithdrawalHistory string
+	ExchangeName      string
+}{
+	WithdrawalHistory: "WithdrawalHistory",
+	ExchangeName:      "ExchangeName",
+}
+
+// depositHistoryR is where relationships are stored.
+type depositHistoryR struct {
+	WithdrawalHistory *WithdrawalHistory
+	ExchangeName      *Exchange
+}
+
+// NewStruct creates a new relationship struct
+func (*depositHistoryR) NewStruct() *depositHistoryR {
+	return &depositHistoryR{}
+}
+
+// depositHistoryL is where Load methods for each relationship are stored.
+type depositHistoryL struct{}
+
+var (
+	depositHistoryAllColumns            = []string{"id", "exchange_name_id", "exchange_id", "status", "currency", "amount", "fee", "address", "tx_id", "withdrawal_history_id", "deposited_at", "created_at"}
+	depositHistoryColumnsWithoutDefault = []string{"id", "exchange_name_id", "exchange_id", "status", "currency", "amount", "address", "tx_id", "withdrawal_history_id", "deposited_at"}
+	depositHistoryColumnsWithDefault    = []string{"fee", "created_at"}
+	depositHistoryPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// DepositHistorySlice is an alias for a slice of pointers to DepositHistory.
+	// This should generally be used opposed to []DepositHistory.
+	DepositHistorySlice []*DepositHistory
+	// DepositHistoryHook is the signature for custom DepositHistory hook methods
+	DepositHistoryHook func(context.Context, boil.ContextExecutor, *DepositHistory) error
+
+	depositHistoryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	depositHistoryType                 = reflect.TypeOf(&DepositHistory{})
+	depositHistoryMapping              = queries.MakeStructMapping(depositHistoryType)
+	depositHistoryPrimaryKeyMapping, _ = queries.BindMapping(depositHistoryType, depositHistoryMapping, depositHistoryPrimaryKeyColumns)
+	depositHistoryInsertCacheMut       sync.RWMutex
+	depositHistoryInsertCache          = make(map[string]insertCache)
+	depositHistoryUpdateCacheMut       sync.RWMutex
+	depositHistoryUpdateCache          = make(map[string]updateCache)
+	depositHistoryUpsertCacheMut       sync.RWMutex
+	depositHistoryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var depositHistoryBeforeInsertHooks []DepositHistoryHook
+var depositHistoryBeforeUpdateHooks []DepositHistoryHook
+var depositHistoryBeforeDeleteHooks []DepositHistoryHook
+var depositHistoryBeforeUpsertHooks []DepositHistoryHook
+
+var depositHistoryAfterInsertHooks []DepositHistoryHook
+var depositHistoryAfterSelectHooks []DepositHistoryHook
+var depositHistoryAfterUpdateHooks []DepositHistoryHook
+var depositHistoryAfterDeleteHooks []DepositHistoryHook
+var depositHistoryAfterUpsertHooks []DepositHistoryHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *DepositHistory) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *DepositHistory) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *DepositHistory) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *DepositHistory) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *DepositHistory) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *DepositHistory) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *DepositHistory) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *DepositHistory) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *DepositHistory) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range depositHistoryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddDepositHistoryHook registers your hook function for all future operations.
+func AddDepositHistoryHook(hookPoint boil.HookPoint, depositHistoryHook DepositHistoryHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		depositHistoryBeforeInsertHooks = append(depositHistoryBeforeInsertHooks, depositHistoryHook)
+	case boil.BeforeUpdateHook:
+		depositHistoryBeforeUpdateHooks = append(depositHistoryBeforeUpdateHooks, depositHistoryHook)
+	case boil.BeforeDeleteHook:
+		depositHistoryBeforeDeleteHooks = append(depositHistoryBeforeDeleteHooks, depositHistoryHook)
+	case boil.BeforeUpsertHook:
+		depositHistoryBeforeUpsertHooks = append(depositHistoryBeforeUpsertHooks, depositHistoryHook)
+	case boil.AfterInsertHook:
+		depositHistoryAfterInsertHooks = append(depositHistoryAfterInsertHooks, depositHistoryHook)
+	case boil.AfterSelectHook:
+		depositHistoryAfterSelectHooks = append(depositHistoryAfterSelectHooks, depositHistoryHook)
+	case boil.AfterUpdateHook:
+		depositHistoryAfterUpdateHooks = append(depositHistoryAfterUpdateHooks, depositHistoryHook)
+	case boil.AfterDeleteHook:
+		depositHistoryAfterDeleteHooks = append(depositHistoryAfterDeleteHooks, depositHistoryHook)
+	case boil.AfterUpsertHook:
+		depositHistoryAfterUpsertHooks = append(depositHistoryAfterUpsertHooks, depositHistoryHook)
+	}
+}
+
+// One returns a single depositHistory record from the query.
+func (q depositHistoryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*DepositHistory, error) {
+	o := &DepositHistory{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for deposit_history")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all DepositHistory records from the query.
+func (q depositHistoryQuery) All(ctx context.Context, exec boil.ContextExecutor) (DepositHistorySlice, error) {
+	var o []*DepositHistory
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to DepositHistory slice")
+	}
+
+	if len(depositHistoryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all DepositHistory records in the query.
+func (q depositHistoryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count deposit_history rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q depositHistoryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if deposit_history exists")
+	}
+
+	return count > 0, nil
+}
+
+// WithdrawalHistory pointed to by the foreign key.
+func (o *DepositHistory) WithdrawalHistory(mods ...qm.QueryMod) withdrawalHistoryQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.WithdrawalHistoryID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := WithdrawalHistories(queryMods...)
+	queries.SetFrom(query.Query, "\"withdrawal_history\"")
+
+	return query
+}
+
+// ExchangeName pointed to by the foreign key.
+func (o *DepositHistory) ExchangeName(mods ...qm.QueryMod) exchangeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ExchangeNameID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Exchanges(queryMods...)
+	queries.SetFrom(query.Query, "\"exchange\"")
+
+	return query
+}
+
+// LoadWithdrawalHistory allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (depositHistoryL) LoadWithdrawalHistory(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDepositHistory interface{}, mods queries.Applicator) error {
+	var slice []*DepositHistory
+	var object *DepositHistory
+
+	if singular {
+		object = maybeDepositHistory.(*DepositHistory)
+	} else {
+		slice = *maybeDepositHistory.(*[]*DepositHistory)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &depositHistoryR{}
+		}
+		if !queries.IsNil(object.WithdrawalHistoryID) {
+			args = append(args, object.WithdrawalHistoryID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &depositHistoryR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.WithdrawalHistoryID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.WithdrawalHistoryID) {
+				args = append(args, obj.WithdrawalHistoryID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`withdrawal_history`), qm.WhereIn(`withdrawal_history.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load WithdrawalHistory")
+	}
+
+	var resultSlice []*WithdrawalHistory
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice WithdrawalHistory")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for withdrawal_history")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for withdrawal_history")
+	}
+
+	if len(depositHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.WithdrawalHistory = foreign
+		if foreign.R == nil {
+			foreign.R = &withdrawalHistoryR{}
+		}
+		foreign.R.DepositHistories = append(foreign.R.DepositHistories, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.WithdrawalHistoryID, foreign.ID) {
+				local.R.WithdrawalHistory = foreign
+				if foreign.R == nil {
+					foreign.R = &withdrawalHistoryR{}
+				}
+				foreign.R.DepositHistories = append(foreign.R.DepositHistories, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadExchangeName allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (depositHistoryL) LoadExchangeName(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDepositHistory interface{}, mods queries.Applicator) error {
+	var slice []*DepositHistory
+	var object *DepositHistory
+
+	if singular {
+		object = maybeDepositHistory.(*DepositHistory)
+	} else {
+		slice = *maybeDepositHistory.(*[]*DepositHistory)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &depositHistoryR{}
+		}
+		args = append(args, object.ExchangeNameID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &depositHistoryR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ExchangeNameID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ExchangeNameID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`exchange`), qm.WhereIn(`exchange.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Exchange")
+	}
+
+	var resultSlice []*Exchange
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Exchange")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for exchange")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for exchange")
+	}
+
+	if len(depositHistoryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ExchangeName = foreign
+		if foreign.R == nil {
+			foreign.R = &exchangeR{}
+		}
+		foreign.R.ExchangeNameDepositHistory = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ExchangeNameID == foreign.ID {
+				local.R.ExchangeName = foreign
+				if foreign.R == nil {
+					foreign.R = &exchangeR{}
+				}
+				foreign.R.ExchangeNameDepositHistory = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetWithdrawalHistory of the depositHistory to the related item.
+// Sets o.R.WithdrawalHistory to related.
+// Adds o to related.R.DepositHistories.
+func (o *DepositHistory) SetWithdrawalHistory(ctx context.Context, exec boil.ContextExecutor, insert bool, related *WithdrawalHistory) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"deposit_history\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"withdrawal_history_id"}),
+		strmangle.WhereClause("\"", "\"", 0, depositHistoryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.WithdrawalHistoryID, related.ID)
+	if o.R == nil {
+		o.R = &depositHistoryR{
+			WithdrawalHistory: related,
+		}
+	} else {
+		o.R.WithdrawalHistory = related
+	}
+
+	if related.R == nil {
+		related.R = &withdrawalHistoryR{
+			DepositHistories: DepositHistorySlice{o},
+		}
+	} else {
+		related.R.DepositHistories = append(related.R.DepositHistories, o)
+	}
+
+	return nil
+}
+
+// RemoveWithdrawalHistory relationship.
+// Sets o.R.WithdrawalHistory to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *DepositHistory) RemoveWithdrawalHistory(ctx context.Context, exec boil.ContextExecutor, related *WithdrawalHistory) error {
+	var err error
+
+	queries.SetScanner(&o.WithdrawalHistoryID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("withdrawal_history_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.R.WithdrawalHistory = nil
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.DepositHistories {
+		if queries.Equal(o.WithdrawalHistoryID, ri.WithdrawalHistoryID) {
+			continue
+		}
+
+		ln := len(related.R.DepositHistories)
+		if ln > 1 && i < ln-1 {
+			related.R.DepositHistories[i] = related.R.DepositHistories[ln-1]
+		}
+		related.R.DepositHistories = related.R.DepositHistories[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetExchangeName of the depositHistory to the related item.
+// Sets o.R.ExchangeName to related.
+// Adds o to related.R.ExchangeNameDepositHistory.
+func (o *DepositHistory) SetExchangeName(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Exchange) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"deposit_history\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"exchange_name_id"}),
+		strmangle.WhereClause("\"", "\"", 0, depositHistoryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ExchangeNameID = related.ID
+	if o.R == nil {
+		o.R = &depositHistoryR{
+			ExchangeName: related,
+		}
+	} else {
+		o.R.ExchangeName = related
+	}
+
+	if related.R == nil {
+		related.R = &exchangeR{
+			ExchangeNameDepositHistory: o,
+		}
+	} else {
+		related.R.ExchangeNameDepositHistory = o
+	}
+
+	return nil
+}
+
+// DepositHistories retrieves all the records using an executor.
+func DepositHistories(mods ...qm.QueryMod) depositHistoryQuery {
+	mods = append(mods, qm.From("\"deposit_history\""))
+	return depositHistoryQuery{NewQuery(mods...)}
+}
+
+// FindDepositHistory retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindDepositHistory(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*DepositHistory, error) {
+	depositHistoryObj := &DepositHistory{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"deposit_history\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, depositHistoryObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from deposit_history")
+	}
+
+	return depositHistoryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *DepositHistory) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no deposit_history provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(depositHistoryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	depositHistoryInsertCacheMut.RLock()
+	cache, cached := depositHistoryInsertCache[key]
+	depositHistoryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			depositHistoryAllColumns,
+			depositHistoryColumnsWithDefault,
+			depositHistoryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(depositHistoryType, depositHistoryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(depositHistoryType, depositHistoryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"deposit_history\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"deposit_history\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"deposit_history\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, depositHistoryPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into deposit_history")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for deposit_history")
+	}
+
+CacheNoHooks:
+	if !cached {
+		depositHistoryInsertCacheMut.Lock()
+		depositHistoryInsertCache[key] = cache
+		depositHistoryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the DepositHistory.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *DepositHistory) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	depositHistoryUpdateCacheMut.RLock()
+	cache, cached := depositHistoryUpdateCache[key]
+	depositHistoryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			depositHistoryAllColumns,
+			depositHistoryPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update deposit_history, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"deposit_history\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, depositHistoryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(depositHistoryType, depositHistoryMapping, append(wl, depositHistoryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update deposit_history row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for deposit_history")
+	}
+
+	if !cached {
+		depositHistoryUpdateCacheMut.Lock()
+		depositHistoryUpdateCache[key] = cache
+		depositHistoryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q depositHistoryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for deposit_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for deposit_history")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o DepositHistorySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), depositHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"deposit_history\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, depositHistoryPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in depositHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all depositHistory")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single DepositHistory record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *DepositHistory) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no DepositHistory provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), depositHistoryPrimaryKeyMapping)
+	sql := "DELETE FROM \"deposit_history\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from deposit_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for deposit_history")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q depositHistoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no depositHistoryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from deposit_history")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for deposit_history")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o DepositHistorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(depositHistoryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), depositHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"deposit_history\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, depositHistoryPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from depositHistory slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for deposit_history")
+	}
+
+	if len(depositHistoryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *DepositHistory) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDepositHistory(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DepositHistorySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := DepositHistorySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), depositHistoryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"deposit_history\".* FROM \"deposit_history\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, depositHistoryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in DepositHistorySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// DepositHistoryExists checks if the DepositHistory row exists.
+func DepositHistoryExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"deposit_history\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if deposit_history exists")
+	}
+
+	return exists, nil
+}
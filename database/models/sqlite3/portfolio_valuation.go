@@ -0,0 +1,795 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// PortfolioValuation is an object representing the database table.
+type PortfolioValuation struct {
+	ID           string  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Source       string  `boil:"source" json:"source" toml:"source" yaml:"source"`
+	Currency     string  `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	Amount       float64 `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Price        float64 `boil:"price" json:"price" toml:"price" yaml:"price"`
+	Value        float64 `boil:"value" json:"value" toml:"value" yaml:"value"`
+	FiatCurrency string  `boil:"fiat_currency" json:"fiat_currency" toml:"fiat_currency" yaml:"fiat_currency"`
+	Timestamp    string  `boil:"timestamp" json:"timestamp" toml:"timestamp" yaml:"timestamp"`
+
+	R *portfolioValuationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L portfolioValuationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PortfolioValuationColumns = struct {
+	ID           string
+	Source       string
+	Currency     string
+	Amount       string
+	Price        string
+	Value        string
+	FiatCurrency string
+	Timestamp    string
+}{
+	ID:           "id",
+	Source:       "source",
+	Currency:     "currency",
+	Amount:       "amount",
+	Price:        "price",
+	Value:        "value",
+	FiatCurrency: "fiat_currency",
+	Timestamp:    "timestamp",
+}
+
+// Generated where
+
+var PortfolioValuationWhere = struct {
+	ID           whereHelperstring
+	Source       whereHelperstring
+	Currency     whereHelperstring
+	Amount       whereHelperfloat64
+	Price        whereHelperfloat64
+	Value        whereHelperfloat64
+	FiatCurrency whereHelperstring
+	Timestamp    whereHelperstring
+}{
+	ID:           whereHelperstring{field: "\"portfolio_valuation\".\"id\""},
+	Source:       whereHelperstring{field: "\"portfolio_valuation\".\"source\""},
+	Currency:     whereHelperstring{field: "\"portfolio_valuation\".\"currency\""},
+	Amount:       whereHelperfloat64{field: "\"portfolio_valuation\".\"amount\""},
+	Price:        whereHelperfloat64{field: "\"portfolio_valuation\".\"price\""},
+	Value:        whereHelperfloat64{field: "\"portfolio_valuation\".\"value\""},
+	FiatCurrency: whereHelperstring{field: "\"portfolio_valuation\".\"fiat_currency\""},
+	Timestamp:    whereHelperstring{field: "\"portfolio_valuation\".\"timestamp\""},
+}
+
+// PortfolioValuationRels is where relationship names are stored.
+var PortfolioValuationRels = struct {
+}{}
+
+// portfolioValuationR is where relationships are stored.
+type portfolioValuationR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*portfolioValuationR) NewStruct() *portfolioValuationR {
+	return &portfolioValuationR{}
+}
+
+// portfolioValuationL is where Load methods for each relationship are stored.
+type portfolioValuationL struct{}
+
+var (
+	portfolioValuationAllColumns            = []string{"id", "source", "currency", "amount", "price", "value", "fiat_currency", "timestamp"}
+	portfolioValuationColumnsWithoutDefault = []string{"id", "source", "currency", "amount", "price", "value", "fiat_currency", "timestamp"}
+	portfolioValuationColumnsWithDefault    = []string{}
+	portfolioValuationPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// PortfolioValuationSlice is an alias for a slice of pointers to PortfolioValuation.
+	// This should generally be used opposed to []PortfolioValuation.
+	PortfolioValuationSlice []*PortfolioValuation
+	// PortfolioValuationHook is the signature for custom PortfolioValuation hook methods
+	PortfolioValuationHook func(context.Context, boil.ContextExecutor, *PortfolioValuation) error
+
+	portfolioValuationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	portfolioValuationType                 = reflect.TypeOf(&PortfolioValuation{})
+	portfolioValuationMapping              = queries.MakeStructMapping(portfolioValuationType)
+	portfolioValuationPrimaryKeyMapping, _ = queries.BindMapping(portfolioValuationType, portfolioValuationMapping, portfolioValuationPrimaryKeyColumns)
+	portfolioValuationInsertCacheMut       sync.RWMutex
+	portfolioValuationInsertCache          = make(map[string]insertCache)
+	portfolioValuationUpdateCacheMut       sync.RWMutex
+	portfolioValuationUpdateCache          = make(map[string]updateCache)
+	portfolioValuationUpsertCacheMut       sync.RWMutex
+	portfolioValuationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var portfolioValuationBeforeInsertHooks []PortfolioValuationHook
+var portfolioValuationBeforeUpdateHooks []PortfolioValuationHook
+var portfolioValuationBeforeDeleteHooks []PortfolioValuationHook
+var portfolioValuationBeforeUpsertHooks []PortfolioValuationHook
+
+var portfolioValuationAfterInsertHooks []PortfolioValuationHook
+var portfolioValuationAfterSelectHooks []PortfolioValuationHook
+var portfolioValuationAfterUpdateHooks []PortfolioValuationHook
+var portfolioValuationAfterDeleteHooks []PortfolioValuationHook
+var portfolioValuationAfterUpsertHooks []PortfolioValuationHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PortfolioValuation) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PortfolioValuation) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PortfolioValuation) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PortfolioValuation) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PortfolioValuation) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PortfolioValuation) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PortfolioValuation) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PortfolioValuation) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PortfolioValuation) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range portfolioValuationAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPortfolioValuationHook registers your hook function for all future operations.
+func AddPortfolioValuationHook(hookPoint boil.HookPoint, portfolioValuationHook PortfolioValuationHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		portfolioValuationBeforeInsertHooks = append(portfolioValuationBeforeInsertHooks, portfolioValuationHook)
+	case boil.BeforeUpdateHook:
+		portfolioValuationBeforeUpdateHooks = append(portfolioValuationBeforeUpdateHooks, portfolioValuationHook)
+	case boil.BeforeDeleteHook:
+		portfolioValuationBeforeDeleteHooks = append(portfolioValuationBeforeDeleteHooks, portfolioValuationHook)
+	case boil.BeforeUpsertHook:
+		portfolioValuationBeforeUpsertHooks = append(portfolioValuationBeforeUpsertHooks, portfolioValuationHook)
+	case boil.AfterInsertHook:
+		portfolioValuationAfterInsertHooks = append(portfolioValuationAfterInsertHooks, portfolioValuationHook)
+	case boil.AfterSelectHook:
+		portfolioValuationAfterSelectHooks = append(portfolioValuationAfterSelectHooks, portfolioValuationHook)
+	case boil.AfterUpdateHook:
+		portfolioValuationAfterUpdateHooks = append(portfolioValuationAfterUpdateHooks, portfolioValuationHook)
+	case boil.AfterDeleteHook:
+		portfolioValuationAfterDeleteHooks = append(portfolioValuationAfterDeleteHooks, portfolioValuationHook)
+	case boil.AfterUpsertHook:
+		portfolioValuationAfterUpsertHooks = append(portfolioValuationAfterUpsertHooks, portfolioValuationHook)
+	}
+}
+
+// One returns a single portfolioValuation record from the query.
+func (q portfolioValuationQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PortfolioValuation, error) {
+	o := &PortfolioValuation{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for portfolio_valuation")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PortfolioValuation records from the query.
+func (q portfolioValuationQuery) All(ctx context.Context, exec boil.ContextExecutor) (PortfolioValuationSlice, error) {
+	var o []*PortfolioValuation
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to PortfolioValuation slice")
+	}
+
+	if len(portfolioValuationAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PortfolioValuation records in the query.
+func (q portfolioValuationQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count portfolio_valuation rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q portfolioValuationQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if portfolio_valuation exists")
+	}
+
+	return count > 0, nil
+}
+
+// PortfolioValuations retrieves all the records using an executor.
+func PortfolioValuations(mods ...qm.QueryMod) portfolioValuationQuery {
+	mods = append(mods, qm.From("\"portfolio_valuation\""))
+	return portfolioValuationQuery{NewQuery(mods...)}
+}
+
+// FindPortfolioValuation retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPortfolioValuation(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*PortfolioValuation, error) {
+	portfolioValuationObj := &PortfolioValuation{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"portfolio_valuation\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, portfolioValuationObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from portfolio_valuation")
+	}
+
+	return portfolioValuationObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PortfolioValuation) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no portfolio_valuation provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(portfolioValuationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	portfolioValuationInsertCacheMut.RLock()
+	cache, cached := portfolioValuationInsertCache[key]
+	portfolioValuationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			portfolioValuationAllColumns,
+			portfolioValuationColumnsWithDefault,
+			portfolioValuationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(portfolioValuationType, portfolioValuationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(portfolioValuationType, portfolioValuationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"portfolio_valuation\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"portfolio_valuation\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"portfolio_valuation\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, portfolioValuationPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into portfolio_valuation")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for portfolio_valuation")
+	}
+
+CacheNoHooks:
+	if !cached {
+		portfolioValuationInsertCacheMut.Lock()
+		portfolioValuationInsertCache[key] = cache
+		portfolioValuationInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PortfolioValuation.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PortfolioValuation) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	portfolioValuationUpdateCacheMut.RLock()
+	cache, cached := portfolioValuationUpdateCache[key]
+	portfolioValuationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			portfolioValuationAllColumns,
+			portfolioValuationPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update portfolio_valuation, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"portfolio_valuation\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, portfolioValuationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(portfolioValuationType, portfolioValuationMapping, append(wl, portfolioValuationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update portfolio_valuation row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for portfolio_valuation")
+	}
+
+	if !cached {
+		portfolioValuationUpdateCacheMut.Lock()
+		portfolioValuationUpdateCache[key] = cache
+		portfolioValuationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q portfolioValuationQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for portfolio_valuation")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for portfolio_valuation")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PortfolioValuationSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), portfolioValuationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"portfolio_valuation\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, portfolioValuationPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in portfolioValuation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all portfolioValuation")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single PortfolioValuation record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PortfolioValuation) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no PortfolioValuation provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), portfolioValuationPrimaryKeyMapping)
+	sql := "DELETE FROM \"portfolio_valuation\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from portfolio_valuation")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for portfolio_valuation")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q portfolioValuationQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no portfolioValuationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from portfolio_valuation")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for portfolio_valuation")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PortfolioValuationSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(portfolioValuationBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), portfolioValuationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"portfolio_valuation\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, portfolioValuationPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from portfolioValuation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for portfolio_valuation")
+	}
+
+	if len(portfolioValuationAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PortfolioValuation) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPortfolioValuation(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PortfolioValuationSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PortfolioValuationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), portfolioValuationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"portfolio_valuation\".* FROM \"portfolio_valuation\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, portfolioValuationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in PortfolioValuationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PortfolioValuationExists checks if the PortfolioValuation row exists.
+func PortfolioValuationExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"portfolio_valuation\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if portfolio_valuation exists")
+	}
+
+	return exists, nil
+}
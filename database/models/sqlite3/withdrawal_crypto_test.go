@@ -677,7 +677,7 @@ func testWithdrawalCryptosSelect(t *testing.T) {
 }
 
 var (
-	withdrawalCryptoDBTypes = map[string]string{`ID`: `INTEGER`, `Address`: `TEXT`, `AddressTag`: `TEXT`, `Fee`: `REAL`, `WithdrawalHistoryID`: `TEXT`}
+	withdrawalCryptoDBTypes = map[string]string{`ID`: `INTEGER`, `Address`: `TEXT`, `AddressTag`: `TEXT`, `Fee`: `REAL`, `WithdrawalHistoryID`: `TEXT`, `TXID`: `TEXT`}
 	_                       = bytes.MinRead
 )
 
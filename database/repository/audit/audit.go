@@ -1,9 +1,19 @@
 package audit
 
 import (
+	"bufio"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
@@ -13,12 +23,19 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
-// Event inserts a new audit event to database
+// chainMtx serialises inserts so each event is chained to the last one
+var chainMtx sync.Mutex
+
+// Event inserts a new audit event to database, each event stores a hash of
+// the previous event so any later edit or deletion breaks the chain
 func Event(id, msgtype, message string) {
 	if database.DB.SQL == nil {
 		return
 	}
 
+	chainMtx.Lock()
+	defer chainMtx.Unlock()
+
 	ctx := context.Background()
 	ctx = boil.SkipTimestamps(ctx)
 
@@ -28,20 +45,36 @@ func Event(id, msgtype, message string) {
 		return
 	}
 
-	if repository.GetSQLDialect() == database.DBSQLite3 {
-		var tempEvent = modelSQLite.AuditEvent{
-			Type:       msgtype,
-			Identifier: id,
-			Message:    message,
-		}
-		err = tempEvent.Insert(ctx, tx, boil.Blacklist("created_at"))
-	} else {
-		var tempEvent = modelPSQL.AuditEvent{
-			Type:       msgtype,
-			Identifier: id,
-			Message:    message,
+	record := Record{
+		Type:       msgtype,
+		Identifier: id,
+		Message:    message,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+	record.PrevHash, err = lastHash(ctx, tx)
+	if err == nil {
+		record.Hash = record.computeHash()
+		if repository.GetSQLDialect() == database.DBSQLite3 {
+			var tempEvent = modelSQLite.AuditEvent{
+				Type:       record.Type,
+				Identifier: record.Identifier,
+				Message:    record.Message,
+				CreatedAt:  record.CreatedAt.Format(sqliteTimeFormat),
+				PrevHash:   record.PrevHash,
+				Hash:       record.Hash,
+			}
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		} else {
+			var tempEvent = modelPSQL.AuditEvent{
+				Type:       record.Type,
+				Identifier: record.Identifier,
+				Message:    record.Message,
+				CreatedAt:  record.CreatedAt,
+				PrevHash:   record.PrevHash,
+				Hash:       record.Hash,
+			}
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
 		}
-		err = tempEvent.Insert(ctx, tx, boil.Blacklist("created_at"))
 	}
 
 	if err != nil {
@@ -60,26 +93,288 @@ func Event(id, msgtype, message string) {
 	}
 }
 
-// GetEvent () returns list of order events matching query
-func GetEvent(startTime, endTime time.Time, order string, limit int) (interface{}, error) {
+// lastHash returns the hash of the most recent audit event
+func lastHash(ctx context.Context, exec boil.ContextExecutor) (string, error) {
+	latest := qm.OrderBy("id desc")
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		event, err := modelSQLite.AuditEvents(latest).One(ctx, exec)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return event.Hash, nil
+	}
+	event, err := modelPSQL.AuditEvents(latest).One(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return event.Hash, nil
+}
+
+// GetEvent () returns list of order events matching query, an empty event
+// type matches all types
+func GetEvent(startTime, endTime time.Time, eventType, order string, limit int) (interface{}, error) {
 	if database.DB.SQL == nil {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
 
-	query := qm.Where("created_at BETWEEN ? AND ?", startTime, endTime)
+	query := []qm.QueryMod{qm.Where("created_at BETWEEN ? AND ?", startTime, endTime)}
+	if eventType != "" {
+		query = append(query, qm.Where("type = ?", eventType))
+	}
 
 	orderByQueryString := "id"
 	if order == "desc" {
 		orderByQueryString += " desc"
 	}
 
-	orderByQuery := qm.OrderBy(orderByQueryString)
-	limitQuery := qm.Limit(limit)
+	query = append(query, qm.OrderBy(orderByQueryString), qm.Limit(limit))
 
 	ctx := context.Background()
 	if repository.GetSQLDialect() == database.DBSQLite3 {
-		return modelSQLite.AuditEvents(query, orderByQuery, limitQuery).All(ctx, database.DB.SQL)
+		return modelSQLite.AuditEvents(query...).All(ctx, database.DB.SQL)
 	}
 
-	return modelPSQL.AuditEvents(query, orderByQuery, limitQuery).All(ctx, database.DB.SQL)
+	return modelPSQL.AuditEvents(query...).All(ctx, database.DB.SQL)
+}
+
+// Verify walks every audit event in insertion order and checks each event
+// matches its hash and follows the hash of the event before it
+func Verify() (Verification, error) {
+	var v Verification
+	if database.DB.SQL == nil {
+		return v, database.ErrDatabaseSupportDisabled
+	}
+	for {
+		records, err := getRecords(qm.Where("id > ?", v.LastID),
+			qm.OrderBy("id"),
+			qm.Limit(verifyBatchSize))
+		if err != nil {
+			return v, err
+		}
+		if len(records) == 0 {
+			return v, nil
+		}
+		for i := range records {
+			if err = v.add(&records[i]); err != nil {
+				return v, err
+			}
+		}
+	}
+}
+
+// Export writes the audit events created between start and end to w as JSON
+// lines. The final line signs every preceding line with key so the export
+// can be verified with VerifyExport
+func Export(w io.Writer, key []byte, start, end time.Time) (int, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	if len(key) == 0 {
+		return 0, errSigningKeyUnset
+	}
+
+	var between qm.QueryMod
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		between = qm.Where("created_at BETWEEN ? AND ?",
+			start.UTC().Format(sqliteTimeFormat),
+			end.UTC().Format(sqliteTimeFormat))
+	} else {
+		between = qm.Where("created_at BETWEEN ? AND ?", start.UTC(), end.UTC())
+	}
+
+	mac := hmac.New(sha256.New, key)
+	var lastID int64
+	var events int
+	for {
+		records, err := getRecords(between,
+			qm.Where("id > ?", lastID),
+			qm.OrderBy("id"),
+			qm.Limit(verifyBatchSize))
+		if err != nil {
+			return events, err
+		}
+		if len(records) == 0 {
+			break
+		}
+		for i := range records {
+			line, err := json.Marshal(&records[i])
+			if err != nil {
+				return events, err
+			}
+			line = append(line, '\n')
+			mac.Write(line)
+			if _, err = w.Write(line); err != nil {
+				return events, err
+			}
+			events++
+		}
+		lastID = records[len(records)-1].ID
+	}
+
+	line, err := json.Marshal(&signature{
+		Algorithm: exportSignatureAlgorithm,
+		Events:    events,
+		Signature: crypto.HexEncodeToString(mac.Sum(nil)),
+	})
+	if err != nil {
+		return events, err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return events, err
+}
+
+// VerifyExport checks the signature of an export written by Export and the
+// hash chain of the events it contains
+func VerifyExport(r io.Reader, key []byte) (Verification, error) {
+	var v Verification
+	if len(key) == 0 {
+		return v, errSigningKeyUnset
+	}
+
+	mac := hmac.New(sha256.New, key)
+	reader := bufio.NewReader(r)
+	var sig *signature
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if sig != nil {
+				return v, errUnexpectedExportRow
+			}
+			var s signature
+			if err = json.Unmarshal(line, &s); err != nil {
+				return v, err
+			}
+			if s.Signature != "" {
+				sig = &s
+				continue
+			}
+			var record Record
+			if err = json.Unmarshal(line, &record); err != nil {
+				return v, err
+			}
+			mac.Write(line)
+			if v.Events == 0 {
+				// An export may start part way along the chain
+				v.LastHash = record.PrevHash
+			}
+			if err = v.add(&record); err != nil {
+				return v, err
+			}
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return v, err
+		}
+	}
+
+	if sig == nil {
+		return v, errSignatureMissing
+	}
+	expected := crypto.HexEncodeToString(mac.Sum(nil))
+	if sig.Algorithm != exportSignatureAlgorithm ||
+		sig.Events != v.Events ||
+		!hmac.Equal([]byte(expected), []byte(sig.Signature)) {
+		return v, ErrSignatureInvalid
+	}
+	return v, nil
+}
+
+// add checks the record follows the events verified so far. Events recorded
+// before hash chaining was introduced are only accepted ahead of the chain
+func (v *Verification) add(r *Record) error {
+	if r.PrevHash == "" && r.Hash == "" {
+		if v.LastHash != "" {
+			return fmt.Errorf("%w: event %d is missing its hash", ErrChainBroken, r.ID)
+		}
+		v.Unchained++
+	} else {
+		if r.PrevHash != v.LastHash {
+			return fmt.Errorf("%w: event %d does not follow event %d", ErrChainBroken, r.ID, v.LastID)
+		}
+		if r.computeHash() != r.Hash {
+			return fmt.Errorf("%w: event %d does not match its hash", ErrChainBroken, r.ID)
+		}
+		v.LastHash = r.Hash
+	}
+	v.Events++
+	v.LastID = r.ID
+	return nil
+}
+
+// computeHash returns the SHA256 hash of the event contents and the hash of
+// the previous event
+func (r *Record) computeHash() string {
+	contents, _ := json.Marshal([]string{
+		r.PrevHash,
+		r.Type,
+		r.Identifier,
+		r.Message,
+		r.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	return crypto.HexEncodeToString(crypto.GetSHA256(contents))
+}
+
+// getRecords returns the audit events matching the query
+func getRecords(mods ...qm.QueryMod) ([]Record, error) {
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		events, err := modelSQLite.AuditEvents(mods...).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]Record, len(events))
+		for i := range events {
+			createdAt, err := parseSQLiteTime(events[i].CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("audit event %d: %w", events[i].ID, err)
+			}
+			records[i] = Record{
+				ID:         events[i].ID,
+				Type:       events[i].Type,
+				Identifier: events[i].Identifier,
+				Message:    events[i].Message,
+				CreatedAt:  createdAt,
+				PrevHash:   events[i].PrevHash,
+				Hash:       events[i].Hash,
+			}
+		}
+		return records, nil
+	}
+
+	events, err := modelPSQL.AuditEvents(mods...).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, len(events))
+	for i := range events {
+		records[i] = Record{
+			ID:         events[i].ID,
+			Type:       events[i].Type,
+			Identifier: events[i].Identifier,
+			Message:    events[i].Message,
+			CreatedAt:  events[i].CreatedAt.UTC(),
+			PrevHash:   events[i].PrevHash,
+			Hash:       events[i].Hash,
+		}
+	}
+	return records, nil
+}
+
+// parseSQLiteTime parses a timestamp column which the driver returns either
+// as stored or reformatted to RFC3339
+func parseSQLiteTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(sqliteTimeFormat, s)
 }
@@ -1,6 +1,8 @@
 package audit
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -61,6 +63,17 @@ func TestAudit(t *testing.T) {
 			testhelpers.CloseDatabase,
 			nil,
 		},
+		{
+			"SQLite-Verify",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+
+			verifyHelper,
+			testhelpers.CloseDatabase,
+			nil,
+		},
 		{
 			"Postgres-Write",
 			testhelpers.PostgresTestDatabase,
@@ -75,6 +88,13 @@ func TestAudit(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"Postgres-Verify",
+			testhelpers.PostgresTestDatabase,
+			verifyHelper,
+			nil,
+			nil,
+		},
 	}
 
 	for _, tests := range testCases {
@@ -123,8 +143,89 @@ func writeAudit(t *testing.T) {
 func readHelper(t *testing.T) {
 	t.Helper()
 
-	_, err := GetEvent(time.Now().Add(-time.Hour*60), time.Now(), "asc", 1)
+	_, err := GetEvent(time.Now().Add(-time.Hour*60), time.Now(), "", "asc", 1)
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func verifyHelper(t *testing.T) {
+	t.Helper()
+
+	v, err := Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Events-v.Unchained < 20 {
+		t.Errorf("expected at least 20 chained events, received %d", v.Events-v.Unchained)
+	}
+
+	var buf bytes.Buffer
+	key := []byte("test")
+	events, err := Export(&buf, key, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := VerifyExport(bytes.NewReader(buf.Bytes()), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exported.Events != events || exported.LastHash != v.LastHash {
+		t.Errorf("expected export of %d events ending %s, received %d ending %s",
+			events, v.LastHash, exported.Events, exported.LastHash)
+	}
+
+	tampered := bytes.Replace(buf.Bytes(), []byte(`"message":"test-1"`), []byte(`"message":"test-2"`), 1)
+	_, err = VerifyExport(bytes.NewReader(tampered), key)
+	if !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected %v, received %v", ErrChainBroken, err)
+	}
+	_, err = VerifyExport(bytes.NewReader(buf.Bytes()), []byte("wrong"))
+	if !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("expected %v, received %v", ErrSignatureInvalid, err)
+	}
+}
+
+func TestVerificationAdd(t *testing.T) {
+	var records []Record
+	prevHash := ""
+	for x := 0; x < 3; x++ {
+		r := Record{
+			ID:         int64(x + 2),
+			Type:       OrderEvent,
+			Identifier: "test",
+			Message:    fmt.Sprintf("order %d", x),
+			CreatedAt:  time.Now(),
+			PrevHash:   prevHash,
+		}
+		r.Hash = r.computeHash()
+		prevHash = r.Hash
+		records = append(records, r)
+	}
+	verify := func(records ...Record) error {
+		var v Verification
+		for i := range records {
+			if err := v.add(&records[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	legacy := Record{ID: 1, Type: LoginEvent}
+	if err := verify(append([]Record{legacy}, records...)...); err != nil {
+		t.Error(err)
+	}
+	if err := verify(records[0], records[2]); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected deleted event to break chain, received %v", err)
+	}
+	edited := records[1]
+	edited.Message = "edited"
+	if err := verify(records[0], edited, records[2]); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected edited event to break chain, received %v", err)
+	}
+	legacy.ID = 5
+	if err := verify(append(records, legacy)...); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected unhashed event after chain to break chain, received %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// Audit event types, events are categorised by type so they can be queried
+// and exported per category
+const (
+	OrderEvent        = "order"
+	WithdrawalEvent   = "withdrawal"
+	ConfigChangeEvent = "config_change"
+	LoginEvent        = "login"
+	RemoteAccessEvent = "remote_access"
+	CommandEvent      = "command"
+)
+
+const (
+	// sqliteTimeFormat matches the CURRENT_TIMESTAMP column default so
+	// chained and legacy events sort and compare alike
+	sqliteTimeFormat = "2006-01-02 15:04:05"
+	// exportSignatureAlgorithm is the algorithm recorded in export signatures
+	exportSignatureAlgorithm = "HMAC-SHA256"
+	// verifyBatchSize is the number of events loaded per verification query
+	verifyBatchSize = 1000
+)
+
+var (
+	// ErrChainBroken is returned when an audit event does not follow the
+	// hash of the previous event or its contents do not match its hash
+	ErrChainBroken = errors.New("audit event hash chain broken")
+	// ErrSignatureInvalid is returned when an export signature does not
+	// match its contents
+	ErrSignatureInvalid = errors.New("audit export signature invalid")
+
+	errSigningKeyUnset     = errors.New("audit export signing key not set")
+	errSignatureMissing    = errors.New("audit export signature missing")
+	errUnexpectedExportRow = errors.New("unexpected audit export row after signature")
+)
+
+// Record is an audit event and its position in the hash chain, events
+// recorded before hash chaining was introduced have no hashes
+type Record struct {
+	ID         int64     `json:"id"`
+	Type       string    `json:"type"`
+	Identifier string    `json:"identifier"`
+	Message    string    `json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// Verification holds the outcome of an audit event chain verification
+type Verification struct {
+	Events    int
+	Unchained int
+	LastID    int64
+	LastHash  string
+}
+
+// signature is the final line of an export and signs every preceding line
+type signature struct {
+	Algorithm string `json:"algorithm"`
+	Events    int    `json:"events"`
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,304 @@
+package deposit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	exchangeDB "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/volatiletech/null"
+)
+
+// Insert saves deposits to the database, deposits already stored for the same
+// exchange and exchange ID are ignored
+func Insert(values ...Data) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	exchangeUUIDs := make(map[string]string)
+	for i := range values {
+		if values[i].Exchange == "" || values[i].ExchangeID == "" {
+			return errExchangeIDUnset
+		}
+		name := strings.ToLower(values[i].Exchange)
+		if _, ok := exchangeUUIDs[name]; ok {
+			continue
+		}
+		exchangeUUID, err := exchangeDB.UUIDByName(name)
+		if err != nil {
+			return fmt.Errorf("%s %w", values[i].Exchange, err)
+		}
+		exchangeUUIDs[name] = exchangeUUID.String()
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = insertSQLite(ctx, tx, exchangeUUIDs, values...)
+	} else {
+		err = insertPostgres(ctx, tx, exchangeUUIDs, values...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, exchangeUUIDs map[string]string, values ...Data) error {
+	for i := range values {
+		if values[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			values[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelSQLite.DepositHistory{
+			ID:                  values[i].ID,
+			ExchangeNameID:      exchangeUUIDs[strings.ToLower(values[i].Exchange)],
+			ExchangeID:          values[i].ExchangeID,
+			Status:              values[i].Status,
+			Currency:            strings.ToUpper(values[i].Currency),
+			Amount:              values[i].Amount,
+			Fee:                 values[i].Fee,
+			Address:             nullString(values[i].Address),
+			TXID:                nullString(values[i].TxID),
+			WithdrawalHistoryID: nullString(values[i].WithdrawalID),
+			DepositedAt:         values[i].Timestamp.UTC().Format(time.RFC3339),
+			CreatedAt:           time.Now().UTC().Format(time.RFC3339),
+		}
+		// Duplicates are ignored by the unique constraint, so every column with
+		// a default is supplied to avoid reading back a row which was not
+		// inserted
+		err := tempEvent.Insert(ctx, tx, boil.Greylist("fee"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, exchangeUUIDs map[string]string, values ...Data) error {
+	var err error
+	for i := range values {
+		if values[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			values[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelPSQL.DepositHistory{
+			ID:                  values[i].ID,
+			ExchangeNameID:      exchangeUUIDs[strings.ToLower(values[i].Exchange)],
+			ExchangeID:          values[i].ExchangeID,
+			Status:              values[i].Status,
+			Currency:            strings.ToUpper(values[i].Currency),
+			Amount:              values[i].Amount,
+			Fee:                 values[i].Fee,
+			Address:             nullString(values[i].Address),
+			TXID:                nullString(values[i].TxID),
+			WithdrawalHistoryID: nullString(values[i].WithdrawalID),
+			DepositedAt:         values[i].Timestamp.UTC(),
+			CreatedAt:           time.Now().UTC(),
+		}
+		err = tempEvent.Upsert(ctx, tx, false, []string{"exchange_name_id", "exchange_id"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status of a stored deposit
+func UpdateStatus(id, status string) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	cols := map[string]interface{}{"status": status}
+	var rows int64
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		rows, err = modelSQLite.DepositHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	} else {
+		rows, err = modelPSQL.DepositHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	}
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// GetByExchangeID returns a stored deposit by its exchange transfer ID
+func GetByExchangeID(exchange, exchangeID string) (Data, error) {
+	exchangeUUID, err := exchangeDB.UUIDByName(exchange)
+	if err != nil {
+		return Data{}, err
+	}
+	resp, err := getByQuery([]qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.Where("exchange_id = ?", exchangeID),
+	})
+	if err != nil {
+		return Data{}, err
+	}
+	return resp[0], nil
+}
+
+// GetByWithdrawalID returns the stored deposit matched to a withdrawal
+// initiated from another exchange
+func GetByWithdrawalID(withdrawalID string) (Data, error) {
+	resp, err := getByQuery([]qm.QueryMod{
+		qm.Where("withdrawal_history_id = ?", withdrawalID),
+	})
+	if err != nil {
+		return Data{}, err
+	}
+	return resp[0], nil
+}
+
+// GetInRange returns deposits received between the start and end dates
+// ordered by deposit time. All exchanges are returned if exchange is empty
+func GetInRange(exchange string, start, end time.Time) ([]Data, error) {
+	var q []qm.QueryMod
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		q = append(q, qm.Where("deposited_at BETWEEN ? AND ?",
+			start.UTC().Format(time.RFC3339),
+			end.UTC().Format(time.RFC3339)))
+	} else {
+		q = append(q, qm.Where("deposited_at BETWEEN ? AND ?", start.UTC(), end.UTC()))
+	}
+	if exchange != "" {
+		exchangeUUID, err := exchangeDB.UUIDByName(exchange)
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, qm.Where("exchange_name_id = ?", exchangeUUID.String()))
+	}
+	q = append(q, qm.OrderBy("deposited_at"))
+	resp, err := getByQuery(q)
+	if err == ErrNoResults {
+		return nil, nil
+	}
+	return resp, err
+}
+
+func getByQuery(q []qm.QueryMod) ([]Data, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	var resp []Data
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		result, err := modelSQLite.DepositHistories(q...).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for i := range result {
+			ts, err := time.Parse(time.RFC3339, result[i].DepositedAt)
+			if err != nil {
+				return nil, err
+			}
+			createdAt, err := time.Parse(time.RFC3339, result[i].CreatedAt)
+			if err != nil {
+				log.Errorf(log.DatabaseMgr, "record: %v has an incorrect time format ( %v ) - defaulting to empty time: %v", result[i].ID, result[i].CreatedAt, err)
+			}
+			resp = append(resp, Data{
+				ID:           result[i].ID,
+				Exchange:     result[i].ExchangeNameID,
+				ExchangeID:   result[i].ExchangeID,
+				Status:       result[i].Status,
+				Currency:     result[i].Currency,
+				Amount:       result[i].Amount,
+				Fee:          result[i].Fee,
+				Address:      result[i].Address.String,
+				TxID:         result[i].TXID.String,
+				WithdrawalID: result[i].WithdrawalHistoryID.String,
+				Timestamp:    ts,
+				CreatedAt:    createdAt,
+			})
+		}
+	} else {
+		result, err := modelPSQL.DepositHistories(q...).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for i := range result {
+			resp = append(resp, Data{
+				ID:           result[i].ID,
+				Exchange:     result[i].ExchangeNameID,
+				ExchangeID:   result[i].ExchangeID,
+				Status:       result[i].Status,
+				Currency:     result[i].Currency,
+				Amount:       result[i].Amount,
+				Fee:          result[i].Fee,
+				Address:      result[i].Address.String,
+				TxID:         result[i].TXID.String,
+				WithdrawalID: result[i].WithdrawalHistoryID.String,
+				Timestamp:    result[i].DepositedAt,
+				CreatedAt:    result[i].CreatedAt,
+			})
+		}
+	}
+	if len(resp) == 0 {
+		return nil, ErrNoResults
+	}
+
+	// Translate exchange UUIDs back to names
+	names := make(map[string]string)
+	for i := range resp {
+		name, ok := names[resp[i].Exchange]
+		if !ok {
+			exchangeUUID, err := uuid.FromString(resp[i].Exchange)
+			if err != nil {
+				return nil, err
+			}
+			details, err := exchangeDB.OneByUUID(exchangeUUID)
+			if err != nil {
+				return nil, err
+			}
+			name = details.Name
+			names[resp[i].Exchange] = name
+		}
+		resp[i].Exchange = name
+	}
+	return resp, nil
+}
+
+func nullString(s string) null.String {
+	return null.NewString(s, s != "")
+}
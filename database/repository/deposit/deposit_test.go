@@ -0,0 +1,157 @@
+package deposit
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var (
+	verbose      = false
+	testExchange = "one"
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		log.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestDeposits(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			exchange.ResetExchangeCache()
+			err = exchange.InsertMany([]exchange.Details{{Name: testExchange}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			depositSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func depositSQLTester(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	d := Data{
+		Exchange:   testExchange,
+		ExchangeID: "deposit-1",
+		Status:     "completed",
+		Currency:   currency.BTC.String(),
+		Amount:     1.5,
+		Address:    "address",
+		TxID:       "0xdeadbeef",
+		Timestamp:  now,
+	}
+	err := Insert(Data{Exchange: testExchange})
+	if !errors.Is(err, errExchangeIDUnset) {
+		t.Errorf("expected %v received %v", errExchangeIDUnset, err)
+	}
+	err = Insert(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Duplicate deposits are ignored
+	err = Insert(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := GetByExchangeID(testExchange, "deposit-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Exchange != testExchange ||
+		stored.Amount != 1.5 ||
+		stored.TxID != "0xdeadbeef" ||
+		!stored.Timestamp.Equal(now) {
+		t.Errorf("unexpected deposit %+v", stored)
+	}
+
+	err = UpdateStatus(stored.ID, "confirmed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err = GetByExchangeID(testExchange, "deposit-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "confirmed" {
+		t.Errorf("expected status confirmed received %v", stored.Status)
+	}
+	err = UpdateStatus("missing", "confirmed")
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v received %v", ErrNoResults, err)
+	}
+
+	_, err = GetByWithdrawalID("missing")
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v received %v", ErrNoResults, err)
+	}
+
+	_, err = GetByExchangeID(testExchange, "deposit-2")
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v received %v", ErrNoResults, err)
+	}
+
+	deposits, err := GetInRange("", now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 1 {
+		t.Errorf("expected 1 deposit received %v", len(deposits))
+	}
+}
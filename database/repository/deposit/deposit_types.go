@@ -0,0 +1,31 @@
+package deposit
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNoResults is the error returned if no results are found
+	ErrNoResults = errors.New("no results found")
+
+	errExchangeIDUnset = errors.New("exchange/exchange id not set, cannot insert")
+)
+
+// Data defines a deposit received on an exchange in its simplest db friendly
+// form. WithdrawalID is set when the deposit was matched to a withdrawal
+// initiated from another exchange
+type Data struct {
+	ID           string
+	Exchange     string
+	ExchangeID   string
+	Status       string
+	Currency     string
+	Amount       float64
+	Fee          float64
+	Address      string
+	TxID         string
+	WithdrawalID string
+	Timestamp    time.Time
+	CreatedAt    time.Time
+}
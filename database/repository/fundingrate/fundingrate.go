@@ -0,0 +1,220 @@
+package fundingrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Insert saves funding rate data to the database, existing rates for the same
+// exchange, pair, asset and timestamp are ignored
+func Insert(rates ...Data) error {
+	for i := range rates {
+		if rates[i].ExchangeNameID == "" && rates[i].Exchange != "" {
+			exchangeUUID, err := exchange.UUIDByName(rates[i].Exchange)
+			if err != nil {
+				return err
+			}
+			rates[i].ExchangeNameID = exchangeUUID.String()
+		} else if rates[i].ExchangeNameID == "" && rates[i].Exchange == "" {
+			return errors.New("exchange name/uuid not set, cannot insert")
+		}
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = insertSQLite(ctx, tx, rates...)
+	} else {
+		err = insertPostgres(ctx, tx, rates...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	for i := range rates {
+		if rates[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelSQLite.FundingRate{
+			ID:             rates[i].ID,
+			ExchangeNameID: rates[i].ExchangeNameID,
+			Base:           strings.ToUpper(rates[i].Base),
+			Quote:          strings.ToUpper(rates[i].Quote),
+			Asset:          strings.ToLower(rates[i].AssetType),
+			Rate:           rates[i].Rate,
+			Timestamp:      rates[i].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err := tempEvent.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	var err error
+	for i := range rates {
+		if rates[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelPSQL.FundingRate{
+			ID:             rates[i].ID,
+			ExchangeNameID: rates[i].ExchangeNameID,
+			Base:           strings.ToUpper(rates[i].Base),
+			Quote:          strings.ToUpper(rates[i].Quote),
+			Asset:          strings.ToLower(rates[i].AssetType),
+			Rate:           rates[i].Rate,
+			Timestamp:      rates[i].Timestamp.UTC(),
+		}
+
+		err = tempEvent.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInRange returns all funding rates by an exchange in a date range
+func GetInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (rates []Data, err error) {
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		rates, err = getInRangeSQLite(exchangeName, assetType, base, quote, startDate, endDate)
+		if err != nil {
+			return rates, fmt.Errorf("fundingrate.GetInRange getInRangeSQLite %w", err)
+		}
+	} else {
+		rates, err = getInRangePostgres(exchangeName, assetType, base, quote, startDate, endDate)
+		if err != nil {
+			return rates, fmt.Errorf("fundingrate.GetInRange getInRangePostgres %w", err)
+		}
+	}
+
+	return rates, nil
+}
+
+func getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (rates []Data, err error) {
+	var exchangeUUID uuid.UUID
+	exchangeUUID, err = exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	wheres := map[string]interface{}{
+		"exchange_name_id": exchangeUUID,
+		"asset":            strings.ToLower(assetType),
+		"base":             strings.ToUpper(base),
+		"quote":            strings.ToUpper(quote),
+	}
+	q := generateQuery(wheres, startDate, endDate)
+	query := modelSQLite.FundingRates(q...)
+	var result []*modelSQLite.FundingRate
+	result, err = query.All(context.Background(), database.DB.SQL)
+	if err != nil {
+		return rates, err
+	}
+	for i := range result {
+		ts, err := time.Parse(time.RFC3339, result[i].Timestamp)
+		if err != nil {
+			return rates, err
+		}
+		rates = append(rates, Data{
+			ID:             result[i].ID,
+			Exchange:       strings.ToLower(exchangeName),
+			ExchangeNameID: result[i].ExchangeNameID,
+			Base:           strings.ToUpper(result[i].Base),
+			Quote:          strings.ToUpper(result[i].Quote),
+			AssetType:      strings.ToLower(result[i].Asset),
+			Rate:           result[i].Rate,
+			Timestamp:      ts,
+		})
+	}
+	return rates, nil
+}
+
+func getInRangePostgres(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (rates []Data, err error) {
+	var exchangeUUID uuid.UUID
+	exchangeUUID, err = exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	wheres := map[string]interface{}{
+		"exchange_name_id": exchangeUUID,
+		"asset":            strings.ToLower(assetType),
+		"base":             strings.ToUpper(base),
+		"quote":            strings.ToUpper(quote),
+	}
+	q := generateQuery(wheres, startDate, endDate)
+	query := modelPSQL.FundingRates(q...)
+	var result []*modelPSQL.FundingRate
+	result, err = query.All(context.Background(), database.DB.SQL)
+	if err != nil {
+		return rates, err
+	}
+	for i := range result {
+		rates = append(rates, Data{
+			ID:             result[i].ID,
+			Exchange:       strings.ToLower(exchangeName),
+			ExchangeNameID: result[i].ExchangeNameID,
+			Base:           strings.ToUpper(result[i].Base),
+			Quote:          strings.ToUpper(result[i].Quote),
+			AssetType:      strings.ToLower(result[i].Asset),
+			Rate:           result[i].Rate,
+			Timestamp:      result[i].Timestamp,
+		})
+	}
+	return rates, nil
+}
+
+func generateQuery(clauses map[string]interface{}, start, end time.Time) []qm.QueryMod {
+	query := []qm.QueryMod{
+		qm.Where("timestamp BETWEEN ? AND ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)),
+		qm.OrderBy("timestamp"),
+	}
+	for k, v := range clauses {
+		query = append(query, qm.Where(k+` = ?`, v))
+	}
+	return query
+}
@@ -0,0 +1,147 @@
+package fundingrate
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+		{
+			Name: "two",
+		},
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestFundingRates(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func() error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.seedDB != nil {
+				err = test.seedDB()
+				if err != nil {
+					t.Error(err)
+				}
+			}
+
+			fundingRateSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func fundingRateSQLTester(t *testing.T) {
+	start := time.Now().Truncate(time.Hour).Add(-time.Hour * 24)
+	var rates []Data
+	for i := 0; i < 3; i++ {
+		rates = append(rates, Data{
+			Exchange:  testExchanges[0].Name,
+			Base:      currency.BTC.String(),
+			Quote:     currency.USD.String(),
+			AssetType: asset.PerpetualSwap.String(),
+			Rate:      0.0001 * float64(i+1),
+			Timestamp: start.Add(time.Hour * 8 * time.Duration(i)),
+		})
+	}
+	err := Insert(rates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// insert the same rates again to test conflict resolution
+	var duplicates []Data
+	for i := range rates {
+		duplicates = append(duplicates, Data{
+			Exchange:  rates[i].Exchange,
+			Base:      rates[i].Base,
+			Quote:     rates[i].Quote,
+			AssetType: rates[i].AssetType,
+			Rate:      rates[i].Rate,
+			Timestamp: rates[i].Timestamp,
+		})
+	}
+	err = Insert(duplicates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := GetInRange(testExchanges[0].Name,
+		asset.PerpetualSwap.String(),
+		currency.BTC.String(),
+		currency.USD.String(),
+		start.Add(-time.Hour),
+		time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 3 {
+		t.Fatalf("unique constraints failing, expected 3 rates received %v", len(resp))
+	}
+	if resp[2].Rate != rates[2].Rate {
+		t.Errorf("expected rate %v received %v", rates[2].Rate, resp[2].Rate)
+	}
+}
+
+func seedDB() error {
+	return exchange.InsertMany(testExchanges)
+}
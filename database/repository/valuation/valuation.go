@@ -0,0 +1,196 @@
+package valuation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+var errFiatCurrencyUnset = errors.New("fiat currency not set")
+
+// Insert saves portfolio valuation data to the database, existing entries for
+// the same source, currency, fiat currency and timestamp are ignored
+func Insert(values ...Data) error {
+	for i := range values {
+		if values[i].Source == "" || values[i].Currency == "" {
+			return errors.New("source/currency not set, cannot insert")
+		}
+		if values[i].FiatCurrency == "" {
+			return errFiatCurrencyUnset
+		}
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = insertSQLite(ctx, tx, values...)
+	} else {
+		err = insertPostgres(ctx, tx, values...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, values ...Data) error {
+	for i := range values {
+		if values[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			values[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelSQLite.PortfolioValuation{
+			ID:           values[i].ID,
+			Source:       strings.ToLower(values[i].Source),
+			Currency:     strings.ToUpper(values[i].Currency),
+			Amount:       values[i].Amount,
+			Price:        values[i].Price,
+			Value:        values[i].Value,
+			FiatCurrency: strings.ToUpper(values[i].FiatCurrency),
+			Timestamp:    values[i].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err := tempEvent.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, values ...Data) error {
+	var err error
+	for i := range values {
+		if values[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			values[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelPSQL.PortfolioValuation{
+			ID:           values[i].ID,
+			Source:       strings.ToLower(values[i].Source),
+			Currency:     strings.ToUpper(values[i].Currency),
+			Amount:       values[i].Amount,
+			Price:        values[i].Price,
+			Value:        values[i].Value,
+			FiatCurrency: strings.ToUpper(values[i].FiatCurrency),
+			Timestamp:    values[i].Timestamp.UTC(),
+		}
+		err = tempEvent.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInRange returns all portfolio valuations in a fiat currency between the
+// start and end dates ordered by timestamp
+func GetInRange(fiatCurrency string, startDate, endDate time.Time) (vd []Data, err error) {
+	if fiatCurrency == "" {
+		return nil, errFiatCurrencyUnset
+	}
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		vd, err = getInRangeSQLite(fiatCurrency, startDate, endDate)
+		if err != nil {
+			return vd, fmt.Errorf("valuation.GetInRange getInRangeSQLite %w", err)
+		}
+	} else {
+		vd, err = getInRangePostgres(fiatCurrency, startDate, endDate)
+		if err != nil {
+			return vd, fmt.Errorf("valuation.GetInRange getInRangePostgres %w", err)
+		}
+	}
+
+	return vd, nil
+}
+
+func getInRangeSQLite(fiatCurrency string, startDate, endDate time.Time) (vd []Data, err error) {
+	q := generateQuery(fiatCurrency, startDate, endDate)
+	var result []*modelSQLite.PortfolioValuation
+	result, err = modelSQLite.PortfolioValuations(q...).All(context.Background(), database.DB.SQL)
+	if err != nil {
+		return vd, err
+	}
+	for i := range result {
+		ts, err := time.Parse(time.RFC3339, result[i].Timestamp)
+		if err != nil {
+			return vd, err
+		}
+		vd = append(vd, Data{
+			ID:           result[i].ID,
+			Source:       result[i].Source,
+			Currency:     result[i].Currency,
+			Amount:       result[i].Amount,
+			Price:        result[i].Price,
+			Value:        result[i].Value,
+			FiatCurrency: result[i].FiatCurrency,
+			Timestamp:    ts,
+		})
+	}
+	return vd, nil
+}
+
+func getInRangePostgres(fiatCurrency string, startDate, endDate time.Time) (vd []Data, err error) {
+	q := generateQuery(fiatCurrency, startDate, endDate)
+	var result []*modelPSQL.PortfolioValuation
+	result, err = modelPSQL.PortfolioValuations(q...).All(context.Background(), database.DB.SQL)
+	if err != nil {
+		return vd, err
+	}
+	for i := range result {
+		vd = append(vd, Data{
+			ID:           result[i].ID,
+			Source:       result[i].Source,
+			Currency:     result[i].Currency,
+			Amount:       result[i].Amount,
+			Price:        result[i].Price,
+			Value:        result[i].Value,
+			FiatCurrency: result[i].FiatCurrency,
+			Timestamp:    result[i].Timestamp,
+		})
+	}
+	return vd, nil
+}
+
+func generateQuery(fiatCurrency string, start, end time.Time) []qm.QueryMod {
+	return []qm.QueryMod{
+		qm.Where("timestamp BETWEEN ? AND ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)),
+		qm.Where("fiat_currency = ?", strings.ToUpper(fiatCurrency)),
+		qm.OrderBy("timestamp"),
+	}
+}
@@ -0,0 +1,136 @@
+package valuation
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestValuations(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			valuationSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func valuationSQLTester(t *testing.T) {
+	start := time.Now().Truncate(time.Hour).Add(-time.Hour * 24)
+	var values []Data
+	for i := 0; i < 3; i++ {
+		values = append(values,
+			Data{
+				Source:       "binance",
+				Currency:     currency.BTC.String(),
+				Amount:       1,
+				Price:        10000 + float64(i),
+				Value:        10000 + float64(i),
+				FiatCurrency: currency.USD.String(),
+				Timestamp:    start.Add(time.Hour * time.Duration(i)),
+			},
+			Data{
+				Source:       "wallet",
+				Currency:     currency.ETH.String(),
+				Amount:       2,
+				Price:        400,
+				Value:        800,
+				FiatCurrency: currency.USD.String(),
+				Timestamp:    start.Add(time.Hour * time.Duration(i)),
+			})
+	}
+	err := Insert(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// insert the same values again to test conflict resolution
+	var duplicates []Data
+	for i := range values {
+		d := values[i]
+		d.ID = ""
+		duplicates = append(duplicates, d)
+	}
+	err = Insert(duplicates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Insert(Data{Source: "wallet", Currency: "BTC"})
+	if !errors.Is(err, errFiatCurrencyUnset) {
+		t.Errorf("expected %v received %v", errFiatCurrencyUnset, err)
+	}
+
+	resp, err := GetInRange(currency.USD.String(), start.Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 6 {
+		t.Fatalf("unique constraints failing, expected 6 values received %v", len(resp))
+	}
+	if !resp[5].Timestamp.Equal(start.Add(time.Hour * 2)) {
+		t.Errorf("expected results ordered by timestamp received %v", resp[5].Timestamp)
+	}
+
+	resp, err = GetInRange(currency.EUR.String(), start.Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 0 {
+		t.Errorf("expected no EUR values received %v", len(resp))
+	}
+}
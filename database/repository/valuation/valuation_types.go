@@ -0,0 +1,16 @@
+package valuation
+
+import "time"
+
+// Data defines a single fiat valued holding of a portfolio snapshot in its
+// simplest db friendly form
+type Data struct {
+	ID           string
+	Source       string
+	Currency     string
+	Amount       float64
+	Price        float64
+	Value        float64
+	FiatCurrency string
+	Timestamp    time.Time
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -80,6 +81,9 @@ func addPSQLEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (err
 	if res.RequestDetails.Description != "" {
 		tempEvent.Description.SetValid(res.RequestDetails.Description)
 	}
+	if res.RequestDetails.AccountID != "" {
+		tempEvent.AccountID.SetValid(res.RequestDetails.AccountID)
+	}
 
 	err = tempEvent.Insert(ctx, tx, boil.Infer())
 	if err != nil {
@@ -117,6 +121,9 @@ func addPSQLEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (err
 			Address: res.RequestDetails.Crypto.Address,
 			Fee:     res.RequestDetails.Crypto.FeeAmount,
 		}
+		if res.Exchange.TxID != "" {
+			cryptoEvent.TXID.SetValid(res.Exchange.TxID)
+		}
 		if res.RequestDetails.Crypto.AddressTag != "" {
 			cryptoEvent.AddressTag.SetValid(res.RequestDetails.Crypto.AddressTag)
 		}
@@ -161,6 +168,9 @@ func addSQLiteEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (er
 	if res.RequestDetails.Description != "" {
 		tempEvent.Description.SetValid(res.RequestDetails.Description)
 	}
+	if res.RequestDetails.AccountID != "" {
+		tempEvent.AccountID.SetValid(res.RequestDetails.AccountID)
+	}
 
 	err = tempEvent.Insert(ctx, tx, boil.Infer())
 	if err != nil {
@@ -199,6 +209,9 @@ func addSQLiteEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (er
 			Address: res.RequestDetails.Crypto.Address,
 			Fee:     res.RequestDetails.Crypto.FeeAmount,
 		}
+		if res.Exchange.TxID != "" {
+			cryptoEvent.TXID.SetValid(res.Exchange.TxID)
+		}
 
 		if res.RequestDetails.Crypto.AddressTag != "" {
 			cryptoEvent.AddressTag.SetValid(res.RequestDetails.Crypto.AddressTag)
@@ -219,6 +232,166 @@ func addSQLiteEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (er
 	return nil
 }
 
+// UpdateStatus updates the exchange ID and status of a stored withdrawal
+// request
+func UpdateStatus(id, exchangeID, status string) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	cols := map[string]interface{}{
+		"exchange_id": exchangeID,
+		"status":      status,
+		"updated_at":  time.Now().UTC(),
+	}
+	var rows int64
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		cols["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+		rows, err = modelSQLite.WithdrawalHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	} else {
+		rows, err = modelPSQL.WithdrawalHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	}
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// UpdateApprovers stores the operators which have approved a withdrawal
+// request held for approval
+func UpdateApprovers(id string, approvers []string) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	cols := map[string]interface{}{
+		"approvers": strings.Join(approvers, ","),
+	}
+	var rows int64
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		rows, err = modelSQLite.WithdrawalHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	} else {
+		rows, err = modelPSQL.WithdrawalHistories(qm.Where("id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	}
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// GetApprovers returns the operators which have approved a withdrawal request
+// held for approval
+func GetApprovers(id string) ([]string, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	var approvers string
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.WithdrawalHistories(qm.Where("id = ?", id)).One(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		approvers = v.Approvers.String
+	} else {
+		v, err := modelPSQL.WithdrawalHistories(qm.Where("id = ?", id)).One(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		approvers = v.Approvers.String
+	}
+	if approvers == "" {
+		return nil, nil
+	}
+	return strings.Split(approvers, ","), nil
+}
+
+// GetEventsByStatus returns all withdrawal requests with the supplied status
+func GetEventsByStatus(status string) ([]*withdraw.Response, error) {
+	return getByColumns(generateWhereQuery([]string{"status"}, []string{status}, 0))
+}
+
+// UpdateCryptoDetails updates the transaction ID and fee of a stored
+// cryptocurrency withdrawal request
+func UpdateCryptoDetails(id, txID string, fee float64) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	cols := map[string]interface{}{
+		"tx_id": txID,
+		"fee":   fee,
+	}
+	var rows int64
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		rows, err = modelSQLite.WithdrawalCryptos(qm.Where("withdrawal_history_id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	} else {
+		rows, err = modelPSQL.WithdrawalCryptos(qm.Where("withdrawal_crypto_id = ?", id)).UpdateAll(ctx, database.DB.SQL, cols)
+	}
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// GetOpenEvents returns withdrawal requests which have been accepted by an
+// exchange and whose status is not one of the supplied final statuses
+func GetOpenEvents(finalStatuses []string) ([]*withdraw.Response, error) {
+	q := []qm.QueryMod{
+		qm.Where("exchange_id != ?", ""),
+		qm.Where("exchange_id != ?", "error"),
+	}
+	if len(finalStatuses) > 0 {
+		args := make([]interface{}, len(finalStatuses))
+		for i := range finalStatuses {
+			args[i] = finalStatuses[i]
+		}
+		q = append(q, qm.WhereIn("lower(status) NOT IN ?", args...))
+	}
+	return getByColumns(q)
+}
+
+// GetCryptoEventsByTxID returns cryptocurrency withdrawal requests with the
+// supplied transaction ID
+func GetCryptoEventsByTxID(txID string) ([]*withdraw.Response, error) {
+	return getByColumns([]qm.QueryMod{
+		cryptoJoin(),
+		qm.Where("withdrawal_crypto.tx_id = ?", txID),
+	})
+}
+
+// GetCryptoEventsByAddress returns cryptocurrency withdrawal requests sent to
+// the supplied address
+func GetCryptoEventsByAddress(address string) ([]*withdraw.Response, error) {
+	return getByColumns([]qm.QueryMod{
+		cryptoJoin(),
+		qm.Where("withdrawal_crypto.address = ?", address),
+	})
+}
+
+func cryptoJoin() qm.QueryMod {
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		return qm.InnerJoin("withdrawal_crypto on withdrawal_crypto.withdrawal_history_id = withdrawal_history.id")
+	}
+	return qm.InnerJoin("withdrawal_crypto on withdrawal_crypto.withdrawal_crypto_id = withdrawal_history.id")
+}
+
 // GetEventByUUID return requested withdraw information by ID
 func GetEventByUUID(id string) (*withdraw.Response, error) {
 	resp, err := getByColumns(generateWhereQuery([]string{"id"}, []string{id}, 1))
@@ -311,6 +484,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				Description: v[x].Description.String,
 				Amount:      v[x].Amount,
 				Type:        withdraw.RequestType(v[x].WithdrawType),
+				AccountID:   v[x].AccountID.String,
 			}
 
 			exchangeName, err := v[x].ExchangeName().One(ctx, database.DB.SQL)
@@ -350,11 +524,14 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				tempResp.RequestDetails.Crypto.Address = x.Address
 				tempResp.RequestDetails.Crypto.AddressTag = x.AddressTag.String
 				tempResp.RequestDetails.Crypto.FeeAmount = x.Fee
+				tempResp.Exchange.TxID = x.TXID.String
 			} else {
 				x, err := v[x].WithdrawalFiats().One(ctx, database.DB.SQL)
 				if err != nil {
 					return nil, err
 				}
+				tempResp.RequestDetails.Fiat.Bank.BankName = x.BankName
+				tempResp.RequestDetails.Fiat.Bank.BankAddress = x.BankAddress
 				tempResp.RequestDetails.Fiat.Bank.AccountName = x.BankAccountName
 				tempResp.RequestDetails.Fiat.Bank.AccountNumber = x.BankAccountNumber
 				tempResp.RequestDetails.Fiat.Bank.IBAN = x.Iban
@@ -380,6 +557,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				Description: v[x].Description.String,
 				Amount:      v[x].Amount,
 				Type:        withdraw.RequestType(v[x].WithdrawType),
+				AccountID:   v[x].AccountID.String,
 			}
 			tempResp.CreatedAt = v[x].CreatedAt
 			tempResp.UpdatedAt = v[x].UpdatedAt
@@ -405,11 +583,14 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				tempResp.RequestDetails.Crypto.Address = x.Address
 				tempResp.RequestDetails.Crypto.AddressTag = x.AddressTag.String
 				tempResp.RequestDetails.Crypto.FeeAmount = x.Fee
+				tempResp.Exchange.TxID = x.TXID.String
 			} else if withdraw.RequestType(v[x].WithdrawType) == withdraw.Fiat {
 				x, err := v[x].WithdrawalFiatWithdrawalFiats().One(ctx, database.DB.SQL)
 				if err != nil {
 					return nil, err
 				}
+				tempResp.RequestDetails.Fiat.Bank.BankName = x.BankName
+				tempResp.RequestDetails.Fiat.Bank.BankAddress = x.BankAddress
 				tempResp.RequestDetails.Fiat.Bank.AccountName = x.BankAccountName
 				tempResp.RequestDetails.Fiat.Bank.AccountNumber = x.BankAccountNumber
 				tempResp.RequestDetails.Fiat.Bank.IBAN = x.Iban
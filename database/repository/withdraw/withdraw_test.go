@@ -187,4 +187,102 @@ func withdrawHelper(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+
+	err = UpdateStatus(v[0].ID.String(), "exchange-id", "updated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := GetEventByUUID(v[0].ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Exchange.Status != "updated" || updated.Exchange.ID != "exchange-id" {
+		t.Errorf("expected status to be updated received %v %v", updated.Exchange.Status, updated.Exchange.ID)
+	}
+
+	err = UpdateStatus(withdraw.DryRunID.String(), "", "updated")
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v received %v", ErrNoResults, err)
+	}
+
+	open, err := GetOpenEvents([]string{"updated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range open {
+		if open[i].ID == v[0].ID {
+			t.Error("expected event with final status to be excluded")
+		}
+		if open[i].RequestDetails.Type != withdraw.Crypto {
+			continue
+		}
+		err = UpdateCryptoDetails(open[i].ID.String(), "0xdeadbeef", 0.1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated, err = GetEventByUUID(open[i].ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Exchange.TxID != "0xdeadbeef" || updated.RequestDetails.Crypto.FeeAmount != 0.1 {
+			t.Errorf("expected crypto details to be updated received %v %v",
+				updated.Exchange.TxID,
+				updated.RequestDetails.Crypto.FeeAmount)
+		}
+		byTx, err := GetCryptoEventsByTxID("0xdeadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(byTx) != 1 || byTx[0].ID != open[i].ID {
+			t.Errorf("expected withdrawal %v by transaction ID", open[i].ID)
+		}
+		byAddress, err := GetCryptoEventsByAddress(open[i].RequestDetails.Crypto.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(byAddress) != 1 || byAddress[0].ID != open[i].ID {
+			t.Errorf("expected withdrawal %v by address", open[i].ID)
+		}
+		break
+	}
+
+	pending := &withdraw.Response{
+		Exchange: withdraw.ExchangeResponse{
+			Name:   testExchanges[0].Name,
+			Status: "pending_approval",
+		},
+		RequestDetails: withdraw.Request{
+			Exchange:  testExchanges[0].Name,
+			AccountID: "sub",
+			Currency:  currency.BTC,
+			Amount:    1,
+			Type:      withdraw.Crypto,
+			Crypto: withdraw.CryptoRequest{
+				Address: "pending-address",
+			},
+		},
+	}
+	Event(pending)
+	err = UpdateApprovers(pending.ID.String(), []string{"alice", "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	held, err := GetEventsByStatus("pending_approval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(held) != 1 || held[0].ID != pending.ID || held[0].RequestDetails.AccountID != "sub" {
+		t.Fatalf("expected pending withdrawal %v with account to be returned", pending.ID)
+	}
+	approvers, err := GetApprovers(pending.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(approvers) != 2 || approvers[0] != "alice" || approvers[1] != "bob" {
+		t.Errorf("expected stored approvers received %v", approvers)
+	}
+	err = UpdateApprovers(withdraw.DryRunID.String(), []string{"alice"})
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("expected %v received %v", ErrNoResults, err)
+	}
 }
@@ -57,6 +57,18 @@ func IsRunning() bool {
 	return dispatcher.isRunning()
 }
 
+// QueueDepth returns the number of jobs waiting to be relayed and the job
+// queue capacity
+func QueueDepth() (depth, capacity int) {
+	if dispatcher == nil {
+		return 0, 0
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	return len(dispatcher.jobs), cap(dispatcher.jobs)
+}
+
 // DropWorker drops a worker routine
 func DropWorker() error {
 	if dispatcher == nil {
@@ -308,3 +308,13 @@ func BenchmarkSubscribe(b *testing.B) {
 		}
 	}
 }
+
+func TestQueueDepth(t *testing.T) {
+	depth, capacity := QueueDepth()
+	if depth < 0 || depth > capacity {
+		t.Errorf("unexpected queue depth %d capacity %d", depth, capacity)
+	}
+	if IsRunning() && capacity == 0 {
+		t.Error("running dispatcher should have a job queue")
+	}
+}
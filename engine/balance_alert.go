@@ -0,0 +1,377 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Balance alert rule types
+const (
+	BalanceAlertBelow       = "below"
+	BalanceAlertChange      = "change"
+	BalanceAlertNewCurrency = "new_currency"
+)
+
+const (
+	// balanceAlertSubscribeInterval is how often exchanges which have not yet
+	// published account holdings are checked again
+	balanceAlertSubscribeInterval = time.Minute
+	balanceAlertSubscriberBuffer  = 32
+)
+
+var errBalanceAlertsNotStarted = errors.New("balance alert manager not started")
+
+// BalanceAlert is a triggered balance alert rule
+type BalanceAlert struct {
+	Exchange      string
+	Currency      currency.Code
+	Rule          string
+	Balance       float64
+	Threshold     float64
+	ChangePercent float64
+	Message       string
+	Time          time.Time
+}
+
+// balanceSample is the total balance of a currency at a point in time
+type balanceSample struct {
+	Time  time.Time
+	Total float64
+}
+
+// balanceAlertManager evaluates the configured balance alert rules against
+// every exchange account update
+type balanceAlertManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m           sync.Mutex
+	subscribed  map[string]bool
+	samples     map[string]map[string][]balanceSample
+	held        map[string]map[string]bool
+	below       map[string]bool
+	lastChange  map[string]time.Time
+	subscribers map[chan BalanceAlert]struct{}
+}
+
+func (b *balanceAlertManager) Started() bool {
+	return atomic.LoadInt32(&b.started) == 1
+}
+
+func (b *balanceAlertManager) Start() error {
+	if atomic.AddInt32(&b.started, 1) != 1 {
+		return errors.New("balance alert manager already started")
+	}
+
+	log.Debugln(log.Global, "Balance alert manager starting...")
+	b.m.Lock()
+	b.subscribed = make(map[string]bool)
+	b.samples = make(map[string]map[string][]balanceSample)
+	b.held = make(map[string]map[string]bool)
+	b.below = make(map[string]bool)
+	b.lastChange = make(map[string]time.Time)
+	b.m.Unlock()
+	b.shutdown = make(chan struct{})
+	go b.run()
+	return nil
+}
+
+func (b *balanceAlertManager) Stop() error {
+	if atomic.LoadInt32(&b.started) == 0 {
+		return errors.New("balance alert manager not started")
+	}
+	if atomic.AddInt32(&b.stopped, 1) != 1 {
+		return errors.New("balance alert manager is already stopped")
+	}
+
+	close(b.shutdown)
+	log.Debugln(log.Global, "Balance alert manager shutting down...")
+	return nil
+}
+
+func (b *balanceAlertManager) run() {
+	log.Debugln(log.Global, "Balance alert manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(balanceAlertSubscribeInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&b.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&b.started, 1, 0)
+		tick.Stop()
+		b.m.Lock()
+		for ch := range b.subscribers {
+			close(ch)
+		}
+		b.subscribers = nil
+		b.m.Unlock()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Balance alert manager shutdown.")
+	}()
+
+	b.subscribe()
+	for {
+		select {
+		case <-b.shutdown:
+			return
+		case <-tick.C:
+			b.subscribe()
+		}
+	}
+}
+
+// subscribe watches the account updates of every enabled exchange which has
+// published account holdings and is not already watched
+func (b *balanceAlertManager) subscribe() {
+	exchanges := Bot.GetExchanges()
+	for i := range exchanges {
+		if !exchanges[i].IsEnabled() {
+			continue
+		}
+		name := exchanges[i].GetName()
+		b.m.Lock()
+		if b.subscribed[name] {
+			b.m.Unlock()
+			continue
+		}
+		pipe, err := account.SubscribeToExchangeAccount(name)
+		if err != nil {
+			b.m.Unlock()
+			continue
+		}
+		b.subscribed[name] = true
+		b.m.Unlock()
+		holdings, err := account.GetHoldings(name)
+		if err == nil {
+			b.process(&holdings)
+		}
+		go b.watch(name, pipe)
+	}
+}
+
+func (b *balanceAlertManager) watch(exchName string, pipe dispatch.Pipe) {
+	defer func() {
+		err := pipe.Release()
+		if err != nil {
+			log.Errorf(log.Global, "Balance alert manager %s release error: %v\n", exchName, err)
+		}
+		b.m.Lock()
+		delete(b.subscribed, exchName)
+		b.m.Unlock()
+	}()
+	for {
+		select {
+		case <-b.shutdown:
+			return
+		case data, ok := <-pipe.C:
+			if !ok {
+				return
+			}
+			holdings, ok := (*data.(*interface{})).(account.Holdings)
+			if !ok {
+				continue
+			}
+			b.process(&holdings)
+		}
+	}
+}
+
+func (b *balanceAlertManager) process(h *account.Holdings) {
+	alerts := b.evaluate(h,
+		Bot.Config.BalanceAlerts.Rules,
+		Bot.Config.BalanceAlerts.Cooldown,
+		time.Now())
+	for i := range alerts {
+		log.Warnln(log.Global, alerts[i].Message)
+		Bot.CommsManager.PushEvent(base.Event{Type: "balance_alert", Severity: base.SeverityWarning, Message: alerts[i].Message})
+		b.publish(alerts[i])
+	}
+}
+
+// evaluate records the holdings and returns the alerts triggered by them.
+// Below alerts trigger once until the balance recovers, change alerts are
+// limited by the cooldown and new currency alerts are only raised after the
+// first update of an exchange has set the held currencies
+func (b *balanceAlertManager) evaluate(h *account.Holdings, rules []config.BalanceAlertRule, cooldown time.Duration, now time.Time) []BalanceAlert {
+	totals, codes := holdingsTotals(h)
+	name := strings.ToLower(h.Exchange)
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.samples == nil {
+		b.samples = make(map[string]map[string][]balanceSample)
+		b.held = make(map[string]map[string]bool)
+		b.below = make(map[string]bool)
+		b.lastChange = make(map[string]time.Time)
+	}
+	previous, seen := b.samples[name]
+	if !seen {
+		previous = make(map[string][]balanceSample)
+		b.samples[name] = previous
+		b.held[name] = make(map[string]bool)
+	}
+	// Currencies no longer returned by the exchange have been fully drained
+	for c := range previous {
+		if _, ok := totals[c]; !ok {
+			totals[c] = 0
+			codes[c] = currency.NewCode(c)
+		}
+	}
+
+	var alerts []BalanceAlert
+	var maxWindow time.Duration
+	for i := range rules {
+		if rules[i].Exchange != "" && !strings.EqualFold(rules[i].Exchange, h.Exchange) {
+			continue
+		}
+		if rules[i].ChangeWindow > maxWindow {
+			maxWindow = rules[i].ChangeWindow
+		}
+		var currencies []string
+		if rules[i].Currency.IsEmpty() {
+			for c := range totals {
+				currencies = append(currencies, c)
+			}
+			sort.Strings(currencies)
+		} else {
+			c := rules[i].Currency.Upper().String()
+			if _, ok := codes[c]; !ok {
+				codes[c] = rules[i].Currency
+			}
+			currencies = []string{c}
+		}
+
+		for _, c := range currencies {
+			total := totals[c]
+			key := fmt.Sprintf("%d|%s|%s", i, name, c)
+			alert := BalanceAlert{
+				Exchange: h.Exchange,
+				Currency: codes[c],
+				Balance:  total,
+				Time:     now,
+			}
+
+			if rules[i].Below > 0 {
+				if total < rules[i].Below {
+					if !b.below[key] {
+						b.below[key] = true
+						alert.Rule = BalanceAlertBelow
+						alert.Threshold = rules[i].Below
+						alert.Message = fmt.Sprintf("Balance alert: %s %s balance %v is below %v",
+							h.Exchange,
+							codes[c],
+							total,
+							rules[i].Below)
+						alerts = append(alerts, alert)
+					}
+				} else {
+					delete(b.below, key)
+				}
+			}
+
+			if rules[i].ChangePercent > 0 && now.Sub(b.lastChange[key]) >= cooldown {
+				ref := referenceSample(previous[c], now.Add(-rules[i].ChangeWindow))
+				if ref != nil && ref.Total > 0 {
+					change := (total - ref.Total) / ref.Total * 100
+					if math.Abs(change) >= rules[i].ChangePercent {
+						b.lastChange[key] = now
+						alert.Rule = BalanceAlertChange
+						alert.Threshold = rules[i].ChangePercent
+						alert.ChangePercent = change
+						alert.Message = fmt.Sprintf("Balance alert: %s %s balance changed %.2f%% from %v to %v within %s",
+							h.Exchange,
+							codes[c],
+							change,
+							ref.Total,
+							total,
+							now.Sub(ref.Time).Round(time.Second))
+						alerts = append(alerts, alert)
+					}
+				}
+			}
+
+			if rules[i].NewCurrency && seen && total > 0 && !b.held[name][c] {
+				alert.Rule = BalanceAlertNewCurrency
+				alert.Message = fmt.Sprintf("Balance alert: %s received new currency %s with balance %v",
+					h.Exchange,
+					codes[c],
+					total)
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	for c, total := range totals {
+		samples := append(previous[c], balanceSample{Time: now, Total: total})
+		// Retain the latest sample and those within the longest change window
+		cutoff := now.Add(-maxWindow)
+		j := 0
+		for j < len(samples)-1 && samples[j].Time.Before(cutoff) {
+			j++
+		}
+		previous[c] = samples[j:]
+		if total > 0 {
+			b.held[name][c] = true
+		}
+	}
+	return alerts
+}
+
+// referenceSample returns the oldest sample at or after the window start
+func referenceSample(samples []balanceSample, start time.Time) *balanceSample {
+	for i := range samples {
+		if !samples[i].Time.Before(start) {
+			return &samples[i]
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel which receives every triggered balance alert,
+// it must be released with Unsubscribe and is closed on shutdown
+func (b *balanceAlertManager) Subscribe() (chan BalanceAlert, error) {
+	if !b.Started() {
+		return nil, errBalanceAlertsNotStarted
+	}
+	ch := make(chan BalanceAlert, balanceAlertSubscriberBuffer)
+	b.m.Lock()
+	if b.subscribers == nil {
+		b.subscribers = make(map[chan BalanceAlert]struct{})
+	}
+	b.subscribers[ch] = struct{}{}
+	b.m.Unlock()
+	return ch, nil
+}
+
+// Unsubscribe stops alerts being sent to the channel
+func (b *balanceAlertManager) Unsubscribe(ch chan BalanceAlert) {
+	b.m.Lock()
+	delete(b.subscribers, ch)
+	b.m.Unlock()
+}
+
+// publish sends the alert to every subscriber, alerts are dropped for
+// subscribers which are not keeping up
+func (b *balanceAlertManager) publish(alert BalanceAlert) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- alert:
+		default:
+			log.Warnf(log.Global, "Balance alert subscriber buffer full, dropping alert: %s\n", alert.Message)
+		}
+	}
+}
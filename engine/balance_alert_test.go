@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+)
+
+func balanceAlertHoldings(balances ...account.Balance) *account.Holdings {
+	return &account.Holdings{
+		Exchange: testExchange,
+		Accounts: []account.SubAccount{{Currencies: balances}},
+	}
+}
+
+func TestBalanceAlertBelow(t *testing.T) {
+	var b balanceAlertManager
+	rules := []config.BalanceAlertRule{{Exchange: testExchange, Currency: currency.BTC, Below: 1}}
+	now := time.Now()
+
+	alerts := b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.BTC, TotalValue: 0.5}),
+		rules, time.Minute, now)
+	if len(alerts) != 1 || alerts[0].Rule != BalanceAlertBelow || alerts[0].Balance != 0.5 {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+	alerts = b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.BTC, TotalValue: 0.4}),
+		rules, time.Minute, now)
+	if len(alerts) != 0 {
+		t.Error("below alert should only trigger once until recovered")
+	}
+	b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.BTC, TotalValue: 2}),
+		rules, time.Minute, now)
+	alerts = b.evaluate(balanceAlertHoldings(), rules, time.Minute, now)
+	if len(alerts) != 1 || alerts[0].Balance != 0 || !alerts[0].Currency.Match(currency.BTC) {
+		t.Errorf("expected drained currency alert, received %+v", alerts)
+	}
+
+	alerts = b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.BTC, TotalValue: 0.1}),
+		[]config.BalanceAlertRule{{Exchange: "Bitfinex", Below: 1}}, time.Minute, now)
+	if len(alerts) != 0 {
+		t.Error("rules for other exchanges should not trigger")
+	}
+}
+
+func TestBalanceAlertChange(t *testing.T) {
+	var b balanceAlertManager
+	rules := []config.BalanceAlertRule{{ChangePercent: 10, ChangeWindow: time.Minute * 10}}
+	start := time.Now()
+
+	b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.LTC, TotalValue: 100}),
+		rules, time.Minute*5, start)
+	alerts := b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.LTC, TotalValue: 95}),
+		rules, time.Minute*5, start.Add(time.Minute))
+	if len(alerts) != 0 {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+	alerts = b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.LTC, TotalValue: 80}),
+		rules, time.Minute*5, start.Add(time.Minute*2))
+	if len(alerts) != 1 || alerts[0].Rule != BalanceAlertChange || alerts[0].ChangePercent != -20 {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+	alerts = b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.LTC, TotalValue: 50}),
+		rules, time.Minute*5, start.Add(time.Minute*3))
+	if len(alerts) != 0 {
+		t.Error("change alert should be limited by the cooldown")
+	}
+	// Samples older than the window are no longer compared against
+	alerts = b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.LTC, TotalValue: 50}),
+		rules, time.Minute*5, start.Add(time.Minute*14))
+	if len(alerts) != 0 {
+		t.Errorf("unexpected alerts %+v", alerts)
+	}
+}
+
+func TestBalanceAlertNewCurrency(t *testing.T) {
+	var b balanceAlertManager
+	rules := []config.BalanceAlertRule{{NewCurrency: true}}
+	now := time.Now()
+
+	alerts := b.evaluate(balanceAlertHoldings(account.Balance{CurrencyName: currency.BTC, TotalValue: 1}),
+		rules, time.Minute, now)
+	if len(alerts) != 0 {
+		t.Error("first update should only set the held currencies")
+	}
+	alerts = b.evaluate(balanceAlertHoldings(
+		account.Balance{CurrencyName: currency.BTC, TotalValue: 1},
+		account.Balance{CurrencyName: currency.XRP, TotalValue: 0}),
+		rules, time.Minute, now)
+	if len(alerts) != 0 {
+		t.Error("empty balances should not trigger")
+	}
+	alerts = b.evaluate(balanceAlertHoldings(
+		account.Balance{CurrencyName: currency.BTC, TotalValue: 1},
+		account.Balance{CurrencyName: currency.XRP, TotalValue: 10}),
+		rules, time.Minute, now)
+	if len(alerts) != 1 || alerts[0].Rule != BalanceAlertNewCurrency || !alerts[0].Currency.Match(currency.XRP) {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+	alerts = b.evaluate(balanceAlertHoldings(
+		account.Balance{CurrencyName: currency.BTC, TotalValue: 1},
+		account.Balance{CurrencyName: currency.XRP, TotalValue: 11}),
+		rules, time.Minute, now)
+	if len(alerts) != 0 {
+		t.Error("held currencies should not trigger again")
+	}
+}
+
+func TestBalanceAlertSubscribe(t *testing.T) {
+	var b balanceAlertManager
+	if _, err := b.Subscribe(); err != errBalanceAlertsNotStarted {
+		t.Fatalf("expected %v, received %v", errBalanceAlertsNotStarted, err)
+	}
+	b.started = 1
+	ch, err := b.Subscribe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.publish(BalanceAlert{Exchange: testExchange})
+	select {
+	case alert := <-ch:
+		if alert.Exchange != testExchange {
+			t.Error("unexpected alert")
+		}
+	default:
+		t.Fatal("expected alert")
+	}
+	b.Unsubscribe(ch)
+	b.publish(BalanceAlert{Exchange: testExchange})
+	if len(ch) != 0 {
+		t.Error("unsubscribed channel should not receive alerts")
+	}
+}
@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Read only commands communication relayers can run
+const (
+	cmdBalances = "balances"
+	cmdOrders   = "orders"
+	cmdTicker   = "ticker"
+	cmdPnL      = "pnl"
+)
+
+var errTickerCommandUsage = errors.New("usage: ticker <exchange> <pair> [asset]")
+
+// commsCommands answers queries about the engine and runs trading commands
+// once they are confirmed
+type commsCommands struct {
+	bot     *Engine
+	m       sync.Mutex
+	pending map[string]*pendingCommsAction
+}
+
+// registerCommands registers the queries and trading commands with the
+// communication relayers which accept commands and audits every command
+// received, including refused ones, against the sender. All but the ticker
+// are restricted to authorised senders
+func (c *commsManager) registerCommands(bot *Engine) {
+	q := &commsCommands{bot: bot}
+	base.SetCommandAuditor(auditCommsCommand)
+	base.RegisterAuthorisedCommand(cmdBalances, q.balances)
+	base.RegisterAuthorisedCommand(cmdOrders, q.orders)
+	base.RegisterCommand(cmdTicker, q.ticker)
+	base.RegisterAuthorisedCommand(cmdPnL, q.pnl)
+	base.RegisterAuthorisedCommand(cmdCancelOrder, q.cancelOrder)
+	base.RegisterAuthorisedCommand(cmdSubmitOrder, q.submitOrder)
+	base.RegisterAuthorisedCommand(cmdKillSwitch, q.killSwitch)
+	base.RegisterAuthorisedCommand(cmdConfirm, q.confirm)
+}
+
+// deregisterCommands removes the queries and trading commands and stops
+// auditing commands
+func (c *commsManager) deregisterCommands() {
+	base.DeregisterCommand(cmdBalances)
+	base.DeregisterCommand(cmdOrders)
+	base.DeregisterCommand(cmdTicker)
+	base.DeregisterCommand(cmdPnL)
+	base.DeregisterCommand(cmdCancelOrder)
+	base.DeregisterCommand(cmdSubmitOrder)
+	base.DeregisterCommand(cmdKillSwitch)
+	base.DeregisterCommand(cmdConfirm)
+	base.SetCommandAuditor(nil)
+}
+
+// auditCommsCommand records a command in the audit log against the sender's
+// chat identity. One-time codes are not recorded
+func auditCommsCommand(sender, name string, args []string, err error) {
+	recorded := append([]string{strings.ToLower(name)}, args...)
+	if recorded[0] == cmdConfirm && len(recorded) > 2 {
+		recorded[2] = "<otp>"
+	}
+	msg := strings.Join(recorded, " ")
+	if err != nil {
+		msg += " failed: " + err.Error()
+	}
+	audit.Event(sender, audit.CommandEvent, msg)
+}
+
+// balances replies with the last known non zero balances of every
+// enabled exchange or of the exchange named in the first argument
+func (c *commsCommands) balances(_ string, args []string) (string, error) {
+	var sb strings.Builder
+	exchanges := c.bot.GetExchanges()
+	for i := range exchanges {
+		name := exchanges[i].GetName()
+		if len(args) > 0 && !strings.EqualFold(name, args[0]) {
+			continue
+		}
+		holdings, err := account.GetHoldings(name)
+		if err != nil {
+			continue
+		}
+		for j := range holdings.Accounts {
+			for k := range holdings.Accounts[j].Currencies {
+				b := &holdings.Accounts[j].Currencies[k]
+				if b.TotalValue == 0 {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("%s %s: %v %s (%v on hold)\n",
+					name,
+					holdings.Accounts[j].ID,
+					b.TotalValue,
+					b.CurrencyName,
+					b.Hold))
+			}
+		}
+	}
+	if sb.Len() == 0 {
+		return "No balances available", nil
+	}
+	return sb.String(), nil
+}
+
+// orders replies with the open orders tracked by the order manager
+// for every exchange or for the exchange named in the first argument
+func (c *commsCommands) orders(_ string, args []string) (string, error) {
+	store := &c.bot.OrderManager.orderStore
+	store.m.RLock()
+	defer store.m.RUnlock()
+
+	var exchanges []string
+	orders := store.Orders
+	for exch := range orders {
+		if len(args) > 0 && !strings.EqualFold(exch, args[0]) {
+			continue
+		}
+		exchanges = append(exchanges, exch)
+	}
+	sort.Strings(exchanges)
+
+	var sb strings.Builder
+	for _, exch := range exchanges {
+		for _, o := range orders[exch] {
+			if !isOpenOrder(o.Status) {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s %s: %s %s %v %s @ %v %s\n",
+				o.Exchange,
+				o.ID,
+				o.Side,
+				o.Type,
+				o.Amount,
+				o.Pair,
+				o.Price,
+				o.Status))
+		}
+	}
+	if sb.Len() == 0 {
+		return "No open orders", nil
+	}
+	return sb.String(), nil
+}
+
+// ticker replies with the ticker of an exchange currency pair, the
+// asset type defaults to spot
+func (c *commsCommands) ticker(_ string, args []string) (string, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return "", errTickerCommandUsage
+	}
+	p, err := currency.NewPairFromString(args[1])
+	if err != nil {
+		return "", err
+	}
+	a := asset.Spot
+	if len(args) == 3 {
+		a, err = asset.New(args[2])
+		if err != nil {
+			return "", err
+		}
+	}
+	t, err := c.bot.GetSpecificTicker(p, args[0], a)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s %s: last %v bid %v ask %v high %v low %v volume %v",
+		t.ExchangeName,
+		t.Pair,
+		t.AssetType,
+		t.Last,
+		t.Bid,
+		t.Ask,
+		t.High,
+		t.Low,
+		t.Volume), nil
+}
+
+// isOpenOrder returns whether an order with the status may still be filled
+func isOpenOrder(s order.Status) bool {
+	switch s {
+	case order.New, order.Active, order.Open, order.PartiallyFilled, order.PendingCancel:
+		return true
+	}
+	return false
+}
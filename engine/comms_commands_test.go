@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// newCommsCommandsTest returns commands for an engine with only Bitstamp
+// loaded, the global Bot is left untouched
+func newCommsCommandsTest(t *testing.T) *commsCommands {
+	t.Helper()
+	cfg, err := config.ReadReloadConfig(config.TestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg, err := cfg.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := new(bitstamp.Bitstamp)
+	exch.SetDefaults()
+	if err = exch.Setup(exchCfg); err != nil {
+		t.Fatal(err)
+	}
+	bot := &Engine{Config: cfg}
+	bot.exchangeManager.add(exch)
+	return &commsCommands{bot: bot}
+}
+
+func TestCommsCommands(t *testing.T) {
+	c := newCommsCommandsTest(t)
+	bot := c.bot
+
+	err := account.Process(&account.Holdings{
+		Exchange: testExchange,
+		Accounts: []account.SubAccount{{
+			ID: "commstest",
+			Currencies: []account.Balance{
+				{CurrencyName: currency.XRP, TotalValue: 1337, Hold: 1},
+				{CurrencyName: currency.DOGE},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := c.balances("tester", []string{"BITSTAMP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(reply, "Bitstamp commstest: 1337 XRP (1 on hold)") ||
+		strings.Contains(reply, "DOGE") {
+		t.Errorf("unexpected balances reply %q", reply)
+	}
+	if reply, _ = c.balances("tester", []string{"missing"}); reply != "No balances available" {
+		t.Errorf("unexpected balances reply %q", reply)
+	}
+
+	p := currency.NewPair(currency.XRP, currency.USD)
+	bot.OrderManager.orderStore.Orders = map[string][]*order.Detail{
+		"commstest": {
+			{Exchange: "CommsTest", ID: "1", Side: order.Buy, Type: order.Limit, Amount: 2, Price: 0.5, Pair: p, Status: order.Active},
+			{Exchange: "CommsTest", ID: "2", Side: order.Sell, Type: order.Limit, Amount: 1, Price: 0.6, Pair: p, Status: order.Filled},
+		},
+	}
+	reply, err = c.orders("tester", []string{"commstest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "CommsTest 1: BUY LIMIT 2 XRPUSD @ 0.5 ACTIVE\n" {
+		t.Errorf("unexpected orders reply %q", reply)
+	}
+
+	_, err = c.ticker("tester", []string{testExchange})
+	if !errors.Is(err, errTickerCommandUsage) {
+		t.Errorf("expected %v, received %v", errTickerCommandUsage, err)
+	}
+	err = ticker.ProcessTicker(&ticker.Price{
+		Pair:         p,
+		Last:         0.55,
+		Bid:          0.5,
+		Ask:          0.6,
+		AssetType:    asset.Spot,
+		ExchangeName: testExchange,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err = c.ticker("tester", []string{testExchange, "XRPUSD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(reply, "Bitstamp XRPUSD spot: last 0.55 bid 0.5 ask 0.6") {
+		t.Errorf("unexpected ticker reply %q", reply)
+	}
+}
+
+func TestCommsTradingCommands(t *testing.T) {
+	c := newCommsCommandsTest(t)
+	if reply, err := c.pnl("tester", nil); err != nil || reply != "No open positions" {
+		t.Errorf("unexpected pnl reply %q %v", reply, err)
+	}
+
+	_, err := c.cancelOrder("tester", []string{testExchange, "1"})
+	if !errors.Is(err, errNoExchangeOTP) {
+		t.Fatalf("expected %v, received %v", errNoExchangeOTP, err)
+	}
+	exchCfg, err := c.bot.Config.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const otpSecret = "JBSWY3DPEHPK3PXP"
+	exchCfg.API.Credentials.OTPSecret = otpSecret
+
+	if _, err = c.cancelOrder("tester", []string{"missing", "1"}); !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("expected %v, received %v", ErrExchangeNotFound, err)
+	}
+	if _, err = c.submitOrder("tester", []string{testExchange, "XRPUSD", "buy"}); !errors.Is(err, errSubmitOrderCommandUsage) {
+		t.Errorf("expected %v, received %v", errSubmitOrderCommandUsage, err)
+	}
+	if _, err = c.submitOrder("tester", []string{testExchange, "XRPUSD", "buy", "limit", "1"}); err == nil {
+		t.Error("expected a limit order without a price to fail validation")
+	}
+	reply, err := c.submitOrder("tester", []string{testExchange, "XRPUSD", "buy", "limit", "1", "0.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(reply, "To submit Bitstamp BUY LIMIT 1 XRPUSD spot @ 0.5 reply confirm ") {
+		t.Errorf("unexpected submit order reply %q", reply)
+	}
+	if reply, err = c.killSwitch("tester", nil); err != nil || !strings.Contains(reply, "all open orders on Bitstamp") {
+		t.Errorf("unexpected kill switch reply %q %v", reply, err)
+	}
+
+	var ran int
+	reply, err = c.requestConfirmation("tester", "test", []string{testExchange}, func() (string, error) {
+		ran++
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := strings.Fields(reply)[4]
+	if _, err = c.confirm("tester", []string{id}); !errors.Is(err, errConfirmCommandUsage) {
+		t.Errorf("expected %v, received %v", errConfirmCommandUsage, err)
+	}
+	if _, err = c.confirm("other", []string{id, "000000"}); !errors.Is(err, errConfirmationSender) {
+		t.Errorf("expected %v, received %v", errConfirmationSender, err)
+	}
+	code, err := totp.GenerateCode(otpSecret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply, err = c.confirm("tester", []string{id, code}); err != nil || reply != "done" || ran != 1 {
+		t.Errorf("unexpected confirm reply %q %v", reply, err)
+	}
+	if _, err = c.confirm("tester", []string{id, code}); !errors.Is(err, errConfirmationNotFound) {
+		t.Errorf("expected %v, received %v", errConfirmationNotFound, err)
+	}
+
+	reply, err = c.requestConfirmation("tester", "test", []string{testExchange}, func() (string, error) {
+		ran++
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id = strings.Fields(reply)[4]
+	if _, err = c.confirm("tester", []string{id, "x" + code}); !errors.Is(err, errInvalidOTP) {
+		t.Errorf("expected %v, received %v", errInvalidOTP, err)
+	}
+	if _, err = c.confirm("tester", []string{id, code}); !errors.Is(err, errConfirmationNotFound) || ran != 1 {
+		t.Errorf("expected a failed code to discard the confirmation, received %v", err)
+	}
+}
+
+func TestCommsCommandAudit(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+
+	c := new(commsManager)
+	c.registerCommands(engerino)
+	defer c.deregisterCommands()
+
+	start := time.Now().Add(-time.Minute)
+	if _, err := base.HandleCommand("chat:intruder", "killswitch"); !errors.Is(err, base.ErrCommandUnauthorised) {
+		t.Fatalf("expected %v, received %v", base.ErrCommandUnauthorised, err)
+	}
+	if _, err := base.HandleAuthorisedCommand("chat:tester", "CONFIRM 1 123456"); err == nil {
+		t.Fatal("expected unknown confirmation error")
+	}
+	base.AuditCommand("chat:tester", "status", nil)
+
+	events, err := audit.GetEvent(start, time.Now().Add(time.Minute), audit.CommandEvent, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, ok := events.(sqlite3.AuditEventSlice)
+	if !ok || len(records) != 3 {
+		t.Fatalf("expected every command audited, received %v", events)
+	}
+	if records[0].Identifier != "chat:intruder" ||
+		!strings.HasPrefix(records[0].Message, "killswitch failed: ") {
+		t.Errorf("expected refused command audited, received %+v", records[0])
+	}
+	if records[1].Identifier != "chat:tester" ||
+		!strings.HasPrefix(records[1].Message, "confirm 1 <otp> failed: ") {
+		t.Errorf("expected one-time code redacted, received %+v", records[1])
+	}
+	if records[2].Message != "status" {
+		t.Errorf("expected built in command audited, received %+v", records[2])
+	}
+}
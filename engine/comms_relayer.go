@@ -3,6 +3,7 @@ package engine
 import (
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
@@ -40,6 +41,7 @@ func (c *commsManager) Start() (err error) {
 		return err
 	}
 
+	c.registerCommands(Bot)
 	c.shutdown = make(chan struct{})
 	c.relayMsg = make(chan base.Event)
 	go c.run()
@@ -63,6 +65,7 @@ func (c *commsManager) Stop() error {
 		return errors.New("communications manager is already stopped")
 	}
 
+	c.deregisterCommands()
 	close(c.shutdown)
 	log.Debugln(log.CommunicationMgr, "Communications manager shutting down...")
 	return nil
@@ -82,15 +85,26 @@ func (c *commsManager) PushEvent(evt base.Event) {
 func (c *commsManager) run() {
 	defer func() {
 		// TO-DO shutdown comms connections for connected services (Slack etc)
+		c.comms.Shutdown()
 		atomic.CompareAndSwapInt32(&c.stopped, 1, 0)
 		atomic.CompareAndSwapInt32(&c.started, 1, 0)
 		log.Debugln(log.CommunicationMgr, "Communications manager shutdown.")
 	}()
 
+	// Digests are only pushed when event routing is enabled
+	var digest <-chan time.Time
+	if interval := c.comms.DigestInterval(); interval > 0 {
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		digest = tick.C
+	}
+
 	for {
 		select {
 		case msg := <-c.relayMsg:
 			c.comms.PushEvent(msg)
+		case <-digest:
+			c.comms.PushDigests()
 		case <-c.shutdown:
 			return
 		}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+func TestEventSeverityRouting(t *testing.T) {
+	t.Parallel()
+	r, err := base.NewRouter(&config.EventRoutingConfig{
+		Enabled: true,
+		Routes: []config.EventRouteConfig{
+			{Relayer: "alerts", MinSeverity: "warning"},
+			{Relayer: "trades", MinSeverity: "trade"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failedReload := &ConfigReloadResult{Errors: []string{"bad setting"}}
+	tt := []struct {
+		name     string
+		severity base.Severity
+		alerts   bool
+		trades   bool
+	}{
+		{"withdrawal failed", withdrawalSeverity(withdraw.LifecycleFailed), true, true},
+		{"withdrawal completed", withdrawalSeverity(withdraw.LifecycleCompleted), false, true},
+		{"withdrawal pending", withdrawalSeverity(withdraw.LifecyclePending), false, false},
+		{"config reload error", failedReload.severity(), true, true},
+		{"config reload", new(ConfigReloadResult).severity(), false, false},
+		{"rebalance proposal", rebalanceSeverity(RebalanceStatusPending), false, true},
+		{"rebalance blocked", rebalanceSeverity(RebalanceStatusBlocked), true, true},
+	}
+	now := time.Now()
+	for i := range tt {
+		e := base.Event{Type: "test", Severity: tt[i].severity, Message: tt[i].name}
+		if routed := r.Route("alerts", e, now); routed != tt[i].alerts {
+			t.Errorf("%s: expected routed to warning relayer %v, received %v", tt[i].name, tt[i].alerts, routed)
+		}
+		if routed := r.Route("trades", e, now); routed != tt[i].trades {
+			t.Errorf("%s: expected routed to trade relayer %v, received %v", tt[i].name, tt[i].trades, routed)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package engine
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Trading commands communication relayers can run, each is only run once the
+// sender confirms it with a one-time code for the exchange
+const (
+	cmdCancelOrder = "cancelorder"
+	cmdSubmitOrder = "submitorder"
+	cmdKillSwitch  = "killswitch"
+	cmdConfirm     = "confirm"
+
+	commsConfirmationExpiry = 2 * time.Minute
+)
+
+var (
+	errCancelOrderCommandUsage = errors.New("usage: cancelorder <exchange> <order id> [pair] [asset]")
+	errSubmitOrderCommandUsage = errors.New("usage: submitorder <exchange> <pair> <side> <type> <amount> [price] [asset]")
+	errConfirmCommandUsage     = errors.New("usage: confirm <confirmation id> <one-time code>")
+	errConfirmationNotFound    = errors.New("confirmation not found or expired")
+	errConfirmationSender      = errors.New("confirmation was requested by another sender")
+)
+
+// pendingCommsAction is a trading command awaiting confirmation by the
+// sender who requested it
+type pendingCommsAction struct {
+	sender      string
+	exchanges   []string
+	description string
+	expires     time.Time
+	run         func() (string, error)
+}
+
+// pnl replies with the profit and loss of the open leveraged positions of
+// every enabled exchange or of the exchange named in the first argument
+func (c *commsCommands) pnl(_ string, args []string) (string, error) {
+	var sb strings.Builder
+	unrealised := make(map[currency.Code]float64)
+	realised := make(map[currency.Code]float64)
+	exchanges := c.bot.GetExchanges()
+	for i := range exchanges {
+		name := exchanges[i].GetName()
+		if len(args) > 0 && !strings.EqualFold(name, args[0]) {
+			continue
+		}
+		assets := exchanges[i].GetAssetTypes()
+		for j := range assets {
+			positions, err := exchanges[i].GetLeveragedPositions(assets[j])
+			if err != nil {
+				continue
+			}
+			for k := range positions {
+				p := &positions[k]
+				sb.WriteString(fmt.Sprintf("%s %s %s %s %v: unrealised %v realised %v %s\n",
+					name,
+					p.Pair,
+					p.Asset,
+					p.Side,
+					p.Size,
+					p.UnrealisedPNL,
+					p.RealisedPNL,
+					p.MarginCurrency))
+				unrealised[p.MarginCurrency] += p.UnrealisedPNL
+				realised[p.MarginCurrency] += p.RealisedPNL
+			}
+		}
+	}
+	if sb.Len() == 0 {
+		return "No open positions", nil
+	}
+	codes := make([]currency.Code, 0, len(unrealised))
+	for code := range unrealised {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].String() < codes[j].String() })
+	for i := range codes {
+		sb.WriteString(fmt.Sprintf("Total %s: unrealised %v realised %v\n",
+			codes[i], unrealised[codes[i]], realised[codes[i]]))
+	}
+	return sb.String(), nil
+}
+
+// cancelOrder requests confirmation to cancel an order, the pair and asset
+// default to those of the order when it is tracked by the order manager
+func (c *commsCommands) cancelOrder(sender string, args []string) (string, error) {
+	if len(args) < 2 || len(args) > 4 {
+		return "", errCancelOrderCommandUsage
+	}
+	exch := c.bot.GetExchangeByName(args[0])
+	if exch == nil {
+		return "", ErrExchangeNotFound
+	}
+	cancel := &order.Cancel{Exchange: exch.GetName(), ID: args[1]}
+	if od, err := c.bot.OrderManager.orderStore.GetByExchangeAndID(cancel.Exchange, cancel.ID); err == nil {
+		cancel.AccountID = od.AccountID
+		cancel.ClientID = od.ClientID
+		cancel.Side = od.Side
+		cancel.Type = od.Type
+		cancel.Pair = od.Pair
+		cancel.AssetType = od.AssetType
+	}
+	if len(args) > 2 {
+		p, err := currency.NewPairFromString(args[2])
+		if err != nil {
+			return "", err
+		}
+		cancel.Pair = p
+	}
+	if len(args) > 3 {
+		a, err := asset.New(args[3])
+		if err != nil {
+			return "", err
+		}
+		cancel.AssetType = a
+	}
+	return c.requestConfirmation(sender,
+		fmt.Sprintf("cancel %s order %s", cancel.Exchange, cancel.ID),
+		[]string{cancel.Exchange},
+		func() (string, error) {
+			if err := c.bot.OrderManager.Cancel(cancel); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s order %s cancelled", cancel.Exchange, cancel.ID), nil
+		})
+}
+
+// submitOrder requests confirmation to submit an order, the price is
+// required for limit orders and the asset type defaults to spot
+func (c *commsCommands) submitOrder(sender string, args []string) (string, error) {
+	if len(args) < 5 || len(args) > 7 {
+		return "", errSubmitOrderCommandUsage
+	}
+	exch := c.bot.GetExchangeByName(args[0])
+	if exch == nil {
+		return "", ErrExchangeNotFound
+	}
+	p, err := currency.NewPairFromString(args[1])
+	if err != nil {
+		return "", err
+	}
+	side, err := order.StringToOrderSide(args[2])
+	if err != nil {
+		return "", err
+	}
+	oType, err := order.StringToOrderType(args[3])
+	if err != nil {
+		return "", err
+	}
+	amount, err := strconv.ParseFloat(args[4], 64)
+	if err != nil {
+		return "", err
+	}
+	submit := &order.Submit{
+		Exchange:  exch.GetName(),
+		Pair:      p,
+		Side:      side,
+		Type:      oType,
+		Amount:    amount,
+		AssetType: asset.Spot,
+	}
+	if len(args) > 5 {
+		submit.Price, err = strconv.ParseFloat(args[5], 64)
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(args) > 6 {
+		submit.AssetType, err = asset.New(args[6])
+		if err != nil {
+			return "", err
+		}
+	}
+	if err = submit.Validate(); err != nil {
+		return "", err
+	}
+	description := fmt.Sprintf("submit %s %s %s %v %s %s", submit.Exchange, submit.Side, submit.Type, submit.Amount, submit.Pair, submit.AssetType)
+	if submit.Type != order.Market {
+		description += fmt.Sprintf(" @ %v", submit.Price)
+	}
+	return c.requestConfirmation(sender, description, []string{submit.Exchange},
+		func() (string, error) {
+			resp, err := c.bot.OrderManager.Submit(submit)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s order %s submitted", submit.Exchange, resp.OrderID), nil
+		})
+}
+
+// killSwitch requests confirmation to cancel every open order on every
+// enabled exchange or on the exchange named in the first argument. Any
+// exchange's one-time code confirms a kill switch across all exchanges
+func (c *commsCommands) killSwitch(sender string, args []string) (string, error) {
+	var names []string
+	exchanges := c.bot.GetExchanges()
+	for i := range exchanges {
+		name := exchanges[i].GetName()
+		if len(args) > 0 && !strings.EqualFold(name, args[0]) {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "", ErrExchangeNotFound
+	}
+	return c.requestConfirmation(sender,
+		"cancel all open orders on "+strings.Join(names, ", "),
+		names,
+		func() (string, error) {
+			msg := "Kill switch triggered, cancelling all open orders on " + strings.Join(names, ", ")
+			log.Warnln(log.OrderMgr, msg)
+			c.bot.CommsManager.PushEvent(base.Event{Type: "order", Severity: base.SeverityCritical, Message: msg})
+			c.bot.OrderManager.CancelAllOrders(names)
+			return msg, nil
+		})
+}
+
+// requestConfirmation holds a trading command until the sender confirms it
+// with a one-time code for one of the exchanges
+func (c *commsCommands) requestConfirmation(sender, description string, exchanges []string, run func() (string, error)) (string, error) {
+	var hasOTP bool
+	for i := range exchanges {
+		if _, err := c.bot.GetExchangeoOTPByName(exchanges[i]); err == nil {
+			hasOTP = true
+			break
+		}
+	}
+	if !hasOTP {
+		return "", errNoExchangeOTP
+	}
+
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	now := time.Now()
+	c.m.Lock()
+	if c.pending == nil {
+		c.pending = make(map[string]*pendingCommsAction)
+	}
+	for k, v := range c.pending {
+		if now.After(v.expires) {
+			delete(c.pending, k)
+		}
+	}
+	c.pending[id] = &pendingCommsAction{
+		sender:      sender,
+		exchanges:   exchanges,
+		description: description,
+		expires:     now.Add(commsConfirmationExpiry),
+		run:         run,
+	}
+	c.m.Unlock()
+	return fmt.Sprintf("To %s reply %s %s <one-time code> within %s",
+		description, cmdConfirm, id, commsConfirmationExpiry), nil
+}
+
+// confirm runs a pending trading command once the one-time code is
+// validated. A pending command is discarded after a failed attempt
+func (c *commsCommands) confirm(sender string, args []string) (string, error) {
+	if len(args) != 2 {
+		return "", errConfirmCommandUsage
+	}
+	c.m.Lock()
+	p, ok := c.pending[args[0]]
+	if ok && p.sender != sender {
+		c.m.Unlock()
+		return "", errConfirmationSender
+	}
+	delete(c.pending, args[0])
+	c.m.Unlock()
+	if !ok || time.Now().After(p.expires) {
+		return "", errConfirmationNotFound
+	}
+	if err := c.bot.ValidateExchangeOTP(args[1], p.exchanges...); err != nil {
+		return "", err
+	}
+	return p.run()
+}
@@ -0,0 +1,471 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	subsystemStopTimeout      = time.Second * 10
+	subsystemStopPollInterval = time.Millisecond * 10
+)
+
+// ConfigReloadResult reports the changes applied to the running engine by a
+// config reload and the changes which only take effect after a restart
+type ConfigReloadResult struct {
+	Applied         []string
+	RestartRequired []string
+	Errors          []string
+}
+
+func (r *ConfigReloadResult) applied(format string, args ...interface{}) {
+	r.Applied = append(r.Applied, fmt.Sprintf(format, args...))
+}
+
+func (r *ConfigReloadResult) restartRequired(format string, args ...interface{}) {
+	r.RestartRequired = append(r.RestartRequired, fmt.Sprintf(format, args...))
+}
+
+func (r *ConfigReloadResult) failed(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// severity returns the severity of the reload event, a warning when any
+// change failed to apply
+func (r *ConfigReloadResult) severity() base.Severity {
+	if len(r.Errors) > 0 {
+		return base.SeverityWarning
+	}
+	return base.SeverityInfo
+}
+
+// ReloadConfig re-reads the config file, diffs it against the running engine
+// and applies the changes per subsystem. Settings which cannot be applied
+// while running are stored so they take effect on the next restart
+func (bot *Engine) ReloadConfig() (*ConfigReloadResult, error) {
+	bot.configReloadMtx.Lock()
+	defer bot.configReloadMtx.Unlock()
+
+	newCfg, err := config.ReadReloadConfig(bot.Settings.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigReloadResult{}
+	bot.reloadExchanges(newCfg, result)
+	bot.reloadSubsystems(newCfg, result)
+	bot.reloadRestartSettings(newCfg, result)
+
+	msg := fmt.Sprintf("Config reloaded: %d change(s) applied, %d require a restart, %d error(s)",
+		len(result.Applied),
+		len(result.RestartRequired),
+		len(result.Errors))
+	log.Infoln(log.ConfigMgr, msg)
+	for i := range result.Applied {
+		log.Infof(log.ConfigMgr, "Config reload applied: %s\n", result.Applied[i])
+		audit.Event(bot.Settings.ConfigFile, audit.ConfigChangeEvent, "applied: "+result.Applied[i])
+	}
+	for i := range result.RestartRequired {
+		log.Warnf(log.ConfigMgr, "Config reload requires restart: %s\n", result.RestartRequired[i])
+		audit.Event(bot.Settings.ConfigFile, audit.ConfigChangeEvent, "requires restart: "+result.RestartRequired[i])
+	}
+	for i := range result.Errors {
+		log.Errorf(log.ConfigMgr, "Config reload error: %s\n", result.Errors[i])
+	}
+	bot.CommsManager.PushEvent(base.Event{Type: "config", Severity: result.severity(), Message: msg})
+	return result, nil
+}
+
+// reloadExchanges loads, unloads and updates exchanges from the new config
+func (bot *Engine) reloadExchanges(newCfg *config.Config, result *ConfigReloadResult) {
+	for i := range newCfg.Exchanges {
+		updated := &newCfg.Exchanges[i]
+		current, err := bot.Config.GetExchangeConfig(updated.Name)
+		if err != nil {
+			result.restartRequired("%s exchange added", updated.Name)
+			continue
+		}
+
+		exch := bot.GetExchangeByName(updated.Name)
+		switch {
+		case updated.Enabled && exch == nil:
+			bot.Config.UpdateSettings(func() { *current = *updated })
+			err = bot.LoadExchange(updated.Name, false, nil)
+			if err != nil {
+				result.failed("%s exchange failed to load: %v", updated.Name, err)
+				continue
+			}
+			result.applied("%s exchange enabled", updated.Name)
+			if bot.Settings.EnableWebsocketRoutine {
+				go bot.connectExchangeWebsocket(bot.GetExchangeByName(updated.Name))
+			}
+		case !updated.Enabled && exch != nil:
+			if exch.IsWebsocketEnabled() {
+				ws, wsErr := exch.GetWebsocket()
+				if wsErr == nil && ws.IsConnected() {
+					if wsErr = ws.Shutdown(); wsErr != nil {
+						result.failed("%s websocket shutdown: %v", updated.Name, wsErr)
+					}
+				}
+			}
+			err = bot.UnloadExchange(updated.Name)
+			if err != nil {
+				result.failed("%s exchange failed to unload: %v", updated.Name, err)
+				continue
+			}
+			result.applied("%s exchange disabled", updated.Name)
+		case exch != nil:
+			bot.reloadExchange(exch, current, updated, result)
+		default:
+			bot.Config.UpdateSettings(func() { *current = *updated })
+		}
+	}
+
+	for i := range bot.Config.Exchanges {
+		if _, err := newCfg.GetExchangeConfig(bot.Config.Exchanges[i].Name); err != nil {
+			result.restartRequired("%s exchange removed", bot.Config.Exchanges[i].Name)
+		}
+	}
+}
+
+// reloadExchange applies enabled pair, credential, named account and verbosity
+// changes to a loaded exchange
+func (bot *Engine) reloadExchange(exch exchange.IBotExchange, current, updated *config.ExchangeConfig, result *ConfigReloadResult) {
+	name := current.Name
+	b := exch.GetBase()
+
+	flush := false
+	if !bot.Settings.EnableAllPairs && updated.CurrencyPairs != nil && current.CurrencyPairs != nil {
+		assets := updated.CurrencyPairs.GetAssetTypes()
+		for x := range assets {
+			assetEnabled := updated.CurrencyPairs.IsAssetEnabled(assets[x]) == nil
+			if assetEnabled != (current.CurrencyPairs.IsAssetEnabled(assets[x]) == nil) {
+				err := b.CurrencyPairs.SetAssetEnabled(assets[x], assetEnabled)
+				if err != nil {
+					result.failed("%s %s asset: %v", name, assets[x], err)
+					continue
+				}
+				err = current.CurrencyPairs.SetAssetEnabled(assets[x], assetEnabled)
+				if err != nil {
+					result.failed("%s %s asset: %v", name, assets[x], err)
+					continue
+				}
+				flush = true
+				result.applied("%s %s asset enabled set to %v", name, assets[x], assetEnabled)
+			}
+
+			newPairs, err := updated.CurrencyPairs.GetPairs(assets[x], true)
+			if err != nil {
+				continue
+			}
+			oldPairs, _ := current.CurrencyPairs.GetPairs(assets[x], true)
+			added, removed := oldPairs.FindDifferences(newPairs)
+			if len(added) == 0 && len(removed) == 0 {
+				continue
+			}
+			if len(newPairs) == 0 {
+				b.CurrencyPairs.StorePairs(assets[x], nil, true)
+				current.CurrencyPairs.StorePairs(assets[x], nil, true)
+			} else if err = b.SetPairs(newPairs, assets[x], true); err != nil {
+				result.failed("%s %s enabled pairs: %v", name, assets[x], err)
+				continue
+			}
+			flush = true
+			result.applied("%s %s enabled pairs updated, added [%s] removed [%s]",
+				name, assets[x], added.Join(), removed.Join())
+		}
+	}
+
+	if updated.API.Credentials != current.API.Credentials ||
+		updated.API.AuthenticatedSupport != current.API.AuthenticatedSupport ||
+		updated.API.AuthenticatedWebsocketSupport != current.API.AuthenticatedWebsocketSupport {
+		bot.Config.UpdateSettings(func() {
+			current.API.Credentials = updated.API.Credentials
+			current.API.AuthenticatedSupport = updated.API.AuthenticatedSupport
+			current.API.AuthenticatedWebsocketSupport = updated.API.AuthenticatedWebsocketSupport
+		})
+		b.API.AuthenticatedSupport = updated.API.AuthenticatedSupport
+		b.API.AuthenticatedWebsocketSupport = updated.API.AuthenticatedWebsocketSupport
+		b.API.Credentials.PEMKey = updated.API.Credentials.PEMKey
+		b.SetAPIKeys(updated.API.Credentials.Key,
+			updated.API.Credentials.Secret,
+			updated.API.Credentials.ClientID)
+		if b.API.AuthenticatedSupport || b.API.AuthenticatedWebsocketSupport {
+			if err := exch.ValidateCredentials(); err != nil {
+				b.API.AuthenticatedSupport = false
+				b.API.AuthenticatedWebsocketSupport = false
+				bot.Config.UpdateSettings(func() {
+					current.API.AuthenticatedSupport = false
+					current.API.AuthenticatedWebsocketSupport = false
+				})
+				result.failed("%s credentials invalid, authenticated support has been disabled: %v", name, err)
+			}
+		}
+		result.applied("%s API credentials updated", name)
+		if b.API.AuthenticatedWebsocketSupport {
+			result.restartRequired("%s authenticated websocket uses the new credentials after reconnecting", name)
+		}
+	}
+
+	if !reflect.DeepEqual(updated.API.Accounts, current.API.Accounts) {
+		bot.Config.UpdateSettings(func() { current.API.Accounts = updated.API.Accounts })
+		bot.exchangeManager.removeAccounts(name)
+		bot.loadExchangeAccounts(current)
+		result.applied("%s named accounts reloaded", name)
+	}
+
+	if !reflect.DeepEqual(updated.API.Permissions, current.API.Permissions) {
+		bot.Config.UpdateSettings(func() { current.API.Permissions = updated.API.Permissions })
+		result.applied("%s API key permission policy updated", name)
+	}
+
+	if updated.Verbose != current.Verbose {
+		bot.Config.UpdateSettings(func() { current.Verbose = updated.Verbose })
+		b.Verbose = updated.Verbose
+		result.applied("%s verbose set to %v", name, updated.Verbose)
+	}
+
+	if exchangeSettingsChanged(current, updated) {
+		bot.Config.UpdateSettings(func() {
+			pairs, features, api := current.CurrencyPairs, current.Features, current.API
+			*current = *updated
+			current.CurrencyPairs, current.Features = pairs, features
+			current.API.AuthenticatedSupport = api.AuthenticatedSupport
+			current.API.AuthenticatedWebsocketSupport = api.AuthenticatedWebsocketSupport
+			current.API.CredentialsValidator = api.CredentialsValidator
+			if updated.Features != nil && features != nil {
+				current.Features.Enabled = updated.Features.Enabled
+			}
+		})
+		result.restartRequired("%s exchange settings changed", name)
+	}
+
+	if flush && exch.IsWebsocketEnabled() && b.Websocket.IsConnected() {
+		if err := exch.FlushWebsocketChannels(); err != nil {
+			result.failed("%s websocket subscriptions: %v", name, err)
+		}
+	}
+}
+
+// exchangeSettingsChanged returns whether exchange settings which are only
+// applied when the exchange is loaded differ
+func exchangeSettingsChanged(current, updated *config.ExchangeConfig) bool {
+	return current.UseSandbox != updated.UseSandbox ||
+		current.HTTPTimeout != updated.HTTPTimeout ||
+		current.HTTPUserAgent != updated.HTTPUserAgent ||
+		current.HTTPDebugging != updated.HTTPDebugging ||
+		current.ProxyAddress != updated.ProxyAddress ||
+		current.WebsocketResponseCheckTimeout != updated.WebsocketResponseCheckTimeout ||
+		current.WebsocketResponseMaxLimit != updated.WebsocketResponseMaxLimit ||
+		current.WebsocketTrafficTimeout != updated.WebsocketTrafficTimeout ||
+		current.API.Endpoints != updated.API.Endpoints ||
+		current.OrderbookConfig != updated.OrderbookConfig ||
+		current.BaseCurrencies.Join() != updated.BaseCurrencies.Join() ||
+		featuresEnabled(current) != featuresEnabled(updated)
+}
+
+func featuresEnabled(c *config.ExchangeConfig) config.FeaturesEnabledConfig {
+	if c.Features == nil {
+		return config.FeaturesEnabledConfig{}
+	}
+	return c.Features.Enabled
+}
+
+// reloadSubsystems restarts the subsystems whose config changed
+func (bot *Engine) reloadSubsystems(newCfg *config.Config, result *ConfigReloadResult) {
+	bot.reloadSubsystem("communications",
+		!reflect.DeepEqual(bot.Config.Communications, newCfg.Communications),
+		func() { bot.Config.Communications = newCfg.Communications },
+		bot.CommsManager.Started(),
+		result)
+	bot.reloadSubsystem("portfolio_valuation",
+		!reflect.DeepEqual(bot.Config.PortfolioValuation, newCfg.PortfolioValuation),
+		func() { bot.Config.PortfolioValuation = newCfg.PortfolioValuation },
+		newCfg.PortfolioValuation.Enabled,
+		result)
+	bot.reloadSubsystem("rebalancer",
+		!reflect.DeepEqual(bot.Config.Rebalancer, newCfg.Rebalancer),
+		func() { bot.Config.Rebalancer = newCfg.Rebalancer },
+		newCfg.Rebalancer.Enabled,
+		result)
+	bot.reloadSubsystem("withdrawal_approval",
+		!reflect.DeepEqual(bot.Config.WithdrawalApproval, newCfg.WithdrawalApproval),
+		func() { bot.Config.WithdrawalApproval = newCfg.WithdrawalApproval },
+		newCfg.WithdrawalApproval.Enabled,
+		result)
+	bot.reloadSubsystem("withdrawal_tracker",
+		!reflect.DeepEqual(bot.Config.WithdrawalTracker, newCfg.WithdrawalTracker),
+		func() { bot.Config.WithdrawalTracker = newCfg.WithdrawalTracker },
+		newCfg.WithdrawalTracker.Enabled,
+		result)
+	bot.reloadSubsystem("deposit_monitor",
+		!reflect.DeepEqual(bot.Config.DepositMonitor, newCfg.DepositMonitor),
+		func() { bot.Config.DepositMonitor = newCfg.DepositMonitor },
+		newCfg.DepositMonitor.Enabled,
+		result)
+	bot.reloadSubsystem("balance_alerts",
+		!reflect.DeepEqual(bot.Config.BalanceAlerts, newCfg.BalanceAlerts),
+		func() { bot.Config.BalanceAlerts = newCfg.BalanceAlerts },
+		newCfg.BalanceAlerts.Enabled,
+		result)
+	bot.reloadSubsystem("metrics",
+		bot.Config.Metrics != newCfg.Metrics,
+		func() { bot.Config.Metrics = newCfg.Metrics },
+		newCfg.Metrics.Enabled,
+		result)
+
+	// The watcher may be the caller so it is never waited on, it picks up
+	// interval changes on its next check
+	if bot.Config.ConfigReload != newCfg.ConfigReload {
+		bot.Config.UpdateSettings(func() { bot.Config.ConfigReload = newCfg.ConfigReload })
+		switch {
+		case newCfg.ConfigReload.WatchFile && !bot.ConfigWatcher.Started():
+			if err := bot.ConfigWatcher.Start(); err != nil {
+				result.failed("config_watcher: %v", err)
+				return
+			}
+			result.applied("config_watcher started")
+		case !newCfg.ConfigReload.WatchFile && bot.ConfigWatcher.Started():
+			if err := bot.ConfigWatcher.Stop(); err != nil {
+				result.failed("config_watcher: %v", err)
+				return
+			}
+			result.applied("config_watcher stopped")
+		default:
+			result.applied("config_watcher settings updated")
+		}
+	}
+}
+
+// reloadSubsystem stops a running subsystem, applies its new config and
+// starts it again when enabled
+func (bot *Engine) reloadSubsystem(name string, changed bool, apply func(), enabled bool, result *ConfigReloadResult) {
+	if !changed {
+		return
+	}
+	running := bot.GetSubsystemsStatus()[name]
+	if running {
+		err := bot.SetSubsystem(name, false)
+		if err == nil {
+			err = bot.waitForSubsystemStop(name)
+		}
+		if err != nil {
+			result.failed("%s failed to stop: %v", name, err)
+			return
+		}
+	}
+
+	bot.Config.UpdateSettings(apply)
+	if !enabled {
+		if running {
+			result.applied("%s stopped", name)
+		} else {
+			result.applied("%s settings updated", name)
+		}
+		return
+	}
+
+	err := bot.SetSubsystem(name, true)
+	if err != nil {
+		result.failed("%s failed to start: %v", name, err)
+		return
+	}
+	if running {
+		result.applied("%s restarted", name)
+	} else {
+		result.applied("%s started", name)
+	}
+}
+
+// waitForSubsystemStop waits for a stopped subsystem to finish shutting down
+// so it can be started again
+func (bot *Engine) waitForSubsystemStop(name string) error {
+	deadline := time.Now().Add(subsystemStopTimeout)
+	for bot.GetSubsystemsStatus()[name] {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("still running after %v", subsystemStopTimeout)
+		}
+		time.Sleep(subsystemStopPollInterval)
+	}
+	return nil
+}
+
+// reloadRestartSettings stores settings which are only read on startup and
+// reports them as requiring a restart. Remote control credentials, websocket
+// RPC limits and bank accounts are read on use so they are applied immediately,
+// only the remote control listeners need a restart
+func (bot *Engine) reloadRestartSettings(newCfg *config.Config, result *ConfigReloadResult) {
+	current := bot.Config.GetRemoteControlConfig()
+	updated := newCfg.RemoteControl
+	if current.Username != updated.Username || current.Password != updated.Password {
+		result.applied("remote control admin credentials updated")
+	}
+	if !reflect.DeepEqual(current.Users, updated.Users) {
+		result.applied("remote control users updated")
+	}
+	if current.WebsocketRPC.ConnectionLimit != updated.WebsocketRPC.ConnectionLimit ||
+		current.WebsocketRPC.MaxAuthFailures != updated.WebsocketRPC.MaxAuthFailures ||
+		current.WebsocketRPC.AllowInsecureOrigin != updated.WebsocketRPC.AllowInsecureOrigin {
+		result.applied("websocket RPC limits updated")
+	}
+	if current.GRPC != updated.GRPC ||
+		current.DeprecatedRPC != updated.DeprecatedRPC ||
+		current.WebsocketRPC.Enabled != updated.WebsocketRPC.Enabled ||
+		current.WebsocketRPC.ListenAddress != updated.WebsocketRPC.ListenAddress {
+		result.restartRequired("remote control listener settings changed")
+	}
+	if !reflect.DeepEqual(bot.Config.BankAccounts, newCfg.BankAccounts) {
+		result.applied("bank accounts updated")
+	}
+
+	if bot.Config.Database != newCfg.Database {
+		result.restartRequired("database settings changed")
+	}
+	if !reflect.DeepEqual(bot.Config.NTPClient, newCfg.NTPClient) {
+		result.restartRequired("ntp client settings changed")
+	}
+	if !reflect.DeepEqual(bot.Config.ConnectionMonitor, newCfg.ConnectionMonitor) {
+		result.restartRequired("connection monitor settings changed")
+	}
+	if !reflect.DeepEqual(bot.Config.GCTScript, newCfg.GCTScript) {
+		result.restartRequired("gctscript settings changed")
+	}
+	if bot.Config.Profiler != newCfg.Profiler {
+		result.restartRequired("profiler settings changed")
+	}
+	loggingChanged := !reflect.DeepEqual(bot.Config.Logging, newCfg.Logging)
+	if loggingChanged {
+		result.restartRequired("logging settings changed")
+	}
+	if bot.Config.DataDirectory != newCfg.DataDirectory {
+		result.restartRequired("data directory changed")
+	}
+	if bot.Config.GlobalHTTPTimeout != newCfg.GlobalHTTPTimeout {
+		result.restartRequired("global HTTP timeout changed")
+	}
+
+	bot.Config.UpdateSettings(func() {
+		bot.Config.RemoteControl = updated
+		bot.Config.BankAccounts = newCfg.BankAccounts
+		bot.Config.Database = newCfg.Database
+		bot.Config.NTPClient = newCfg.NTPClient
+		bot.Config.ConnectionMonitor = newCfg.ConnectionMonitor
+		bot.Config.GCTScript = newCfg.GCTScript
+		bot.Config.Profiler = newCfg.Profiler
+		bot.Config.DataDirectory = newCfg.DataDirectory
+		bot.Config.GlobalHTTPTimeout = newCfg.GlobalHTTPTimeout
+		if loggingChanged {
+			// The logger reads its settings from the running config
+			log.RWM.Lock()
+			bot.Config.Logging = newCfg.Logging
+			log.RWM.Unlock()
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func containsString(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReloadConfig(t *testing.T) {
+	logCfg := log.GlobalLogConfig
+	defer func() {
+		log.RWM.Lock()
+		log.GlobalLogConfig = logCfg
+		log.RWM.Unlock()
+	}()
+
+	f, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	onDisk, err := config.ReadReloadConfig(config.TestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range onDisk.Exchanges {
+		onDisk.Exchanges[i].Enabled = onDisk.Exchanges[i].Name == testExchange
+	}
+	if err = onDisk.SaveConfigToFile(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	running, err := config.ReadReloadConfig(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.RWM.Lock()
+	log.GlobalLogConfig = &running.Logging
+	log.RWM.Unlock()
+	bot := &Engine{Config: running, GctScriptManager: new(gctscript.GctScriptManager)}
+	bot.Settings.ConfigFile = f.Name()
+	exchCfg, err := running.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := new(bitstamp.Bitstamp)
+	exch.SetDefaults()
+	if err = exch.Setup(exchCfg); err != nil {
+		t.Fatal(err)
+	}
+	bot.exchangeManager.add(exch)
+
+	result, err := bot.ReloadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Applied) != 0 || len(result.RestartRequired) != 0 || len(result.Errors) != 0 {
+		t.Errorf("expected no changes, received %+v", result)
+	}
+
+	onDisk.Database.Verbose = !onDisk.Database.Verbose
+	onDisk.Rebalancer.CheckInterval = time.Minute * 3
+	onDisk.RemoteControl.Users = append(onDisk.RemoteControl.Users, config.RemoteControlUser{
+		Username: "reader",
+		APIToken: "token",
+		Role:     auth.RoleReadOnly,
+	})
+	onDisk.RemoteControl.Password = "reloaded"
+	onDisk.RemoteControl.GRPC.ListenAddress = "localhost:9053"
+	onDisk.Logging.Level = "ERROR"
+	onDisk.Exchanges[0].HTTPUserAgent = "reloaded"
+	bitstampCfg, err := onDisk.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bitstampCfg.Verbose = true
+	btcusd, err := currency.NewPairFromString("BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bitstampCfg.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{btcusd}, true)
+	if err = onDisk.SaveConfigToFile(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = bot.ReloadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"remote control admin credentials updated",
+		"remote control users updated",
+		"rebalancer settings updated",
+		"Bitstamp verbose set to true",
+		"Bitstamp spot enabled pairs updated, added [] removed [BTCEUR,EURUSD,XRPUSD,XRPEUR]",
+	} {
+		if !containsString(result.Applied, expected) {
+			t.Errorf("expected %q to be applied, received %v", expected, result.Applied)
+		}
+	}
+	for _, expected := range []string{
+		"database settings changed",
+		"remote control listener settings changed",
+		"logging settings changed",
+	} {
+		if !containsString(result.RestartRequired, expected) {
+			t.Errorf("expected %q to require a restart, received %v", expected, result.RestartRequired)
+		}
+	}
+	if len(result.RestartRequired) != 3 {
+		t.Errorf("expected only the listener, database and logging settings to require a restart, received %v", result.RestartRequired)
+	}
+	log.RWM.RLock()
+	logCfgReloaded := log.GlobalLogConfig
+	log.RWM.RUnlock()
+	if logCfgReloaded != &bot.Config.Logging {
+		t.Error("expected the logger to keep using the running config")
+	}
+	if bot.Config.Database.Verbose != onDisk.Database.Verbose ||
+		bot.Config.Rebalancer.CheckInterval != time.Minute*3 ||
+		bot.Config.Exchanges[0].HTTPUserAgent != "reloaded" {
+		t.Error("expected running config to be updated")
+	}
+	enabled, err := exch.GetEnabledPairs(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enabled) != 1 || !exch.Verbose {
+		t.Errorf("expected running exchange to be updated, received %v", enabled)
+	}
+	if _, err = bot.identityByToken("token"); err != nil {
+		t.Errorf("expected reloaded API token to authenticate, received %v", err)
+	}
+	if _, err = bot.identityByPassword(onDisk.RemoteControl.Username, func(password string) bool {
+		return password == "reloaded"
+	}); err != nil {
+		t.Errorf("expected reloaded admin password to authenticate, received %v", err)
+	}
+
+	bot.Settings.ConfigFile = "missing.json"
+	if _, err = bot.ReloadConfig(); err == nil {
+		t.Error("expected error reloading a missing config file")
+	}
+}
+
+func TestExchangeSettingsChanged(t *testing.T) {
+	t.Parallel()
+	current := &config.ExchangeConfig{HTTPTimeout: time.Second}
+	updated := &config.ExchangeConfig{
+		HTTPTimeout: time.Second,
+		Verbose:     true,
+		Features:    &config.FeaturesConfig{},
+	}
+	if exchangeSettingsChanged(current, updated) {
+		t.Error("verbose and empty features should not require a restart")
+	}
+	updated.Features.Enabled.Websocket = true
+	if !exchangeSettingsChanged(current, updated) {
+		t.Error("expected websocket feature change to require a restart")
+	}
+}
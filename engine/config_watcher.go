@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// configWatcher reloads the config when the config file is modified
+type configWatcher struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	path     string
+	modTime  time.Time
+}
+
+func (c *configWatcher) Started() bool {
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+func (c *configWatcher) Start() error {
+	if atomic.AddInt32(&c.started, 1) != 1 {
+		return errors.New("config watcher already started")
+	}
+
+	path, _, err := config.GetFilePath(Bot.Settings.ConfigFile)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		return err
+	}
+
+	log.Debugln(log.ConfigMgr, "Config watcher starting...")
+	c.path = path
+	c.modTime = info.ModTime()
+	c.shutdown = make(chan struct{})
+	go c.run()
+	return nil
+}
+
+func (c *configWatcher) Stop() error {
+	if atomic.LoadInt32(&c.started) == 0 {
+		return errors.New("config watcher not started")
+	}
+	if atomic.AddInt32(&c.stopped, 1) != 1 {
+		return errors.New("config watcher is already stopped")
+	}
+
+	close(c.shutdown)
+	log.Debugln(log.ConfigMgr, "Config watcher shutting down...")
+	return nil
+}
+
+func (c *configWatcher) run() {
+	log.Debugf(log.ConfigMgr, "Config watcher started, watching %s.\n", c.path)
+	Bot.ServicesWG.Add(1)
+	timer := time.NewTimer(Bot.Config.ConfigReload.WatchInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&c.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		timer.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.ConfigMgr, "Config watcher shutdown.")
+	}()
+
+	for {
+		select {
+		case <-c.shutdown:
+			return
+		case <-timer.C:
+			if c.modified() {
+				if _, err := Bot.ReloadConfig(); err != nil {
+					log.Errorf(log.ConfigMgr, "Config watcher failed to reload config: %v\n", err)
+				}
+			}
+			timer.Reset(Bot.Config.ConfigReload.WatchInterval)
+		}
+	}
+}
+
+// modified returns whether the config file modification time changed since
+// the last check
+func (c *configWatcher) modified() bool {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		log.Errorf(log.ConfigMgr, "Config watcher unable to stat %s: %v\n", c.path, err)
+		return false
+	}
+	if info.ModTime().Equal(c.modTime) {
+		return false
+	}
+	c.modTime = info.ModTime()
+	return true
+}
@@ -0,0 +1,403 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/deposit"
+	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// depositAmountTolerance allows for rounding differences between the amount
+// withdrawn and the amount credited
+const depositAmountTolerance = 1e-8
+
+var errDepositMonitorDatabaseNotStarted = errors.New("database manager not started, deposits cannot be stored")
+
+// balanceIncrease is a positive change in the total balance of a currency
+// held on an exchange
+type balanceIncrease struct {
+	Exchange string
+	Currency currency.Code
+	Amount   float64
+}
+
+// depositMonitor detects deposits from exchange funding history, checked
+// periodically and whenever an exchange account balance increases
+type depositMonitor struct {
+	started   int32
+	stopped   int32
+	shutdown  chan struct{}
+	increases chan []balanceIncrease
+	startTime time.Time
+
+	m           sync.Mutex
+	balances    map[string]map[string]float64
+	subscribed  map[string]bool
+	unsupported map[string]bool
+}
+
+func (d *depositMonitor) Started() bool {
+	return atomic.LoadInt32(&d.started) == 1
+}
+
+func (d *depositMonitor) Start() error {
+	if atomic.AddInt32(&d.started, 1) != 1 {
+		return errors.New("deposit monitor already started")
+	}
+
+	log.Debugln(log.Global, "Deposit monitor starting...")
+	d.m.Lock()
+	d.balances = make(map[string]map[string]float64)
+	d.subscribed = make(map[string]bool)
+	d.unsupported = make(map[string]bool)
+	d.m.Unlock()
+	d.startTime = time.Now()
+	d.shutdown = make(chan struct{})
+	d.increases = make(chan []balanceIncrease, 16)
+	go d.run()
+	return nil
+}
+
+func (d *depositMonitor) Stop() error {
+	if atomic.LoadInt32(&d.started) == 0 {
+		return errors.New("deposit monitor not started")
+	}
+	if atomic.AddInt32(&d.stopped, 1) != 1 {
+		return errors.New("deposit monitor is already stopped")
+	}
+
+	close(d.shutdown)
+	log.Debugln(log.Global, "Deposit monitor shutting down...")
+	return nil
+}
+
+func (d *depositMonitor) run() {
+	log.Debugln(log.Global, "Deposit monitor started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.DepositMonitor.CheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&d.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&d.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Deposit monitor shutdown.")
+	}()
+
+	d.subscribe()
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case increases := <-d.increases:
+			err := d.check(increases[0].Exchange, increases)
+			if err != nil {
+				log.Errorf(log.Global, "Deposit monitor %s error: %v\n", increases[0].Exchange, err)
+			}
+		case <-tick.C:
+			d.subscribe()
+			exchanges := Bot.GetExchanges()
+			for i := range exchanges {
+				if !exchanges[i].IsEnabled() {
+					continue
+				}
+				err := d.check(exchanges[i].GetName(), nil)
+				if err != nil {
+					log.Errorf(log.Global, "Deposit monitor %s error: %v\n", exchanges[i].GetName(), err)
+				}
+			}
+		}
+	}
+}
+
+// subscribe watches the account updates of every enabled exchange which has
+// published account holdings and is not already watched
+func (d *depositMonitor) subscribe() {
+	exchanges := Bot.GetExchanges()
+	for i := range exchanges {
+		if !exchanges[i].IsEnabled() {
+			continue
+		}
+		name := exchanges[i].GetName()
+		d.m.Lock()
+		if d.subscribed[name] {
+			d.m.Unlock()
+			continue
+		}
+		pipe, err := account.SubscribeToExchangeAccount(name)
+		if err != nil {
+			d.m.Unlock()
+			continue
+		}
+		d.subscribed[name] = true
+		d.m.Unlock()
+		holdings, err := account.GetHoldings(name)
+		if err == nil {
+			d.balanceIncreases(&holdings)
+		}
+		go d.watch(name, pipe)
+	}
+}
+
+func (d *depositMonitor) watch(exchName string, pipe dispatch.Pipe) {
+	defer func() {
+		err := pipe.Release()
+		if err != nil {
+			log.Errorf(log.Global, "Deposit monitor %s release error: %v\n", exchName, err)
+		}
+		d.m.Lock()
+		delete(d.subscribed, exchName)
+		d.m.Unlock()
+	}()
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case data, ok := <-pipe.C:
+			if !ok {
+				return
+			}
+			holdings, ok := (*data.(*interface{})).(account.Holdings)
+			if !ok {
+				continue
+			}
+			increases := d.balanceIncreases(&holdings)
+			if len(increases) == 0 {
+				continue
+			}
+			select {
+			case d.increases <- increases:
+			case <-d.shutdown:
+				return
+			}
+		}
+	}
+}
+
+// balanceIncreases stores the latest currency totals of the holdings and
+// returns the currencies which increased since the previous update. The first
+// update of an exchange only sets the baseline
+func (d *depositMonitor) balanceIncreases(h *account.Holdings) []balanceIncrease {
+	totals, codes := holdingsTotals(h)
+	name := strings.ToLower(h.Exchange)
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.balances == nil {
+		d.balances = make(map[string]map[string]float64)
+	}
+	previous, ok := d.balances[name]
+	d.balances[name] = totals
+	if !ok {
+		return nil
+	}
+	var resp []balanceIncrease
+	for c, total := range totals {
+		if delta := total - previous[c]; delta > depositAmountTolerance {
+			resp = append(resp, balanceIncrease{
+				Exchange: h.Exchange,
+				Currency: codes[c],
+				Amount:   delta,
+			})
+		}
+	}
+	return resp
+}
+
+// holdingsTotals sums the currency totals across every account of the
+// holdings, keyed by upper case currency code
+func holdingsTotals(h *account.Holdings) (map[string]float64, map[string]currency.Code) {
+	totals := make(map[string]float64)
+	codes := make(map[string]currency.Code)
+	for i := range h.Accounts {
+		for j := range h.Accounts[i].Currencies {
+			code := h.Accounts[i].Currencies[j].CurrencyName
+			totals[code.Upper().String()] += h.Accounts[i].Currencies[j].TotalValue
+			codes[code.Upper().String()] = code
+		}
+	}
+	return totals, codes
+}
+
+// check stores and reports deposits in the exchange funding history which
+// have not been seen before or whose status changed. Balance increases are reported as unconfirmed
+// when the exchange does not support funding history
+func (d *depositMonitor) check(exchName string, increases []balanceIncrease) error {
+	exch := Bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+	d.m.Lock()
+	unsupported := d.unsupported[exchName]
+	d.m.Unlock()
+	if unsupported {
+		d.reportUnconfirmed(increases)
+		return nil
+	}
+
+	history, err := exch.GetFundingHistory()
+	if err != nil {
+		if errors.Is(err, common.ErrFunctionNotSupported) ||
+			errors.Is(err, common.ErrNotYetImplemented) {
+			d.m.Lock()
+			d.unsupported[exchName] = true
+			d.m.Unlock()
+			d.reportUnconfirmed(increases)
+			return nil
+		}
+		return err
+	}
+	if !Bot.DatabaseManager.Started() {
+		return errDepositMonitorDatabaseNotStarted
+	}
+
+	for i := range history {
+		err = d.record(exchName, &history[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record stores a deposit from the funding history and reports it, a deposit
+// which is already stored has its status updated when it changes
+func (d *depositMonitor) record(exchName string, fh *exchange.FundHistory) error {
+	if !strings.Contains(strings.ToLower(fh.TransferType), "deposit") {
+		return nil
+	}
+	id := fh.TransferID
+	if id == "" {
+		id = fh.CryptoTxID
+	}
+	if id == "" {
+		return nil
+	}
+	stored, err := deposit.GetByExchangeID(exchName, id)
+	if err == nil {
+		if stored.Status == fh.Status {
+			return nil
+		}
+		err = deposit.UpdateStatus(stored.ID, fh.Status)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Deposit of %v %s on %s status changed from %s to %s",
+			stored.Amount,
+			stored.Currency,
+			exchName,
+			stored.Status,
+			fh.Status)
+		log.Infoln(log.Global, msg)
+		Bot.CommsManager.PushEvent(base.Event{Type: "deposit", Severity: base.SeverityTrade, Message: msg})
+		return nil
+	}
+	if !errors.Is(err, deposit.ErrNoResults) {
+		return err
+	}
+
+	dep := deposit.Data{
+		Exchange:   exchName,
+		ExchangeID: id,
+		Status:     fh.Status,
+		Currency:   fh.Currency,
+		Amount:     fh.Amount,
+		Fee:        fh.Fee,
+		Address:    fh.CryptoToAddress,
+		TxID:       fh.CryptoTxID,
+		Timestamp:  fh.Timestamp,
+	}
+	matched := matchDepositWithdrawal(fh, exchName)
+	if matched != nil {
+		dep.WithdrawalID = matched.ID.String()
+	}
+	err = deposit.Insert(dep)
+	if err != nil {
+		return err
+	}
+	if fh.Timestamp.Before(d.startTime) {
+		// Deposits received before the monitor started are stored
+		// without notification
+		return nil
+	}
+	msg := fmt.Sprintf("Deposit of %v %s received on %s with status %s",
+		dep.Amount,
+		dep.Currency,
+		exchName,
+		dep.Status)
+	if matched != nil {
+		msg += fmt.Sprintf(", transfer from %s withdrawal %s",
+			matched.Exchange.Name,
+			matched.ID)
+	}
+	log.Infoln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{Type: "deposit", Severity: base.SeverityTrade, Message: msg})
+	return nil
+}
+
+func (d *depositMonitor) reportUnconfirmed(increases []balanceIncrease) {
+	if !Bot.Config.DepositMonitor.ReportBalanceIncreases {
+		return
+	}
+	for i := range increases {
+		msg := fmt.Sprintf("Balance of %s on %s increased by %v, funding history unsupported so deposit is unconfirmed",
+			increases[i].Currency,
+			increases[i].Exchange,
+			increases[i].Amount)
+		log.Infoln(log.Global, msg)
+		Bot.CommsManager.PushEvent(base.Event{Type: "deposit", Severity: base.SeverityTrade, Message: msg})
+	}
+}
+
+// matchDepositWithdrawal returns the withdrawal initiated from another
+// exchange which funded the deposit, matched by transaction ID or by
+// deposit address, currency and amount within the configured window
+func matchDepositWithdrawal(fh *exchange.FundHistory, exchName string) *withdraw.Response {
+	if fh.CryptoTxID != "" {
+		withdrawals, err := withdrawDataStore.GetCryptoEventsByTxID(fh.CryptoTxID)
+		if err == nil {
+			return withdrawals[0]
+		}
+	}
+	if fh.CryptoToAddress == "" {
+		return nil
+	}
+	withdrawals, err := withdrawDataStore.GetCryptoEventsByAddress(fh.CryptoToAddress)
+	if err != nil {
+		return nil
+	}
+	for i := range withdrawals {
+		if depositMatchesWithdrawal(fh, exchName, withdrawals[i], Bot.Config.DepositMonitor.MatchWindow) {
+			return withdrawals[i]
+		}
+	}
+	return nil
+}
+
+// depositMatchesWithdrawal returns whether a withdrawal from another exchange
+// of the same currency, made within the window before the deposit, delivered
+// the deposited amount either before or after its withdrawal fee
+func depositMatchesWithdrawal(fh *exchange.FundHistory, exchName string, w *withdraw.Response, window time.Duration) bool {
+	if strings.EqualFold(w.Exchange.Name, exchName) ||
+		!w.RequestDetails.Currency.Match(currency.NewCode(fh.Currency)) {
+		return false
+	}
+	if !w.CreatedAt.IsZero() &&
+		(w.CreatedAt.After(fh.Timestamp) || fh.Timestamp.Sub(w.CreatedAt) > window) {
+		return false
+	}
+	return math.Abs(w.RequestDetails.Amount-fh.Amount) <= depositAmountTolerance ||
+		math.Abs(w.RequestDetails.Amount-w.RequestDetails.Crypto.FeeAmount-fh.Amount) <= depositAmountTolerance
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/deposit"
+	exchangeDB "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+func TestDepositBalanceIncreases(t *testing.T) {
+	var d depositMonitor
+	h := &account.Holdings{
+		Exchange: testExchange,
+		Accounts: []account.SubAccount{
+			{Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 1}}},
+			{Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 1}}},
+		},
+	}
+	if len(d.balanceIncreases(h)) != 0 {
+		t.Error("first update should only set the baseline")
+	}
+	h.Accounts[1].Currencies = append(h.Accounts[1].Currencies,
+		account.Balance{CurrencyName: currency.LTC, TotalValue: 5})
+	h.Accounts[0].Currencies[0].TotalValue = 0.5
+	increases := d.balanceIncreases(h)
+	if len(increases) != 1 {
+		t.Fatalf("expected 1 increase received %v", len(increases))
+	}
+	if !increases[0].Currency.Match(currency.LTC) || increases[0].Amount != 5 {
+		t.Errorf("unexpected increase %+v", increases[0])
+	}
+}
+
+func TestDepositMatchesWithdrawal(t *testing.T) {
+	now := time.Now()
+	fh := &exchange.FundHistory{
+		Currency:  "BTC",
+		Amount:    0.999,
+		Timestamp: now,
+	}
+	w := &withdraw.Response{
+		Exchange: withdraw.ExchangeResponse{Name: fakePassExchange},
+		RequestDetails: withdraw.Request{
+			Currency: currency.BTC,
+			Amount:   1,
+			Crypto:   withdraw.CryptoRequest{FeeAmount: 0.001},
+		},
+		CreatedAt: now.Add(-time.Hour),
+	}
+	if !depositMatchesWithdrawal(fh, testExchange, w, time.Hour*2) {
+		t.Error("expected deposit to match withdrawal net of fees")
+	}
+	if depositMatchesWithdrawal(fh, fakePassExchange, w, time.Hour*2) {
+		t.Error("withdrawal from the same exchange should not match")
+	}
+	if depositMatchesWithdrawal(fh, testExchange, w, time.Minute) {
+		t.Error("withdrawal outside the window should not match")
+	}
+	fh.Amount = 0.5
+	if depositMatchesWithdrawal(fh, testExchange, w, time.Hour*2) {
+		t.Error("different amount should not match")
+	}
+}
+
+func TestDepositRecordStatusChange(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	if Bot == nil {
+		Bot = new(Engine)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exchangeDB.Insert(exchangeDB.Details{Name: fakePassExchange, UUID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchangeDB.ResetExchangeCache()
+
+	d := depositMonitor{startTime: time.Now()}
+	fh := &exchange.FundHistory{
+		TransferID:   "deposit-1",
+		TransferType: "deposit",
+		Status:       "pending",
+		Currency:     "BTC",
+		Amount:       1,
+		Timestamp:    time.Now(),
+	}
+	err = d.record(fakePassExchange, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fh.Status = "completed"
+	err = d.record(fakePassExchange, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := deposit.GetByExchangeID(fakePassExchange, "deposit-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "completed" {
+		t.Errorf("expected status completed, received %v", stored.Status)
+	}
+}
@@ -36,12 +36,22 @@ type Engine struct {
 	GctScriptManager            *gctscript.GctScriptManager
 	OrderManager                orderManager
 	PortfolioManager            portfolioManager
+	ValuationManager            valuationManager
+	RebalanceManager            rebalanceManager
+	WithdrawalApprovalManager   withdrawalApprovalManager
+	WithdrawalTracker           withdrawalTracker
+	DepositMonitor              depositMonitor
+	BalanceAlertManager         balanceAlertManager
+	MetricsServer               metricsServer
+	ConfigWatcher               configWatcher
 	CommsManager                commsManager
 	exchangeManager             exchangeManager
+	keyPermissions              keyPermissionsStore
 	DepositAddressManager       *DepositAddressManager
 	Settings                    Settings
 	Uptime                      time.Time
 	ServicesWG                  sync.WaitGroup
+	configReloadMtx             sync.Mutex
 }
 
 // Vars for engine
@@ -394,6 +404,10 @@ func (bot *Engine) Start() error {
 		return errors.New("no exchanges are loaded")
 	}
 
+	if err := bot.CheckKeyPermissions(); err != nil {
+		return err
+	}
+
 	if bot.Settings.EnableCommsRelayer {
 		if err := bot.CommsManager.Start(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Communications manager unable to start: %v\n", err)
@@ -439,6 +453,54 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Config.PortfolioValuation.Enabled {
+		if err = bot.ValuationManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Portfolio valuation manager unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.Rebalancer.Enabled {
+		if err = bot.RebalanceManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Rebalance manager unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.WithdrawalApproval.Enabled {
+		if err = bot.WithdrawalApprovalManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Withdrawal approval manager unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.WithdrawalTracker.Enabled {
+		if err = bot.WithdrawalTracker.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Withdrawal tracker unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.DepositMonitor.Enabled {
+		if err = bot.DepositMonitor.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Deposit monitor unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.BalanceAlerts.Enabled {
+		if err = bot.BalanceAlertManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Balance alert manager unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.Metrics.Enabled {
+		if err = bot.MetricsServer.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Metrics server unable to start: %v", err)
+		}
+	}
+
+	if bot.Config.ConfigReload.WatchFile {
+		if err = bot.ConfigWatcher.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Config watcher unable to start: %v", err)
+		}
+	}
+
 	if bot.Settings.EnableDepositAddressManager {
 		bot.DepositAddressManager = new(DepositAddressManager)
 		go bot.DepositAddressManager.Sync()
@@ -523,6 +585,54 @@ func (bot *Engine) Stop() {
 		}
 	}
 
+	if bot.ValuationManager.Started() {
+		if err := bot.ValuationManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Portfolio valuation manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.RebalanceManager.Started() {
+		if err := bot.RebalanceManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Rebalance manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.WithdrawalApprovalManager.Started() {
+		if err := bot.WithdrawalApprovalManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Withdrawal approval manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.WithdrawalTracker.Started() {
+		if err := bot.WithdrawalTracker.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Withdrawal tracker unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.DepositMonitor.Started() {
+		if err := bot.DepositMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Deposit monitor unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.BalanceAlertManager.Started() {
+		if err := bot.BalanceAlertManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Balance alert manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.MetricsServer.Started() {
+		if err := bot.MetricsServer.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Metrics server unable to stop. Error: %v", err)
+		}
+	}
+
+	if bot.ConfigWatcher.Started() {
+		if err := bot.ConfigWatcher.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Config watcher unable to stop. Error: %v", err)
+		}
+	}
+
 	if bot.ConnectionManager.Started() {
 		if err := bot.ConnectionManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Connection manager unable to stop. Error: %v", err)
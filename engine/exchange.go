@@ -50,6 +50,7 @@ var (
 type exchangeManager struct {
 	m         sync.Mutex
 	exchanges map[string]exchange.IBotExchange
+	accounts  map[string]map[string]exchange.IBotExchange
 }
 
 func (bot *Engine) dryrunParamInteraction(param string) {
@@ -100,6 +101,7 @@ func (e *exchangeManager) removeExchange(exchName string) error {
 	e.m.Lock()
 	defer e.m.Unlock()
 	delete(e.exchanges, strings.ToLower(exchName))
+	delete(e.accounts, strings.ToLower(exchName))
 	log.Infof(log.ExchangeSys, "%s exchange unloaded successfully.\n", exchName)
 	return nil
 }
@@ -153,16 +155,10 @@ func (bot *Engine) GetExchanges() []exchange.IBotExchange {
 	return bot.exchangeManager.getExchanges()
 }
 
-// LoadExchange loads an exchange by name
-func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
-	nameLower := strings.ToLower(name)
+// newExchangeByName returns a new instance of the named exchange
+func newExchangeByName(name string) (exchange.IBotExchange, error) {
 	var exch exchange.IBotExchange
-
-	if bot.exchangeManager.getExchangeByName(nameLower) != nil {
-		return ErrExchangeAlreadyLoaded
-	}
-
-	switch nameLower {
+	switch strings.ToLower(name) {
 	case "binance":
 		exch = new(binance.Binance)
 	case "bitfinex":
@@ -220,11 +216,25 @@ func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) err
 	case "zb":
 		exch = new(zb.ZB)
 	default:
-		return ErrExchangeNotFound
+		return nil, ErrExchangeNotFound
 	}
 
 	if exch == nil {
-		return ErrExchangeFailedToLoad
+		return nil, ErrExchangeFailedToLoad
+	}
+	return exch, nil
+}
+
+// LoadExchange loads an exchange by name
+func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
+	nameLower := strings.ToLower(name)
+	if bot.exchangeManager.getExchangeByName(nameLower) != nil {
+		return ErrExchangeAlreadyLoaded
+	}
+
+	exch, err := newExchangeByName(nameLower)
+	if err != nil {
+		return err
 	}
 
 	exch.SetDefaults()
@@ -344,6 +354,8 @@ func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) err
 		}
 	}
 
+	bot.loadExchangeAccounts(exchCfg)
+
 	if useWG {
 		exch.Start(wg)
 	} else {
@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+)
+
+var errExchangeAccountNotFound = errors.New("exchange account not found")
+
+func (e *exchangeManager) addAccount(exch exchange.IBotExchange) {
+	name := strings.ToLower(exch.GetName())
+	e.m.Lock()
+	if e.accounts == nil {
+		e.accounts = make(map[string]map[string]exchange.IBotExchange)
+	}
+	if e.accounts[name] == nil {
+		e.accounts[name] = make(map[string]exchange.IBotExchange)
+	}
+	e.accounts[name][strings.ToLower(exchangeAccountName(exch))] = exch
+	e.m.Unlock()
+}
+
+func (e *exchangeManager) getAccount(exchName, account string) exchange.IBotExchange {
+	e.m.Lock()
+	defer e.m.Unlock()
+	return e.accounts[strings.ToLower(exchName)][strings.ToLower(account)]
+}
+
+func (e *exchangeManager) getAccounts(exchName string) []exchange.IBotExchange {
+	e.m.Lock()
+	defer e.m.Unlock()
+	var exchs []exchange.IBotExchange
+	for _, exch := range e.accounts[strings.ToLower(exchName)] {
+		exchs = append(exchs, exch)
+	}
+	return exchs
+}
+
+func (e *exchangeManager) removeAccounts(exchName string) {
+	e.m.Lock()
+	delete(e.accounts, strings.ToLower(exchName))
+	e.m.Unlock()
+}
+
+// GetExchangeAccount returns the exchange instance which signs requests with
+// the named account's credentials, an empty account returns the exchange
+// instance using the default credentials. When an exchange has no named
+// accounts configured the account is treated as an exchange specific
+// identifier and the default instance is returned
+func (bot *Engine) GetExchangeAccount(exchName, account string) (exchange.IBotExchange, error) {
+	exch := bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if account == "" {
+		return exch, nil
+	}
+	if acc := bot.exchangeManager.getAccount(exchName, account); acc != nil {
+		return acc, nil
+	}
+	exchCfg, err := bot.Config.GetExchangeConfig(exchName)
+	if err != nil {
+		return nil, err
+	}
+	if len(exchCfg.API.Accounts) == 0 {
+		return exch, nil
+	}
+	return nil, fmt.Errorf("%s %s %w", exchName, account, errExchangeAccountNotFound)
+}
+
+// exchangeAccountID returns the account identifier to pass on to an exchange
+// instance, named account selectors are consumed when selecting the instance
+func exchangeAccountID(exch exchange.IBotExchange, account string) string {
+	if exchangeAccountName(exch) != "" {
+		return ""
+	}
+	return account
+}
+
+// exchangeAccountName returns the named account an exchange instance signs
+// requests for, empty for the default credentials
+func exchangeAccountName(exch exchange.IBotExchange) string {
+	if b := exch.GetBase(); b != nil {
+		return b.Account
+	}
+	return ""
+}
+
+// GetExchangeAccounts returns the exchange instances loaded for the named
+// accounts of an exchange
+func (bot *Engine) GetExchangeAccounts(exchName string) []exchange.IBotExchange {
+	return bot.exchangeManager.getAccounts(exchName)
+}
+
+// loadExchangeAccounts loads an exchange instance for each named account of an
+// exchange. Account instances share the exchange settings but sign requests
+// with the account's credentials, have their own rate limiter and holdings and
+// do not run websocket or syncer routines
+func (bot *Engine) loadExchangeAccounts(exchCfg *config.ExchangeConfig) {
+	for i := range exchCfg.API.Accounts {
+		err := bot.loadExchangeAccount(exchCfg, &exchCfg.API.Accounts[i])
+		if err != nil {
+			log.Errorf(log.ExchangeSys,
+				"%s account %s failed to load: %s\n",
+				exchCfg.Name,
+				exchCfg.API.Accounts[i].Name,
+				err)
+			continue
+		}
+		log.Debugf(log.ExchangeSys,
+			"%s account %s loaded.\n",
+			exchCfg.Name,
+			exchCfg.API.Accounts[i].Name)
+	}
+}
+
+func (bot *Engine) loadExchangeAccount(exchCfg *config.ExchangeConfig, acc *config.APIAccountConfig) error {
+	exch, err := newExchangeByName(exchCfg.Name)
+	if err != nil {
+		return err
+	}
+	exch.SetDefaults()
+
+	if !bot.Settings.EnableExchangeHTTPRateLimiter {
+		err = exch.DisableRateLimiter()
+		if err != nil {
+			log.Errorf(log.ExchangeSys,
+				"%s account %s rate limiting cannot be turned off: %s.\n",
+				exchCfg.Name,
+				acc.Name,
+				err)
+		}
+	}
+
+	accCfg := exchangeAccountConfig(exchCfg, acc)
+	err = exch.Setup(accCfg)
+	if err != nil {
+		return err
+	}
+
+	base := exch.GetBase()
+	base.Account = acc.Name
+	base.API.AuthenticatedWebsocketSupport = false
+	if base.API.AuthenticatedSupport {
+		err = exch.ValidateCredentials()
+		if err != nil {
+			return fmt.Errorf("cannot validate credentials: %w", err)
+		}
+	}
+
+	bot.exchangeManager.addAccount(exch)
+	return nil
+}
+
+// exchangeAccountConfig returns a copy of the exchange config using the named
+// account credentials. Fields the exchange mutates at runtime are deep copied
+// so an account enabling pairs or assets does not change the parent config
+func exchangeAccountConfig(exchCfg *config.ExchangeConfig, acc *config.APIAccountConfig) *config.ExchangeConfig {
+	accCfg := *exchCfg
+	accCfg.API.Credentials = acc.Credentials
+	accCfg.API.Accounts = nil
+	if exchCfg.BaseCurrencies != nil {
+		accCfg.BaseCurrencies = append(currency.Currencies{}, exchCfg.BaseCurrencies...)
+	}
+	if exchCfg.CurrencyPairs != nil {
+		accCfg.CurrencyPairs = exchCfg.CurrencyPairs.Copy()
+	}
+	if exchCfg.BankAccounts != nil {
+		accCfg.BankAccounts = append([]banking.Account{}, exchCfg.BankAccounts...)
+	}
+	if exchCfg.API.CredentialsValidator != nil {
+		validator := *exchCfg.API.CredentialsValidator
+		accCfg.API.CredentialsValidator = &validator
+	}
+	if exchCfg.API.Permissions != nil {
+		permissions := *exchCfg.API.Permissions
+		accCfg.API.Permissions = &permissions
+	}
+	if exchCfg.Features != nil {
+		features := *exchCfg.Features
+		features.Enabled.Websocket = false
+		accCfg.Features = &features
+	}
+	return &accCfg
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
+)
+
+func TestGetExchangeAccount(t *testing.T) {
+	cfg, err := config.ReadReloadConfig(config.TestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot := &Engine{Config: cfg}
+	exchCfg, err := cfg.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := new(bitstamp.Bitstamp)
+	exch.SetDefaults()
+	if err = exch.Setup(exchCfg); err != nil {
+		t.Fatal(err)
+	}
+	bot.exchangeManager.add(exch)
+
+	exchCfg.API.Accounts = []config.APIAccountConfig{
+		{
+			Name: "sub",
+			Credentials: config.APICredentialsConfig{
+				Key:      "subkey",
+				Secret:   "subsecret",
+				ClientID: "subclient",
+			},
+		},
+	}
+	bot.loadExchangeAccounts(exchCfg)
+
+	defaultExch, err := bot.GetExchangeAccount(testExchange, "")
+	if err != nil || defaultExch != exch {
+		t.Errorf("expected default exchange instance, received %v", err)
+	}
+
+	sub, err := bot.GetExchangeAccount("bitstamp", "SUB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub == exch {
+		t.Fatal("expected a separate instance for the named account")
+	}
+	b := sub.GetBase()
+	if b.Account != "sub" || b.Config.API.Credentials.Key != "subkey" || b.Websocket.IsEnabled() {
+		t.Errorf("unexpected account instance %s %s", b.Account, b.Config.API.Credentials.Key)
+	}
+	if exchCfg.API.Credentials.Key == "subkey" || exch.Config != exchCfg {
+		t.Error("named account credentials should not change the default instance")
+	}
+	if len(bot.GetExchangeAccounts(testExchange)) != 1 {
+		t.Error("expected one named account instance")
+	}
+
+	pair := b.Config.CurrencyPairs.Pairs[asset.Spot].Enabled[0]
+	if err = b.Config.CurrencyPairs.DisablePair(asset.Spot, pair); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Config.CurrencyPairs.SetAssetEnabled(asset.Spot, false); err != nil {
+		t.Fatal(err)
+	}
+	enabled, err := exchCfg.CurrencyPairs.GetPairs(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !enabled.Contains(pair, true) {
+		t.Error("disabling a pair on a named account should not change the parent config")
+	}
+	if err = exchCfg.CurrencyPairs.IsAssetEnabled(asset.Spot); err != nil {
+		t.Error("disabling an asset on a named account should not change the parent config")
+	}
+
+	if exchangeAccountID(exch, "1337") != "1337" || exchangeAccountID(sub, "sub") != "" {
+		t.Error("expected only exchange specific account identifiers to be passed on")
+	}
+
+	_, err = bot.GetExchangeAccount(testExchange, "missing")
+	if !errors.Is(err, errExchangeAccountNotFound) {
+		t.Errorf("expected %v, received %v", errExchangeAccountNotFound, err)
+	}
+	_, err = bot.GetExchangeAccount("Binance", "")
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("expected %v, received %v", ErrExchangeNotFound, err)
+	}
+
+	// Without named accounts the selector is an exchange specific identifier
+	exchCfg.API.Accounts = nil
+	acc, err := bot.GetExchangeAccount(testExchange, "1337")
+	if err != nil || acc != exch {
+		t.Errorf("expected default exchange instance, received %v", err)
+	}
+
+	if err = bot.exchangeManager.removeExchange(testExchange); err != nil {
+		t.Fatal(err)
+	}
+	if len(bot.GetExchangeAccounts(testExchange)) != 0 {
+		t.Error("expected named accounts to be removed with the exchange")
+	}
+}
@@ -9,7 +9,9 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
@@ -19,7 +21,8 @@ import (
 )
 
 const (
-	fakePassExchange = "FakePassExchange"
+	fakePassExchange   = "FakePassExchange"
+	fakeDepositAddress = "FakePassExchangeDepositAddress"
 )
 
 // FakePassingExchange is used to override IBotExchange responses in tests
@@ -177,7 +180,7 @@ func (h *FakePassingExchange) GetWithdrawalsHistory(_ currency.Code) ([]exchange
 	return nil, nil
 }
 func (h *FakePassingExchange) GetDepositAddress(_ currency.Code, _ string) (string, error) {
-	return "", nil
+	return fakeDepositAddress, nil
 }
 func (h *FakePassingExchange) GetOrderHistory(_ *order.GetOrdersRequest) ([]order.Detail, error) {
 	return nil, nil
@@ -232,7 +235,7 @@ func (h *FakePassingExchange) GetHistoricCandlesExtended(_ currency.Pair, _ asse
 func (h *FakePassingExchange) DisableRateLimiter() error { return nil }
 func (h *FakePassingExchange) EnableRateLimiter() error  { return nil }
 func (h *FakePassingExchange) WithdrawCryptocurrencyFunds(_ *withdraw.Request) (*withdraw.ExchangeResponse, error) {
-	return nil, nil
+	return &withdraw.ExchangeResponse{ID: "fake", Status: "submitted"}, nil
 }
 func (h *FakePassingExchange) WithdrawFiatFunds(_ *withdraw.Request) (*withdraw.ExchangeResponse, error) {
 	return nil, nil
@@ -240,3 +243,67 @@ func (h *FakePassingExchange) WithdrawFiatFunds(_ *withdraw.Request) (*withdraw.
 func (h *FakePassingExchange) WithdrawFiatFundsToInternationalBank(_ *withdraw.Request) (*withdraw.ExchangeResponse, error) {
 	return nil, nil
 }
+func (h *FakePassingExchange) GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	return &futures.FundingRate{
+		Exchange:      fakePassExchange,
+		Asset:         a,
+		Pair:          p,
+		Rate:          0.0001,
+		PredictedRate: 0.0002,
+		Time:          time.Now(),
+	}, nil
+}
+func (h *FakePassingExchange) GetFundingRateHistory(p currency.Pair, a asset.Item, start, _ time.Time) ([]futures.FundingRate, error) {
+	return []futures.FundingRate{
+		{
+			Exchange: fakePassExchange,
+			Asset:    a,
+			Pair:     p,
+			Rate:     0.0001,
+			Time:     start,
+		},
+	}, nil
+}
+func (h *FakePassingExchange) GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	return &futures.OpenInterest{Exchange: fakePassExchange, Asset: a, Pair: p, Amount: 1337, Time: time.Now()}, nil
+}
+func (h *FakePassingExchange) GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	return &futures.MarkPrice{Exchange: fakePassExchange, Asset: a, Pair: p, MarkPrice: 1337, IndexPrice: 1336, Time: time.Now()}, nil
+}
+func (h *FakePassingExchange) GetFuturesContracts(a asset.Item) ([]futures.Contract, error) {
+	return []futures.Contract{
+		{
+			Exchange:           fakePassExchange,
+			Asset:              a,
+			Pair:               currency.NewPairWithDelimiter("BTC", "PERP", currency.DashDelimiter),
+			Underlying:         "BTC",
+			Multiplier:         1,
+			SettlementCurrency: currency.USD,
+		},
+	}, nil
+}
+func (h *FakePassingExchange) GetLeveragedPositions(a asset.Item) ([]margin.Position, error) {
+	return []margin.Position{
+		{
+			Exchange:         fakePassExchange,
+			Asset:            a,
+			Pair:             currency.NewPairWithDelimiter("BTC", "PERP", currency.DashDelimiter),
+			Side:             margin.Long,
+			MarginType:       margin.Cross,
+			Size:             1,
+			EntryPrice:       1337,
+			LiquidationPrice: 1000,
+			Leverage:         10,
+			LastUpdated:      time.Now(),
+		},
+	}, nil
+}
+func (h *FakePassingExchange) SetLeverage(_ currency.Pair, _ asset.Item, leverage float64) error {
+	return margin.CheckLeverage(leverage)
+}
+func (h *FakePassingExchange) SetMarginType(_ currency.Pair, _ asset.Item, _ margin.Type) error {
+	return nil
+}
+func (h *FakePassingExchange) ModifyIsolatedMargin(_ currency.Pair, _ asset.Item, _ float64) error {
+	return nil
+}
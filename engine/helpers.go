@@ -36,6 +36,8 @@ var (
 	errCertExpired     = errors.New("gRPC TLS certificate has expired")
 	errCertDataIsNil   = errors.New("gRPC TLS certificate PEM data is nil")
 	errCertTypeInvalid = errors.New("gRPC TLS certificate type is invalid")
+	errNoExchangeOTP   = errors.New("exchange does not have a OTP secret stored")
+	errInvalidOTP      = errors.New("invalid one-time code")
 )
 
 // GetSubsystemsStatus returns the status of various subsystems
@@ -45,6 +47,14 @@ func (bot *Engine) GetSubsystemsStatus() map[string]bool {
 	systems["internet_monitor"] = bot.ConnectionManager.Started()
 	systems["orders"] = bot.OrderManager.Started()
 	systems["portfolio"] = bot.PortfolioManager.Started()
+	systems["portfolio_valuation"] = bot.ValuationManager.Started()
+	systems["rebalancer"] = bot.RebalanceManager.Started()
+	systems["withdrawal_approval"] = bot.WithdrawalApprovalManager.Started()
+	systems["withdrawal_tracker"] = bot.WithdrawalTracker.Started()
+	systems["deposit_monitor"] = bot.DepositMonitor.Started()
+	systems["balance_alerts"] = bot.BalanceAlertManager.Started()
+	systems["metrics"] = bot.MetricsServer.Started()
+	systems["config_watcher"] = bot.ConfigWatcher.Started()
 	systems["ntp_timekeeper"] = bot.NTPManager.Started()
 	systems["database"] = bot.DatabaseManager.Started()
 	systems["exchange_syncer"] = bot.Settings.EnableExchangeSyncManager
@@ -108,6 +118,46 @@ func (bot *Engine) SetSubsystem(subsys string, enable bool) error {
 			return bot.PortfolioManager.Start()
 		}
 		return bot.OrderManager.Stop()
+	case "portfolio_valuation":
+		if enable {
+			return bot.ValuationManager.Start()
+		}
+		return bot.ValuationManager.Stop()
+	case "rebalancer":
+		if enable {
+			return bot.RebalanceManager.Start()
+		}
+		return bot.RebalanceManager.Stop()
+	case "withdrawal_approval":
+		if enable {
+			return bot.WithdrawalApprovalManager.Start()
+		}
+		return bot.WithdrawalApprovalManager.Stop()
+	case "withdrawal_tracker":
+		if enable {
+			return bot.WithdrawalTracker.Start()
+		}
+		return bot.WithdrawalTracker.Stop()
+	case "deposit_monitor":
+		if enable {
+			return bot.DepositMonitor.Start()
+		}
+		return bot.DepositMonitor.Stop()
+	case "balance_alerts":
+		if enable {
+			return bot.BalanceAlertManager.Start()
+		}
+		return bot.BalanceAlertManager.Stop()
+	case "metrics":
+		if enable {
+			return bot.MetricsServer.Start()
+		}
+		return bot.MetricsServer.Stop()
+	case "config_watcher":
+		if enable {
+			return bot.ConfigWatcher.Start()
+		}
+		return bot.ConfigWatcher.Stop()
 	case "ntp_timekeeper":
 		if enable {
 			return bot.NTPManager.Start()
@@ -174,7 +224,30 @@ func (bot *Engine) GetExchangeoOTPByName(exchName string) (string, error) {
 			return totp.GenerateCode(otpSecret, time.Now())
 		}
 	}
-	return "", errors.New("exchange does not have a OTP secret stored")
+	return "", errNoExchangeOTP
+}
+
+// ValidateExchangeOTP checks a OTP code against the OTP secrets stored for
+// the supplied exchanges, the code is accepted if it matches any of them
+func (bot *Engine) ValidateExchangeOTP(code string, exchNames ...string) error {
+	var found bool
+	for x := range bot.Config.Exchanges {
+		if !common.StringDataCompareInsensitive(exchNames, bot.Config.Exchanges[x].Name) {
+			continue
+		}
+		otpSecret := bot.Config.Exchanges[x].API.Credentials.OTPSecret
+		if otpSecret == "" {
+			continue
+		}
+		if totp.Validate(code, otpSecret) {
+			return nil
+		}
+		found = true
+	}
+	if !found {
+		return errNoExchangeOTP
+	}
+	return errInvalidOTP
 }
 
 // GetAuthAPISupportedExchanges returns a list of auth api enabled exchanges
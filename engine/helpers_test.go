@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"net"
 	"os"
@@ -126,6 +127,34 @@ func TestGetExchangeoOTPByName(t *testing.T) {
 	bCfg.API.Credentials.OTPSecret = ""
 }
 
+func TestValidateExchangeOTP(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	if err := bot.ValidateExchangeOTP("123456", "Bitstamp"); !errors.Is(err, errNoExchangeOTP) {
+		t.Fatalf("expected %v, received %v", errNoExchangeOTP, err)
+	}
+
+	bCfg, err := bot.Config.GetExchangeConfig("Bitstamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bCfg.API.Credentials.OTPSecret = "JBSWY3DPEHPK3PXP"
+	defer func() { bCfg.API.Credentials.OTPSecret = "" }()
+
+	code, err := bot.GetExchangeoOTPByName("Bitstamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bot.ValidateExchangeOTP(code, "Bitfinex", "bitstamp"); err != nil {
+		t.Error(err)
+	}
+	if err = bot.ValidateExchangeOTP(code, "Bitfinex"); !errors.Is(err, errNoExchangeOTP) {
+		t.Errorf("expected %v, received %v", errNoExchangeOTP, err)
+	}
+	if err = bot.ValidateExchangeOTP("000000"+code, "Bitstamp"); !errors.Is(err, errInvalidOTP) {
+		t.Errorf("expected %v, received %v", errInvalidOTP, err)
+	}
+}
+
 func TestGetAuthAPISupportedExchanges(t *testing.T) {
 	e := SetupTestHelpers(t)
 	if result := e.GetAuthAPISupportedExchanges(); len(result) != 1 {
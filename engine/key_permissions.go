@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errKeyPermissionsExceeded = errors.New("API key holds more rights than declared or could not be checked")
+
+// keyPermissionsStore holds the API key permissions checked at startup keyed
+// by exchange and named account
+type keyPermissionsStore struct {
+	m     sync.Mutex
+	perms map[string]map[string]exchange.KeyPermissions
+}
+
+func (k *keyPermissionsStore) set(exchName, account string, p exchange.KeyPermissions) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	if k.perms == nil {
+		k.perms = make(map[string]map[string]exchange.KeyPermissions)
+	}
+	name := strings.ToLower(exchName)
+	if k.perms[name] == nil {
+		k.perms[name] = make(map[string]exchange.KeyPermissions)
+	}
+	k.perms[name][account] = p
+}
+
+// get returns the key permissions of an exchange by account name
+func (k *keyPermissionsStore) get(exchName string) map[string]exchange.KeyPermissions {
+	k.m.Lock()
+	defer k.m.Unlock()
+	perms := make(map[string]exchange.KeyPermissions)
+	for account, p := range k.perms[strings.ToLower(exchName)] {
+		perms[account] = p
+	}
+	return perms
+}
+
+// CheckKeyPermissions queries the permissions of the API keys of each
+// authenticated exchange and named account, logs them and applies the
+// declared permission policy. An error is returned when a key holds more
+// rights than declared, or its permissions could not be checked, and the
+// exchange is set to refuse excess rights
+func (bot *Engine) CheckKeyPermissions() error {
+	var refused []string
+	exchanges := bot.GetExchanges()
+	for x := range exchanges {
+		exchCfg, err := bot.Config.GetExchangeConfig(exchanges[x].GetName())
+		if err != nil {
+			continue
+		}
+		instances := append([]exchange.IBotExchange{exchanges[x]},
+			bot.GetExchangeAccounts(exchanges[x].GetName())...)
+		for y := range instances {
+			if !instances[y].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+				continue
+			}
+			name := instances[y].GetName()
+			if account := exchangeAccountName(instances[y]); account != "" {
+				name += " account " + account
+			}
+			p, err := getKeyPermissions(instances[y])
+			if err != nil {
+				log.Warnf(log.ExchangeSys, "%s API key permissions could not be checked: %v\n", name, err)
+				if declared := exchCfg.API.Permissions; declared != nil && declared.RefuseExcess {
+					refused = append(refused, fmt.Sprintf("%s [unchecked: %v]", name, err))
+				}
+				continue
+			}
+			bot.keyPermissions.set(instances[y].GetName(), exchangeAccountName(instances[y]), p)
+			log.Infof(log.ExchangeSys,
+				"%s API key permissions: read %s, trade %s, withdraw %s, IP restricted %s%s\n",
+				name,
+				p.Read,
+				p.Trade,
+				p.Withdraw,
+				p.IPRestricted,
+				probedSuffix(p))
+
+			declared := exchCfg.API.Permissions
+			if declared == nil {
+				continue
+			}
+			if declared.Trade && p.Trade == exchange.KeyPermissionDenied {
+				log.Warnf(log.ExchangeSys, "%s API key lacks the declared trading rights\n", name)
+			}
+			if declared.Withdraw && p.Withdraw == exchange.KeyPermissionDenied {
+				log.Warnf(log.ExchangeSys, "%s API key lacks the declared withdrawal rights\n", name)
+			}
+			excess := excessKeyPermissions(p, declared)
+			if len(excess) == 0 {
+				continue
+			}
+			log.Warnf(log.ExchangeSys, "%s API key holds undeclared rights: %s\n",
+				name,
+				strings.Join(excess, ", "))
+			if declared.RefuseExcess {
+				refused = append(refused, fmt.Sprintf("%s [%s]", name, strings.Join(excess, ", ")))
+			}
+		}
+	}
+	if len(refused) > 0 {
+		sort.Strings(refused)
+		return fmt.Errorf("%w: %s", errKeyPermissionsExceeded, strings.Join(refused, ", "))
+	}
+	return nil
+}
+
+// getKeyPermissions returns the key permissions reported by the exchange. When
+// the exchange does not expose them a read only request is made as a probe,
+// trading and withdrawal rights cannot be probed without side effects and are
+// left unknown
+func getKeyPermissions(exch exchange.IBotExchange) (exchange.KeyPermissions, error) {
+	p, err := exch.GetAPIKeyPermissions()
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		return p, err
+	}
+	_, err = exch.UpdateAccountInfo()
+	if err != nil {
+		return exchange.KeyPermissions{}, err
+	}
+	return exchange.KeyPermissions{
+		Read:   exchange.KeyPermissionGranted,
+		Probed: true,
+	}, nil
+}
+
+// excessKeyPermissions returns the rights a key holds beyond those declared
+func excessKeyPermissions(p exchange.KeyPermissions, declared *config.APIPermissionsConfig) []string {
+	var excess []string
+	if p.Trade == exchange.KeyPermissionGranted && !declared.Trade {
+		excess = append(excess, "trade")
+	}
+	if p.Withdraw == exchange.KeyPermissionGranted && !declared.Withdraw {
+		excess = append(excess, "withdraw")
+	}
+	if p.IPRestricted == exchange.KeyPermissionDenied && declared.RequireIPRestriction {
+		excess = append(excess, "unrestricted IP access")
+	}
+	return excess
+}
+
+func probedSuffix(p exchange.KeyPermissions) string {
+	if p.Probed {
+		return " (probed)"
+	}
+	return ""
+}
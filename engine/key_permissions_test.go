@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+)
+
+// permissionsExchange reports fixed API key permissions
+type permissionsExchange struct {
+	FakePassingExchange
+	perms exchange.KeyPermissions
+}
+
+func (p *permissionsExchange) GetAPIKeyPermissions() (exchange.KeyPermissions, error) {
+	return p.perms, nil
+}
+
+// unverifiableExchange fails every request used to check key permissions
+type unverifiableExchange struct {
+	FakePassingExchange
+}
+
+func (u *unverifiableExchange) GetAPIKeyPermissions() (exchange.KeyPermissions, error) {
+	return exchange.KeyPermissions{}, errTestKeyPermissions
+}
+
+func (u *unverifiableExchange) UpdateAccountInfo() (account.Holdings, error) {
+	return account.Holdings{}, errTestKeyPermissions
+}
+
+var errTestKeyPermissions = errors.New("invalid API key")
+
+func TestCheckKeyPermissions(t *testing.T) {
+	t.Parallel()
+	declared := &config.APIPermissionsConfig{Trade: true}
+	bot := &Engine{Config: &config.Config{
+		Exchanges: []config.ExchangeConfig{
+			{Name: fakePassExchange, API: config.APIConfig{Permissions: declared}},
+		},
+	}}
+	bot.exchangeManager.add(new(FakePassingExchange))
+
+	// The fake exchange does not expose permissions so they are probed
+	if err := bot.CheckKeyPermissions(); err != nil {
+		t.Fatal(err)
+	}
+	p := bot.keyPermissions.get(fakePassExchange)[""]
+	if !p.Probed ||
+		p.Read != exchange.KeyPermissionGranted ||
+		p.Withdraw != exchange.KeyPermissionUnknown {
+		t.Errorf("unexpected probed permissions %+v", p)
+	}
+
+	bot.exchangeManager.add(&permissionsExchange{perms: exchange.KeyPermissions{
+		Read:     exchange.KeyPermissionGranted,
+		Trade:    exchange.KeyPermissionGranted,
+		Withdraw: exchange.KeyPermissionGranted,
+	}})
+	if err := bot.CheckKeyPermissions(); err != nil {
+		t.Errorf("expected excess rights to be logged only, received %v", err)
+	}
+
+	declared.RefuseExcess = true
+	if err := bot.CheckKeyPermissions(); !errors.Is(err, errKeyPermissionsExceeded) {
+		t.Errorf("expected %v, received %v", errKeyPermissionsExceeded, err)
+	}
+	if p = bot.keyPermissions.get(fakePassExchange)[""]; p.Probed {
+		t.Error("expected reported permissions to replace probed permissions")
+	}
+}
+
+func TestCheckKeyPermissionsUnverified(t *testing.T) {
+	t.Parallel()
+	declared := &config.APIPermissionsConfig{}
+	bot := &Engine{Config: &config.Config{
+		Exchanges: []config.ExchangeConfig{
+			{Name: fakePassExchange, API: config.APIConfig{Permissions: declared}},
+		},
+	}}
+	bot.exchangeManager.add(new(unverifiableExchange))
+
+	if err := bot.CheckKeyPermissions(); err != nil {
+		t.Errorf("expected unchecked permissions to be logged only, received %v", err)
+	}
+
+	declared.RefuseExcess = true
+	if err := bot.CheckKeyPermissions(); !errors.Is(err, errKeyPermissionsExceeded) {
+		t.Errorf("expected %v, received %v", errKeyPermissionsExceeded, err)
+	}
+	if len(bot.keyPermissions.get(fakePassExchange)) != 0 {
+		t.Error("expected unchecked permissions not to be stored")
+	}
+}
+
+func TestExcessKeyPermissions(t *testing.T) {
+	t.Parallel()
+	p := exchange.KeyPermissions{
+		Read:         exchange.KeyPermissionGranted,
+		Trade:        exchange.KeyPermissionGranted,
+		Withdraw:     exchange.KeyPermissionUnknown,
+		IPRestricted: exchange.KeyPermissionDenied,
+	}
+	excess := excessKeyPermissions(p, &config.APIPermissionsConfig{})
+	if len(excess) != 1 || excess[0] != "trade" {
+		t.Errorf("expected undeclared trade rights, received %v", excess)
+	}
+	excess = excessKeyPermissions(p, &config.APIPermissionsConfig{
+		Trade:                true,
+		RequireIPRestriction: true,
+	})
+	if len(excess) != 1 || excess[0] != "unrestricted IP access" {
+		t.Errorf("expected unrestricted IP access, received %v", excess)
+	}
+}
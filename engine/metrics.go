@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
+)
+
+const metricsShutdownTimeout = time.Second * 5
+
+var (
+	orderActions = metrics.NewCounterVec("gct_orders_total",
+		"Orders submitted and cancelled through the order manager by result",
+		"exchange", "action", "result")
+
+	_ = metrics.NewGaugeFunc("gct_sync_update_age_seconds",
+		"Seconds since the currency pair syncer last updated an item",
+		[]string{"exchange", "asset", "pair", "item"},
+		syncUpdateAges)
+	_ = metrics.NewGaugeFunc("gct_dispatch_queue_depth",
+		"Jobs waiting to be relayed by the dispatch system",
+		nil,
+		func() []metrics.Sample {
+			depth, _ := dispatch.QueueDepth()
+			return []metrics.Sample{{Value: float64(depth)}}
+		})
+	_ = metrics.NewGaugeFunc("gct_dispatch_queue_capacity",
+		"Capacity of the dispatch system job queue",
+		nil,
+		func() []metrics.Sample {
+			_, capacity := dispatch.QueueDepth()
+			return []metrics.Sample{{Value: float64(capacity)}}
+		})
+	_ = metrics.NewGaugeFunc("gct_gctscript_virtual_machines",
+		"Running gctscript virtual machines",
+		nil,
+		func() []metrics.Sample {
+			return []metrics.Sample{{Value: float64(gctscript.VMSCount.Len())}}
+		})
+)
+
+// observeOrderAction counts an order manager action by its result
+func observeOrderAction(exchangeName, action string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	orderActions.WithLabelValues(exchangeName, action, result).Inc()
+}
+
+// syncUpdateAges returns the seconds since each synced item was last updated
+func syncUpdateAges() []metrics.Sample {
+	if Bot == nil || Bot.ExchangeCurrencyPairManager == nil {
+		return nil
+	}
+	return Bot.ExchangeCurrencyPairManager.updateAges(time.Now())
+}
+
+// updateAges returns the seconds since each item with data was last updated
+func (e *ExchangeCurrencyPairSyncer) updateAges(now time.Time) []metrics.Sample {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	var resp []metrics.Sample
+	for i := range e.CurrencyPairs {
+		c := &e.CurrencyPairs[i]
+		items := []struct {
+			name string
+			base *SyncBase
+		}{
+			{"ticker", &c.Ticker},
+			{"orderbook", &c.Orderbook},
+			{"trade", &c.Trade},
+		}
+		for j := range items {
+			if !items[j].base.HaveData {
+				continue
+			}
+			resp = append(resp, metrics.Sample{
+				LabelValues: []string{c.Exchange, c.AssetType.String(), c.Pair.String(), items[j].name},
+				Value:       now.Sub(items[j].base.LastUpdated).Seconds(),
+			})
+		}
+	}
+	return resp
+}
+
+// metricsServer serves the Prometheus metrics endpoint
+type metricsServer struct {
+	started int32
+	stopped int32
+	server  *http.Server
+	address string
+}
+
+func (m *metricsServer) Started() bool {
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+func (m *metricsServer) Start() error {
+	if atomic.AddInt32(&m.started, 1) != 1 {
+		return errors.New("metrics server already started")
+	}
+
+	listenAddr := Bot.Config.Metrics.ListenAddress
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	m.server = &http.Server{Handler: mux}
+	m.address = lis.Addr().String()
+	log.Debugf(log.Global, "Metrics server started on http://%s/metrics\n", m.address)
+	go func(server *http.Server) {
+		err := server.Serve(lis)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf(log.Global, "Metrics server failed to serve: %v\n", err)
+		}
+	}(m.server)
+	return nil
+}
+
+func (m *metricsServer) Stop() error {
+	if atomic.LoadInt32(&m.started) == 0 {
+		return errors.New("metrics server not started")
+	}
+	if atomic.AddInt32(&m.stopped, 1) != 1 {
+		return errors.New("metrics server is already stopped")
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+	log.Debugln(log.Global, "Metrics server shutting down...")
+	return m.server.Shutdown(ctx)
+}
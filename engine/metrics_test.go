@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSyncerUpdateAges(t *testing.T) {
+	now := time.Now()
+	e := ExchangeCurrencyPairSyncer{
+		CurrencyPairs: []CurrencyPairSyncAgent{
+			{
+				Exchange:  testExchange,
+				AssetType: asset.Spot,
+				Pair:      currency.NewPair(currency.BTC, currency.USD),
+				Ticker:    SyncBase{HaveData: true, LastUpdated: now.Add(-time.Second * 5)},
+				Orderbook: SyncBase{HaveData: true, LastUpdated: now.Add(-time.Second)},
+			},
+		},
+	}
+	ages := e.updateAges(now)
+	if len(ages) != 2 {
+		t.Fatalf("expected 2 ages, received %d", len(ages))
+	}
+	if ages[0].LabelValues[3] != "ticker" || ages[0].Value != 5 {
+		t.Errorf("unexpected ticker age %+v", ages[0])
+	}
+	if ages[1].LabelValues[0] != testExchange || ages[1].Value != 1 {
+		t.Errorf("unexpected orderbook age %+v", ages[1])
+	}
+}
+
+func TestMetricsServer(t *testing.T) {
+	SetupTestHelpers(t)
+	Bot.Config.Metrics.ListenAddress = "localhost:0"
+
+	var m metricsServer
+	if err := m.Stop(); err == nil {
+		t.Error("expected error stopping metrics server which is not started")
+	}
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Start(); err == nil {
+		t.Error("expected error starting metrics server twice")
+	}
+
+	observeOrderAction(testExchange, "submit", errors.New("rejected"))
+	resp, err := http.Get("http://" + m.address + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"gct_dispatch_queue_depth ",
+		"gct_gctscript_virtual_machines ",
+		`gct_orders_total{exchange="Bitstamp",action="submit",result="failure"}`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("metrics missing %s", expected)
+		}
+	}
+
+	if err = m.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Started() {
+		t.Error("metrics server should be stopped")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -230,10 +232,14 @@ func (o *orderManager) CancelAllOrders(exchangeNames []string) {
 func (o *orderManager) Cancel(cancel *order.Cancel) error {
 	var err error
 	defer func() {
+		if cancel != nil {
+			observeOrderAction(cancel.Exchange, "cancel", err)
+		}
 		if err != nil {
 			Bot.CommsManager.PushEvent(base.Event{
-				Type:    "order",
-				Message: err.Error(),
+				Type:     "order",
+				Severity: base.SeverityWarning,
+				Message:  err.Error(),
 			})
 		}
 	}()
@@ -251,9 +257,9 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 		return err
 	}
 
-	exch := Bot.GetExchangeByName(cancel.Exchange)
-	if exch == nil {
-		err = ErrExchangeNotFound
+	var exch exchange.IBotExchange
+	exch, err = Bot.GetExchangeAccount(cancel.Exchange, cancel.AccountID)
+	if err != nil {
 		return err
 	}
 
@@ -262,10 +268,16 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 		return err
 	}
 
-	log.Debugf(log.OrderMgr, "Order manager: Cancelling order ID %v [%+v]",
-		cancel.ID, cancel)
+	l := log.With(log.OrderMgr,
+		log.Exchange(cancel.Exchange),
+		log.Pair(cancel.Pair),
+		log.Asset(cancel.AssetType),
+		log.OrderID(cancel.ID))
+	l.Debugf("Order manager: Cancelling order [%+v]", cancel)
 
-	err = exch.CancelOrder(cancel)
+	c := *cancel
+	c.AccountID = exchangeAccountID(exch, cancel.AccountID)
+	err = exch.CancelOrder(&c)
 	if err != nil {
 		err = fmt.Errorf("%v - Failed to cancel order: %v", cancel.Exchange, err)
 		return err
@@ -280,10 +292,12 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 	od.Status = order.Cancelled
 	msg := fmt.Sprintf("Order manager: Exchange %s order ID=%v cancelled.",
 		od.Exchange, od.ID)
-	log.Debugln(log.OrderMgr, msg)
+	l.Debug(msg)
+	audit.Event(od.ID, audit.OrderEvent, msg)
 	Bot.CommsManager.PushEvent(base.Event{
-		Type:    "order",
-		Message: msg,
+		Type:     "order",
+		Severity: base.SeverityTrade,
+		Message:  msg,
 	})
 
 	return nil
@@ -315,10 +329,13 @@ func (o *orderManager) GetOrderInfo(exchangeName, orderID string, cp currency.Pa
 
 // Submit will take in an order struct, send it to the exchange and
 // populate it in the orderManager if successful
-func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, error) {
+func (o *orderManager) Submit(newOrder *order.Submit) (resp *orderSubmitResponse, err error) {
 	if newOrder == nil {
 		return nil, errors.New("order cannot be nil")
 	}
+	defer func() {
+		observeOrderAction(newOrder.Exchange, "submit", err)
+	}()
 
 	if newOrder.Exchange == "" {
 		return nil, errors.New("order exchange name must be specified")
@@ -347,11 +364,13 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		}
 	}
 
-	exch := Bot.GetExchangeByName(newOrder.Exchange)
-	if exch == nil {
-		return nil, ErrExchangeNotFound
+	exch, err := Bot.GetExchangeAccount(newOrder.Exchange, newOrder.AccountID)
+	if err != nil {
+		return nil, err
 	}
-	result, err := exch.SubmitOrder(newOrder)
+	submit := *newOrder
+	submit.AccountID = exchangeAccountID(exch, newOrder.AccountID)
+	result, err := exch.SubmitOrder(&submit)
 	if err != nil {
 		return nil, err
 	}
@@ -377,10 +396,16 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		newOrder.Side,
 		newOrder.Type)
 
-	log.Debugln(log.OrderMgr, msg)
+	log.With(log.OrderMgr,
+		log.Exchange(newOrder.Exchange),
+		log.Pair(newOrder.Pair),
+		log.Asset(newOrder.AssetType),
+		log.OrderID(result.OrderID)).Debug(msg)
+	audit.Event(result.OrderID, audit.OrderEvent, msg)
 	Bot.CommsManager.PushEvent(base.Event{
-		Type:    "order",
-		Message: msg,
+		Type:     "order",
+		Severity: base.SeverityTrade,
+		Message:  msg,
 	})
 	status := order.New
 	if result.FullyMatched {
@@ -434,58 +459,74 @@ func (o *orderManager) processOrders() {
 			"Order manager: Processing orders for exchange %v.",
 			authExchanges[x])
 
-		exch := Bot.GetExchangeByName(authExchanges[x])
-		supportedAssets := exch.GetAssetTypes()
-		for y := range supportedAssets {
-			pairs, err := exch.GetEnabledPairs(supportedAssets[y])
-			if err != nil {
-				log.Errorf(log.OrderMgr,
-					"Order manager: Unable to get enabled pairs for %s and asset type %s: %s",
-					authExchanges[x],
-					supportedAssets[y],
-					err)
-				continue
-			}
+		o.processExchangeOrders(Bot.GetExchangeByName(authExchanges[x]))
+		accounts := Bot.GetExchangeAccounts(authExchanges[x])
+		for y := range accounts {
+			o.processExchangeOrders(accounts[y])
+		}
+	}
+}
 
-			if len(pairs) == 0 {
-				if Bot.Settings.Verbose {
-					log.Debugf(log.OrderMgr,
-						"Order manager: No pairs enabled for %s and asset type %s, skipping...",
-						authExchanges[x],
-						supportedAssets[y])
-				}
-				continue
+// processExchangeOrders adds the active orders of an exchange instance,
+// orders of a named account are tagged with the account name so they are
+// cancelled with the account's credentials
+func (o *orderManager) processExchangeOrders(exch exchange.IBotExchange) {
+	name := exch.GetName()
+	acc := exchangeAccountName(exch)
+	supportedAssets := exch.GetAssetTypes()
+	for y := range supportedAssets {
+		pairs, err := exch.GetEnabledPairs(supportedAssets[y])
+		if err != nil {
+			log.Errorf(log.OrderMgr,
+				"Order manager: Unable to get enabled pairs for %s and asset type %s: %s",
+				name,
+				supportedAssets[y],
+				err)
+			continue
+		}
+
+		if len(pairs) == 0 {
+			if Bot.Settings.Verbose {
+				log.Debugf(log.OrderMgr,
+					"Order manager: No pairs enabled for %s and asset type %s, skipping...",
+					name,
+					supportedAssets[y])
 			}
+			continue
+		}
+
+		req := order.GetOrdersRequest{
+			Side:  order.AnySide,
+			Type:  order.AnyType,
+			Pairs: pairs,
+		}
+		result, err := exch.GetActiveOrders(&req)
+		if err != nil {
+			log.Warnf(log.OrderMgr,
+				"Order manager: Unable to get active orders for %s and asset type %s: %s",
+				name,
+				supportedAssets[y],
+				err)
+			continue
+		}
 
-			req := order.GetOrdersRequest{
-				Side:  order.AnySide,
-				Type:  order.AnyType,
-				Pairs: pairs,
+		for z := range result {
+			ord := &result[z]
+			if acc != "" {
+				ord.AccountID = acc
 			}
-			result, err := exch.GetActiveOrders(&req)
-			if err != nil {
-				log.Warnf(log.OrderMgr,
-					"Order manager: Unable to get active orders for %s and asset type %s: %s",
-					authExchanges[x],
-					supportedAssets[y],
-					err)
+			result := o.orderStore.Add(ord)
+			if result != ErrOrdersAlreadyExists {
+				msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
+					ord.Exchange, ord.ID, ord.Pair, ord.Price, ord.Amount, ord.Side, ord.Type)
+				log.Debugf(log.OrderMgr, "%v", msg)
+				Bot.CommsManager.PushEvent(base.Event{
+					Type:     "order",
+					Severity: base.SeverityTrade,
+					Message:  msg,
+				})
 				continue
 			}
-
-			for z := range result {
-				ord := &result[z]
-				result := o.orderStore.Add(ord)
-				if result != ErrOrdersAlreadyExists {
-					msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
-						ord.Exchange, ord.ID, ord.Pair, ord.Price, ord.Amount, ord.Side, ord.Type)
-					log.Debugf(log.OrderMgr, "%v", msg)
-					Bot.CommsManager.PushEvent(base.Event{
-						Type:    "order",
-						Message: msg,
-					})
-					continue
-				}
-			}
 		}
 	}
 }
@@ -0,0 +1,525 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/deposit"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/rebalance"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// Rebalance proposal statuses
+const (
+	RebalanceStatusPending    = "pending"
+	RebalanceStatusBlocked    = "blocked"
+	RebalanceStatusSubmitting = "submitting"
+	RebalanceStatusApproved   = "approved"
+	RebalanceStatusSettled    = "settled"
+	RebalanceStatusRejected   = "rejected"
+	RebalanceStatusFailed     = "failed"
+	RebalanceStatusSuperseded = "superseded"
+)
+
+const (
+	// rebalanceSettleTimeout is how long an approved transfer whose
+	// withdrawal or deposit cannot be found is treated as in flight
+	rebalanceSettleTimeout = time.Hour * 24
+	// rebalanceProposalRetention is how long proposals which are no longer
+	// pending or in flight are kept
+	rebalanceProposalRetention = time.Hour * 24
+	// rebalanceAmountTolerance allows for float rounding when comparing a
+	// planned transfer with a pending proposal
+	rebalanceAmountTolerance = 1e-8
+)
+
+var (
+	errRebalanceProposalNotFound    = errors.New("rebalance proposal not found")
+	errRebalanceProposalNotPending  = errors.New("rebalance proposal is not pending")
+	errRebalancerDryRun             = errors.New("rebalancer is in dry run mode, proposals cannot be approved")
+	errDepositAddressNotWhitelisted = errors.New("deposit address is not whitelisted")
+)
+
+// RebalanceProposal is a planned transfer between exchanges awaiting approval
+// before its withdrawal is submitted
+type RebalanceProposal struct {
+	ID uuid.UUID
+	rebalance.Transfer
+	Address      string
+	Status       string
+	Reason       string
+	WithdrawalID string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type rebalanceManager struct {
+	started   int32
+	stopped   int32
+	shutdown  chan struct{}
+	m         sync.Mutex
+	proposals map[uuid.UUID]*RebalanceProposal
+}
+
+func (r *rebalanceManager) Started() bool {
+	return atomic.LoadInt32(&r.started) == 1
+}
+
+func (r *rebalanceManager) Start() error {
+	if atomic.AddInt32(&r.started, 1) != 1 {
+		return errors.New("rebalance manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Rebalance manager starting...")
+	r.shutdown = make(chan struct{})
+	go r.run()
+	return nil
+}
+
+func (r *rebalanceManager) Stop() error {
+	if atomic.LoadInt32(&r.started) == 0 {
+		return errors.New("rebalance manager not started")
+	}
+	if atomic.AddInt32(&r.stopped, 1) != 1 {
+		return errors.New("rebalance manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Rebalance manager shutting down...")
+	close(r.shutdown)
+	return nil
+}
+
+func (r *rebalanceManager) run() {
+	log.Debugln(log.PortfolioMgr, "Rebalance manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.Rebalancer.CheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&r.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&r.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Rebalance manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-r.shutdown:
+			return
+		case <-tick.C:
+			_, err := r.Plan()
+			if err != nil {
+				log.Errorf(log.PortfolioMgr, "Rebalance manager plan error: %v\n", err)
+			}
+		}
+	}
+}
+
+// Plan computes transfers from the current free exchange balances towards the
+// configured targets, counting approved transfers which have not settled as
+// already moved. Pending proposals are kept while the planned transfer is
+// unchanged and superseded when it changes
+func (r *rebalanceManager) Plan() ([]RebalanceProposal, error) {
+	targets := Bot.Config.Rebalancer.Targets
+	err := rebalance.ValidateTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := append(rebalanceBalances(targets), r.inFlight()...)
+	transfers, err := rebalance.Plan(targets, balances, rebalanceWithdrawalFee)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	planned := make([]RebalanceProposal, 0, len(transfers))
+	for i := range transfers {
+		p := RebalanceProposal{
+			Transfer:  transfers[i],
+			Status:    RebalanceStatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		p.Address, err = rebalanceDepositAddress(transfers[i].To, transfers[i].Currency)
+		if err != nil {
+			p.Status = RebalanceStatusBlocked
+			p.Reason = err.Error()
+		}
+		planned = append(planned, p)
+	}
+
+	r.m.Lock()
+	if r.proposals == nil {
+		r.proposals = make(map[uuid.UUID]*RebalanceProposal)
+	}
+	kept := make(map[uuid.UUID]bool)
+	var proposals, added []RebalanceProposal
+	for i := range planned {
+		if existing := r.matchingProposal(&planned[i], kept); existing != nil {
+			kept[existing.ID] = true
+			proposals = append(proposals, *existing)
+			continue
+		}
+		planned[i].ID, err = uuid.NewV4()
+		if err != nil {
+			r.m.Unlock()
+			return nil, err
+		}
+		p := planned[i]
+		r.proposals[p.ID] = &p
+		kept[p.ID] = true
+		proposals = append(proposals, p)
+		added = append(added, p)
+	}
+	for id, p := range r.proposals {
+		switch {
+		case (p.Status == RebalanceStatusPending || p.Status == RebalanceStatusBlocked) && !kept[id]:
+			p.Status = RebalanceStatusSuperseded
+			p.UpdatedAt = now
+		case rebalanceProposalFinal(p.Status) && now.Sub(p.UpdatedAt) > rebalanceProposalRetention:
+			delete(r.proposals, id)
+		}
+	}
+	r.m.Unlock()
+
+	for i := range added {
+		msg := fmt.Sprintf("Rebalance proposal %s: %f %s from %s to %s (fee %f) %s",
+			added[i].ID,
+			added[i].Amount,
+			added[i].Currency,
+			added[i].From,
+			added[i].To,
+			added[i].Fee,
+			added[i].Status)
+		if added[i].Reason != "" {
+			msg += ": " + added[i].Reason
+		}
+		log.Infoln(log.PortfolioMgr, msg)
+		Bot.CommsManager.PushEvent(base.Event{Type: "rebalance", Severity: rebalanceSeverity(added[i].Status), Message: msg})
+	}
+	return proposals, nil
+}
+
+// matchingProposal returns the pending or blocked proposal which plans the
+// same transfer with the same outcome, r.m must be held
+func (r *rebalanceManager) matchingProposal(p *RebalanceProposal, kept map[uuid.UUID]bool) *RebalanceProposal {
+	for id, existing := range r.proposals {
+		if kept[id] ||
+			existing.Status != p.Status ||
+			existing.Reason != p.Reason ||
+			existing.Address != p.Address ||
+			existing.From != p.From ||
+			existing.To != p.To ||
+			!existing.Currency.Match(p.Currency) ||
+			math.Abs(existing.Amount-p.Amount) > rebalanceAmountTolerance ||
+			math.Abs(existing.Fee-p.Fee) > rebalanceAmountTolerance {
+			continue
+		}
+		return existing
+	}
+	return nil
+}
+
+// rebalanceSeverity returns the severity of a new proposal, blocked proposals
+// need attention before they can be approved
+func rebalanceSeverity(status string) base.Severity {
+	if status == RebalanceStatusBlocked {
+		return base.SeverityWarning
+	}
+	return base.SeverityTrade
+}
+
+// rebalanceProposalFinal returns whether a proposal status can no longer
+// change
+func rebalanceProposalFinal(status string) bool {
+	switch status {
+	case RebalanceStatusSettled,
+		RebalanceStatusRejected,
+		RebalanceStatusFailed,
+		RebalanceStatusSuperseded:
+		return true
+	}
+	return false
+}
+
+// inFlight returns balance adjustments for approved transfers which have not
+// settled so they are not planned again. Funds of a withdrawal the exchange
+// has not completed are removed from the source and counted at the
+// destination, once completed they are counted at the destination until the
+// matching deposit is stored. Transfers which settled or failed are marked
+// as such
+func (r *rebalanceManager) inFlight() []rebalance.Balance {
+	r.m.Lock()
+	var open []RebalanceProposal
+	for _, p := range r.proposals {
+		if p.Status == RebalanceStatusSubmitting || p.Status == RebalanceStatusApproved {
+			open = append(open, *p)
+		}
+	}
+	r.m.Unlock()
+
+	now := time.Now()
+	var balances []rebalance.Balance
+	settled := make(map[uuid.UUID]string)
+	for i := range open {
+		source, destination := true, true
+		if open[i].WithdrawalID != "" {
+			var status string
+			source, destination, status = rebalanceTransferState(&open[i], now)
+			if status != "" {
+				settled[open[i].ID] = status
+				continue
+			}
+		}
+		if source {
+			balances = append(balances, rebalance.Balance{
+				Exchange: open[i].From,
+				Currency: open[i].Currency,
+				Amount:   -open[i].Amount,
+			})
+		}
+		if destination {
+			balances = append(balances, rebalance.Balance{
+				Exchange: open[i].To,
+				Currency: open[i].Currency,
+				Amount:   open[i].Received(),
+			})
+		}
+	}
+
+	if len(settled) > 0 {
+		r.m.Lock()
+		for id, status := range settled {
+			if p, ok := r.proposals[id]; ok && p.Status == RebalanceStatusApproved {
+				p.Status = status
+				if status == RebalanceStatusFailed {
+					p.Reason = "withdrawal failed"
+				}
+				p.UpdatedAt = now
+			}
+		}
+		r.m.Unlock()
+	}
+	return balances
+}
+
+// rebalanceTransferState returns whether the funds of an approved transfer
+// are still held at the source exchange and still to be credited at the
+// destination, using the tracked withdrawal status and the deposit matched to
+// the withdrawal. status is set once the transfer has settled or failed. A
+// transfer which cannot be confirmed is settled after rebalanceSettleTimeout
+func rebalanceTransferState(p *RebalanceProposal, now time.Time) (source, destination bool, status string) {
+	expired := now.Sub(p.UpdatedAt) > rebalanceSettleTimeout
+	resp, err := WithdrawalEventByID(p.WithdrawalID)
+	if err != nil {
+		// Unknown, e.g. a dry run withdrawal or the database is disabled
+		if expired {
+			return false, false, RebalanceStatusSettled
+		}
+		return true, true, ""
+	}
+	switch withdraw.Lifecycle(resp.Exchange.Status) {
+	case withdraw.LifecycleFailed:
+		return false, false, RebalanceStatusFailed
+	case withdraw.LifecyclePending:
+		return true, true, ""
+	}
+	if _, err = deposit.GetByWithdrawalID(p.WithdrawalID); err == nil || expired {
+		return false, false, RebalanceStatusSettled
+	}
+	return false, true, ""
+}
+
+// Proposals returns all rebalance proposals, optionally filtered by status,
+// ordered by creation time
+func (r *rebalanceManager) Proposals(status string) []RebalanceProposal {
+	r.m.Lock()
+	defer r.m.Unlock()
+	var resp []RebalanceProposal
+	for _, p := range r.proposals {
+		if status != "" && !strings.EqualFold(p.Status, status) {
+			continue
+		}
+		resp = append(resp, *p)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].CreatedAt.Before(resp[j].CreatedAt)
+	})
+	return resp
+}
+
+// Approve submits the withdrawal for a pending rebalance proposal. The
+// proposal is marked as submitting while the withdrawal is sent so the
+// manager is not locked during the exchange request
+func (r *rebalanceManager) Approve(id string) (*RebalanceProposal, error) {
+	if Bot.Config.Rebalancer.DryRun {
+		return nil, errRebalancerDryRun
+	}
+	r.m.Lock()
+	p, err := r.pendingProposal(id)
+	if err != nil {
+		r.m.Unlock()
+		return nil, err
+	}
+	p.UpdatedAt = time.Now()
+	// The whitelist may have changed since the proposal was made
+	if !portfolio.IsWhiteListed(p.Address) {
+		p.Status = RebalanceStatusBlocked
+		p.Reason = errDepositAddressNotWhitelisted.Error()
+		r.m.Unlock()
+		return nil, fmt.Errorf("%s %w", p.Address, errDepositAddressNotWhitelisted)
+	}
+	p.Status = RebalanceStatusSubmitting
+	submit := *p
+	r.m.Unlock()
+
+	resp, err := SubmitWithdrawal(&withdraw.Request{
+		Exchange:    submit.From,
+		Currency:    submit.Currency,
+		Description: "rebalance transfer to " + submit.To,
+		Amount:      submit.Amount,
+		Type:        withdraw.Crypto,
+		Crypto: withdraw.CryptoRequest{
+			Address:   submit.Address,
+			FeeAmount: submit.Fee,
+		},
+	})
+	if err == nil && resp.Exchange.ID == StatusError {
+		err = errors.New(resp.Exchange.Status)
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	p.UpdatedAt = time.Now()
+	if err != nil {
+		p.Status = RebalanceStatusFailed
+		p.Reason = err.Error()
+		return nil, err
+	}
+	p.Status = RebalanceStatusApproved
+	p.WithdrawalID = resp.ID.String()
+	cpy := *p
+	return &cpy, nil
+}
+
+// Reject discards a pending rebalance proposal
+func (r *rebalanceManager) Reject(id string) (*RebalanceProposal, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	p, err := r.pendingProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	p.Status = RebalanceStatusRejected
+	p.UpdatedAt = time.Now()
+	cpy := *p
+	return &cpy, nil
+}
+
+// pendingProposal returns a pending proposal by ID, r.m must be held
+func (r *rebalanceManager) pendingProposal(id string) (*RebalanceProposal, error) {
+	u, err := uuid.FromString(id)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := r.proposals[u]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", id, errRebalanceProposalNotFound)
+	}
+	if p.Status != RebalanceStatusPending {
+		return nil, fmt.Errorf("%s %w, status %s", id, errRebalanceProposalNotPending, p.Status)
+	}
+	return p, nil
+}
+
+// rebalanceBalances returns the free balances of the stored account holdings
+// of every exchange referenced by the targets, summed across sub accounts
+func rebalanceBalances(targets []rebalance.Target) []rebalance.Balance {
+	var balances []rebalance.Balance
+	seen := make(map[string]bool)
+	for i := range targets {
+		for j := range targets[i].Allocations {
+			name := strings.ToLower(targets[i].Allocations[j].Exchange)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			h, err := account.GetHoldings(name)
+			if err != nil {
+				log.Warnf(log.PortfolioMgr,
+					"Rebalance manager: no account holdings for %s: %v\n",
+					name,
+					err)
+				continue
+			}
+			for k := range h.Accounts {
+				for l := range h.Accounts[k].Currencies {
+					// Funds on hold for open orders cannot be withdrawn
+					balances = append(balances, rebalance.Balance{
+						Exchange: name,
+						Currency: h.Accounts[k].Currencies[l].CurrencyName,
+						Amount: h.Accounts[k].Currencies[l].TotalValue -
+							h.Accounts[k].Currencies[l].Hold,
+					})
+				}
+			}
+		}
+	}
+	return balances
+}
+
+// rebalanceWithdrawalFee returns the withdrawal fee of an exchange, treating
+// an unknown fee as zero
+func rebalanceWithdrawalFee(exchName string, c currency.Code, amount float64) (float64, error) {
+	exch := Bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return 0, fmt.Errorf("%s %w", exchName, ErrExchangeNotFound)
+	}
+	fee, err := exch.GetFeeByType(&exchange.FeeBuilder{
+		FeeType: exchange.CryptocurrencyWithdrawalFee,
+		Pair:    currency.Pair{Base: c},
+		Amount:  amount,
+	})
+	if err != nil {
+		log.Warnf(log.PortfolioMgr,
+			"Rebalance manager: unable to get %s %s withdrawal fee, assuming zero: %v\n",
+			exchName,
+			c,
+			err)
+		return 0, nil
+	}
+	return fee, nil
+}
+
+// rebalanceDepositAddress returns the deposit address of the destination
+// exchange, which must be whitelisted in the portfolio
+func rebalanceDepositAddress(exchName string, c currency.Code) (string, error) {
+	exch := Bot.GetExchangeByName(exchName)
+	if exch == nil {
+		return "", fmt.Errorf("%s %w", exchName, ErrExchangeNotFound)
+	}
+	address, err := exch.GetDepositAddress(c, "")
+	if err != nil {
+		return "", err
+	}
+	if address == "" {
+		return "", fmt.Errorf("%s returned an empty %s deposit address", exchName, c)
+	}
+	if !portfolio.IsWhiteListed(address) {
+		return address, fmt.Errorf("%s %w", address, errDepositAddressNotWhitelisted)
+	}
+	return address, nil
+}
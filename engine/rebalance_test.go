@@ -0,0 +1,245 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/rebalance"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+func setupRebalanceTest(t *testing.T) func() {
+	SetupTestHelpers(t)
+	oldConfig := Bot.Config.Rebalancer
+	oldDryRun := Bot.Settings.EnableDryRun
+	oldPortfolio := portfolio.Portfolio.Addresses
+
+	Bot.Config.Rebalancer = config.RebalancerConfig{
+		Targets: []rebalance.Target{
+			{
+				Currency: currency.BTC,
+				Allocations: []rebalance.Allocation{
+					{Exchange: testExchange, Weight: 1},
+					{Exchange: fakePassExchange, Weight: 1},
+				},
+			},
+		},
+	}
+	Bot.Settings.EnableDryRun = true
+	portfolio.Portfolio.Addresses = nil
+
+	for _, h := range []account.Holdings{
+		{
+			Exchange: testExchange,
+			Accounts: []account.SubAccount{
+				{Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 10}}},
+			},
+		},
+		{
+			Exchange: fakePassExchange,
+			Accounts: []account.SubAccount{
+				{Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 2}}},
+			},
+		},
+	} {
+		h := h
+		err := account.Process(&h)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return func() {
+		Bot.Config.Rebalancer = oldConfig
+		Bot.Settings.EnableDryRun = oldDryRun
+		portfolio.Portfolio.Addresses = oldPortfolio
+	}
+}
+
+func TestRebalancePlan(t *testing.T) {
+	defer setupRebalanceTest(t)()
+	var r rebalanceManager
+
+	proposals, err := r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 {
+		t.Fatalf("expected 1 proposal received %v", len(proposals))
+	}
+	if proposals[0].From != "bitstamp" ||
+		proposals[0].To != "fakepassexchange" ||
+		proposals[0].Amount != 4 {
+		t.Errorf("unexpected proposal %+v", proposals[0])
+	}
+	if proposals[0].Status != RebalanceStatusBlocked {
+		t.Errorf("expected non whitelisted address to be blocked, received %v", proposals[0].Status)
+	}
+	blocked := proposals[0].ID
+
+	proposals, err = r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 || proposals[0].ID != blocked {
+		t.Errorf("expected unchanged proposal %v to be kept, received %+v", blocked, proposals)
+	}
+	if len(r.Proposals("")) != 1 {
+		t.Error("expected an unchanged plan not to add proposals")
+	}
+
+	portfolio.Portfolio.Addresses = append(portfolio.Portfolio.Addresses, portfolio.Address{
+		Address:     fakeDepositAddress,
+		CoinType:    currency.BTC,
+		WhiteListed: true,
+	})
+	proposals, err = r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 || proposals[0].Status != RebalanceStatusPending {
+		t.Fatalf("expected 1 pending proposal received %+v", proposals)
+	}
+	if proposals[0].Address != fakeDepositAddress {
+		t.Errorf("expected %v received %v", fakeDepositAddress, proposals[0].Address)
+	}
+	if len(r.Proposals(RebalanceStatusSuperseded)) != 1 {
+		t.Error("expected previous proposal to be superseded")
+	}
+	if len(r.Proposals("")) != 2 {
+		t.Error("expected 2 proposals in total")
+	}
+}
+
+func TestRebalanceApproveReject(t *testing.T) {
+	defer setupRebalanceTest(t)()
+	portfolio.Portfolio.Addresses = append(portfolio.Portfolio.Addresses, portfolio.Address{
+		Address:     fakeDepositAddress,
+		CoinType:    currency.BTC,
+		WhiteListed: true,
+	})
+	var r rebalanceManager
+	proposals, err := r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 {
+		t.Fatalf("expected 1 proposal received %v", len(proposals))
+	}
+	id := proposals[0].ID.String()
+
+	_, err = r.Approve("00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, errRebalanceProposalNotFound) {
+		t.Errorf("expected %v received %v", errRebalanceProposalNotFound, err)
+	}
+
+	Bot.Config.Rebalancer.DryRun = true
+	_, err = r.Approve(id)
+	if !errors.Is(err, errRebalancerDryRun) {
+		t.Errorf("expected %v received %v", errRebalancerDryRun, err)
+	}
+	Bot.Config.Rebalancer.DryRun = false
+
+	p, err := r.Approve(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != RebalanceStatusApproved || p.WithdrawalID != withdraw.DryRunID.String() {
+		t.Errorf("unexpected approved proposal %+v", p)
+	}
+
+	_, err = r.Reject(id)
+	if !errors.Is(err, errRebalanceProposalNotPending) {
+		t.Errorf("expected %v received %v", errRebalanceProposalNotPending, err)
+	}
+
+	// Forget the approved transfer so the same shortfall is planned again
+	r.proposals = nil
+	proposals, err = r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err = r.Reject(proposals[0].ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != RebalanceStatusRejected {
+		t.Errorf("expected %v received %v", RebalanceStatusRejected, p.Status)
+	}
+}
+
+func TestRebalancePlanInFlight(t *testing.T) {
+	defer setupRebalanceTest(t)()
+	portfolio.Portfolio.Addresses = append(portfolio.Portfolio.Addresses, portfolio.Address{
+		Address:     fakeDepositAddress,
+		CoinType:    currency.BTC,
+		WhiteListed: true,
+	})
+	var r rebalanceManager
+	proposals, err := r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 {
+		t.Fatalf("expected 1 proposal received %v", len(proposals))
+	}
+	_, err = r.Approve(proposals[0].ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposals, err = r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 0 {
+		t.Errorf("expected the approved transfer not to be proposed again, received %+v", proposals)
+	}
+	if len(r.Proposals(RebalanceStatusApproved)) != 1 {
+		t.Error("expected the approved transfer to remain in flight")
+	}
+}
+
+func TestRebalancePlanFreeBalance(t *testing.T) {
+	defer setupRebalanceTest(t)()
+	err := account.Process(&account.Holdings{
+		Exchange: testExchange,
+		Accounts: []account.SubAccount{
+			{Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 10, Hold: 6}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r rebalanceManager
+	proposals, err := r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 || proposals[0].Amount != 1 {
+		t.Errorf("expected 1 BTC to be proposed from the free balance, received %+v", proposals)
+	}
+}
+
+func TestRebalancePlanPrune(t *testing.T) {
+	defer setupRebalanceTest(t)()
+	var r rebalanceManager
+	old := &RebalanceProposal{
+		Status:    RebalanceStatusSuperseded,
+		UpdatedAt: time.Now().Add(-rebalanceProposalRetention * 2),
+	}
+	r.proposals = map[uuid.UUID]*RebalanceProposal{old.ID: old}
+	_, err := r.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.proposals[old.ID]; ok {
+		t.Error("expected old superseded proposal to be pruned")
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+)
+
+var (
+	errAuthorizationMissing = errors.New("authorization header missing")
+	errAuthorizationInvalid = errors.New("invalid authorization header")
+	errCredentialsMismatch  = errors.New("username/password mismatch")
+	errAPITokenInvalid      = errors.New("invalid API token")
+)
+
+// secureCompare compares secrets in constant time
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// identityByPassword returns the remote control identity for the username,
+// match is called with the configured password to verify it
+func (bot *Engine) identityByPassword(username string, match func(password string) bool) (auth.Identity, error) {
+	rc := bot.Config.GetRemoteControlConfig()
+	if username == rc.Username && rc.Password != "" && match(rc.Password) {
+		return auth.Identity{Name: rc.Username, Role: auth.RoleAdmin}, nil
+	}
+	for i := range rc.Users {
+		if rc.Users[i].Username != username || rc.Users[i].Password == "" {
+			continue
+		}
+		if match(rc.Users[i].Password) {
+			return auth.Identity{Name: rc.Users[i].Username, Role: rc.Users[i].Role}, nil
+		}
+		break
+	}
+	return auth.Identity{}, errCredentialsMismatch
+}
+
+// identityByToken returns the remote control identity owning the API token
+func (bot *Engine) identityByToken(token string) (auth.Identity, error) {
+	if token == "" {
+		return auth.Identity{}, errAPITokenInvalid
+	}
+	users := bot.Config.GetRemoteControlConfig().Users
+	for i := range users {
+		if users[i].APIToken != "" && secureCompare(users[i].APIToken, token) {
+			return auth.Identity{Name: users[i].Username, Role: users[i].Role}, nil
+		}
+	}
+	return auth.Identity{}, errAPITokenInvalid
+}
+
+// identityFromAuthorization returns the identity of a Basic or Bearer
+// authorization header value
+func (bot *Engine) identityFromAuthorization(header string) (auth.Identity, error) {
+	if header == "" {
+		return auth.Identity{}, errAuthorizationMissing
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 {
+		return auth.Identity{}, errAuthorizationInvalid
+	}
+	switch strings.ToLower(parts[0]) {
+	case "basic":
+		decoded, err := crypto.Base64Decode(parts[1])
+		if err != nil {
+			return auth.Identity{}, errAuthorizationInvalid
+		}
+		creds := strings.SplitN(string(decoded), ":", 2)
+		if len(creds) != 2 {
+			return auth.Identity{}, errAuthorizationInvalid
+		}
+		return bot.identityByPassword(creds[0], func(password string) bool {
+			return secureCompare(password, creds[1])
+		})
+	case "bearer":
+		return bot.identityByToken(strings.TrimSpace(parts[1]))
+	}
+	return auth.Identity{}, errAuthorizationInvalid
+}
+
+// auditRemoteAccess records a remote control call made by an identity
+func auditRemoteAccess(identity auth.Identity, service, action string, err error) {
+	msg := fmt.Sprintf("%s %s by %s", service, action, identity)
+	if err != nil {
+		msg += " denied: " + err.Error()
+	}
+	audit.Event(identity.Name, audit.RemoteAccessEvent, msg)
+}
+
+// auditLogin records a remote control authentication attempt for the user,
+// the username is unknown when the credentials could not be parsed
+func auditLogin(username, service string, err error) {
+	if username == "" {
+		username = "unknown user"
+	}
+	msg := fmt.Sprintf("%s login by %s", service, username)
+	if err != nil {
+		msg += " failed: " + err.Error()
+	}
+	audit.Event(username, audit.LoginEvent, msg)
+}
+
+// permissionDeniedError returns the error for an identity lacking a permission
+func permissionDeniedError(identity auth.Identity, p auth.Permission) error {
+	return fmt.Errorf("%s lacks %s permission", identity, p)
+}
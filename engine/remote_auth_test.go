@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func remoteAuthTestEngine() *Engine {
+	return &Engine{Config: &config.Config{
+		RemoteControl: config.RemoteControlConfig{
+			Username: "admin",
+			Password: "adminpw",
+			Users: []config.RemoteControlUser{
+				{Username: "reader", Password: "readerpw", Role: auth.RoleReadOnly},
+				{Username: "bot", APIToken: "bottoken", Role: auth.RoleTrader},
+			},
+		},
+	}}
+}
+
+func basicAuthorization(username, password string) string {
+	return "Basic " + crypto.Base64Encode([]byte(username+":"+password))
+}
+
+func TestIdentityFromAuthorization(t *testing.T) {
+	e := remoteAuthTestEngine()
+
+	identity, err := e.identityFromAuthorization(basicAuthorization("admin", "adminpw"))
+	if err != nil || identity.Role != auth.RoleAdmin {
+		t.Errorf("unexpected identity %v error %v", identity, err)
+	}
+	identity, err = e.identityFromAuthorization(basicAuthorization("reader", "readerpw"))
+	if err != nil || identity.Name != "reader" || identity.Role != auth.RoleReadOnly {
+		t.Errorf("unexpected identity %v error %v", identity, err)
+	}
+	identity, err = e.identityFromAuthorization("Bearer bottoken")
+	if err != nil || identity.Name != "bot" || identity.Role != auth.RoleTrader {
+		t.Errorf("unexpected identity %v error %v", identity, err)
+	}
+
+	if _, err = e.identityFromAuthorization(basicAuthorization("reader", "adminpw")); err != errCredentialsMismatch {
+		t.Errorf("expected %v, received %v", errCredentialsMismatch, err)
+	}
+	if _, err = e.identityFromAuthorization(basicAuthorization("bot", "")); err != errCredentialsMismatch {
+		t.Errorf("expected %v, received %v", errCredentialsMismatch, err)
+	}
+	if _, err = e.identityFromAuthorization("Bearer wrong"); err != errAPITokenInvalid {
+		t.Errorf("expected %v, received %v", errAPITokenInvalid, err)
+	}
+	if _, err = e.identityFromAuthorization(""); err != errAuthorizationMissing {
+		t.Errorf("expected %v, received %v", errAuthorizationMissing, err)
+	}
+	if _, err = e.identityFromAuthorization("Digest abc"); err != errAuthorizationInvalid {
+		t.Errorf("expected %v, received %v", errAuthorizationInvalid, err)
+	}
+}
+
+func TestAuthoriseClient(t *testing.T) {
+	e := remoteAuthTestEngine()
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", basicAuthorization("reader", "readerpw")))
+
+	ctx, err := e.authoriseClient(ctx, "/gctrpc.GoCryptoTrader/GetInfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity, ok := auth.FromContext(ctx); !ok || identity.Name != "reader" {
+		t.Errorf("unexpected identity %v", identity)
+	}
+
+	_, err = e.authoriseClient(ctx, "/gctrpc.GoCryptoTrader/SubmitOrder")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected permission denied, received %v", err)
+	}
+	_, err = e.authoriseClient(context.Background(), "/gctrpc.GoCryptoTrader/GetInfo")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected unauthenticated, received %v", err)
+	}
+}
+
+func TestRESTAuth(t *testing.T) {
+	e := remoteAuthTestEngine()
+	handler := e.restAuth(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "GetAllSettings", auth.PermissionAdmin)
+
+	for authorization, expected := range map[string]int{
+		"":                                       http.StatusUnauthorized,
+		basicAuthorization("reader", "readerpw"): http.StatusForbidden,
+		basicAuthorization("admin", "adminpw"):   http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/config/all", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		if resp.Code != expected {
+			t.Errorf("%q expected status %d, received %d", authorization, expected, resp.Code)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -30,6 +31,28 @@ func RESTLogger(inner http.Handler, name string) http.Handler {
 	})
 }
 
+// restAuth rejects requests without a Basic or Bearer authorization header
+// for an identity granted the permission, every attempt is audited
+func (bot *Engine) restAuth(inner http.Handler, name string, p auth.Permission) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := bot.identityFromAuthorization(r.Header.Get("Authorization"))
+		if err != nil {
+			auditLogin(identity.Name, "REST", err)
+			w.Header().Set("WWW-Authenticate", `Basic realm="GoCryptoTrader"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !identity.Can(p) {
+			err = permissionDeniedError(identity, p)
+			auditRemoteAccess(identity, "REST", name, err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		auditRemoteAccess(identity, "REST", name, nil)
+		inner.ServeHTTP(w, r)
+	})
+}
+
 // StartRESTServer starts a REST server
 func StartRESTServer(bot *Engine) {
 	listenAddr := bot.Config.RemoteControl.DeprecatedRPC.ListenAddress
@@ -76,13 +99,13 @@ func newRouter(bot *Engine, isREST bool) *mux.Router {
 
 	if isREST {
 		routes = []Route{
-			{"", http.MethodGet, "/", getIndex},
-			{"GetAllSettings", http.MethodGet, "/config/all", RESTGetAllSettings},
-			{"SaveAllSettings", http.MethodPost, "/config/all/save", RESTSaveAllSettings},
-			{"AllEnabledAccountInfo", http.MethodGet, "/exchanges/enabled/accounts/all", RESTGetAllEnabledAccountInfo},
-			{"AllActiveExchangesAndCurrencies", http.MethodGet, "/exchanges/enabled/latest/all", RESTGetAllActiveTickers},
-			{"GetPortfolio", http.MethodGet, "/portfolio/all", RESTGetPortfolio},
-			{"AllActiveExchangesAndOrderbooks", http.MethodGet, "/exchanges/orderbook/latest/all", RESTGetAllActiveOrderbooks},
+			{"", http.MethodGet, "/", getIndex, 0},
+			{"GetAllSettings", http.MethodGet, "/config/all", RESTGetAllSettings, auth.PermissionAdmin},
+			{"SaveAllSettings", http.MethodPost, "/config/all/save", RESTSaveAllSettings, auth.PermissionAdmin},
+			{"AllEnabledAccountInfo", http.MethodGet, "/exchanges/enabled/accounts/all", RESTGetAllEnabledAccountInfo, auth.PermissionRead},
+			{"AllActiveExchangesAndCurrencies", http.MethodGet, "/exchanges/enabled/latest/all", RESTGetAllActiveTickers, auth.PermissionRead},
+			{"GetPortfolio", http.MethodGet, "/portfolio/all", RESTGetPortfolio, auth.PermissionRead},
+			{"AllActiveExchangesAndOrderbooks", http.MethodGet, "/exchanges/orderbook/latest/all", RESTGetAllActiveOrderbooks, auth.PermissionRead},
 		}
 
 		if bot.Config.Profiler.Enabled {
@@ -97,16 +120,20 @@ func newRouter(bot *Engine, isREST bool) *mux.Router {
 		}
 	} else {
 		routes = []Route{
-			{"ws", http.MethodGet, "/ws", WebsocketClientHandler},
+			{"ws", http.MethodGet, "/ws", WebsocketClientHandler, 0},
 		}
 	}
 
 	for _, route := range routes {
+		var handler http.Handler = route.HandlerFunc
+		if route.Permission != 0 {
+			handler = bot.restAuth(handler, route.Name, route.Permission)
+		}
 		router.
 			Methods(route.Method).
 			Path(route.Pattern).
 			Name(route.Name).
-			Handler(RESTLogger(route.HandlerFunc, route.Name)).
+			Handler(RESTLogger(handler, route.Name)).
 			Host(listenAddr)
 	}
 	return router
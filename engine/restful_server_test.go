@@ -66,6 +66,7 @@ func TestValidHostRequest(t *testing.T) {
 		t.Fatal(err)
 	}
 	req.Host = "localhost:9050"
+	req.SetBasicAuth(e.Config.RemoteControl.Username, e.Config.RemoteControl.Password)
 
 	resp := httptest.NewRecorder()
 	newRouter(e, true).ServeHTTP(resp, req)
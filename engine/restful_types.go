@@ -6,14 +6,17 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 )
 
-// Route is a sub type that holds the request routes
+// Route is a sub type that holds the request routes. Routes with a
+// permission require an authorised identity
 type Route struct {
 	Name        string
 	Method      string
 	Pattern     string
 	HandlerFunc http.HandlerFunc
+	Permission  auth.Permission
 }
 
 // AllEnabledExchangeOrderbooks holds the enabled exchange orderbooks
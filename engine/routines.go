@@ -9,6 +9,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
@@ -200,53 +201,60 @@ func (bot *Engine) WebsocketRoutine() {
 
 	exchanges := bot.GetExchanges()
 	for i := range exchanges {
-		go func(i int) {
-			if exchanges[i].SupportsWebsocket() {
-				if bot.Settings.Verbose {
-					log.Debugf(log.WebsocketMgr,
-						"Exchange %s websocket support: Yes Enabled: %v\n",
-						exchanges[i].GetName(),
-						common.IsEnabled(exchanges[i].IsWebsocketEnabled()),
-					)
-				}
+		go bot.connectExchangeWebsocket(exchanges[i])
+	}
+}
+
+// connectExchangeWebsocket starts the data receiver and connects the exchange
+// websocket when it is supported and enabled
+func (bot *Engine) connectExchangeWebsocket(exch exchange.IBotExchange) {
+	if !exch.SupportsWebsocket() {
+		if bot.Settings.Verbose {
+			log.Debugf(log.WebsocketMgr,
+				"Exchange %s websocket support: No\n",
+				exch.GetName(),
+			)
+		}
+		return
+	}
+
+	if bot.Settings.Verbose {
+		log.Debugf(log.WebsocketMgr,
+			"Exchange %s websocket support: Yes Enabled: %v\n",
+			exch.GetName(),
+			common.IsEnabled(exch.IsWebsocketEnabled()),
+		)
+	}
 
-				ws, err := exchanges[i].GetWebsocket()
-				if err != nil {
-					log.Errorf(
-						log.WebsocketMgr,
-						"Exchange %s GetWebsocket error: %s\n",
-						exchanges[i].GetName(),
-						err,
-					)
-					return
-				}
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		log.Errorf(
+			log.WebsocketMgr,
+			"Exchange %s GetWebsocket error: %s\n",
+			exch.GetName(),
+			err,
+		)
+		return
+	}
 
-				// Exchange sync manager might have already started ws
-				// service or is in the process of connecting, so check
-				if ws.IsConnected() || ws.IsConnecting() {
-					return
-				}
+	// Exchange sync manager might have already started ws
+	// service or is in the process of connecting, so check
+	if ws.IsConnected() || ws.IsConnecting() {
+		return
+	}
 
-				// Data handler routine
-				go bot.WebsocketDataReceiver(ws)
+	// Data handler routine
+	go bot.WebsocketDataReceiver(ws)
 
-				if ws.IsEnabled() {
-					err = ws.Connect()
-					if err != nil {
-						log.Errorf(log.WebsocketMgr, "%v\n", err)
-					}
-					err = ws.FlushChannels()
-					if err != nil {
-						log.Errorf(log.WebsocketMgr, "Failed to subscribe: %v\n", err)
-					}
-				}
-			} else if bot.Settings.Verbose {
-				log.Debugf(log.WebsocketMgr,
-					"Exchange %s websocket support: No\n",
-					exchanges[i].GetName(),
-				)
-			}
-		}(i)
+	if ws.IsEnabled() {
+		err = ws.Connect()
+		if err != nil {
+			log.Errorf(log.WebsocketMgr, "%v\n", err)
+		}
+		err = ws.FlushChannels()
+		if err != nil {
+			log.Errorf(log.WebsocketMgr, "Failed to subscribe: %v\n", err)
+		}
 	}
 }
 
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,28 +11,34 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
-	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/thrasher-corp/gocryptotrader/common"
-	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
 	"github.com/thrasher-corp/gocryptotrader/common/timeperiods"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/deposit"
 	exchangeDB "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	valuationDB "github.com/thrasher-corp/gocryptotrader/database/repository/valuation"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -42,11 +49,14 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/valuation"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -77,27 +87,54 @@ func (bot *Engine) authenticateClient(ctx context.Context) (context.Context, err
 	}
 
 	authStr, ok := md["authorization"]
-	if !ok {
-		return ctx, fmt.Errorf("authorization header missing")
+	if !ok || len(authStr) == 0 {
+		return ctx, errAuthorizationMissing
 	}
 
-	if !strings.Contains(authStr[0], "Basic") {
-		return ctx, fmt.Errorf("basic not found in authorization header")
+	identity, err := bot.identityFromAuthorization(authStr[0])
+	if err != nil {
+		return ctx, err
 	}
 
-	decoded, err := crypto.Base64Decode(strings.Split(authStr[0], " ")[1])
+	return auth.NewContext(ctx, identity), nil
+}
+
+// authoriseClient authenticates the client and checks its role permits the
+// gRPC method, every attempt is audited
+func (bot *Engine) authoriseClient(ctx context.Context, fullMethod string) (context.Context, error) {
+	ctx, err := bot.authenticateClient(ctx)
 	if err != nil {
-		return ctx, fmt.Errorf("unable to base64 decode authorization header")
+		auditLogin("", "gRPC", err)
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	username := strings.Split(string(decoded), ":")[0]
-	password := strings.Split(string(decoded), ":")[1]
+	identity, _ := auth.FromContext(ctx)
+	p := auth.MethodPermission(fullMethod)
+	if !identity.Can(p) {
+		err = permissionDeniedError(identity, p)
+		auditRemoteAccess(identity, "gRPC", fullMethod, err)
+		return ctx, status.Error(codes.PermissionDenied, err.Error())
+	}
+	auditRemoteAccess(identity, "gRPC", fullMethod, nil)
+	return ctx, nil
+}
 
-	if username != bot.Config.RemoteControl.Username || password != bot.Config.RemoteControl.Password {
-		return ctx, fmt.Errorf("username/password mismatch")
+func (bot *Engine) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := bot.authoriseClient(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
 	}
+	return handler(ctx, req)
+}
 
-	return ctx, nil
+func (bot *Engine) streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := bot.authoriseClient(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	wrapped := grpcmiddleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
 }
 
 // StartRPCServer starts a gRPC server with TLS auth
@@ -124,7 +161,8 @@ func StartRPCServer(engine *Engine) {
 
 	opts := []grpc.ServerOption{
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(engine.authenticateClient)),
+		grpc.UnaryInterceptor(engine.unaryAuthInterceptor),
+		grpc.StreamInterceptor(engine.streamAuthInterceptor),
 	}
 	server := grpc.NewServer(opts...)
 	s := RPCServer{Engine: engine}
@@ -156,12 +194,9 @@ func (s *RPCServer) StartRPCRESTProxy() {
 	}
 
 	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
-			Username: s.Config.RemoteControl.Username,
-			Password: s.Config.RemoteControl.Password,
-		}),
-	}
+	// The Authorization header of each proxied request is forwarded so that
+	// callers are authorised with their own identity
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	err = gctrpc.RegisterGoCryptoTraderHandlerFromEndpoint(context.Background(),
 		mux, s.Config.RemoteControl.GRPC.ListenAddress, opts)
 	if err != nil {
@@ -327,6 +362,25 @@ func (s *RPCServer) GetExchangeInfo(_ context.Context, r *gctrpc.GenericExchange
 			AvailablePairs: ps.Available.Join(),
 		}
 	}
+
+	perms := s.keyPermissions.get(exchCfg.Name)
+	accounts := make([]string, 0, len(perms))
+	for account := range perms {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	for i := range accounts {
+		p := perms[accounts[i]]
+		resp.KeyPermissions = append(resp.KeyPermissions, &gctrpc.KeyPermissions{
+			AccountId:    accounts[i],
+			Read:         p.Read.String(),
+			Trade:        p.Trade.String(),
+			Withdraw:     p.Withdraw.String(),
+			IpRestricted: p.IPRestricted.String(),
+			IpWhitelist:  p.IPWhitelist,
+			Probed:       p.Probed,
+		})
+	}
 	return resp, nil
 }
 
@@ -495,11 +549,20 @@ func (s *RPCServer) GetOrderbooks(_ context.Context, r *gctrpc.GetOrderbooksRequ
 	return &gctrpc.GetOrderbooksResponse{Orderbooks: orderbooks}, nil
 }
 
+// getExchangeAccount returns the loaded exchange instance for an account
+// selector, an empty selector returns the exchange's default instance
+func (s *RPCServer) getExchangeAccount(exchName, account string) (exchange.IBotExchange, error) {
+	if s.GetExchangeByName(exchName) == nil {
+		return nil, errExchangeNotLoaded
+	}
+	return s.GetExchangeAccount(exchName, account)
+}
+
 // GetAccountInfo returns an account balance for a specific exchange
 func (s *RPCServer) GetAccountInfo(_ context.Context, r *gctrpc.GetAccountInfoRequest) (*gctrpc.GetAccountInfoResponse, error) {
-	exch := s.GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errExchangeNotLoaded
+	exch, err := s.getExchangeAccount(r.Exchange, r.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := exch.FetchAccountInfo()
@@ -512,9 +575,9 @@ func (s *RPCServer) GetAccountInfo(_ context.Context, r *gctrpc.GetAccountInfoRe
 
 // UpdateAccountInfo forces an update of the account info
 func (s *RPCServer) UpdateAccountInfo(ctx context.Context, r *gctrpc.GetAccountInfoRequest) (*gctrpc.GetAccountInfoResponse, error) {
-	exch := s.GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errExchangeNotLoaded
+	exch, err := s.getExchangeAccount(r.Exchange, r.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := exch.UpdateAccountInfo()
@@ -540,7 +603,11 @@ func createAccountInfoRequest(h account.Holdings) (*gctrpc.GetAccountInfoRespons
 		accounts = append(accounts, &a)
 	}
 
-	return &gctrpc.GetAccountInfoResponse{Exchange: h.Exchange, Accounts: accounts}, nil
+	return &gctrpc.GetAccountInfoResponse{
+		Exchange:  h.Exchange,
+		Accounts:  accounts,
+		AccountId: h.Account,
+	}, nil
 }
 
 // GetAccountInfoStream streams an account balance for a specific exchange
@@ -788,9 +855,9 @@ func (s *RPCServer) GetForexRates(_ context.Context, r *gctrpc.GetForexRatesRequ
 // GetOrders returns all open orders, filtered by exchange, currency pair or
 // asset type
 func (s *RPCServer) GetOrders(_ context.Context, r *gctrpc.GetOrdersRequest) (*gctrpc.GetOrdersResponse, error) {
-	exch := s.GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errExchangeNotLoaded
+	exch, err := s.getExchangeAccount(r.Exchange, r.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := exch.GetActiveOrders(&order.GetOrdersRequest{
@@ -886,9 +953,9 @@ func (s *RPCServer) GetOrder(_ context.Context, r *gctrpc.GetOrderRequest) (*gct
 // SubmitOrder submits an order specified by exchange, currency pair and asset
 // type
 func (s *RPCServer) SubmitOrder(_ context.Context, r *gctrpc.SubmitOrderRequest) (*gctrpc.SubmitOrderResponse, error) {
-	exch := s.GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errExchangeNotLoaded
+	exch, err := s.getExchangeAccount(r.Exchange, r.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	p, err := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
@@ -908,6 +975,7 @@ func (s *RPCServer) SubmitOrder(_ context.Context, r *gctrpc.SubmitOrderRequest)
 		Amount:    r.Amount,
 		Price:     r.Price,
 		ClientID:  r.ClientId,
+		AccountID: exchangeAccountID(exch, r.AccountId),
 		Exchange:  r.Exchange,
 		AssetType: a,
 	}
@@ -1019,9 +1087,9 @@ func (s *RPCServer) WhaleBomb(_ context.Context, r *gctrpc.WhaleBombRequest) (*g
 // CancelOrder cancels an order specified by exchange, currency pair and asset
 // type
 func (s *RPCServer) CancelOrder(_ context.Context, r *gctrpc.CancelOrderRequest) (*gctrpc.GenericResponse, error) {
-	exch := s.GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errExchangeNotLoaded
+	exch, err := s.getExchangeAccount(r.Exchange, r.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	p, err := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
@@ -1035,7 +1103,7 @@ func (s *RPCServer) CancelOrder(_ context.Context, r *gctrpc.CancelOrderRequest)
 	}
 
 	err = exch.CancelOrder(&order.Cancel{
-		AccountID:     r.AccountId,
+		AccountID:     exchangeAccountID(exch, r.AccountId),
 		ID:            r.OrderId,
 		Side:          order.Side(r.Side),
 		WalletAddress: r.WalletAddress,
@@ -1184,6 +1252,7 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(_ context.Context, r *gctrpc.Wit
 
 	request := &withdraw.Request{
 		Exchange:    r.Exchange,
+		AccountID:   r.AccountId,
 		Amount:      r.Amount,
 		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
 		Type:        withdraw.Crypto,
@@ -1228,6 +1297,7 @@ func (s *RPCServer) WithdrawFiatFunds(_ context.Context, r *gctrpc.WithdrawFiatR
 
 	request := &withdraw.Request{
 		Exchange:    r.Exchange,
+		AccountID:   r.AccountId,
 		Amount:      r.Amount,
 		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
 		Type:        withdraw.Fiat,
@@ -1250,7 +1320,7 @@ func (s *RPCServer) WithdrawFiatFunds(_ context.Context, r *gctrpc.WithdrawFiatR
 
 // WithdrawalEventByID returns previous withdrawal request details
 func (s *RPCServer) WithdrawalEventByID(_ context.Context, r *gctrpc.WithdrawalEventByIDRequest) (*gctrpc.WithdrawalEventByIDResponse, error) {
-	if !s.Config.Database.Enabled {
+	if !s.Config.GetDatabaseConfig().Enabled {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
 	v, err := WithdrawalEventByID(r.Id)
@@ -1312,7 +1382,7 @@ func (s *RPCServer) WithdrawalEventByID(_ context.Context, r *gctrpc.WithdrawalE
 
 // WithdrawalEventsByExchange returns previous withdrawal request details by exchange
 func (s *RPCServer) WithdrawalEventsByExchange(_ context.Context, r *gctrpc.WithdrawalEventsByExchangeRequest) (*gctrpc.WithdrawalEventsByExchangeResponse, error) {
-	if !s.Config.Database.Enabled {
+	if !s.Config.GetDatabaseConfig().Enabled {
 		if r.Id == "" {
 			exch := s.GetExchangeByName(r.Exchange)
 			if exch == nil {
@@ -1743,7 +1813,7 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 	if err != nil {
 		return nil, err
 	}
-	events, err := audit.GetEvent(UTCStartTime, UTCEndTime, r.OrderBy, int(r.Limit))
+	events, err := audit.GetEvent(UTCStartTime, UTCEndTime, r.Type, r.OrderBy, int(r.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -1758,6 +1828,9 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 				Identifier: v[x].Identifier,
 				Message:    v[x].Message,
 				Timestamp:  v[x].CreatedAt.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+				Id:         v[x].ID,
+				PrevHash:   v[x].PrevHash,
+				Hash:       v[x].Hash,
 			}
 
 			resp.Events = append(resp.Events, tempEvent)
@@ -1769,6 +1842,9 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 				Identifier: v[x].Identifier,
 				Message:    v[x].Message,
 				Timestamp:  v[x].CreatedAt,
+				Id:         v[x].ID,
+				PrevHash:   v[x].PrevHash,
+				Hash:       v[x].Hash,
 			}
 			resp.Events = append(resp.Events, tempEvent)
 		}
@@ -1777,6 +1853,47 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 	return &resp, nil
 }
 
+// VerifyAuditEvents checks the hash chain of every audit event in database
+func (s *RPCServer) VerifyAuditEvents(_ context.Context, _ *gctrpc.VerifyAuditEventsRequest) (*gctrpc.VerifyAuditEventsResponse, error) {
+	v, err := audit.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.VerifyAuditEventsResponse{
+		Events:    int64(v.Events),
+		Unchained: int64(v.Unchained),
+		LastId:    v.LastID,
+		LastHash:  v.LastHash,
+	}, nil
+}
+
+// ExportAuditEvents returns the audit events created between the start and
+// end dates as JSON lines signed with the configured audit signing key
+func (s *RPCServer) ExportAuditEvents(_ context.Context, r *gctrpc.ExportAuditEventsRequest) (*gctrpc.ExportAuditEventsResponse, error) {
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	UTCEndTime, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	key, err := config.ResolveSecret(s.Config.GetDatabaseConfig().AuditSigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	events, err := audit.Export(&buf, []byte(key), UTCStartTime, UTCEndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.ExportAuditEventsResponse{
+		Events: int64(events),
+		Data:   buf.Bytes(),
+	}, nil
+}
+
 // GetHistoricCandles returns historical candles for a given exchange
 func (s *RPCServer) GetHistoricCandles(_ context.Context, r *gctrpc.GetHistoricCandlesRequest) (*gctrpc.GetHistoricCandlesResponse, error) {
 	if r.Exchange == "" {
@@ -2376,13 +2493,19 @@ func (s *RPCServer) WebsocketGetInfo(_ context.Context, r *gctrpc.WebsocketGetIn
 		return nil, err
 	}
 
+	stats := w.Orderbook.GetResyncStats()
 	return &gctrpc.WebsocketGetInfoResponse{
-		Exchange:      exch.GetName(),
-		Supported:     exch.SupportsWebsocket(),
-		Enabled:       exch.IsWebsocketEnabled(),
-		Authenticated: w.CanUseAuthenticatedEndpoints(),
-		RunningUrl:    w.GetWebsocketURL(),
-		ProxyAddress:  w.GetProxyAddress(),
+		Exchange:                  exch.GetName(),
+		Supported:                 exch.SupportsWebsocket(),
+		Enabled:                   exch.IsWebsocketEnabled(),
+		Authenticated:             w.CanUseAuthenticatedEndpoints(),
+		RunningUrl:                w.GetWebsocketURL(),
+		ProxyAddress:              w.GetProxyAddress(),
+		OrderbookSequenceGaps:     stats.Gaps,
+		OrderbookResyncs:          stats.Resyncs,
+		OrderbookResyncFailures:   stats.Failures,
+		OrderbookChecksumFailures: stats.ChecksumFailures,
+		Connections:               int64(w.GetConnectionCount()),
 	}, nil
 }
 
@@ -2443,10 +2566,11 @@ func (s *RPCServer) WebsocketGetSubscriptions(_ context.Context, r *gctrpc.Webso
 		}
 		payload.Subscriptions = append(payload.Subscriptions,
 			&gctrpc.WebsocketSubscription{
-				Channel:  subs[i].Channel,
-				Currency: subs[i].Currency.String(),
-				Asset:    subs[i].Asset.String(),
-				Params:   string(params),
+				Channel:    subs[i].Channel,
+				Currency:   subs[i].Currency.String(),
+				Asset:      subs[i].Asset.String(),
+				Params:     string(params),
+				Connection: int64(subs[i].Connection),
 			})
 	}
 	return payload, nil
@@ -2977,3 +3101,548 @@ func (s *RPCServer) GetRecentTrades(_ context.Context, r *gctrpc.GetSavedTradesR
 
 	return resp, nil
 }
+
+// getFuturesExchangeAndPair validates common futures request parameters and
+// returns the loaded exchange, asset and currency pair
+func (s *RPCServer) getFuturesExchangeAndPair(exchName, assetType string, p *gctrpc.CurrencyPair) (exchange.IBotExchange, asset.Item, currency.Pair, error) {
+	if exchName == "" || assetType == "" || p == nil || p.String() == "" {
+		return nil, "", currency.Pair{}, errInvalidArguments
+	}
+	exch := s.GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, "", currency.Pair{}, errExchangeNotLoaded
+	}
+	a, err := asset.New(assetType)
+	if err != nil {
+		return nil, "", currency.Pair{}, err
+	}
+	if !futures.IsFuturesAsset(a) {
+		return nil, "", currency.Pair{}, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	cp := currency.Pair{
+		Delimiter: p.Delimiter,
+		Base:      currency.NewCode(p.Base),
+		Quote:     currency.NewCode(p.Quote),
+	}
+	return exch, a, cp, nil
+}
+
+// GetFundingRate returns the current funding rate for a perpetual contract
+func (s *RPCServer) GetFundingRate(_ context.Context, r *gctrpc.GetFuturesDataRequest) (*gctrpc.GetFundingRatesResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := exch.GetFundingRate(cp, a)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GetFundingRatesResponse{
+		Exchange:  exch.GetName(),
+		AssetType: a.String(),
+		Pair:      r.Pair,
+		Rates: []*gctrpc.FundingRate{
+			{
+				Time:          rate.Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+				Rate:          rate.Rate,
+				PredictedRate: rate.PredictedRate,
+			},
+		},
+	}, nil
+}
+
+// GetFundingRateHistory returns historic funding rates for a perpetual
+// contract from either the exchange or the database
+func (s *RPCServer) GetFundingRateHistory(_ context.Context, r *gctrpc.GetFundingRateHistoryRequest) (*gctrpc.GetFundingRatesResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
+	if err != nil {
+		return nil, err
+	}
+	UTCEndTime, err := time.Parse(common.SimpleTimeFormat, r.End)
+	if err != nil {
+		return nil, err
+	}
+	err = common.StartEndTimeCheck(UTCStartTime, UTCEndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []futures.FundingRate
+	if r.UseDb {
+		rates, err = futures.GetFundingRatesInRange(exch.GetName(), a, cp, UTCStartTime, UTCEndTime)
+	} else {
+		rates, err = exch.GetFundingRateHistory(cp, a, UTCStartTime, UTCEndTime)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Sync && !r.UseDb && len(rates) > 0 {
+		err = futures.SaveFundingRatesToDatabase(rates...)
+		if err != nil {
+			if errors.Is(err, exchangeDB.ErrNoExchangeFound) {
+				return nil, errors.New("exchange was not found in database, you can seed existing data or insert a new exchange via the dbseed")
+			}
+			return nil, err
+		}
+	}
+
+	resp := &gctrpc.GetFundingRatesResponse{
+		Exchange:  exch.GetName(),
+		AssetType: a.String(),
+		Pair:      r.Pair,
+	}
+	for i := range rates {
+		resp.Rates = append(resp.Rates, &gctrpc.FundingRate{
+			Time: rates[i].Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+			Rate: rates[i].Rate,
+		})
+	}
+	return resp, nil
+}
+
+// GetOpenInterest returns the open interest for a derivative contract
+func (s *RPCServer) GetOpenInterest(_ context.Context, r *gctrpc.GetFuturesDataRequest) (*gctrpc.GetOpenInterestResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	oi, err := exch.GetOpenInterest(cp, a)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GetOpenInterestResponse{
+		Exchange:  exch.GetName(),
+		AssetType: a.String(),
+		Pair:      r.Pair,
+		Amount:    oi.Amount,
+		Time:      oi.Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+	}, nil
+}
+
+// GetMarkPrice returns the mark and index price for a derivative contract
+func (s *RPCServer) GetMarkPrice(_ context.Context, r *gctrpc.GetFuturesDataRequest) (*gctrpc.GetMarkPriceResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := exch.GetMarkPrice(cp, a)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GetMarkPriceResponse{
+		Exchange:   exch.GetName(),
+		AssetType:  a.String(),
+		Pair:       r.Pair,
+		MarkPrice:  mp.MarkPrice,
+		IndexPrice: mp.IndexPrice,
+		Time:       mp.Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+	}, nil
+}
+
+// GetFuturesContracts returns the contract specifications for an exchanges
+// derivative asset type
+func (s *RPCServer) GetFuturesContracts(_ context.Context, r *gctrpc.GetFuturesContractsRequest) (*gctrpc.GetFuturesContractsResponse, error) {
+	if r.Exchange == "" || r.AssetType == "" {
+		return nil, errInvalidArguments
+	}
+	exch := s.GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errExchangeNotLoaded
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := exch.GetFuturesContracts(a)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetFuturesContractsResponse{
+		Exchange:  exch.GetName(),
+		AssetType: a.String(),
+	}
+	for i := range contracts {
+		c := &gctrpc.FuturesContract{
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: contracts[i].Pair.Delimiter,
+				Base:      contracts[i].Pair.Base.String(),
+				Quote:     contracts[i].Pair.Quote.String(),
+			},
+			Underlying:         contracts[i].Underlying,
+			Multiplier:         contracts[i].Multiplier,
+			SettlementCurrency: contracts[i].SettlementCurrency.String(),
+			IsInverse:          contracts[i].IsInverse,
+		}
+		if !contracts[i].IsPerpetual() {
+			c.Expiry = contracts[i].Expiry.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone)
+		}
+		resp.Contracts = append(resp.Contracts, c)
+	}
+	return resp, nil
+}
+
+// GetLeveragedPositions returns all open leveraged positions for an exchange
+// asset type
+func (s *RPCServer) GetLeveragedPositions(_ context.Context, r *gctrpc.GetLeveragedPositionsRequest) (*gctrpc.GetLeveragedPositionsResponse, error) {
+	if r.Exchange == "" || r.AssetType == "" {
+		return nil, errInvalidArguments
+	}
+	exch := s.GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errExchangeNotLoaded
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := exch.GetLeveragedPositions(a)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetLeveragedPositionsResponse{
+		Exchange:  exch.GetName(),
+		AssetType: a.String(),
+	}
+	for i := range positions {
+		resp.Positions = append(resp.Positions, &gctrpc.LeveragedPosition{
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: positions[i].Pair.Delimiter,
+				Base:      positions[i].Pair.Base.String(),
+				Quote:     positions[i].Pair.Quote.String(),
+			},
+			Side:              positions[i].Side.String(),
+			MarginType:        positions[i].MarginType.String(),
+			Size:              positions[i].Size,
+			EntryPrice:        positions[i].EntryPrice,
+			MarkPrice:         positions[i].MarkPrice,
+			LiquidationPrice:  positions[i].LiquidationPrice,
+			Leverage:          positions[i].Leverage,
+			Margin:            positions[i].Margin,
+			MaintenanceMargin: positions[i].MaintenanceMargin,
+			MarginCurrency:    positions[i].MarginCurrency.String(),
+			UnrealisedPnl:     positions[i].UnrealisedPNL,
+			RealisedPnl:       positions[i].RealisedPNL,
+			LastUpdated:       positions[i].LastUpdated.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+		})
+	}
+	return resp, nil
+}
+
+// SetLeverage sets the leverage used for a contract
+func (s *RPCServer) SetLeverage(_ context.Context, r *gctrpc.SetLeverageRequest) (*gctrpc.GenericResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	err = exch.SetLeverage(cp, a, r.Leverage)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess,
+		Data: fmt.Sprintf("%s %s %s leverage set to %v", exch.GetName(), a, cp, r.Leverage)}, nil
+}
+
+// SetMarginType sets whether a contract uses isolated or cross margin
+func (s *RPCServer) SetMarginType(_ context.Context, r *gctrpc.SetMarginTypeRequest) (*gctrpc.GenericResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	t, err := margin.StringToType(r.MarginType)
+	if err != nil {
+		return nil, err
+	}
+	err = exch.SetMarginType(cp, a, t)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess,
+		Data: fmt.Sprintf("%s %s %s margin type set to %s", exch.GetName(), a, cp, t)}, nil
+}
+
+// ModifyIsolatedMargin adds a positive amount to or removes a negative amount
+// from the margin allocated to an isolated position
+func (s *RPCServer) ModifyIsolatedMargin(_ context.Context, r *gctrpc.ModifyIsolatedMarginRequest) (*gctrpc.GenericResponse, error) {
+	exch, a, cp, err := s.getFuturesExchangeAndPair(r.Exchange, r.AssetType, r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	err = exch.ModifyIsolatedMargin(cp, a, r.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess,
+		Data: fmt.Sprintf("%s %s %s isolated margin modified by %v", exch.GetName(), a, cp, r.Amount)}, nil
+}
+
+// getPortfolioValuations parses a portfolio valuation request and returns the
+// stored valuations in range
+func (s *RPCServer) getPortfolioValuations(r *gctrpc.GetPortfolioValuationRequest) (currency.Code, []valuationDB.Data, error) {
+	fiat := s.Config.GetPortfolioValuationConfig().FiatCurrency
+	if r.FiatCurrency != "" {
+		fiat = currency.NewCode(r.FiatCurrency)
+	}
+	if !fiat.IsFiatCurrency() {
+		return fiat, nil, fmt.Errorf("%w %s is not a fiat currency", errInvalidArguments, fiat)
+	}
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
+	if err != nil {
+		return fiat, nil, err
+	}
+	UTCEndTime, err := time.Parse(common.SimpleTimeFormat, r.End)
+	if err != nil {
+		return fiat, nil, err
+	}
+	err = common.StartEndTimeCheck(UTCStartTime, UTCEndTime)
+	if err != nil {
+		return fiat, nil, err
+	}
+	data, err := valuationDB.GetInRange(fiat.String(), UTCStartTime, UTCEndTime)
+	return fiat, data, err
+}
+
+// GetPortfolioEquityCurve returns the total fiat value of the portfolio over
+// time from stored valuation snapshots
+func (s *RPCServer) GetPortfolioEquityCurve(_ context.Context, r *gctrpc.GetPortfolioValuationRequest) (*gctrpc.GetPortfolioEquityCurveResponse, error) {
+	fiat, data, err := s.getPortfolioValuations(r)
+	if err != nil {
+		return nil, err
+	}
+	curve := valuation.EquityCurve(data)
+	resp := &gctrpc.GetPortfolioEquityCurveResponse{
+		FiatCurrency: fiat.String(),
+	}
+	for i := range curve {
+		resp.Points = append(resp.Points, &gctrpc.PortfolioEquityPoint{
+			Timestamp: curve[i].Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+			Value:     curve[i].Value,
+		})
+	}
+	return resp, nil
+}
+
+// GetPortfolioAllocation returns the per currency allocation of each stored
+// valuation snapshot in range as a time series
+func (s *RPCServer) GetPortfolioAllocation(_ context.Context, r *gctrpc.GetPortfolioValuationRequest) (*gctrpc.GetPortfolioAllocationResponse, error) {
+	fiat, data, err := s.getPortfolioValuations(r)
+	if err != nil {
+		return nil, err
+	}
+	series, err := valuation.AllocationSeries(data)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPortfolioAllocationResponse{
+		FiatCurrency: fiat.String(),
+	}
+	for i := range series {
+		point := &gctrpc.PortfolioAllocationPoint{
+			Timestamp: series[i].Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+			Total:     series[i].Total,
+		}
+		for j := range series[i].Allocations {
+			point.Allocations = append(point.Allocations, &gctrpc.PortfolioAllocation{
+				Currency:   series[i].Allocations[j].Currency,
+				Amount:     series[i].Allocations[j].Amount,
+				Value:      series[i].Allocations[j].Value,
+				Percentage: series[i].Allocations[j].Percentage,
+			})
+		}
+		resp.Points = append(resp.Points, point)
+	}
+	return resp, nil
+}
+
+func rebalanceProposalToRPC(p *RebalanceProposal) *gctrpc.RebalanceProposal {
+	return &gctrpc.RebalanceProposal{
+		Id:           p.ID.String(),
+		FromExchange: p.From,
+		ToExchange:   p.To,
+		Currency:     p.Currency.String(),
+		Amount:       p.Amount,
+		Fee:          p.Fee,
+		Address:      p.Address,
+		Status:       p.Status,
+		Reason:       p.Reason,
+		WithdrawalId: p.WithdrawalID,
+		CreatedAt:    p.CreatedAt.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		UpdatedAt:    p.UpdatedAt.UTC().Format(common.SimpleTimeFormatWithTimezone),
+	}
+}
+
+// PlanRebalance computes new rebalance proposals from the current exchange
+// holdings, superseding any pending proposals
+func (s *RPCServer) PlanRebalance(_ context.Context, _ *gctrpc.PlanRebalanceRequest) (*gctrpc.RebalanceProposalsResponse, error) {
+	proposals, err := s.RebalanceManager.Plan()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.RebalanceProposalsResponse{DryRun: s.Config.GetRebalancerConfig().DryRun}
+	for i := range proposals {
+		resp.Proposals = append(resp.Proposals, rebalanceProposalToRPC(&proposals[i]))
+	}
+	return resp, nil
+}
+
+// GetRebalanceProposals returns rebalance proposals, optionally filtered by
+// status
+func (s *RPCServer) GetRebalanceProposals(_ context.Context, r *gctrpc.GetRebalanceProposalsRequest) (*gctrpc.RebalanceProposalsResponse, error) {
+	proposals := s.RebalanceManager.Proposals(r.Status)
+	resp := &gctrpc.RebalanceProposalsResponse{DryRun: s.Config.GetRebalancerConfig().DryRun}
+	for i := range proposals {
+		resp.Proposals = append(resp.Proposals, rebalanceProposalToRPC(&proposals[i]))
+	}
+	return resp, nil
+}
+
+// ApproveRebalanceProposal submits the withdrawal of a pending rebalance
+// proposal
+func (s *RPCServer) ApproveRebalanceProposal(_ context.Context, r *gctrpc.RebalanceProposalRequest) (*gctrpc.RebalanceProposal, error) {
+	p, err := s.RebalanceManager.Approve(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return rebalanceProposalToRPC(p), nil
+}
+
+// RejectRebalanceProposal discards a pending rebalance proposal
+func (s *RPCServer) RejectRebalanceProposal(_ context.Context, r *gctrpc.RebalanceProposalRequest) (*gctrpc.RebalanceProposal, error) {
+	p, err := s.RebalanceManager.Reject(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return rebalanceProposalToRPC(p), nil
+}
+
+// GetPendingWithdrawals returns all withdrawals awaiting operator approval
+func (s *RPCServer) GetPendingWithdrawals(_ context.Context, _ *gctrpc.GetPendingWithdrawalsRequest) (*gctrpc.GetPendingWithdrawalsResponse, error) {
+	pending := s.WithdrawalApprovalManager.Pending()
+	resp := &gctrpc.GetPendingWithdrawalsResponse{}
+	for i := range pending {
+		resp.Withdrawals = append(resp.Withdrawals, &gctrpc.PendingWithdrawal{
+			Event:             parseSingleEvents(&pending[i].Response).Event[0],
+			Approvers:         pending[i].Approvers,
+			RequiredApprovals: int64(pending[i].RequiredApprovals),
+			CreatedAt:         pending[i].CreatedAt.Format(common.SimpleTimeFormatWithTimezone),
+			ExpiresAt:         pending[i].ExpiresAt.Format(common.SimpleTimeFormatWithTimezone),
+		})
+	}
+	return resp, nil
+}
+
+// ApproveWithdrawal records an operator approval for a pending withdrawal,
+// submitting it to the exchange once enough approvals are received
+func (s *RPCServer) ApproveWithdrawal(_ context.Context, r *gctrpc.WithdrawalApprovalRequest) (*gctrpc.WithdrawalEventResponse, error) {
+	resp, err := s.WithdrawalApprovalManager.Approve(r.Id, r.Operator, r.Code)
+	if err != nil {
+		return nil, err
+	}
+	return parseSingleEvents(resp).Event[0], nil
+}
+
+// RejectWithdrawal discards a pending withdrawal
+func (s *RPCServer) RejectWithdrawal(_ context.Context, r *gctrpc.WithdrawalApprovalRequest) (*gctrpc.WithdrawalEventResponse, error) {
+	resp, err := s.WithdrawalApprovalManager.Reject(r.Id, r.Operator, r.Code)
+	if err != nil {
+		return nil, err
+	}
+	return parseSingleEvents(resp).Event[0], nil
+}
+
+// GetDepositHistory returns deposits detected by the deposit monitor between
+// the start and end dates
+func (s *RPCServer) GetDepositHistory(_ context.Context, r *gctrpc.GetDepositHistoryRequest) (*gctrpc.GetDepositHistoryResponse, error) {
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
+	if err != nil {
+		return nil, err
+	}
+	UTCEndTime, err := time.Parse(common.SimpleTimeFormat, r.End)
+	if err != nil {
+		return nil, err
+	}
+	err = common.StartEndTimeCheck(UTCStartTime, UTCEndTime)
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := deposit.GetInRange(r.Exchange, UTCStartTime, UTCEndTime)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetDepositHistoryResponse{}
+	for i := range deposits {
+		resp.Deposits = append(resp.Deposits, &gctrpc.DepositEvent{
+			Id:           deposits[i].ID,
+			Exchange:     deposits[i].Exchange,
+			ExchangeId:   deposits[i].ExchangeID,
+			Status:       deposits[i].Status,
+			Currency:     deposits[i].Currency,
+			Amount:       deposits[i].Amount,
+			Fee:          deposits[i].Fee,
+			Address:      deposits[i].Address,
+			TxId:         deposits[i].TxID,
+			WithdrawalId: deposits[i].WithdrawalID,
+			Timestamp:    deposits[i].Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+		})
+	}
+	return resp, nil
+}
+
+// GetBalanceAlertStream streams triggered balance alerts, optionally filtered
+// by exchange
+func (s *RPCServer) GetBalanceAlertStream(r *gctrpc.GetBalanceAlertStreamRequest, stream gctrpc.GoCryptoTrader_GetBalanceAlertStreamServer) error {
+	if r.Exchange != "" && s.GetExchangeByName(r.Exchange) == nil {
+		return errExchangeNotLoaded
+	}
+
+	alerts, err := s.BalanceAlertManager.Subscribe()
+	if err != nil {
+		return err
+	}
+	defer s.BalanceAlertManager.Unsubscribe(alerts)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case alert, ok := <-alerts:
+			if !ok {
+				return errBalanceAlertsNotStarted
+			}
+			if r.Exchange != "" && !strings.EqualFold(alert.Exchange, r.Exchange) {
+				continue
+			}
+			err = stream.Send(&gctrpc.BalanceAlertResponse{
+				Exchange:      alert.Exchange,
+				Currency:      alert.Currency.String(),
+				Rule:          alert.Rule,
+				Balance:       alert.Balance,
+				Threshold:     alert.Threshold,
+				ChangePercent: alert.ChangePercent,
+				Message:       alert.Message,
+				Timestamp:     alert.Time.Format(common.SimpleTimeFormatWithTimezone),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ReloadConfig re-reads the config file and applies the changes to the
+// running engine
+func (s *RPCServer) ReloadConfig(_ context.Context, _ *gctrpc.ReloadConfigRequest) (*gctrpc.ReloadConfigResponse, error) {
+	result, err := s.Engine.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.ReloadConfigResponse{
+		Applied:         result.Applied,
+		RestartRequired: result.RestartRequired,
+		Errors:          result.Errors,
+	}, nil
+}
@@ -17,10 +17,14 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/deposit"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	valuationDB "github.com/thrasher-corp/gocryptotrader/database/repository/valuation"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
@@ -73,6 +77,7 @@ func RPCTestSetup(t *testing.T) *Engine {
 	if err != nil {
 		t.Fatalf("failed to insert exchange %v", err)
 	}
+	exchange.ResetExchangeCache()
 	database.DB.Mu.Unlock()
 
 	return engerino
@@ -818,3 +823,312 @@ func TestUpdateAccountInfo(t *testing.T) {
 		t.Fatalf("TestGetAccountInfo: Unexpected value of the 'TotalValue'")
 	}
 }
+
+func TestGetFundingRate(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	_, err := s.GetFundingRate(context.Background(), &gctrpc.GetFuturesDataRequest{})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("expected %v received %v", errInvalidArguments, err)
+	}
+	pair := &gctrpc.CurrencyPair{
+		Delimiter: currency.DashDelimiter,
+		Base:      currency.BTC.String(),
+		Quote:     "PERP",
+	}
+	_, err = s.GetFundingRate(context.Background(), &gctrpc.GetFuturesDataRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Spot.String(),
+		Pair:      pair,
+	})
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+	resp, err := s.GetFundingRate(context.Background(), &gctrpc.GetFuturesDataRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair:      pair,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rates) != 1 || resp.Rates[0].PredictedRate != 0.0002 {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	pair := &gctrpc.CurrencyPair{
+		Delimiter: currency.DashDelimiter,
+		Base:      currency.BTC.String(),
+		Quote:     "PERP",
+	}
+	_, err := s.GetFundingRateHistory(context.Background(), &gctrpc.GetFundingRateHistoryRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair:      pair,
+		Start:     time.Now().UTC().Format(common.SimpleTimeFormat),
+		End:       time.Now().UTC().Add(-time.Hour).Format(common.SimpleTimeFormat),
+	})
+	if !errors.Is(err, common.ErrStartAfterEnd) {
+		t.Errorf("expected %v received %v", common.ErrStartAfterEnd, err)
+	}
+	resp, err := s.GetFundingRateHistory(context.Background(), &gctrpc.GetFundingRateHistoryRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair:      pair,
+		Start:     time.Now().UTC().Add(-time.Hour).Format(common.SimpleTimeFormat),
+		End:       time.Now().UTC().Format(common.SimpleTimeFormat),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rates) != 1 {
+		t.Errorf("expected 1 rate received %v", len(resp.Rates))
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	resp, err := s.GetOpenInterest(context.Background(), &gctrpc.GetFuturesDataRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     "PERP",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Amount != 1337 {
+		t.Errorf("expected 1337 received %v", resp.Amount)
+	}
+}
+
+func TestGetMarkPrice(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	resp, err := s.GetMarkPrice(context.Background(), &gctrpc.GetFuturesDataRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     "PERP",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.MarkPrice != 1337 || resp.IndexPrice != 1336 {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestGetFuturesContracts(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	_, err := s.GetFuturesContracts(context.Background(), &gctrpc.GetFuturesContractsRequest{})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("expected %v received %v", errInvalidArguments, err)
+	}
+	resp, err := s.GetFuturesContracts(context.Background(), &gctrpc.GetFuturesContractsRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Contracts) != 1 || resp.Contracts[0].Expiry != "" {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestGetLeveragedPositions(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	_, err := s.GetLeveragedPositions(context.Background(), &gctrpc.GetLeveragedPositionsRequest{})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("expected %v received %v", errInvalidArguments, err)
+	}
+	resp, err := s.GetLeveragedPositions(context.Background(), &gctrpc.GetLeveragedPositionsRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Positions) != 1 || resp.Positions[0].Leverage != 10 {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestSetLeverage(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	req := &gctrpc.SetLeverageRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Futures.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     "PERP",
+		},
+	}
+	_, err := s.SetLeverage(context.Background(), req)
+	if !errors.Is(err, margin.ErrInvalidLeverage) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidLeverage, err)
+	}
+	req.Leverage = 5
+	_, err = s.SetLeverage(context.Background(), req)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetMarginType(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	req := &gctrpc.SetMarginTypeRequest{
+		Exchange:   fakePassExchange,
+		AssetType:  asset.Futures.String(),
+		MarginType: "hedge",
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     "PERP",
+		},
+	}
+	_, err := s.SetMarginType(context.Background(), req)
+	if !errors.Is(err, margin.ErrInvalidMarginType) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidMarginType, err)
+	}
+	req.MarginType = margin.Isolated.String()
+	_, err = s.SetMarginType(context.Background(), req)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModifyIsolatedMargin(t *testing.T) {
+	bot := SetupTestHelpers(t)
+	s := RPCServer{Engine: bot}
+	_, err := s.ModifyIsolatedMargin(context.Background(), &gctrpc.ModifyIsolatedMarginRequest{
+		Exchange:  fakePassExchange,
+		AssetType: asset.Spot.String(),
+		Amount:    1,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     currency.USD.String(),
+		},
+	})
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestGetPortfolioValuations(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	engerino.Config.PortfolioValuation.FiatCurrency = currency.USD
+	s := RPCServer{Engine: engerino}
+
+	start := time.Date(2020, 11, 9, 0, 0, 0, 0, time.UTC)
+	err := valuationDB.Insert(
+		valuationDB.Data{Source: testExchange, Currency: "BTC", Amount: 1, Price: 10000, Value: 10000, FiatCurrency: "USD", Timestamp: start},
+		valuationDB.Data{Source: testExchange, Currency: "BTC", Amount: 1, Price: 11000, Value: 11000, FiatCurrency: "USD", Timestamp: start.Add(time.Hour)},
+		valuationDB.Data{Source: "0xdead", Currency: "ETH", Amount: 10, Price: 400, Value: 4000, FiatCurrency: "USD", Timestamp: start.Add(time.Hour)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &gctrpc.GetPortfolioValuationRequest{
+		FiatCurrency: "BTC",
+		Start:        start.Format(common.SimpleTimeFormat),
+		End:          start.Add(time.Hour * 2).Format(common.SimpleTimeFormat),
+	}
+	_, err = s.GetPortfolioEquityCurve(context.Background(), req)
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("expected %v received %v", errInvalidArguments, err)
+	}
+
+	req.FiatCurrency = ""
+	curve, err := s.GetPortfolioEquityCurve(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if curve.FiatCurrency != "USD" || len(curve.Points) != 2 {
+		t.Fatalf("unexpected equity curve %v", curve)
+	}
+	if curve.Points[1].Value != 15000 {
+		t.Errorf("expected 15000 received %v", curve.Points[1].Value)
+	}
+
+	allocation, err := s.GetPortfolioAllocation(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocation.Points) != 2 {
+		t.Fatalf("expected an allocation per snapshot received %v", allocation)
+	}
+	latest := allocation.Points[1]
+	if latest.Total != 15000 || len(latest.Allocations) != 2 {
+		t.Fatalf("unexpected allocation %v", latest)
+	}
+	if latest.Allocations[0].Currency != "BTC" {
+		t.Errorf("expected BTC received %v", latest.Allocations[0].Currency)
+	}
+}
+
+func TestGetDepositHistory(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	s := RPCServer{Engine: engerino}
+
+	start := time.Date(2020, 11, 18, 0, 0, 0, 0, time.UTC)
+	err := deposit.Insert(deposit.Data{
+		Exchange:   testExchange,
+		ExchangeID: "deposit-1",
+		Status:     "completed",
+		Currency:   "BTC",
+		Amount:     1,
+		Timestamp:  start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.GetDepositHistory(context.Background(), &gctrpc.GetDepositHistoryRequest{
+		Exchange: testExchange,
+		Start:    start.Format(common.SimpleTimeFormat),
+		End:      start.Add(time.Hour * 2).Format(common.SimpleTimeFormat),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Deposits) != 1 || resp.Deposits[0].ExchangeId != "deposit-1" {
+		t.Errorf("unexpected deposits %v", resp.Deposits)
+	}
+}
+
+func TestGetBalanceAlertStream(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	s := RPCServer{Engine: engerino}
+
+	err := s.GetBalanceAlertStream(&gctrpc.GetBalanceAlertStreamRequest{Exchange: "fake"}, nil)
+	if err != errExchangeNotLoaded {
+		t.Errorf("expected %v, received %v", errExchangeNotLoaded, err)
+	}
+	err = s.GetBalanceAlertStream(&gctrpc.GetBalanceAlertStreamRequest{Exchange: testExchange}, nil)
+	if err != errBalanceAlertsNotStarted {
+		t.Errorf("expected %v, received %v", errBalanceAlertsNotStarted, err)
+	}
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/valuation"
+)
+
+var errValuationDatabaseNotStarted = errors.New("database manager not started, portfolio valuations cannot be stored")
+
+type valuationManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
+
+func (v *valuationManager) Started() bool {
+	return atomic.LoadInt32(&v.started) == 1
+}
+
+func (v *valuationManager) Start() error {
+	if atomic.AddInt32(&v.started, 1) != 1 {
+		return errors.New("portfolio valuation manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Portfolio valuation manager starting...")
+	v.shutdown = make(chan struct{})
+	go v.run()
+	return nil
+}
+
+func (v *valuationManager) Stop() error {
+	if atomic.LoadInt32(&v.started) == 0 {
+		return errors.New("portfolio valuation manager not started")
+	}
+	if atomic.AddInt32(&v.stopped, 1) != 1 {
+		return errors.New("portfolio valuation manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Portfolio valuation manager shutting down...")
+	close(v.shutdown)
+	return nil
+}
+
+func (v *valuationManager) run() {
+	log.Debugln(log.PortfolioMgr, "Portfolio valuation manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.PortfolioValuation.SnapshotInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&v.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&v.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Portfolio valuation manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-v.shutdown:
+			return
+		case <-tick.C:
+			err := v.snapshot()
+			if err != nil {
+				log.Errorf(log.PortfolioMgr,
+					"Portfolio valuation manager snapshot error: %v\n",
+					err)
+			}
+		}
+	}
+}
+
+// snapshot values all exchange and personal holdings in the configured fiat
+// currency and stores the result in the database
+func (v *valuationManager) snapshot() error {
+	if !Bot.DatabaseManager.Started() {
+		return errValuationDatabaseNotStarted
+	}
+	holdings := valuation.ExchangeHoldings(Bot.GetAllEnabledExchangeAccountInfo().Data)
+	holdings = append(holdings, valuation.PersonalHoldings(portfolio.GetPortfolio())...)
+	s, err := valuation.Evaluate(holdings,
+		Bot.Config.PortfolioValuation.FiatCurrency,
+		valuation.TickerPriceLookup(Bot.GetExchangeNames(true)),
+		time.Now().Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+	if len(s.Values) == 0 {
+		return nil
+	}
+	err = s.Save()
+	if err != nil {
+		return err
+	}
+	log.Debugf(log.PortfolioMgr,
+		"Portfolio valuation manager: Stored %d holdings valued at %f %s\n",
+		len(s.Values),
+		s.Total,
+		s.FiatCurrency)
+	return nil
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctauth "github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -26,20 +29,21 @@ var (
 
 type wsCommandHandler struct {
 	authRequired bool
+	permission   gctauth.Permission
 	handler      func(client *WebsocketClient, data interface{}) error
 }
 
 var wsHandlers = map[string]wsCommandHandler{
 	"auth":             {authRequired: false, handler: wsAuth},
-	"getconfig":        {authRequired: true, handler: wsGetConfig},
-	"saveconfig":       {authRequired: true, handler: wsSaveConfig},
-	"getaccountinfo":   {authRequired: true, handler: wsGetAccountInfo},
+	"getconfig":        {authRequired: true, permission: gctauth.PermissionAdmin, handler: wsGetConfig},
+	"saveconfig":       {authRequired: true, permission: gctauth.PermissionAdmin, handler: wsSaveConfig},
+	"getaccountinfo":   {authRequired: true, permission: gctauth.PermissionRead, handler: wsGetAccountInfo},
 	"gettickers":       {authRequired: false, handler: wsGetTickers},
 	"getticker":        {authRequired: false, handler: wsGetTicker},
 	"getorderbooks":    {authRequired: false, handler: wsGetOrderbooks},
 	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
 	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
-	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+	"getportfolio":     {authRequired: true, permission: gctauth.PermissionRead, handler: wsGetPortfolio},
 }
 
 // NewWebsocketHub Creates a new websocket hub
@@ -138,6 +142,16 @@ func (c *WebsocketClient) read() {
 				continue
 			}
 
+			if result.authRequired {
+				if !c.Identity.Can(result.permission) {
+					err = permissionDeniedError(c.Identity, result.permission)
+					auditRemoteAccess(c.Identity, "websocket", req, err)
+					c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: err.Error()})
+					continue
+				}
+				auditRemoteAccess(c.Identity, "websocket", req, nil)
+			}
+
 			err = result.handler(c, dataJSON)
 			if err != nil {
 				log.Errorf(log.WebsocketMgr, "websocket: request %s failed. Error %s\n", evt.Event, err)
@@ -213,7 +227,8 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 		StartWebsocketHandler()
 	}
 
-	connectionLimit := Bot.Config.RemoteControl.WebsocketRPC.ConnectionLimit
+	wsCfg := Bot.Config.GetRemoteControlConfig().WebsocketRPC
+	connectionLimit := wsCfg.ConnectionLimit
 	numClients := len(wsHub.Clients)
 
 	if numClients >= connectionLimit {
@@ -231,7 +246,7 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Allow insecure origin if the Origin request header is present and not
 	// equal to the Host request header. Default to false
-	if Bot.Config.RemoteControl.WebsocketRPC.AllowInsecureOrigin {
+	if wsCfg.AllowInsecureOrigin {
 		upgrader.CheckOrigin = func(r *http.Request) bool { return true }
 	}
 
@@ -264,29 +279,40 @@ func wsAuth(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
-	hashPW := crypto.HexEncodeToString(crypto.GetSHA256([]byte(Bot.Config.RemoteControl.Password)))
-	if auth.Username == Bot.Config.RemoteControl.Username && auth.Password == hashPW {
+	var identity gctauth.Identity
+	if auth.APIToken != "" {
+		identity, err = Bot.identityByToken(auth.APIToken)
+	} else {
+		identity, err = Bot.identityByPassword(auth.Username, func(password string) bool {
+			return secureCompare(crypto.HexEncodeToString(crypto.GetSHA256([]byte(password))), auth.Password)
+		})
+	}
+	if err == nil {
 		client.Authenticated = true
+		client.Identity = identity
+		auditLogin(identity.Name, "websocket", nil)
 		wsResp.Data = WebsocketResponseSuccess
 		log.Debugln(log.WebsocketMgr,
 			"websocket: client authenticated successfully")
 		return client.SendWebsocketMessage(wsResp)
 	}
 
+	auditLogin(auth.Username, "websocket", err)
 	wsResp.Error = "invalid username/password"
 	client.authFailures++
 	client.SendWebsocketMessage(wsResp)
-	if client.authFailures >= Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures {
+	maxAuthFailures := Bot.Config.GetRemoteControlConfig().WebsocketRPC.MaxAuthFailures
+	if client.authFailures >= maxAuthFailures {
 		log.Debugf(log.WebsocketMgr,
 			"websocket: disconnecting client, maximum auth failures threshold reached (failures: %d limit: %d)\n",
-			client.authFailures, Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
+			client.authFailures, maxAuthFailures)
 		wsHub.Unregister <- client
 		return nil
 	}
 
 	log.Debugf(log.WebsocketMgr,
 		"websocket: client sent wrong username/password (failures: %d limit: %d)\n",
-		client.authFailures, Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
+		client.authFailures, maxAuthFailures)
 	return nil
 }
 
@@ -317,6 +343,8 @@ func wsSaveConfig(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
+	audit.Event(client.Identity.Name, audit.ConfigChangeEvent,
+		fmt.Sprintf("config saved over websocket by %s", client.Identity))
 	Bot.SetupExchanges()
 	wsResp.Data = WebsocketResponseSuccess
 	return client.SendWebsocketMessage(wsResp)
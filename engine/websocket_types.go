@@ -1,12 +1,16 @@
 package engine
 
-import "github.com/gorilla/websocket"
+import (
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+)
 
 // WebsocketClient stores information related to the websocket client
 type WebsocketClient struct {
 	Hub           *WebsocketHub
 	Conn          *websocket.Conn
 	Authenticated bool
+	Identity      auth.Identity
 	authFailures  int
 	Send          chan []byte
 }
@@ -42,8 +46,10 @@ type WebsocketOrderbookTickerRequest struct {
 	AssetType string `json:"assetType"`
 }
 
-// WebsocketAuth is a struct used for
+// WebsocketAuth is a struct used for authenticating a websocket client by
+// username and SHA256 hashed password or by API token
 type WebsocketAuth struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	APIToken string `json:"apiToken,omitempty"`
 }
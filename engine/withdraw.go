@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
@@ -28,9 +29,9 @@ func SubmitWithdrawal(req *withdraw.Request) (*withdraw.Response, error) {
 		return nil, withdraw.ErrRequestCannotBeNil
 	}
 
-	exch := Bot.GetExchangeByName(req.Exchange)
-	if exch == nil {
-		return nil, ErrExchangeNotFound
+	exch, err := Bot.GetExchangeAccount(req.Exchange, req.AccountID)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &withdraw.Response{
@@ -40,41 +41,61 @@ func SubmitWithdrawal(req *withdraw.Request) (*withdraw.Response, error) {
 		RequestDetails: *req,
 	}
 
-	var err error
 	if Bot.Settings.EnableDryRun {
 		log.Warnln(log.Global, "Dry run enabled, no withdrawal request will be submitted or have an event created")
 		resp.ID = withdraw.DryRunID
 		resp.Exchange.Status = "dryrun"
 		resp.Exchange.ID = withdraw.DryRunID.String()
-	} else {
-		var ret *withdraw.ExchangeResponse
-		if req.Type == withdraw.Fiat {
-			ret, err = exch.WithdrawFiatFunds(req)
-			if err != nil {
-				resp.Exchange.ID = StatusError
-				resp.Exchange.Status = err.Error()
-			} else {
-				resp.Exchange.Status = ret.Status
-				resp.Exchange.ID = ret.ID
-			}
-		} else if req.Type == withdraw.Crypto {
-			ret, err = exch.WithdrawCryptocurrencyFunds(req)
-			if err != nil {
-				resp.Exchange.ID = StatusError
-				resp.Exchange.Status = err.Error()
-			} else {
-				resp.Exchange.Status = ret.Status
-				resp.Exchange.ID = ret.ID
-			}
+		withdraw.Cache.Add(resp.ID, resp)
+		return resp, nil
+	}
+
+	if requiresApproval(&Bot.Config.WithdrawalApproval, req) {
+		err = Bot.WithdrawalApprovalManager.Hold(resp)
+		if err != nil {
+			return nil, err
 		}
-		withdrawDataStore.Event(resp)
+		return resp, nil
 	}
-	if err == nil {
+
+	ok := submitToExchange(exch, resp)
+	withdrawDataStore.Event(resp)
+	audit.Event(resp.ID.String(), audit.WithdrawalEvent,
+		fmt.Sprintf("Withdrawal %s of %v %s on %s submitted, exchange status: %s",
+			resp.ID,
+			req.Amount,
+			req.Currency,
+			req.Exchange,
+			resp.Exchange.Status))
+	if ok {
 		withdraw.Cache.Add(resp.ID, resp)
 	}
 	return resp, nil
 }
 
+// submitToExchange sends the withdrawal request to the exchange and sets the
+// exchange response details, returning whether the submission succeeded
+func submitToExchange(exch exchange.IBotExchange, resp *withdraw.Response) bool {
+	var ret *withdraw.ExchangeResponse
+	var err error
+	switch resp.RequestDetails.Type {
+	case withdraw.Fiat:
+		ret, err = exch.WithdrawFiatFunds(&resp.RequestDetails)
+	case withdraw.Crypto:
+		ret, err = exch.WithdrawCryptocurrencyFunds(&resp.RequestDetails)
+	default:
+		return true
+	}
+	if err != nil {
+		resp.Exchange.ID = StatusError
+		resp.Exchange.Status = err.Error()
+		return false
+	}
+	resp.Exchange.Status = ret.Status
+	resp.Exchange.ID = ret.ID
+	return true
+}
+
 // WithdrawalEventByID returns a withdrawal request by ID
 func WithdrawalEventByID(id string) (*withdraw.Response, error) {
 	v := withdraw.Cache.Get(id)
@@ -141,6 +162,7 @@ func parseMultipleEvents(ret []*withdraw.Response) *gctrpc.WithdrawalEventsByExc
 				Address:    ret[x].RequestDetails.Crypto.Address,
 				AddressTag: ret[x].RequestDetails.Crypto.AddressTag,
 				Fee:        ret[x].RequestDetails.Crypto.FeeAmount,
+				TxId:       ret[x].Exchange.TxID,
 			}
 		} else if ret[x].RequestDetails.Type == withdraw.Fiat {
 			if ret[x].RequestDetails.Fiat != (withdraw.FiatRequest{}) {
@@ -230,6 +252,7 @@ func parseSingleEvents(ret *withdraw.Response) *gctrpc.WithdrawalEventsByExchang
 			Address:    ret.RequestDetails.Crypto.Address,
 			AddressTag: ret.RequestDetails.Crypto.AddressTag,
 			Fee:        ret.RequestDetails.Crypto.FeeAmount,
+			TxId:       ret.Exchange.TxID,
 		}
 	} else if ret.RequestDetails.Type == withdraw.Fiat {
 		if ret.RequestDetails.Fiat != (withdraw.FiatRequest{}) {
@@ -0,0 +1,451 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// Withdrawal approval statuses stored against held withdrawal requests
+const (
+	WithdrawalStatusPendingApproval = "pending_approval"
+	WithdrawalStatusRejected        = "approval_rejected"
+	WithdrawalStatusExpired         = "approval_expired"
+
+	withdrawalApprovalCheckInterval = time.Second * 30
+)
+
+var (
+	errWithdrawalApprovalNotStarted = errors.New("withdrawal approval manager not started, withdrawal requires approval")
+	errPendingWithdrawalNotFound    = errors.New("pending withdrawal not found")
+	errPendingWithdrawalExpired     = errors.New("pending withdrawal has expired")
+	errUnknownWithdrawalOperator    = errors.New("unknown withdrawal operator")
+	errInvalidWithdrawalOTP         = errors.New("invalid one-time code")
+	errWithdrawalAlreadyApproved    = errors.New("withdrawal already approved by operator")
+)
+
+// PendingWithdrawal is a withdrawal request held until enough operators have
+// approved it
+type PendingWithdrawal struct {
+	Response          withdraw.Response
+	Approvers         []string
+	RequiredApprovals int
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+}
+
+type withdrawalApprovalManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	m        sync.Mutex
+	pending  map[uuid.UUID]*PendingWithdrawal
+}
+
+func (w *withdrawalApprovalManager) Started() bool {
+	return atomic.LoadInt32(&w.started) == 1
+}
+
+func (w *withdrawalApprovalManager) Start() error {
+	if atomic.AddInt32(&w.started, 1) != 1 {
+		return errors.New("withdrawal approval manager already started")
+	}
+
+	log.Debugln(log.Global, "Withdrawal approval manager starting...")
+	w.m.Lock()
+	if w.pending == nil {
+		w.pending = make(map[uuid.UUID]*PendingWithdrawal)
+	}
+	w.m.Unlock()
+	w.load()
+	base.RegisterCommand("pendingwithdrawals", w.pendingCommand)
+	base.RegisterCommand("approvewithdrawal", w.approveCommand)
+	base.RegisterCommand("rejectwithdrawal", w.rejectCommand)
+	w.shutdown = make(chan struct{})
+	go w.run()
+	return nil
+}
+
+func (w *withdrawalApprovalManager) Stop() error {
+	if atomic.LoadInt32(&w.started) == 0 {
+		return errors.New("withdrawal approval manager not started")
+	}
+	if atomic.AddInt32(&w.stopped, 1) != 1 {
+		return errors.New("withdrawal approval manager is already stopped")
+	}
+
+	base.DeregisterCommand("pendingwithdrawals")
+	base.DeregisterCommand("approvewithdrawal")
+	base.DeregisterCommand("rejectwithdrawal")
+	w.auditPending()
+	close(w.shutdown)
+	log.Debugln(log.Global, "Withdrawal approval manager shutting down...")
+	return nil
+}
+
+func (w *withdrawalApprovalManager) run() {
+	log.Debugln(log.Global, "Withdrawal approval manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(withdrawalApprovalCheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&w.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&w.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Withdrawal approval manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-w.shutdown:
+			return
+		case <-tick.C:
+			w.expire(time.Now())
+		}
+	}
+}
+
+// requiresApproval returns whether the request amount is above the configured
+// threshold for its currency
+func requiresApproval(cfg *config.WithdrawalApprovalConfig, req *withdraw.Request) bool {
+	if !cfg.Enabled {
+		return false
+	}
+	for i := range cfg.Thresholds {
+		if cfg.Thresholds[i].Currency.Match(req.Currency) &&
+			req.Amount > cfg.Thresholds[i].Amount {
+			return true
+		}
+	}
+	return false
+}
+
+// Hold stores the withdrawal as pending approval instead of submitting it to
+// the exchange
+func (w *withdrawalApprovalManager) Hold(resp *withdraw.Response) error {
+	if !w.Started() {
+		return errWithdrawalApprovalNotStarted
+	}
+
+	resp.Exchange.Status = WithdrawalStatusPendingApproval
+	withdrawDataStore.Event(resp)
+	if resp.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		resp.ID = id
+	}
+
+	now := time.Now()
+	p := &PendingWithdrawal{
+		Response:          *resp,
+		RequiredApprovals: Bot.Config.WithdrawalApproval.RequiredApprovals,
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(Bot.Config.WithdrawalApproval.Expiry),
+	}
+	w.m.Lock()
+	w.pending[resp.ID] = p
+	w.m.Unlock()
+	withdraw.Cache.Add(resp.ID.String(), resp)
+
+	msg := fmt.Sprintf("Withdrawal %s of %v %s on %s requires %d approvals before %s",
+		resp.ID,
+		resp.RequestDetails.Amount,
+		resp.RequestDetails.Currency,
+		resp.RequestDetails.Exchange,
+		p.RequiredApprovals,
+		p.ExpiresAt.Format(time.RFC3339))
+	audit.Event(resp.ID.String(), audit.WithdrawalEvent, msg)
+	log.Infoln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{Type: "withdrawal", Severity: base.SeverityWarning, Message: msg})
+	return nil
+}
+
+// Pending returns all withdrawals awaiting approval ordered by creation time
+func (w *withdrawalApprovalManager) Pending() []PendingWithdrawal {
+	w.expire(time.Now())
+	w.m.Lock()
+	defer w.m.Unlock()
+	resp := make([]PendingWithdrawal, 0, len(w.pending))
+	for _, p := range w.pending {
+		cpy := *p
+		cpy.Approvers = append([]string(nil), p.Approvers...)
+		resp = append(resp, cpy)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].CreatedAt.Before(resp[j].CreatedAt)
+	})
+	return resp
+}
+
+// Approve records an operator approval authenticated by a one-time code. Once
+// the required number of approvals is reached the withdrawal is submitted to
+// the exchange
+func (w *withdrawalApprovalManager) Approve(id, operator, code string) (*withdraw.Response, error) {
+	name, err := w.authenticate(id, operator, code)
+	if err != nil {
+		return nil, err
+	}
+
+	w.m.Lock()
+	p, err := w.pendingWithdrawal(id)
+	if err != nil {
+		w.m.Unlock()
+		return nil, err
+	}
+	for i := range p.Approvers {
+		if p.Approvers[i] == name {
+			w.m.Unlock()
+			return nil, fmt.Errorf("%s %w %s", id, errWithdrawalAlreadyApproved, name)
+		}
+	}
+	p.Approvers = append(p.Approvers, name)
+	audit.Event(id, audit.WithdrawalEvent,
+		fmt.Sprintf("approved by %s (%d/%d)", name, len(p.Approvers), p.RequiredApprovals))
+	if len(p.Approvers) < p.RequiredApprovals {
+		resp := p.Response
+		approvers := append([]string(nil), p.Approvers...)
+		w.m.Unlock()
+		err = withdrawDataStore.UpdateApprovers(id, approvers)
+		if err != nil && !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+			log.Errorf(log.Global, "Withdrawal %s approvers update failed: %v\n", id, err)
+		}
+		return &resp, nil
+	}
+	delete(w.pending, p.Response.ID)
+	w.m.Unlock()
+
+	resp := p.Response
+	exch, err := Bot.GetExchangeAccount(resp.RequestDetails.Exchange, resp.RequestDetails.AccountID)
+	if err != nil {
+		resp.Exchange.ID = StatusError
+		resp.Exchange.Status = err.Error()
+	} else {
+		submitToExchange(exch, &resp)
+	}
+	w.updateStatus(&resp)
+	msg := fmt.Sprintf("Withdrawal %s submitted to %s with status %s",
+		id,
+		resp.RequestDetails.Exchange,
+		resp.Exchange.Status)
+	audit.Event(id, audit.WithdrawalEvent, msg)
+	log.Infoln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{Type: "withdrawal", Severity: base.SeverityInfo, Message: msg})
+	return &resp, nil
+}
+
+// Reject discards a pending withdrawal, a single operator rejection is
+// sufficient
+func (w *withdrawalApprovalManager) Reject(id, operator, code string) (*withdraw.Response, error) {
+	name, err := w.authenticate(id, operator, code)
+	if err != nil {
+		return nil, err
+	}
+
+	w.m.Lock()
+	p, err := w.pendingWithdrawal(id)
+	if err != nil {
+		w.m.Unlock()
+		return nil, err
+	}
+	delete(w.pending, p.Response.ID)
+	w.m.Unlock()
+
+	resp := p.Response
+	resp.Exchange.Status = WithdrawalStatusRejected
+	w.updateStatus(&resp)
+	msg := fmt.Sprintf("Withdrawal %s rejected by %s", id, name)
+	audit.Event(id, audit.WithdrawalEvent, msg)
+	log.Infoln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{Type: "withdrawal", Severity: base.SeverityInfo, Message: msg})
+	return &resp, nil
+}
+
+// load restores withdrawals held for approval by a previous run from the
+// withdrawal history along with their approvals. Trade passwords, PINs and
+// one-time passwords are never stored, exchanges requiring them will reject a
+// reloaded withdrawal once approved
+func (w *withdrawalApprovalManager) load() {
+	held, err := withdrawDataStore.GetEventsByStatus(WithdrawalStatusPendingApproval)
+	if err != nil {
+		if !errors.Is(err, database.ErrDatabaseSupportDisabled) &&
+			!errors.Is(err, withdrawDataStore.ErrNoResults) {
+			log.Errorf(log.Global, "Withdrawal approval manager unable to load pending withdrawals: %v\n", err)
+		}
+		return
+	}
+
+	for i := range held {
+		id := held[i].ID.String()
+		approvers, err := withdrawDataStore.GetApprovers(id)
+		if err != nil {
+			log.Errorf(log.Global, "Withdrawal %s approvers could not be loaded, approvals must be resubmitted: %v\n", id, err)
+		}
+		held[i].RequestDetails.Exchange = held[i].Exchange.Name
+		if exch := Bot.GetExchangeByName(held[i].Exchange.Name); exch != nil {
+			held[i].RequestDetails.Exchange = exch.GetName()
+		}
+		p := &PendingWithdrawal{
+			Response:          *held[i],
+			Approvers:         approvers,
+			RequiredApprovals: Bot.Config.WithdrawalApproval.RequiredApprovals,
+			CreatedAt:         held[i].CreatedAt,
+			ExpiresAt:         held[i].CreatedAt.Add(Bot.Config.WithdrawalApproval.Expiry),
+		}
+		w.m.Lock()
+		w.pending[held[i].ID] = p
+		w.m.Unlock()
+		withdraw.Cache.Add(id, held[i])
+		audit.Event(id, audit.WithdrawalEvent,
+			fmt.Sprintf("reloaded pending approval (%d/%d)", len(p.Approvers), p.RequiredApprovals))
+	}
+	log.Infof(log.Global, "Withdrawal approval manager loaded %d pending withdrawals.\n", len(held))
+	w.expire(time.Now())
+}
+
+// auditPending records the withdrawals still awaiting approval at shutdown.
+// Without database support they are lost and must be resubmitted
+func (w *withdrawalApprovalManager) auditPending() {
+	persisted := database.DB.SQL != nil
+	w.m.Lock()
+	defer w.m.Unlock()
+	for _, p := range w.pending {
+		msg := fmt.Sprintf("Withdrawal %s pending approval (%d/%d) at shutdown",
+			p.Response.ID,
+			len(p.Approvers),
+			p.RequiredApprovals)
+		if !persisted {
+			msg += ", database support is disabled and it must be resubmitted"
+		}
+		audit.Event(p.Response.ID.String(), audit.WithdrawalEvent, msg)
+		log.Warnln(log.Global, msg)
+	}
+}
+
+// expire removes pending withdrawals which have not been approved in time
+func (w *withdrawalApprovalManager) expire(now time.Time) {
+	w.m.Lock()
+	var expired []withdraw.Response
+	for k, p := range w.pending {
+		if now.After(p.ExpiresAt) {
+			expired = append(expired, p.Response)
+			delete(w.pending, k)
+		}
+	}
+	w.m.Unlock()
+
+	for i := range expired {
+		expired[i].Exchange.Status = WithdrawalStatusExpired
+		w.updateStatus(&expired[i])
+		msg := fmt.Sprintf("Withdrawal %s expired before approval", expired[i].ID)
+		audit.Event(expired[i].ID.String(), audit.WithdrawalEvent, msg)
+		log.Warnln(log.Global, msg)
+		Bot.CommsManager.PushEvent(base.Event{Type: "withdrawal", Severity: base.SeverityWarning, Message: msg})
+	}
+}
+
+// authenticate validates the operator one-time code and returns the operator
+// name as configured. Failed attempts are audited against the withdrawal ID
+func (w *withdrawalApprovalManager) authenticate(id, operator, code string) (string, error) {
+	if !w.Started() {
+		return "", errWithdrawalApprovalNotStarted
+	}
+	operators := Bot.Config.WithdrawalApproval.Operators
+	for i := range operators {
+		if !strings.EqualFold(operators[i].Name, operator) {
+			continue
+		}
+		if operators[i].OTPSecret == "" || !totp.Validate(code, operators[i].OTPSecret) {
+			audit.Event(id, audit.WithdrawalEvent,
+				"invalid one-time code from "+operators[i].Name)
+			return "", errInvalidWithdrawalOTP
+		}
+		return operators[i].Name, nil
+	}
+	audit.Event(id, audit.WithdrawalEvent, "unknown operator "+operator)
+	return "", fmt.Errorf("%s %w", operator, errUnknownWithdrawalOperator)
+}
+
+// pendingWithdrawal returns a pending withdrawal by ID, w.m must be held
+func (w *withdrawalApprovalManager) pendingWithdrawal(id string) (*PendingWithdrawal, error) {
+	u, err := uuid.FromString(id)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := w.pending[u]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", id, errPendingWithdrawalNotFound)
+	}
+	if time.Now().After(p.ExpiresAt) {
+		return nil, fmt.Errorf("%s %w", id, errPendingWithdrawalExpired)
+	}
+	return p, nil
+}
+
+// updateStatus persists the withdrawal status and refreshes the cache
+func (w *withdrawalApprovalManager) updateStatus(resp *withdraw.Response) {
+	resp.UpdatedAt = time.Now()
+	withdraw.Cache.Add(resp.ID.String(), resp)
+	err := withdrawDataStore.UpdateStatus(resp.ID.String(), resp.Exchange.ID, resp.Exchange.Status)
+	if err != nil && !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		log.Errorf(log.Global, "Withdrawal %s status update failed: %v\n", resp.ID, err)
+	}
+}
+
+func (w *withdrawalApprovalManager) pendingCommand(_ string, _ []string) (string, error) {
+	pending := w.Pending()
+	if len(pending) == 0 {
+		return "No withdrawals pending approval", nil
+	}
+	var sb strings.Builder
+	for i := range pending {
+		sb.WriteString(fmt.Sprintf("%s: %v %s on %s, %d/%d approvals, expires %s\n",
+			pending[i].Response.ID,
+			pending[i].Response.RequestDetails.Amount,
+			pending[i].Response.RequestDetails.Currency,
+			pending[i].Response.RequestDetails.Exchange,
+			len(pending[i].Approvers),
+			pending[i].RequiredApprovals,
+			pending[i].ExpiresAt.Format(time.RFC3339)))
+	}
+	return sb.String(), nil
+}
+
+func (w *withdrawalApprovalManager) approveCommand(sender string, args []string) (string, error) {
+	if len(args) != 3 {
+		return "", errors.New("usage: approvewithdrawal <id> <operator> <code>")
+	}
+	log.Infof(log.Global, "Withdrawal %s approval received via %s\n", args[0], sender)
+	resp, err := w.Approve(args[0], args[1], args[2])
+	if err != nil {
+		return "", err
+	}
+	return "Withdrawal " + resp.ID.String() + " status: " + resp.Exchange.Status, nil
+}
+
+func (w *withdrawalApprovalManager) rejectCommand(sender string, args []string) (string, error) {
+	if len(args) != 3 {
+		return "", errors.New("usage: rejectwithdrawal <id> <operator> <code>")
+	}
+	log.Infof(log.Global, "Withdrawal %s rejection received via %s\n", args[0], sender)
+	resp, err := w.Reject(args[0], args[1], args[2])
+	if err != nil {
+		return "", err
+	}
+	return "Withdrawal " + resp.ID.String() + " status: " + resp.Exchange.Status, nil
+}
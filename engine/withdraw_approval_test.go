@@ -0,0 +1,251 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+const (
+	testOperatorSecretA = "JBSWY3DPEHPK3PXP"
+	testOperatorSecretB = "KRSXG5CTMVRXEZLU"
+)
+
+func setupWithdrawalApprovalTest(t *testing.T) func() {
+	SetupTestHelpers(t)
+	oldConfig := Bot.Config.WithdrawalApproval
+	oldDryRun := Bot.Settings.EnableDryRun
+
+	Bot.Config.WithdrawalApproval = config.WithdrawalApprovalConfig{
+		Enabled:           true,
+		RequiredApprovals: 2,
+		Expiry:            time.Hour,
+		Thresholds: []config.WithdrawalThreshold{
+			{Currency: currency.BTC, Amount: 1},
+		},
+		Operators: []config.WithdrawalOperator{
+			{Name: "alice", OTPSecret: testOperatorSecretA},
+			{Name: "bob", OTPSecret: testOperatorSecretB},
+		},
+	}
+	Bot.Settings.EnableDryRun = false
+	err := Bot.WithdrawalApprovalManager.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		err := Bot.WithdrawalApprovalManager.Stop()
+		if err != nil {
+			t.Error(err)
+		}
+		for Bot.WithdrawalApprovalManager.Started() {
+			time.Sleep(time.Millisecond)
+		}
+		Bot.Config.WithdrawalApproval = oldConfig
+		Bot.Settings.EnableDryRun = oldDryRun
+	}
+}
+
+func testWithdrawalRequest(amount float64) *withdraw.Request {
+	return &withdraw.Request{
+		Exchange: fakePassExchange,
+		Currency: currency.BTC,
+		Amount:   amount,
+		Type:     withdraw.Crypto,
+		Crypto: withdraw.CryptoRequest{
+			Address: fakeDepositAddress,
+		},
+	}
+}
+
+func testOTP(t *testing.T, secret string) string {
+	t.Helper()
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestRequiresApproval(t *testing.T) {
+	cfg := config.WithdrawalApprovalConfig{
+		Thresholds: []config.WithdrawalThreshold{
+			{Currency: currency.BTC, Amount: 1},
+		},
+	}
+	if requiresApproval(&cfg, testWithdrawalRequest(5)) {
+		t.Error("disabled approval should not require approval")
+	}
+	cfg.Enabled = true
+	if !requiresApproval(&cfg, testWithdrawalRequest(5)) {
+		t.Error("amount above threshold should require approval")
+	}
+	if requiresApproval(&cfg, testWithdrawalRequest(1)) {
+		t.Error("amount at threshold should not require approval")
+	}
+	req := testWithdrawalRequest(5)
+	req.Currency = currency.LTC
+	if requiresApproval(&cfg, req) {
+		t.Error("currency without threshold should not require approval")
+	}
+}
+
+func TestWithdrawalApproval(t *testing.T) {
+	defer setupWithdrawalApprovalTest(t)()
+	w := &Bot.WithdrawalApprovalManager
+
+	resp, err := SubmitWithdrawal(testWithdrawalRequest(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Exchange.Status != "submitted" {
+		t.Errorf("expected withdrawal below threshold to be submitted, received %v", resp.Exchange.Status)
+	}
+
+	resp, err = SubmitWithdrawal(testWithdrawalRequest(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Exchange.Status != WithdrawalStatusPendingApproval {
+		t.Fatalf("expected pending approval, received %v", resp.Exchange.Status)
+	}
+	id := resp.ID.String()
+	if len(w.Pending()) != 1 {
+		t.Fatal("expected 1 pending withdrawal")
+	}
+
+	_, err = w.Approve(id, "mallory", testOTP(t, testOperatorSecretA))
+	if !errors.Is(err, errUnknownWithdrawalOperator) {
+		t.Errorf("expected %v, received %v", errUnknownWithdrawalOperator, err)
+	}
+	_, err = w.Approve(id, "alice", testOTP(t, testOperatorSecretB))
+	if !errors.Is(err, errInvalidWithdrawalOTP) {
+		t.Errorf("expected %v, received %v", errInvalidWithdrawalOTP, err)
+	}
+
+	resp, err = w.Approve(id, "alice", testOTP(t, testOperatorSecretA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Exchange.Status != WithdrawalStatusPendingApproval {
+		t.Errorf("expected pending approval, received %v", resp.Exchange.Status)
+	}
+	_, err = w.Approve(id, "ALICE", testOTP(t, testOperatorSecretA))
+	if !errors.Is(err, errWithdrawalAlreadyApproved) {
+		t.Errorf("expected %v, received %v", errWithdrawalAlreadyApproved, err)
+	}
+
+	resp, err = w.Approve(id, "bob", testOTP(t, testOperatorSecretB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Exchange.Status != "submitted" || resp.Exchange.ID != "fake" {
+		t.Errorf("expected submitted withdrawal, received %+v", resp.Exchange)
+	}
+	if len(w.Pending()) != 0 {
+		t.Error("expected no pending withdrawals")
+	}
+	_, err = w.Approve(id, "bob", testOTP(t, testOperatorSecretB))
+	if !errors.Is(err, errPendingWithdrawalNotFound) {
+		t.Errorf("expected %v, received %v", errPendingWithdrawalNotFound, err)
+	}
+}
+
+func TestWithdrawalApprovalRejectAndExpire(t *testing.T) {
+	defer setupWithdrawalApprovalTest(t)()
+	w := &Bot.WithdrawalApprovalManager
+
+	resp, err := SubmitWithdrawal(testWithdrawalRequest(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = w.Reject(resp.ID.String(), "bob", testOTP(t, testOperatorSecretB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Exchange.Status != WithdrawalStatusRejected {
+		t.Errorf("expected %v, received %v", WithdrawalStatusRejected, resp.Exchange.Status)
+	}
+
+	resp, err = SubmitWithdrawal(testWithdrawalRequest(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.expire(time.Now().Add(time.Hour * 2))
+	if len(w.Pending()) != 0 {
+		t.Error("expected pending withdrawal to expire")
+	}
+	cached, err := WithdrawalEventByID(resp.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.Exchange.Status != WithdrawalStatusExpired {
+		t.Errorf("expected %v, received %v", WithdrawalStatusExpired, cached.Exchange.Status)
+	}
+}
+
+func TestWithdrawalApprovalReload(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	id, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exchange.Insert(exchange.Details{Name: fakePassExchange, UUID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange.ResetExchangeCache()
+
+	defer setupWithdrawalApprovalTest(t)()
+	w := &Bot.WithdrawalApprovalManager
+
+	req := testWithdrawalRequest(5)
+	req.AccountID = "sub"
+	resp, err := SubmitWithdrawal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = w.Approve(resp.ID.String(), "alice", testOTP(t, testOperatorSecretA))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for w.Started() {
+		time.Sleep(time.Millisecond)
+	}
+	w.pending = nil
+	err = w.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := w.Pending()
+	if len(pending) != 1 || pending[0].Response.ID != resp.ID {
+		t.Fatalf("expected withdrawal %v to be reloaded, received %+v", resp.ID, pending)
+	}
+	if len(pending[0].Approvers) != 1 || pending[0].Approvers[0] != "alice" {
+		t.Errorf("expected approval to be reloaded, received %v", pending[0].Approvers)
+	}
+	if !strings.EqualFold(pending[0].Response.RequestDetails.Exchange, fakePassExchange) ||
+		pending[0].Response.RequestDetails.AccountID != "sub" {
+		t.Errorf("expected exchange account to be reloaded, received %+v", pending[0].Response.RequestDetails)
+	}
+	_, err = w.Approve(resp.ID.String(), "alice", testOTP(t, testOperatorSecretA))
+	if !errors.Is(err, errWithdrawalAlreadyApproved) {
+		t.Errorf("expected %v, received %v", errWithdrawalAlreadyApproved, err)
+	}
+}
@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+var errWithdrawalTrackerDatabaseNotStarted = errors.New("database manager not started, withdrawals cannot be tracked")
+
+// withdrawalTracker periodically matches stored withdrawals against exchange
+// withdrawal history to follow them through to completion
+type withdrawalTracker struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	m        sync.Mutex
+	stalled  map[uuid.UUID]bool
+}
+
+func (w *withdrawalTracker) Started() bool {
+	return atomic.LoadInt32(&w.started) == 1
+}
+
+func (w *withdrawalTracker) Start() error {
+	if atomic.AddInt32(&w.started, 1) != 1 {
+		return errors.New("withdrawal tracker already started")
+	}
+
+	log.Debugln(log.Global, "Withdrawal tracker starting...")
+	w.shutdown = make(chan struct{})
+	go w.run()
+	return nil
+}
+
+func (w *withdrawalTracker) Stop() error {
+	if atomic.LoadInt32(&w.started) == 0 {
+		return errors.New("withdrawal tracker not started")
+	}
+	if atomic.AddInt32(&w.stopped, 1) != 1 {
+		return errors.New("withdrawal tracker is already stopped")
+	}
+
+	close(w.shutdown)
+	log.Debugln(log.Global, "Withdrawal tracker shutting down...")
+	return nil
+}
+
+func (w *withdrawalTracker) run() {
+	log.Debugln(log.Global, "Withdrawal tracker started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.WithdrawalTracker.CheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&w.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&w.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Withdrawal tracker shutdown.")
+	}()
+
+	for {
+		select {
+		case <-w.shutdown:
+			return
+		case <-tick.C:
+			err := w.track()
+			if err != nil {
+				log.Errorf(log.Global, "Withdrawal tracker error: %v\n", err)
+			}
+		}
+	}
+}
+
+// track fetches the withdrawal history of every exchange with open
+// withdrawals and updates the stored withdrawals which have changed
+func (w *withdrawalTracker) track() error {
+	if !Bot.DatabaseManager.Started() {
+		return errWithdrawalTrackerDatabaseNotStarted
+	}
+	events, err := withdrawDataStore.GetOpenEvents(withdraw.FinalStatuses())
+	if err != nil {
+		if errors.Is(err, withdrawDataStore.ErrNoResults) {
+			return nil
+		}
+		return err
+	}
+
+	byExchange := make(map[string][]*withdraw.Response)
+	for i := range events {
+		name := strings.ToLower(events[i].Exchange.Name)
+		byExchange[name] = append(byExchange[name], events[i])
+	}
+
+	now := time.Now()
+	for name, open := range byExchange {
+		exch := Bot.GetExchangeByName(name)
+		if exch == nil {
+			log.Warnf(log.Global, "Withdrawal tracker: %s %v\n", name, ErrExchangeNotFound)
+			continue
+		}
+		history := make(map[string]*exchange.WithdrawalHistory)
+		fetched := make(map[string]bool)
+		for i := range open {
+			code := open[i].RequestDetails.Currency
+			if !fetched[code.Upper().String()] {
+				fetched[code.Upper().String()] = true
+				// Withdrawals may have been submitted by a named account
+				exchs := append([]exchange.IBotExchange{exch}, Bot.GetExchangeAccounts(name)...)
+				for k := range exchs {
+					h, err := exchs[k].GetWithdrawalsHistory(code)
+					if err != nil && !errors.Is(err, common.ErrFunctionNotSupported) {
+						log.Errorf(log.Global, "Withdrawal tracker: %s %s withdrawal history error: %v\n",
+							name,
+							code,
+							err)
+					}
+					for j := range h {
+						history[h[j].TransferID] = &h[j]
+					}
+				}
+			}
+
+			h := history[open[i].Exchange.ID]
+			if h != nil && reconcileWithdrawal(open[i], h) {
+				w.persist(open[i])
+				w.notifyLifecycle(open[i])
+			}
+			if w.checkStalled(open[i], now, Bot.Config.WithdrawalTracker.StallTimeout) {
+				w.notify(base.SeverityWarning, fmt.Sprintf("Withdrawal %s of %v %s on %s has been %s since %s",
+					open[i].ID,
+					open[i].RequestDetails.Amount,
+					open[i].RequestDetails.Currency,
+					open[i].Exchange.Name,
+					open[i].Exchange.Status,
+					open[i].CreatedAt.Format(time.RFC3339)))
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileWithdrawal applies the exchange reported history to a stored
+// withdrawal, returning whether any details changed
+func reconcileWithdrawal(resp *withdraw.Response, h *exchange.WithdrawalHistory) bool {
+	var changed bool
+	if h.Status != "" && h.Status != resp.Exchange.Status {
+		resp.Exchange.Status = h.Status
+		changed = true
+	}
+	if h.CryptoTxID != "" && h.CryptoTxID != resp.Exchange.TxID {
+		resp.Exchange.TxID = h.CryptoTxID
+		changed = true
+	}
+	if h.Fee > 0 && h.Fee != resp.RequestDetails.Crypto.FeeAmount {
+		resp.RequestDetails.Crypto.FeeAmount = h.Fee
+		changed = true
+	}
+	return changed
+}
+
+// checkStalled returns true the first time a still pending withdrawal is seen
+// beyond the stall timeout
+func (w *withdrawalTracker) checkStalled(resp *withdraw.Response, now time.Time, timeout time.Duration) bool {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.stalled == nil {
+		w.stalled = make(map[uuid.UUID]bool)
+	}
+	if withdraw.Lifecycle(resp.Exchange.Status) != withdraw.LifecyclePending {
+		delete(w.stalled, resp.ID)
+		return false
+	}
+	if now.Sub(resp.CreatedAt) < timeout || w.stalled[resp.ID] {
+		return false
+	}
+	w.stalled[resp.ID] = true
+	return true
+}
+
+// persist stores the updated withdrawal details and refreshes the cache
+func (w *withdrawalTracker) persist(resp *withdraw.Response) {
+	err := withdrawDataStore.UpdateStatus(resp.ID.String(), resp.Exchange.ID, resp.Exchange.Status)
+	if err != nil {
+		log.Errorf(log.Global, "Withdrawal %s status update failed: %v\n", resp.ID, err)
+	}
+	if resp.RequestDetails.Type == withdraw.Crypto {
+		err = withdrawDataStore.UpdateCryptoDetails(resp.ID.String(),
+			resp.Exchange.TxID,
+			resp.RequestDetails.Crypto.FeeAmount)
+		if err != nil {
+			log.Errorf(log.Global, "Withdrawal %s crypto details update failed: %v\n", resp.ID, err)
+		}
+	}
+	resp.UpdatedAt = time.Now()
+	withdraw.Cache.Add(resp.ID.String(), resp)
+}
+
+func (w *withdrawalTracker) notifyLifecycle(resp *withdraw.Response) {
+	lifecycle := withdraw.Lifecycle(resp.Exchange.Status)
+	if lifecycle == withdraw.LifecyclePending {
+		log.Debugf(log.Global, "Withdrawal %s status updated to %s\n", resp.ID, resp.Exchange.Status)
+		return
+	}
+	msg := fmt.Sprintf("Withdrawal %s of %v %s on %s %s with status %s",
+		resp.ID,
+		resp.RequestDetails.Amount,
+		resp.RequestDetails.Currency,
+		resp.Exchange.Name,
+		lifecycle,
+		resp.Exchange.Status)
+	if resp.Exchange.TxID != "" {
+		msg += ", transaction " + resp.Exchange.TxID
+	}
+	w.notify(withdrawalSeverity(lifecycle), msg)
+}
+
+func (w *withdrawalTracker) notify(severity base.Severity, msg string) {
+	log.Infoln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{Type: "withdrawal", Severity: severity, Message: msg})
+}
+
+// withdrawalSeverity returns the severity of a withdrawal reaching the
+// lifecycle state, failed and cancelled withdrawals are critical
+func withdrawalSeverity(lifecycle string) base.Severity {
+	switch lifecycle {
+	case withdraw.LifecycleFailed:
+		return base.SeverityCritical
+	case withdraw.LifecycleCompleted:
+		return base.SeverityTrade
+	}
+	return base.SeverityInfo
+}
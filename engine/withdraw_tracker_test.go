@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+func TestReconcileWithdrawal(t *testing.T) {
+	resp := &withdraw.Response{
+		Exchange: withdraw.ExchangeResponse{ID: "1", Status: "processing"},
+	}
+	if reconcileWithdrawal(resp, &exchange.WithdrawalHistory{Status: "processing"}) {
+		t.Error("expected no change")
+	}
+	if !reconcileWithdrawal(resp, &exchange.WithdrawalHistory{
+		Status:     "Completed",
+		CryptoTxID: "0xdeadbeef",
+		Fee:        0.001,
+	}) {
+		t.Fatal("expected change")
+	}
+	if resp.Exchange.Status != "Completed" ||
+		resp.Exchange.TxID != "0xdeadbeef" ||
+		resp.RequestDetails.Crypto.FeeAmount != 0.001 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestWithdrawalCheckStalled(t *testing.T) {
+	var w withdrawalTracker
+	id, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	resp := &withdraw.Response{
+		ID:        id,
+		Exchange:  withdraw.ExchangeResponse{Status: "processing"},
+		CreatedAt: now.Add(-time.Hour),
+	}
+	if w.checkStalled(resp, now, time.Hour*2) {
+		t.Error("withdrawal should not be stalled before timeout")
+	}
+	if !w.checkStalled(resp, now, time.Minute) {
+		t.Error("withdrawal should be stalled after timeout")
+	}
+	if w.checkStalled(resp, now, time.Minute) {
+		t.Error("stalled withdrawal should only be reported once")
+	}
+	resp.Exchange.Status = "completed"
+	if w.checkStalled(resp, now, time.Minute) {
+		t.Error("completed withdrawal should not be stalled")
+	}
+}
+
+func TestWithdrawalTrack(t *testing.T) {
+	SetupTestHelpers(t)
+	var w withdrawalTracker
+	if Bot.DatabaseManager.Started() {
+		t.Skip("database manager started")
+	}
+	err := w.track()
+	if !errors.Is(err, errWithdrawalTrackerDatabaseNotStarted) {
+		t.Errorf("expected %v received %v", errWithdrawalTrackerDatabaseNotStarted, err)
+	}
+}
@@ -46,24 +46,37 @@ func Process(h *Holdings) error {
 
 // GetHoldings returns full holdings for an exchange
 func GetHoldings(exch string) (Holdings, error) {
+	return GetAccountHoldings(exch, "")
+}
+
+// GetAccountHoldings returns full holdings for a named account on an exchange,
+// an empty account returns the holdings of the default credentials
+func GetAccountHoldings(exch, acc string) (Holdings, error) {
 	if exch == "" {
 		return Holdings{}, errors.New("exchange name unset")
 	}
 
-	exch = strings.ToLower(exch)
-
 	service.Lock()
 	defer service.Unlock()
-	h, ok := service.accounts[exch]
+	h, ok := service.accounts[holdingsKey(exch, acc)]
 	if !ok {
 		return Holdings{}, errors.New("exchange account holdings not found")
 	}
 	return *h.h, nil
 }
 
+// holdingsKey returns the key holdings are stored under, the default
+// credentials are keyed by exchange name alone
+func holdingsKey(exch, acc string) string {
+	if acc == "" {
+		return strings.ToLower(exch)
+	}
+	return strings.ToLower(exch + "/" + acc)
+}
+
 // Update updates holdings with new account info
 func (s *Service) Update(a *Holdings) error {
-	exch := strings.ToLower(a.Exchange)
+	exch := holdingsKey(a.Exchange, a.Account)
 	s.Lock()
 	acc, ok := s.accounts[exch]
 	if !ok {
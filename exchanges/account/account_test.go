@@ -127,3 +127,42 @@ func TestHoldings(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAccountHoldings(t *testing.T) {
+	err := Process(&Holdings{
+		Exchange: "AccountTest",
+		Accounts: []SubAccount{{ID: "main"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Process(&Holdings{
+		Exchange: "AccountTest",
+		Account:  "sub",
+		Accounts: []SubAccount{{ID: "sub"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := GetHoldings("accounttest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Accounts[0].ID != "main" {
+		t.Errorf("expecting main but received %s", h.Accounts[0].ID)
+	}
+
+	h, err = GetAccountHoldings("AccountTest", "SUB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Account != "sub" || h.Accounts[0].ID != "sub" {
+		t.Errorf("expecting sub account holdings but received %+v", h)
+	}
+
+	_, err = GetAccountHoldings("AccountTest", "missing")
+	if err == nil {
+		t.Error("error cannot be nil")
+	}
+}
@@ -27,9 +27,11 @@ type Account struct {
 }
 
 // Holdings is a generic type to hold each exchange's holdings for all enabled
-// currencies
+// currencies. Account is the name of the configured credential set the
+// holdings belong to and is empty for the default credentials
 type Holdings struct {
 	Exchange string
+	Account  string
 	Accounts []SubAccount
 }
 
@@ -85,6 +85,7 @@ func (a *Alphapoint) UpdateTradablePairs(forceUpdate bool) error {
 func (a *Alphapoint) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = a.Name
+	response.Account = a.Account
 	acc, err := a.GetAccountInformation()
 	if err != nil {
 		return response, err
@@ -115,7 +116,7 @@ func (a *Alphapoint) UpdateAccountInfo() (account.Holdings, error) {
 // FetchAccountInfo retrieves balances for all enabled currencies on the
 // Alphapoint exchange
 func (a *Alphapoint) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(a.Name)
+	acc, err := account.GetAccountHoldings(a.Name, a.Account)
 	if err != nil {
 		return a.UpdateAccountInfo()
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -674,6 +675,22 @@ func TestGetAccountInfo(t *testing.T) {
 	}
 }
 
+func TestGetAPIKeyPermissions(t *testing.T) {
+	t.Parallel()
+
+	perms, err := b.GetAPIKeyPermissions()
+	switch {
+	case areTestAPIKeysSet() && err != nil:
+		t.Error("GetAPIKeyPermissions() error", err)
+	case !areTestAPIKeysSet() && err == nil && !mockTests:
+		t.Error("GetAPIKeyPermissions() expecting an error when no keys are set")
+	case mockTests && err != nil:
+		t.Error("Mock GetAPIKeyPermissions() error", err)
+	case mockTests && perms.Trade != exchange.KeyPermissionGranted:
+		t.Errorf("Mock GetAPIKeyPermissions() unexpected permissions %+v", perms)
+	}
+}
+
 func TestModifyOrder(t *testing.T) {
 	t.Parallel()
 
@@ -1008,6 +1025,45 @@ func TestWsDepthUpdate(t *testing.T) {
 	if exp, got := 0.163526, ob.Bids[1].Amount; got != exp {
 		t.Fatalf("Unexpected Bid amount. Exp: %f, got %f", exp, got)
 	}
+
+	// A gap in the update IDs resynchronises the book from a snapshot and
+	// replays the updates received since
+	b.Websocket.Orderbook.SetSnapshotFetcher(func(cp currency.Pair, a asset.Item) (*orderbook.Base, error) {
+		return &orderbook.Base{
+			Pair:         cp,
+			AssetType:    a,
+			ExchangeName: b.Name,
+			Bids:         []orderbook.Item{{Price: 6621.55, Amount: 1}},
+			Asks:         []orderbook.Item{{Price: 6621.8, Amount: 1}},
+			LastUpdateID: 170,
+		}, nil
+	})
+	defer b.Websocket.Orderbook.SetSnapshotFetcher(b.UpdateOrderbook)
+	update3 := []byte(`{"stream":"btcusdt@depth","data":{
+	  "e": "depthUpdate",
+	  "E": 123456790,
+	  "s": "BTCUSDT",
+	  "U": 170,
+	  "u": 172,
+	  "b": [
+		["6621.45", "0.5"]
+	  ],
+	  "a": []
+	}}`)
+	resyncs := b.Websocket.Orderbook.GetResyncStats().Resyncs
+	if err := b.wsHandleData(update3); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; b.Websocket.Orderbook.GetResyncStats().Resyncs == resyncs; i++ {
+		if i == 100 {
+			t.Fatal("expected the book to be resynchronised")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	ob = b.Websocket.Orderbook.GetOrderbook(p, asset.Spot)
+	if ob.LastUpdateID != 172 || len(ob.Bids) != 2 || ob.Bids[1].Amount != 0.5 || len(ob.Asks) != 1 {
+		t.Errorf("expected the update to be replayed on the snapshot, received %+v", ob)
+	}
 }
 
 func TestWsBalanceUpdate(t *testing.T) {
@@ -24,6 +24,9 @@ import (
 const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443/stream"
 	pingDelay                  = time.Minute * 9
+	// wsMaxStreamsPerConnection is the limit of streams a connection can
+	// subscribe to, further streams are subscribed on additional connections
+	wsMaxStreamsPerConnection = 1024
 )
 
 var listenKey string
@@ -96,11 +99,31 @@ func (b *Binance) WsConnect() error {
 		}
 	}
 
-	go b.wsReadData()
+	go b.wsReadData(b.Websocket.Conn)
 	b.setupOrderbookManager()
 	return nil
 }
 
+// wsConnectShard dials an additional connection for streams beyond the per
+// connection limit, only Conn carries the user data stream
+func (b *Binance) wsConnectShard(conn stream.Connection) error {
+	conn.SetURL(strings.Split(conn.GetURL(), "?streams=")[0])
+	var dialer websocket.Dialer
+	err := conn.Dial(&dialer, http.Header{})
+	if err != nil {
+		return fmt.Errorf("%v - Unable to connect to Websocket. Error: %s",
+			b.Name,
+			err)
+	}
+	conn.SetupPingHandler(stream.PingHandler{
+		UseGorillaHandler: true,
+		MessageType:       websocket.PongMessage,
+		Delay:             pingDelay,
+	})
+	go b.wsReadData(conn)
+	return nil
+}
+
 func (b *Binance) setupOrderbookManager() {
 	if b.obm == nil {
 		b.obm = &orderbookManager{
@@ -138,12 +161,12 @@ func (b *Binance) KeepAuthKeyAlive() {
 }
 
 // wsReadData receives and passes on websocket messages for processing
-func (b *Binance) wsReadData() {
+func (b *Binance) wsReadData(conn stream.Connection) {
 	b.Websocket.Wg.Add(1)
 	defer b.Websocket.Wg.Done()
 
 	for {
-		resp := b.Websocket.Conn.ReadMessage()
+		resp := conn.ReadMessage()
 		if resp.Raw == nil {
 			return
 		}
@@ -538,36 +561,57 @@ func (b *Binance) GenerateSubscriptions() ([]stream.ChannelSubscription, error)
 	return subscriptions, nil
 }
 
-// Subscribe subscribes to a set of channels
+// Subscribe subscribes to a set of channels on the connections assigned to
+// carry them
 func (b *Binance) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
-	payload := WsPayload{
-		Method: "SUBSCRIBE",
-	}
-
-	for i := range channelsToSubscribe {
-		payload.Params = append(payload.Params, channelsToSubscribe[i].Channel)
-	}
-	err := b.Websocket.Conn.SendJSONMessage(payload)
-	if err != nil {
-		return err
+	groups := stream.GroupByConnection(channelsToSubscribe)
+	for i := range groups {
+		if len(groups[i]) == 0 {
+			continue
+		}
+		conn, err := b.Websocket.GetConnection(i)
+		if err != nil {
+			return err
+		}
+		payload := WsPayload{
+			Method: "SUBSCRIBE",
+		}
+		for j := range groups[i] {
+			payload.Params = append(payload.Params, groups[i][j].Channel)
+		}
+		err = conn.SendJSONMessage(payload)
+		if err != nil {
+			return err
+		}
+		b.Websocket.AddSuccessfulSubscriptions(groups[i]...)
 	}
-	b.Websocket.AddSuccessfulSubscriptions(channelsToSubscribe...)
 	return nil
 }
 
-// Unsubscribe unsubscribes from a set of channels
+// Unsubscribe unsubscribes from a set of channels on the connections carrying
+// them
 func (b *Binance) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) error {
-	payload := WsPayload{
-		Method: "UNSUBSCRIBE",
-	}
-	for i := range channelsToUnsubscribe {
-		payload.Params = append(payload.Params, channelsToUnsubscribe[i].Channel)
-	}
-	err := b.Websocket.Conn.SendJSONMessage(payload)
-	if err != nil {
-		return err
+	groups := stream.GroupByConnection(channelsToUnsubscribe)
+	for i := range groups {
+		if len(groups[i]) == 0 {
+			continue
+		}
+		conn, err := b.Websocket.GetConnection(i)
+		if err != nil {
+			return err
+		}
+		payload := WsPayload{
+			Method: "UNSUBSCRIBE",
+		}
+		for j := range groups[i] {
+			payload.Params = append(payload.Params, groups[i][j].Channel)
+		}
+		err = conn.SendJSONMessage(payload)
+		if err != nil {
+			return err
+		}
+		b.Websocket.RemoveSuccessfulUnsubscriptions(groups[i]...)
 	}
-	b.Websocket.RemoveSuccessfulUnsubscriptions(channelsToUnsubscribe...)
 	return nil
 }
 
@@ -616,11 +660,12 @@ func (b *Binance) ProcessUpdate(cp currency.Pair, a asset.Item, ws *WebsocketDep
 	}
 
 	return b.Websocket.Orderbook.Update(&buffer.Update{
-		Bids:     updateBid,
-		Asks:     updateAsk,
-		Pair:     cp,
-		UpdateID: ws.LastUpdateID,
-		Asset:    a,
+		Bids:          updateBid,
+		Asks:          updateAsk,
+		Pair:          cp,
+		UpdateID:      ws.LastUpdateID,
+		FirstUpdateID: ws.FirstUpdateID,
+		Asset:         a,
 	})
 }
 
@@ -868,13 +913,10 @@ func (u *update) validate(updt *WebsocketDepthStream, recent *orderbook.Base) (b
 				asset.Spot)
 		}
 		u.initialSync = false
-	} else if updt.FirstUpdateID != id {
-		// While listening to the stream, each new event's U should be
-		// equal to the previous event's u+1.
-		return false, fmt.Errorf("websocket orderbook synchronisation failure for pair %s and asset %s",
-			recent.Pair,
-			asset.Spot)
 	}
+	// While listening to the stream each new event's U should be equal to
+	// the previous event's u+1, the websocket orderbook buffer verifies this
+	// and resynchronises the book from a REST snapshot on a gap
 	return true, nil
 }
 
@@ -186,6 +186,9 @@ func (b *Binance) Setup(exch *config.ExchangeConfig) error {
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
 		SortBuffer:                       true,
 		SortBufferByUpdateIDs:            true,
+		OrderbookSnapshotFetcher:         b.UpdateOrderbook,
+		MaxSubscriptionsPerConnection:    wsMaxStreamsPerConnection,
+		ShardConnector:                   b.wsConnectShard,
 	})
 	if err != nil {
 		return err
@@ -414,6 +417,7 @@ func (b *Binance) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 		return book, err
 	}
 
+	book.LastUpdateID = orderbookNew.LastUpdateID
 	for x := range orderbookNew.Bids {
 		book.Bids = append(book.Bids, orderbook.Item{
 			Amount: orderbookNew.Bids[x].Quantity,
@@ -465,6 +469,7 @@ func (b *Binance) UpdateAccountInfo() (account.Holdings, error) {
 	}
 
 	info.Exchange = b.Name
+	info.Account = b.Account
 	info.Accounts = append(info.Accounts, account.SubAccount{
 		Currencies: currencyBalance,
 	})
@@ -479,7 +484,7 @@ func (b *Binance) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Binance) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -894,6 +899,19 @@ func (b *Binance) ValidateCredentials() error {
 	return b.CheckTransientError(err)
 }
 
+// GetAPIKeyPermissions returns the trading and withdrawal rights of the API key
+func (b *Binance) GetAPIKeyPermissions() (exchange.KeyPermissions, error) {
+	acc, err := b.GetAccount()
+	if err != nil {
+		return exchange.KeyPermissions{}, err
+	}
+	return exchange.KeyPermissions{
+		Read:     exchange.KeyPermissionGranted,
+		Trade:    exchange.NewKeyPermission(acc.CanTrade),
+		Withdraw: exchange.NewKeyPermission(acc.CanWithdraw),
+	}, nil
+}
+
 // FormatExchangeKlineInterval returns Interval to exchange formatted string
 func (b *Binance) FormatExchangeKlineInterval(in kline.Interval) string {
 	if in == kline.OneDay {
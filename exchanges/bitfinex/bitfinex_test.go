@@ -285,6 +285,18 @@ func TestGetKeyPermissions(t *testing.T) {
 	}
 }
 
+func TestGetAPIKeyPermissions(t *testing.T) {
+	if !b.ValidateAPICredentials() {
+		t.SkipNow()
+	}
+	t.Parallel()
+
+	_, err := b.GetAPIKeyPermissions()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestGetMarginInfo(t *testing.T) {
 	if !b.ValidateAPICredentials() {
 		t.SkipNow()
@@ -1504,10 +1516,13 @@ var testOb = orderbook.Base{
 }
 
 func TestChecksum(t *testing.T) {
-	err := validateCRC32(&testOb, 190468240)
+	checksum, err := calculateChecksum(&testOb, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if checksum != 190468240 {
+		t.Fatalf("expected %d but received %d", 190468240, checksum)
+	}
 }
 
 func TestReOrderbyID(t *testing.T) {
@@ -1036,14 +1036,7 @@ func (b *Bitfinex) WsUpdateOrderbook(p currency.Pair, assetType asset.Item, book
 	if checkme.Sequence+1 == sequenceNo {
 		// Sequence numbers get dropped, if checksum is not in line with
 		// sequence, do not check.
-		ob := b.Websocket.Orderbook.GetOrderbook(p, assetType)
-		if ob == nil {
-			return fmt.Errorf("cannot calculate websocket checksum: book not found for %s %s",
-				p,
-				assetType)
-		}
-
-		err := validateCRC32(ob, checkme.Token)
+		err := b.Websocket.Orderbook.VerifyChecksum(p, assetType, uint32(checkme.Token))
 		if err != nil {
 			return err
 		}
@@ -1052,6 +1045,16 @@ func (b *Bitfinex) WsUpdateOrderbook(p currency.Pair, assetType asset.Item, book
 	return b.Websocket.Orderbook.Update(&orderbookUpdate)
 }
 
+// wsResubscribeOrderbook resubscribes to a book which failed checksum
+// verification so a new snapshot is sent
+func (b *Bitfinex) wsResubscribeOrderbook(p currency.Pair, a asset.Item) error {
+	return b.Websocket.ResubscribeToChannel(&stream.ChannelSubscription{
+		Channel:  wsBook,
+		Currency: p,
+		Asset:    a,
+	})
+}
+
 // GenerateDefaultSubscriptions Adds default subscriptions to websocket to be handled by ManageSubscriptions()
 func (b *Bitfinex) GenerateDefaultSubscriptions() ([]stream.ChannelSubscription, error) {
 	var channels = []string{
@@ -1346,21 +1349,24 @@ func makeRequestInterface(channelName string, data interface{}) []interface{} {
 	return []interface{}{0, channelName, nil, data}
 }
 
-func validateCRC32(book *orderbook.Base, token int) error {
+// calculateChecksum calculates the checksum of the top 25 bid and ask
+// levels. Bitfinex sends checksums separately from updates so they are
+// verified before the next update is applied
+func calculateChecksum(book *orderbook.Base, _ *buffer.Update) (uint32, error) {
 	// Order ID's need to be sub-sorted in ascending order, this needs to be
-	// done on the main book to ensure that we do not cut price levels out below
-	reOrderByID(book.Bids)
-	reOrderByID(book.Asks)
+	// done on the whole book to ensure that we do not cut price levels out
+	// below. The buffer's book must not be modified so sort copies
+	bids := append(book.Bids[:0:0], book.Bids...)
+	asks := append(book.Asks[:0:0], book.Asks...)
+	reOrderByID(bids)
+	reOrderByID(asks)
 
 	// RO precision calculation is based on order ID's and amount values
-	var bids, asks []orderbook.Item
-	for i := 0; i < 25; i++ {
-		if i < len(book.Bids) {
-			bids = append(bids, book.Bids[i])
-		}
-		if i < len(book.Asks) {
-			asks = append(asks, book.Asks[i])
-		}
+	if len(bids) > 25 {
+		bids = bids[:25]
+	}
+	if len(asks) > 25 {
+		asks = asks[:25]
 	}
 
 	// ensure '-' (negative amount) is passed back to string buffer as
@@ -1393,15 +1399,7 @@ func validateCRC32(book *orderbook.Base, token int) error {
 	}
 
 	checksumStr := strings.TrimSuffix(check.String(), ":")
-	checksum := crc32.ChecksumIEEE([]byte(checksumStr))
-	if checksum == uint32(token) {
-		return nil
-	}
-	return fmt.Errorf("invalid checksum for %s %s: calculated [%d] does not match [%d]",
-		book.AssetType,
-		book.Pair,
-		checksum,
-		uint32(token))
+	return crc32.ChecksumIEEE([]byte(checksumStr)), nil
 }
 
 // reOrderByID sub sorts orderbook items by its corresponding ID when price
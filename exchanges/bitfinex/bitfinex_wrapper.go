@@ -22,6 +22,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -199,6 +200,8 @@ func (b *Bitfinex) Setup(exch *config.ExchangeConfig) error {
 		GenerateSubscriptions:            b.GenerateDefaultSubscriptions,
 		Features:                         &b.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
+		OrderbookChecksum:                buffer.ChecksumFunc(calculateChecksum),
+		OrderbookResubscriber:            b.wsResubscribeOrderbook,
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
 		UpdateEntriesByID:                true,
 	})
@@ -457,6 +460,7 @@ func (b *Bitfinex) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 func (b *Bitfinex) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = b.Name
+	response.Account = b.Account
 
 	accountBalance, err := b.GetAccountBalance()
 	if err != nil {
@@ -495,7 +499,7 @@ func (b *Bitfinex) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitfinex) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -950,6 +954,20 @@ func (b *Bitfinex) ValidateCredentials() error {
 	return b.CheckTransientError(err)
 }
 
+// GetAPIKeyPermissions returns the trading and withdrawal rights of the API
+// key
+func (b *Bitfinex) GetAPIKeyPermissions() (exchange.KeyPermissions, error) {
+	perms, err := b.GetKeyPermissions()
+	if err != nil {
+		return exchange.KeyPermissions{}, err
+	}
+	return exchange.KeyPermissions{
+		Read:     exchange.NewKeyPermission(perms.Account.Read || perms.Wallets.Read),
+		Trade:    exchange.NewKeyPermission(perms.Orders.Write),
+		Withdraw: exchange.NewKeyPermission(perms.Withdraw.Write),
+	}, nil
+}
+
 // FormatExchangeKlineInterval returns Interval to exchange formatted string
 func (b *Bitfinex) FormatExchangeKlineInterval(in kline.Interval) string {
 	switch in {
@@ -288,8 +288,8 @@ func (b *Bitflyer) GetExecutions() {
 	// Needs to be updated
 }
 
-// GetOpenInterest returns a summary of open interest
-func (b *Bitflyer) GetOpenInterest() {
+// GetOpenInterestSummary returns a summary of open interest
+func (b *Bitflyer) GetOpenInterestSummary() {
 	// Needs to be updated
 }
 
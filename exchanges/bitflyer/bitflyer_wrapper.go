@@ -295,7 +295,7 @@ func (b *Bitflyer) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitflyer) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
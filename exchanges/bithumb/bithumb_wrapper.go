@@ -307,6 +307,7 @@ func (b *Bithumb) UpdateAccountInfo() (account.Holdings, error) {
 	})
 
 	info.Exchange = b.Name
+	info.Account = b.Account
 	err = account.Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
@@ -317,7 +318,7 @@ func (b *Bithumb) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bithumb) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
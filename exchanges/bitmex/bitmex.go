@@ -92,6 +92,9 @@ const (
 	bitmexEndpointUserWalletSummary     = "/user/walletSummary"
 	bitmexEndpointUserRequestWithdraw   = "/user/requestWithdrawal"
 
+	// satoshisPerXBT is used to convert margin values returned in XBt
+	satoshisPerXBT = 1e8
+
 	// ContractPerpetual perpetual contract type
 	ContractPerpetual = iota
 	// ContractFutures futures contract type
@@ -226,6 +229,16 @@ func (b *Bitmex) GetFullFundingHistory() ([]Funding, error) {
 		&fundingHistory)
 }
 
+// GetFundingRates returns funding rate history filtered by the supplied
+// parameters
+func (b *Bitmex) GetFundingRates(params *GenericRequestParams) ([]Funding, error) {
+	var fundingRates []Funding
+
+	return fundingRates, b.SendHTTPRequest(bitmexEndpointFundingHistory,
+		params,
+		&fundingRates)
+}
+
 // GetInstruments returns instrument data
 func (b *Bitmex) GetInstruments(params *GenericRequestParams) ([]Instrument, error) {
 	var instruments []Instrument
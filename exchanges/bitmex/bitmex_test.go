@@ -1,6 +1,7 @@
 package bitmex
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -15,6 +16,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
@@ -966,3 +969,138 @@ func TestGetHistoricTrades(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetFundingRate(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.GetFundingRate(currencyPair, asset.PerpetualContract)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = b.GetFundingRate(currencyPair, asset.Spot)
+	if !errors.Is(err, futures.ErrNotPerpetualAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotPerpetualAsset, err)
+	}
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.GetFundingRateHistory(currencyPair, asset.PerpetualContract, time.Now().Add(-time.Hour*24), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.GetOpenInterest(currencyPair, asset.PerpetualContract)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMarkPrice(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.GetMarkPrice(currencyPair, asset.PerpetualContract)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFuturesContracts(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetFuturesContracts(asset.PerpetualContract)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = b.GetFuturesContracts(asset.Index)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestGetLeveragedPositions(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetLeveragedPositions(asset.PerpetualContract)
+	if areTestAPIKeysSet() && err != nil {
+		t.Error(err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+	_, err = b.GetLeveragedPositions(asset.Index)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestSetLeverage(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.SetLeverage(currencyPair, asset.PerpetualContract, 0)
+	if !errors.Is(err, margin.ErrInvalidLeverage) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidLeverage, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders set to false, skipping test")
+	}
+	err = b.SetLeverage(currencyPair, asset.PerpetualContract, 10)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetMarginType(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.SetMarginType(currencyPair, asset.PerpetualContract, margin.UnknownType)
+	if !errors.Is(err, margin.ErrInvalidMarginType) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidMarginType, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders set to false, skipping test")
+	}
+	err = b.SetMarginType(currencyPair, asset.PerpetualContract, margin.Isolated)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModifyIsolatedMargin(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("XBTUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.ModifyIsolatedMargin(currencyPair, asset.PerpetualContract, 0)
+	if !errors.Is(err, margin.ErrInvalidMarginAmount) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidMarginAmount, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders set to false, skipping test")
+	}
+	err = b.ModifyIsolatedMargin(currencyPair, asset.PerpetualContract, 0.001)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -15,7 +15,9 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
@@ -401,6 +403,7 @@ func (b *Bitmex) UpdateAccountInfo() (account.Holdings, error) {
 	}
 
 	info.Exchange = b.Name
+	info.Account = b.Account
 	info.Accounts = append(info.Accounts, account.SubAccount{
 		Currencies: balances,
 	})
@@ -415,7 +418,7 @@ func (b *Bitmex) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitmex) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -800,3 +803,279 @@ func (b *Bitmex) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end
 func (b *Bitmex) GetHistoricCandlesExtended(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
 	return kline.Item{}, common.ErrFunctionNotSupported
 }
+
+// getInstrument returns the instrument data for a derivative contract
+func (b *Bitmex) getInstrument(p currency.Pair, a asset.Item) (*Instrument, error) {
+	if !futures.IsFuturesAsset(a) {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := b.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	instruments, err := b.GetInstruments(&GenericRequestParams{
+		Symbol: fPair.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range instruments {
+		if instruments[i].Symbol.String() == fPair.String() {
+			return &instruments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s instrument not found", b.Name, fPair)
+}
+
+// GetFundingRate returns the current funding rate for a perpetual contract
+func (b *Bitmex) GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	if !futures.IsPerpetualAsset(a) {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotPerpetualAsset)
+	}
+	instrument, err := b.getInstrument(p, a)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.FundingRate{
+		Exchange:      b.Name,
+		Asset:         a,
+		Pair:          p,
+		Rate:          instrument.FundingRate,
+		PredictedRate: instrument.IndicativeFundingRate,
+		Time:          instrument.FundingTimestamp,
+	}, nil
+}
+
+// GetFundingRateHistory returns historic funding rates for a perpetual
+// contract
+func (b *Bitmex) GetFundingRateHistory(p currency.Pair, a asset.Item, start, end time.Time) ([]futures.FundingRate, error) {
+	if !futures.IsPerpetualAsset(a) {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotPerpetualAsset)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	fPair, err := b.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := b.GetFundingRates(&GenericRequestParams{
+		Symbol:    fPair.String(),
+		StartTime: start.UTC().Format(time.RFC3339),
+		EndTime:   end.UTC().Format(time.RFC3339),
+		Count:     500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]futures.FundingRate, len(rates))
+	for i := range rates {
+		resp[i] = futures.FundingRate{
+			Exchange: b.Name,
+			Asset:    a,
+			Pair:     p,
+			Rate:     rates[i].FundingRate,
+			Time:     rates[i].Timestamp,
+		}
+	}
+	return resp, nil
+}
+
+// GetOpenInterest returns the open interest for a derivative contract
+func (b *Bitmex) GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	instrument, err := b.getInstrument(p, a)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.OpenInterest{
+		Exchange: b.Name,
+		Asset:    a,
+		Pair:     p,
+		Amount:   float64(instrument.OpenInterest),
+		Time:     instrument.Timestamp,
+	}, nil
+}
+
+// GetMarkPrice returns the mark and index price for a derivative contract
+func (b *Bitmex) GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	instrument, err := b.getInstrument(p, a)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.MarkPrice{
+		Exchange:   b.Name,
+		Asset:      a,
+		Pair:       p,
+		MarkPrice:  instrument.MarkPrice,
+		IndexPrice: instrument.IndicativeSettlePrice,
+		Time:       instrument.Timestamp,
+	}, nil
+}
+
+// GetFuturesContracts returns the contract specifications for a derivative
+// asset type
+func (b *Bitmex) GetFuturesContracts(a asset.Item) ([]futures.Contract, error) {
+	if !futures.IsFuturesAsset(a) {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	instruments, err := b.GetActiveInstruments(&GenericRequestParams{})
+	if err != nil {
+		return nil, err
+	}
+	availPairs, err := b.GetAvailablePairs(a)
+	if err != nil {
+		return nil, err
+	}
+	var resp []futures.Contract
+	for i := range instruments {
+		if !availPairs.Contains(instruments[i].Symbol, true) {
+			continue
+		}
+		var expiry time.Time
+		if instruments[i].Expiry != "" {
+			expiry, err = time.Parse(time.RFC3339, instruments[i].Expiry)
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp = append(resp, futures.Contract{
+			Exchange:           b.Name,
+			Asset:              a,
+			Pair:               instruments[i].Symbol,
+			Underlying:         instruments[i].Underlying,
+			Multiplier:         float64(instruments[i].Multiplier),
+			SettlementCurrency: currency.NewCode(instruments[i].SettlCurrency),
+			IsInverse:          instruments[i].IsInverse,
+			Expiry:             expiry,
+		})
+	}
+	return resp, nil
+}
+
+// satoshiToXBT converts Bitmex margin values which are denominated in
+// satoshis
+func satoshiToXBT(v int64) float64 {
+	return float64(v) / satoshisPerXBT
+}
+
+// GetLeveragedPositions returns all open leveraged positions for an asset
+// type
+func (b *Bitmex) GetLeveragedPositions(a asset.Item) ([]margin.Position, error) {
+	if !futures.IsFuturesAsset(a) {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	positions, err := b.GetPositions(PositionGetParams{})
+	if err != nil {
+		return nil, err
+	}
+	availPairs, err := b.GetAvailablePairs(a)
+	if err != nil {
+		return nil, err
+	}
+	format, err := b.GetPairFormat(a, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []margin.Position
+	for i := range positions {
+		if !positions[i].IsOpen || positions[i].CurrentQty == 0 {
+			continue
+		}
+		var p currency.Pair
+		for j := range availPairs {
+			if format.Format(availPairs[j]) == positions[i].Symbol {
+				p = availPairs[j]
+				break
+			}
+		}
+		if p.IsEmpty() {
+			continue
+		}
+		side, marginType := margin.Long, margin.Isolated
+		if positions[i].CurrentQty < 0 {
+			side = margin.Short
+		}
+		if positions[i].CrossMargin {
+			marginType = margin.Cross
+		}
+		resp = append(resp, margin.Position{
+			Exchange:          b.Name,
+			Asset:             a,
+			Pair:              p,
+			Side:              side,
+			MarginType:        marginType,
+			Size:              math.Abs(float64(positions[i].CurrentQty)),
+			EntryPrice:        positions[i].AvgEntryPrice,
+			MarkPrice:         positions[i].MarkPrice,
+			LiquidationPrice:  positions[i].LiquidationPrice,
+			Leverage:          positions[i].Leverage,
+			Margin:            satoshiToXBT(positions[i].PosMargin),
+			MaintenanceMargin: satoshiToXBT(positions[i].MaintMargin),
+			MarginCurrency:    currency.XBT,
+			UnrealisedPNL:     satoshiToXBT(positions[i].UnrealisedPnl),
+			RealisedPNL:       satoshiToXBT(positions[i].RealisedPnl),
+			LastUpdated:       positions[i].Timestamp,
+		})
+	}
+	return resp, nil
+}
+
+// SetLeverage sets the leverage used for a contract, this will switch the
+// position to isolated margin
+func (b *Bitmex) SetLeverage(p currency.Pair, a asset.Item, leverage float64) error {
+	if err := margin.CheckLeverage(leverage); err != nil {
+		return err
+	}
+	if !futures.IsFuturesAsset(a) {
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := b.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return err
+	}
+	_, err = b.LeveragePosition(PositionUpdateLeverageParams{
+		Leverage: leverage,
+		Symbol:   fPair.String(),
+	})
+	return err
+}
+
+// SetMarginType sets whether a contract uses isolated or cross margin
+func (b *Bitmex) SetMarginType(p currency.Pair, a asset.Item, t margin.Type) error {
+	if !t.IsValid() {
+		return margin.ErrInvalidMarginType
+	}
+	if !futures.IsFuturesAsset(a) {
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := b.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return err
+	}
+	_, err = b.IsolatePosition(PositionIsolateMarginParams{
+		Enabled: t == margin.Isolated,
+		Symbol:  fPair.String(),
+	})
+	return err
+}
+
+// ModifyIsolatedMargin adds a positive amount of XBT to or removes a negative
+// amount of XBT from an isolated position
+func (b *Bitmex) ModifyIsolatedMargin(p currency.Pair, a asset.Item, amount float64) error {
+	if amount == 0 {
+		return margin.ErrInvalidMarginAmount
+	}
+	if !futures.IsFuturesAsset(a) {
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := b.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return err
+	}
+	_, err = b.TransferMargin(PositionTransferIsolatedMarginParams{
+		Amount: int64(math.Round(amount * satoshisPerXBT)),
+		Symbol: fPair.String(),
+	})
+	return err
+}
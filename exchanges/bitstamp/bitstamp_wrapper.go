@@ -357,6 +357,7 @@ func (b *Bitstamp) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 func (b *Bitstamp) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = b.Name
+	response.Account = b.Account
 	accountBalance, err := b.GetBalance()
 	if err != nil {
 		return response, err
@@ -384,7 +385,7 @@ func (b *Bitstamp) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitstamp) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -227,6 +227,7 @@ func (b *Bittrex) UpdateTradablePairs(forceUpdate bool) error {
 func (b *Bittrex) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = b.Name
+	response.Account = b.Account
 	accountBalance, err := b.GetAccountBalances()
 	if err != nil {
 		return response, err
@@ -255,7 +256,7 @@ func (b *Bittrex) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bittrex) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -405,6 +405,7 @@ func (b *BTCMarkets) UpdateAccountInfo() (account.Holdings, error) {
 	}
 	resp.Accounts = append(resp.Accounts, acc)
 	resp.Exchange = b.Name
+	resp.Account = b.Account
 
 	err = account.Process(&resp)
 	if err != nil {
@@ -416,7 +417,7 @@ func (b *BTCMarkets) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *BTCMarkets) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
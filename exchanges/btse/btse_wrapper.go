@@ -375,6 +375,7 @@ func (b *BTSE) UpdateAccountInfo() (account.Holdings, error) {
 		)
 	}
 	a.Exchange = b.Name
+	a.Account = b.Account
 	a.Accounts = []account.SubAccount{
 		{
 			Currencies: currencies,
@@ -391,7 +392,7 @@ func (b *BTSE) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *BTSE) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name)
+	acc, err := account.GetAccountHoldings(b.Name, b.Account)
 	if err != nil {
 		return b.UpdateAccountInfo()
 	}
@@ -306,6 +306,7 @@ func (c *CoinbasePro) UpdateTradablePairs(forceUpdate bool) error {
 func (c *CoinbasePro) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = c.Name
+	response.Account = c.Account
 	accountBalance, err := c.GetAccounts()
 	if err != nil {
 		return response, err
@@ -335,7 +336,7 @@ func (c *CoinbasePro) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *CoinbasePro) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name)
+	acc, err := account.GetAccountHoldings(c.Name, c.Account)
 	if err != nil {
 		return c.UpdateAccountInfo()
 	}
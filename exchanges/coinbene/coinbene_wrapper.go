@@ -484,6 +484,7 @@ func (c *Coinbene) UpdateAccountInfo() (account.Holdings, error) {
 	}
 	info.Accounts = append(info.Accounts, acc)
 	info.Exchange = c.Name
+	info.Account = c.Account
 
 	err = account.Process(&info)
 	if err != nil {
@@ -495,7 +496,7 @@ func (c *Coinbene) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *Coinbene) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name)
+	acc, err := account.GetAccountHoldings(c.Name, c.Account)
 	if err != nil {
 		return c.UpdateAccountInfo()
 	}
@@ -371,6 +371,7 @@ func (c *COINUT) UpdateAccountInfo() (account.Holdings, error) {
 		},
 	}
 	info.Exchange = c.Name
+	info.Account = c.Account
 	info.Accounts = append(info.Accounts, account.SubAccount{
 		Currencies: balances,
 	})
@@ -385,7 +386,7 @@ func (c *COINUT) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *COINUT) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name)
+	acc, err := account.GetAccountHoldings(c.Name, c.Account)
 	if err != nil {
 		return c.UpdateAccountInfo()
 	}
@@ -15,6 +15,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -1136,3 +1138,79 @@ func (e *Base) SetSaveTradeDataStatus(enabled bool) {
 		log.Debugf(log.Trade, "Set %v 'SaveTradeData' to %v", e.Name, enabled)
 	}
 }
+
+// GetAPIKeyPermissions returns the permissions of the API key in use, exchanges
+// which expose key permissions override this
+func (e *Base) GetAPIKeyPermissions() (KeyPermissions, error) {
+	return KeyPermissions{}, common.ErrFunctionNotSupported
+}
+
+// String returns the key permission state
+func (k KeyPermission) String() string {
+	switch k {
+	case KeyPermissionDenied:
+		return "denied"
+	case KeyPermissionGranted:
+		return "granted"
+	default:
+		return "unknown"
+	}
+}
+
+// NewKeyPermission returns the state of a permission a key is known to hold or
+// not hold
+func NewKeyPermission(granted bool) KeyPermission {
+	if granted {
+		return KeyPermissionGranted
+	}
+	return KeyPermissionDenied
+}
+
+// GetFundingRate returns the current funding rate for a perpetual contract
+// Exchanges which support perpetual contracts override this
+func (e *Base) GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetFundingRateHistory returns historic funding rates for a perpetual
+// contract
+func (e *Base) GetFundingRateHistory(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time) ([]futures.FundingRate, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetOpenInterest returns the open interest for a derivative contract
+func (e *Base) GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetMarkPrice returns the mark and index price for a derivative contract
+func (e *Base) GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetFuturesContracts returns the contract specifications for a derivative
+// asset type
+func (e *Base) GetFuturesContracts(a asset.Item) ([]futures.Contract, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetLeveragedPositions returns all open leveraged positions for an asset type
+func (e *Base) GetLeveragedPositions(a asset.Item) ([]margin.Position, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SetLeverage sets the leverage used for a contract
+func (e *Base) SetLeverage(p currency.Pair, a asset.Item, leverage float64) error {
+	return common.ErrFunctionNotSupported
+}
+
+// SetMarginType sets whether a contract uses isolated or cross margin
+func (e *Base) SetMarginType(p currency.Pair, a asset.Item, t margin.Type) error {
+	return common.ErrFunctionNotSupported
+}
+
+// ModifyIsolatedMargin adds a positive amount to or removes a negative amount
+// from the margin allocated to an isolated position
+func (e *Base) ModifyIsolatedMargin(p currency.Pair, a asset.Item, amount float64) error {
+	return common.ErrFunctionNotSupported
+}
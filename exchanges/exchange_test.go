@@ -2107,3 +2107,20 @@ func TestAddTradesToBuffer(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestKeyPermission(t *testing.T) {
+	t.Parallel()
+	if NewKeyPermission(true) != KeyPermissionGranted ||
+		NewKeyPermission(false) != KeyPermissionDenied {
+		t.Error("unexpected key permission state")
+	}
+	if KeyPermissionUnknown.String() != "unknown" ||
+		KeyPermissionGranted.String() != "granted" ||
+		KeyPermissionDenied.String() != "denied" {
+		t.Error("unexpected key permission string")
+	}
+	var b Base
+	if _, err := b.GetAPIKeyPermissions(); !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v, received %v", common.ErrFunctionNotSupported, err)
+	}
+}
@@ -127,6 +127,29 @@ type FundHistory struct {
 	BankFrom          string
 }
 
+// KeyPermission is whether an API key holds a permission
+type KeyPermission uint8
+
+// Key permission states
+const (
+	KeyPermissionUnknown KeyPermission = iota
+	KeyPermissionDenied
+	KeyPermissionGranted
+)
+
+// KeyPermissions holds the permissions of an exchange API key. IPRestricted is
+// granted when the key may only be used from the IPWhitelist addresses.
+// Probed is set when the permissions were inferred from requests the key was
+// able to make rather than queried from the exchange
+type KeyPermissions struct {
+	Read         KeyPermission
+	Trade        KeyPermission
+	Withdraw     KeyPermission
+	IPRestricted KeyPermission
+	IPWhitelist  []string
+	Probed       bool
+}
+
 // WithdrawalHistory holds exchange Withdrawal history data
 type WithdrawalHistory struct {
 	Status          string
@@ -221,4 +244,7 @@ type Base struct {
 	Config                      *config.ExchangeConfig
 	settingsMutex               sync.RWMutex
 	OrderbookVerificationBypass bool
+	// Account is the name of the configured credential set the instance signs
+	// requests with and is empty for the default credentials
+	Account string
 }
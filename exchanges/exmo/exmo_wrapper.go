@@ -323,6 +323,7 @@ func (e *EXMO) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderboo
 func (e *EXMO) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = e.Name
+	response.Account = e.Account
 	result, err := e.GetUserInfo()
 	if err != nil {
 		return response, err
@@ -357,7 +358,7 @@ func (e *EXMO) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (e *EXMO) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(e.Name)
+	acc, err := account.GetAccountHoldings(e.Name, e.Account)
 	if err != nil {
 		return e.UpdateAccountInfo()
 	}
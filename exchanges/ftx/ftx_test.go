@@ -1,6 +1,7 @@
 package ftx
 
 import (
+	"errors"
 	"log"
 	"os"
 	"reflect"
@@ -13,7 +14,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -147,7 +150,11 @@ func TestGetFutureStats(t *testing.T) {
 
 func TestGetFundingRates(t *testing.T) {
 	t.Parallel()
-	_, err := f.GetFundingRates()
+	_, err := f.GetFundingRates(time.Time{}, time.Time{}, "")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = f.GetFundingRates(time.Now().Add(-time.Hour), time.Now(), "BTC-PERP")
 	if err != nil {
 		t.Error(err)
 	}
@@ -1278,3 +1285,125 @@ func TestParsingWSOBData2(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetFundingRate(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.GetFundingRate(currencyPair, asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+	currencyPair, err = currency.NewPairFromString("BTC-1225")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.GetFundingRate(currencyPair, asset.Futures)
+	if !errors.Is(err, futures.ErrNotPerpetualAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotPerpetualAsset, err)
+	}
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.GetFundingRateHistory(currencyPair, asset.Futures, time.Now().Add(-time.Hour*24), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.GetOpenInterest(currencyPair, asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMarkPrice(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.GetMarkPrice(currencyPair, asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFuturesContracts(t *testing.T) {
+	t.Parallel()
+	_, err := f.GetFuturesContracts(asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = f.GetFuturesContracts(asset.Spot)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestGetLeveragedPositions(t *testing.T) {
+	t.Parallel()
+	_, err := f.GetLeveragedPositions(asset.Spot)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+	if !areTestAPIKeysSet() {
+		t.Skip()
+	}
+	_, err = f.GetLeveragedPositions(asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetLeverage(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.SetLeverage(currencyPair, asset.Futures, -1)
+	if !errors.Is(err, margin.ErrInvalidLeverage) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidLeverage, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping test, either api keys or canManipulateRealOrders isnt set correctly")
+	}
+	err = f.SetLeverage(currencyPair, asset.Futures, 5)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetMarginType(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.SetMarginType(currencyPair, asset.Futures, margin.Cross)
+	if err != nil {
+		t.Error(err)
+	}
+	err = f.SetMarginType(currencyPair, asset.Futures, margin.Isolated)
+	if !errors.Is(err, margin.ErrMarginTypeUnsupported) {
+		t.Errorf("expected %v received %v", margin.ErrMarginTypeUnsupported, err)
+	}
+	err = f.ModifyIsolatedMargin(currencyPair, asset.Futures, 1)
+	if !errors.Is(err, margin.ErrMarginTypeUnsupported) {
+		t.Errorf("expected %v received %v", margin.ErrMarginTypeUnsupported, err)
+	}
+}
@@ -428,12 +428,13 @@ func (f *FTX) wsHandleData(respRaw []byte) error {
 // WsProcessUpdateOB processes an update on the orderbook
 func (f *FTX) WsProcessUpdateOB(data *WsOrderbookData, p currency.Pair, a asset.Item) error {
 	update := buffer.Update{
-		Asset:      a,
-		Pair:       p,
-		UpdateTime: timestampFromFloat64(data.Time),
+		Asset:          a,
+		Pair:           p,
+		UpdateTime:     timestampFromFloat64(data.Time),
+		Checksum:       uint32(data.Checksum),
+		VerifyChecksum: true,
 	}
 
-	var err error
 	for x := range data.Bids {
 		update.Bids = append(update.Bids, orderbook.Item{
 			Price:  data.Bids[x][0],
@@ -447,21 +448,7 @@ func (f *FTX) WsProcessUpdateOB(data *WsOrderbookData, p currency.Pair, a asset.
 		})
 	}
 
-	err = f.Websocket.Orderbook.Update(&update)
-	if err != nil {
-		return err
-	}
-
-	updatedOb := f.Websocket.Orderbook.GetOrderbook(p, a)
-	checksum := f.CalcUpdateOBChecksum(updatedOb)
-
-	if checksum != data.Checksum {
-		log.Warnf(log.ExchangeSys, "%s checksum failure for item %s",
-			f.Name,
-			p)
-		return errors.New("checksum failed")
-	}
-	return nil
+	return f.Websocket.Orderbook.Update(&update)
 }
 
 func (f *FTX) wsResubToOB(p currency.Pair) error {
@@ -538,24 +525,13 @@ func (f *FTX) CalcPartialOBChecksum(data *WsOrderbookData) int64 {
 	return int64(crc32.ChecksumIEEE([]byte(checksumStr)))
 }
 
-// CalcUpdateOBChecksum calculates checksum of update OB data received from WS
-func (f *FTX) CalcUpdateOBChecksum(data *orderbook.Base) int64 {
-	var checksum strings.Builder
-	var price, amount string
-	for i := 0; i < 100; i++ {
-		if len(data.Bids)-1 >= i {
-			price = checksumParseNumber(data.Bids[i].Price)
-			amount = checksumParseNumber(data.Bids[i].Amount)
-			checksum.WriteString(price + ":" + amount + ":")
-		}
-		if len(data.Asks)-1 >= i {
-			price = checksumParseNumber(data.Asks[i].Price)
-			amount = checksumParseNumber(data.Asks[i].Amount)
-			checksum.WriteString(price + ":" + amount + ":")
-		}
-	}
-	checksumStr := strings.TrimSuffix(checksum.String(), ":")
-	return int64(crc32.ChecksumIEEE([]byte(checksumStr)))
+// orderbookChecksum calculates checksum of the OB once WS updates are applied
+var orderbookChecksum = &buffer.CRC32{
+	Depth:     100,
+	Delimiter: ":",
+	Format: func(item orderbook.Item, _ bool, _ *buffer.Update) string {
+		return checksumParseNumber(item.Price) + ":" + checksumParseNumber(item.Amount)
+	},
 }
 
 func checksumParseNumber(num float64) string {
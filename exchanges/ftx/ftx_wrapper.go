@@ -14,7 +14,9 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
@@ -177,6 +179,7 @@ func (f *FTX) Setup(exch *config.ExchangeConfig) error {
 		GenerateSubscriptions:            f.GenerateDefaultSubscriptions,
 		Features:                         &f.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
+		OrderbookChecksum:                orderbookChecksum,
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
 	})
 	if err != nil {
@@ -383,6 +386,7 @@ func (f *FTX) UpdateAccountInfo() (account.Holdings, error) {
 	}
 	resp.Accounts = append(resp.Accounts, acc)
 	resp.Exchange = f.Name
+	resp.Account = f.Account
 
 	err = account.Process(&resp)
 	if err != nil {
@@ -394,7 +398,7 @@ func (f *FTX) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (f *FTX) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(f.Name)
+	acc, err := account.GetAccountHoldings(f.Name, f.Account)
 	if err != nil {
 		return f.UpdateAccountInfo()
 	}
@@ -1077,3 +1081,259 @@ func (f *FTX) GetHistoricCandlesExtended(p currency.Pair, a asset.Item, start, e
 	}
 	return ret, nil
 }
+
+// formatPerpetual validates and formats a perpetual future name, FTX stores
+// both dated and perpetual contracts under the futures asset type
+func (f *FTX) formatPerpetual(p currency.Pair, a asset.Item) (currency.Pair, error) {
+	if a != asset.Futures {
+		return currency.Pair{}, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := f.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return currency.Pair{}, err
+	}
+	if !strings.EqualFold(fPair.Quote.String(), "PERP") {
+		return currency.Pair{}, fmt.Errorf("%s %w", fPair, futures.ErrNotPerpetualAsset)
+	}
+	return fPair, nil
+}
+
+// GetFundingRate returns the current funding rate for a perpetual contract
+func (f *FTX) GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	fPair, err := f.formatPerpetual(p, a)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := f.GetFutureStats(fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.FundingRate{
+		Exchange:      f.Name,
+		Asset:         a,
+		Pair:          p,
+		PredictedRate: stats.NextFundingRate,
+		Time:          stats.NextFundingTime,
+	}
+	rates, err := f.GetFundingRates(time.Time{}, time.Time{}, fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	for i := range rates {
+		if rates[i].Time.After(resp.Time) || resp.Rate == 0 {
+			resp.Rate = rates[i].Rate
+			resp.Time = rates[i].Time
+		}
+	}
+	return resp, nil
+}
+
+// GetFundingRateHistory returns historic funding rates for a perpetual
+// contract
+func (f *FTX) GetFundingRateHistory(p currency.Pair, a asset.Item, start, end time.Time) ([]futures.FundingRate, error) {
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	fPair, err := f.formatPerpetual(p, a)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := f.GetFundingRates(start, end, fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]futures.FundingRate, len(rates))
+	for i := range rates {
+		resp[i] = futures.FundingRate{
+			Exchange: f.Name,
+			Asset:    a,
+			Pair:     p,
+			Rate:     rates[i].Rate,
+			Time:     rates[i].Time,
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Time.Before(resp[j].Time)
+	})
+	return resp, nil
+}
+
+// GetOpenInterest returns the open interest for a derivative contract
+func (f *FTX) GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	if a != asset.Futures {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := f.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := f.GetFutureStats(fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	return &futures.OpenInterest{
+		Exchange: f.Name,
+		Asset:    a,
+		Pair:     p,
+		Amount:   stats.OpenInterest,
+		Time:     time.Now(),
+	}, nil
+}
+
+// GetMarkPrice returns the mark and index price for a derivative contract
+func (f *FTX) GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	if a != asset.Futures {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	fPair, err := f.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	future, err := f.GetFuture(fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	return &futures.MarkPrice{
+		Exchange:   f.Name,
+		Asset:      a,
+		Pair:       p,
+		MarkPrice:  future.Mark,
+		IndexPrice: future.Index,
+		Time:       time.Now(),
+	}, nil
+}
+
+// GetFuturesContracts returns the contract specifications for a derivative
+// asset type
+func (f *FTX) GetFuturesContracts(a asset.Item) ([]futures.Contract, error) {
+	if a != asset.Futures {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	contracts, err := f.GetFutures()
+	if err != nil {
+		return nil, err
+	}
+	format, err := f.GetPairFormat(a, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []futures.Contract
+	for i := range contracts {
+		if contracts[i].Expired {
+			continue
+		}
+		var p currency.Pair
+		p, err = currency.NewPairDelimiter(contracts[i].Name, format.Delimiter)
+		if err != nil {
+			return nil, err
+		}
+		c := futures.Contract{
+			Exchange:           f.Name,
+			Asset:              a,
+			Pair:               p,
+			Underlying:         contracts[i].Underlying,
+			Multiplier:         1,
+			SettlementCurrency: currency.USD,
+		}
+		if !contracts[i].Perpetual {
+			c.Expiry = contracts[i].Expiry
+		}
+		resp = append(resp, c)
+	}
+	return resp, nil
+}
+
+// GetLeveragedPositions returns all open futures positions, FTX positions are
+// always cross margined against the account collateral
+func (f *FTX) GetLeveragedPositions(a asset.Item) ([]margin.Position, error) {
+	if a != asset.Futures {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	info, err := f.GetAccountInfo()
+	if err != nil {
+		return nil, err
+	}
+	availPairs, err := f.GetAvailablePairs(a)
+	if err != nil {
+		return nil, err
+	}
+	format, err := f.GetPairFormat(a, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []margin.Position
+	for i := range info.Positions {
+		if info.Positions[i].Size == 0 {
+			continue
+		}
+		var p currency.Pair
+		for j := range availPairs {
+			if strings.EqualFold(format.Format(availPairs[j]), info.Positions[i].Future) {
+				p = availPairs[j]
+				break
+			}
+		}
+		if p.IsEmpty() {
+			continue
+		}
+		side := margin.Long
+		if strings.EqualFold(info.Positions[i].Side, order.Sell.String()) {
+			side = margin.Short
+		}
+		resp = append(resp, margin.Position{
+			Exchange:          f.Name,
+			Asset:             a,
+			Pair:              p,
+			Side:              side,
+			MarginType:        margin.Cross,
+			Size:              info.Positions[i].Size,
+			EntryPrice:        info.Positions[i].EntryPrice,
+			LiquidationPrice:  info.Positions[i].EstimatedLiquidationPrice,
+			Leverage:          info.Leverage,
+			Margin:            info.Positions[i].Cost * info.Positions[i].InitialMarginRequirement,
+			MaintenanceMargin: info.Positions[i].Cost * info.Positions[i].MaintenanceMarginRequirement,
+			MarginCurrency:    currency.USD,
+			UnrealisedPNL:     info.Positions[i].UnrealisedPnL,
+			RealisedPNL:       info.Positions[i].RealisedPnL,
+			LastUpdated:       time.Now(),
+		})
+	}
+	return resp, nil
+}
+
+// SetLeverage sets the account wide leverage, FTX does not support per
+// contract leverage so the pair is only validated
+func (f *FTX) SetLeverage(p currency.Pair, a asset.Item, leverage float64) error {
+	if err := margin.CheckLeverage(leverage); err != nil {
+		return err
+	}
+	if a != asset.Futures {
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	if _, err := f.FormatExchangeCurrency(p, a); err != nil {
+		return err
+	}
+	return f.ChangeAccountLeverage(leverage)
+}
+
+// SetMarginType only accepts cross margin as FTX does not support isolated
+// positions
+func (f *FTX) SetMarginType(_ currency.Pair, a asset.Item, t margin.Type) error {
+	if !t.IsValid() {
+		return margin.ErrInvalidMarginType
+	}
+	if a != asset.Futures {
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	if t != margin.Cross {
+		return fmt.Errorf("%s %s %w", f.Name, t, margin.ErrMarginTypeUnsupported)
+	}
+	return nil
+}
+
+// ModifyIsolatedMargin is not supported as FTX does not support isolated
+// positions
+func (f *FTX) ModifyIsolatedMargin(_ currency.Pair, _ asset.Item, _ float64) error {
+	return fmt.Errorf("%s %s %w", f.Name, margin.Isolated, margin.ErrMarginTypeUnsupported)
+}
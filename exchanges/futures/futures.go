@@ -0,0 +1,100 @@
+package futures
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	fundingsql "github.com/thrasher-corp/gocryptotrader/database/repository/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// IsFuturesAsset returns whether the asset type is a derivative contract
+func IsFuturesAsset(a asset.Item) bool {
+	switch a {
+	case asset.PerpetualContract,
+		asset.PerpetualSwap,
+		asset.Futures,
+		asset.UpsideProfitContract,
+		asset.DownsideProfitContract:
+		return true
+	}
+	return false
+}
+
+// IsPerpetualAsset returns whether the asset type is a perpetual contract
+// which pays funding
+func IsPerpetualAsset(a asset.Item) bool {
+	return a == asset.PerpetualContract || a == asset.PerpetualSwap
+}
+
+// IsPerpetual returns whether the contract has no expiry
+func (c *Contract) IsPerpetual() bool {
+	return c.Expiry.IsZero()
+}
+
+// SaveFundingRatesToDatabase converts funding rates and saves results to
+// the database
+func SaveFundingRatesToDatabase(rates ...FundingRate) error {
+	sqlRates, err := fundingRateToSQLData(rates...)
+	if err != nil {
+		return err
+	}
+	return fundingsql.Insert(sqlRates...)
+}
+
+// GetFundingRatesInRange returns stored funding rates in range from the
+// database
+func GetFundingRatesInRange(exchangeName string, a asset.Item, p currency.Pair, startDate, endDate time.Time) ([]FundingRate, error) {
+	if exchangeName == "" || !a.IsValid() || p.IsEmpty() || startDate.IsZero() || endDate.IsZero() {
+		return nil, errInvalidArguments
+	}
+	results, err := fundingsql.GetInRange(exchangeName,
+		a.String(),
+		p.Base.String(),
+		p.Quote.String(),
+		startDate,
+		endDate)
+	if err != nil {
+		return nil, err
+	}
+	return sqlDataToFundingRate(exchangeName, a, p, results...), nil
+}
+
+func fundingRateToSQLData(rates ...FundingRate) ([]fundingsql.Data, error) {
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Time.Before(rates[j].Time)
+	})
+	var results []fundingsql.Data
+	for i := range rates {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fundingsql.Data{
+			ID:        id.String(),
+			Exchange:  rates[i].Exchange,
+			Base:      rates[i].Pair.Base.String(),
+			Quote:     rates[i].Pair.Quote.String(),
+			AssetType: rates[i].Asset.String(),
+			Rate:      rates[i].Rate,
+			Timestamp: rates[i].Time,
+		})
+	}
+	return results, nil
+}
+
+func sqlDataToFundingRate(exchangeName string, a asset.Item, p currency.Pair, dbRates ...fundingsql.Data) []FundingRate {
+	result := make([]FundingRate, len(dbRates))
+	for i := range dbRates {
+		result[i] = FundingRate{
+			Exchange: exchangeName,
+			Asset:    a,
+			Pair:     p,
+			Rate:     dbRates[i].Rate,
+			Time:     dbRates[i].Timestamp.UTC(),
+		}
+	}
+	return result
+}
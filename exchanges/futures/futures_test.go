@@ -0,0 +1,90 @@
+package futures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	fundingsql "github.com/thrasher-corp/gocryptotrader/database/repository/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestIsFuturesAsset(t *testing.T) {
+	t.Parallel()
+	if IsFuturesAsset(asset.Spot) {
+		t.Error("spot should not be a futures asset")
+	}
+	if !IsFuturesAsset(asset.Futures) {
+		t.Error("futures should be a futures asset")
+	}
+	if !IsFuturesAsset(asset.PerpetualSwap) {
+		t.Error("perpetual swap should be a futures asset")
+	}
+	if IsPerpetualAsset(asset.Futures) {
+		t.Error("futures should not be a perpetual asset")
+	}
+	if !IsPerpetualAsset(asset.PerpetualContract) {
+		t.Error("perpetual contract should be a perpetual asset")
+	}
+}
+
+func TestIsPerpetual(t *testing.T) {
+	t.Parallel()
+	c := Contract{}
+	if !c.IsPerpetual() {
+		t.Error("contract without expiry should be perpetual")
+	}
+	c.Expiry = time.Now()
+	if c.IsPerpetual() {
+		t.Error("contract with expiry should not be perpetual")
+	}
+}
+
+func TestFundingRateToSQLData(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	tt := time.Now()
+	rates := []FundingRate{
+		{
+			Exchange: "test",
+			Asset:    asset.PerpetualSwap,
+			Pair:     p,
+			Rate:     2,
+			Time:     tt.Add(time.Hour),
+		},
+		{
+			Exchange: "test",
+			Asset:    asset.PerpetualSwap,
+			Pair:     p,
+			Rate:     1,
+			Time:     tt,
+		},
+	}
+	resp, err := fundingRateToSQLData(rates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected 2 results received %v", len(resp))
+	}
+	if resp[0].Rate != 1 || resp[0].ID == "" {
+		t.Error("expected results to be sorted by time with a generated ID")
+	}
+	if resp[0].Base != "BTC" || resp[0].Quote != "USD" || resp[0].AssetType != "perpetualswap" {
+		t.Errorf("unexpected conversion %+v", resp[0])
+	}
+
+	converted := sqlDataToFundingRate("test", asset.PerpetualSwap, p, fundingsql.Data{Rate: 1, Timestamp: tt})
+	if len(converted) != 1 || converted[0].Rate != 1 || !converted[0].Pair.Equal(p) {
+		t.Errorf("unexpected conversion %+v", converted)
+	}
+}
+
+func TestGetFundingRatesInRange(t *testing.T) {
+	t.Parallel()
+	_, err := GetFundingRatesInRange("", asset.PerpetualSwap, currency.Pair{}, time.Time{}, time.Time{})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("expected %v received %v", errInvalidArguments, err)
+	}
+}
@@ -0,0 +1,65 @@
+package futures
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	// ErrNotFuturesAsset is returned when a derivative only function is called
+	// with a non-derivative asset type
+	ErrNotFuturesAsset = errors.New("asset type is not a futures or perpetual contract")
+	// ErrNotPerpetualAsset is returned when funding rate data is requested for
+	// a contract that does not pay funding
+	ErrNotPerpetualAsset = errors.New("asset type is not a perpetual contract")
+
+	errInvalidArguments = errors.New("invalid arguments received")
+)
+
+// FundingRate defines a funding rate paid between long and short position
+// holders of a perpetual contract at a point in time
+type FundingRate struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Rate     float64
+	// PredictedRate is the estimated rate for the next funding period, this
+	// is only populated for current funding rate requests
+	PredictedRate float64
+	Time          time.Time
+}
+
+// OpenInterest defines the total amount of outstanding contracts
+type OpenInterest struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Amount   float64
+	Time     time.Time
+}
+
+// MarkPrice defines the mark and underlying index price of a contract
+type MarkPrice struct {
+	Exchange   string
+	Asset      asset.Item
+	Pair       currency.Pair
+	MarkPrice  float64
+	IndexPrice float64
+	Time       time.Time
+}
+
+// Contract defines the specifications of a futures or perpetual contract
+type Contract struct {
+	Exchange           string
+	Asset              asset.Item
+	Pair               currency.Pair
+	Underlying         string
+	Multiplier         float64
+	SettlementCurrency currency.Code
+	IsInverse          bool
+	// Expiry is zero for perpetual contracts
+	Expiry time.Time
+}
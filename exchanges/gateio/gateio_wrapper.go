@@ -392,6 +392,7 @@ func (g *Gateio) UpdateAccountInfo() (account.Holdings, error) {
 	}
 
 	info.Exchange = g.Name
+	info.Account = g.Account
 	err := account.Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
@@ -402,7 +403,7 @@ func (g *Gateio) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (g *Gateio) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(g.Name)
+	acc, err := account.GetAccountHoldings(g.Name, g.Account)
 	if err != nil {
 		return g.UpdateAccountInfo()
 	}
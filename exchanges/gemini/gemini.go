@@ -43,6 +43,7 @@ const (
 	geminiWithdraw           = "withdraw/"
 	geminiHeartbeat          = "heartbeat"
 	geminiVolume             = "notionalvolume"
+	geminiRoles              = "roles"
 
 	// Too many requests returns this
 	geminiRateError = "429"
@@ -350,6 +351,13 @@ func (g *Gemini) PostHeartbeat() (string, error) {
 	return response.Result, nil
 }
 
+// GetRoles returns the roles assigned to the API key
+func (g *Gemini) GetRoles() (Roles, error) {
+	var response Roles
+	return response,
+		g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiRoles, nil, &response)
+}
+
 // SendHTTPRequest sends an unauthenticated request
 func (g *Gemini) SendHTTPRequest(path string, result interface{}) error {
 	return g.SendPayload(context.Background(), &request.Item{
@@ -202,6 +202,30 @@ func TestPostHeartbeat(t *testing.T) {
 	}
 }
 
+func TestGetRoles(t *testing.T) {
+	t.Parallel()
+	_, err := g.GetRoles()
+	if err != nil && mockTests {
+		t.Error("GetRoles() error", err)
+	} else if err == nil && !mockTests {
+		t.Error("GetRoles() error cannot be nil")
+	}
+}
+
+func TestGetAPIKeyPermissions(t *testing.T) {
+	t.Parallel()
+	perms, err := g.GetAPIKeyPermissions()
+	if err != nil && mockTests {
+		t.Error("GetAPIKeyPermissions() error", err)
+	} else if err == nil && !mockTests {
+		t.Error("GetAPIKeyPermissions() error cannot be nil")
+	}
+	if mockTests && (perms.Trade != exchange.KeyPermissionGranted ||
+		perms.Withdraw != exchange.KeyPermissionGranted) {
+		t.Errorf("unexpected key permissions %+v", perms)
+	}
+}
+
 func setFeeBuilder() *exchange.FeeBuilder {
 	return &exchange.FeeBuilder{
 		Amount:  1,
@@ -192,6 +192,14 @@ type Balance struct {
 	Available float64 `json:"available,string"`
 }
 
+// Roles holds the roles assigned to an API key
+type Roles struct {
+	IsAuditor     bool   `json:"isAuditor"`
+	IsFundManager bool   `json:"isFundManager"`
+	IsTrader      bool   `json:"isTrader"`
+	Counterparty  string `json:"counterparty_id"`
+}
+
 // DepositAddress holds assigned deposit address for a specific currency
 type DepositAddress struct {
 	Currency string `json:"currency"`
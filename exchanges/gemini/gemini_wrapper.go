@@ -199,6 +199,7 @@ func (g *Gemini) UpdateTradablePairs(forceUpdate bool) error {
 func (g *Gemini) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = g.Name
+	response.Account = g.Account
 	accountBalance, err := g.GetBalances()
 	if err != nil {
 		return response, err
@@ -227,7 +228,7 @@ func (g *Gemini) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (g *Gemini) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(g.Name)
+	acc, err := account.GetAccountHoldings(g.Name, g.Account)
 	if err != nil {
 		return g.UpdateAccountInfo()
 	}
@@ -660,6 +661,20 @@ func (g *Gemini) ValidateCredentials() error {
 	return g.CheckTransientError(err)
 }
 
+// GetAPIKeyPermissions returns the trading and withdrawal rights of the API key
+// from its assigned roles
+func (g *Gemini) GetAPIKeyPermissions() (exchange.KeyPermissions, error) {
+	roles, err := g.GetRoles()
+	if err != nil {
+		return exchange.KeyPermissions{}, err
+	}
+	return exchange.KeyPermissions{
+		Read:     exchange.KeyPermissionGranted,
+		Trade:    exchange.NewKeyPermission(roles.IsTrader),
+		Withdraw: exchange.NewKeyPermission(roles.IsFundManager),
+	}, nil
+}
+
 // GetHistoricCandles returns candles between a time period for a set time interval
 func (g *Gemini) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
 	return kline.Item{}, common.ErrFunctionNotSupported
@@ -407,6 +407,7 @@ func (h *HitBTC) UpdateOrderbook(c currency.Pair, assetType asset.Item) (*orderb
 func (h *HitBTC) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = h.Name
+	response.Account = h.Account
 	accountBalance, err := h.GetBalances()
 	if err != nil {
 		return response, err
@@ -435,7 +436,7 @@ func (h *HitBTC) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (h *HitBTC) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(h.Name)
+	acc, err := account.GetAccountHoldings(h.Name, h.Account)
 	if err != nil {
 		return h.UpdateAccountInfo()
 	}
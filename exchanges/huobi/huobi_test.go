@@ -690,12 +690,34 @@ func TestWsSubResponse(t *testing.T) {
   "ts": 1489474081631,
   "topic": "accounts"
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
+// pongRecorder records the messages sent on a connection
+type pongRecorder struct {
+	stream.Connection
+	sent []interface{}
+}
+
+func (p *pongRecorder) SendJSONMessage(v interface{}) error {
+	p.sent = append(p.sent, v)
+	return nil
+}
+
+func TestWsPingResponse(t *testing.T) {
+	conn := new(pongRecorder)
+	err := h.wsHandleData(conn, []byte(`{"ping":1492420473027}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.sent) != 1 || conn.sent[0] != (WsPong{Pong: 1492420473027}) {
+		t.Errorf("expected the ping to be answered on its connection, received %v", conn.sent)
+	}
+}
+
 func TestWsKline(t *testing.T) {
 	pressXToJSON := []byte(`{
   "ch": "market.btcusdt.kline.1min",
@@ -711,7 +733,7 @@ func TestWsKline(t *testing.T) {
     "vol": 0.0
   }
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -724,7 +746,7 @@ func TestWsUnsubscribe(t *testing.T) {
   "unsubbed": "market.btcusdt.trade.detail",
   "ts": 1494326028889
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -757,7 +779,7 @@ func TestWsKlineArray(t *testing.T) {
     }
   ]
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -780,7 +802,7 @@ func TestWsMarketDepth(t *testing.T) {
     "ts": 1572362902012
   }
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -799,7 +821,7 @@ func TestWsBestBidOffer(t *testing.T) {
 		"askSize": "0.3"
 	  }
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -824,7 +846,7 @@ func TestWsTradeDetail(t *testing.T) {
 			]
 	  }
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -846,7 +868,7 @@ func TestWsTicker(t *testing.T) {
 		"vol":    121906001.754751
 	  }
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -869,7 +891,7 @@ func TestWsAccountUpdate(t *testing.T) {
 		]
 	  }
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -899,7 +921,7 @@ func TestWsOrderUpdate(t *testing.T) {
     "filled-fees": "8.000000000000000000"
   }
 }`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -911,7 +933,7 @@ func TestWsSubsbOp(t *testing.T) {
 	  "topic": "accounts",
 	  "cid": "123"
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -922,7 +944,7 @@ func TestWsSubsbOp(t *testing.T) {
 	  "ts": 1489474081631,
 	  "topic": "accounts"
 	}`)
-	err = h.wsHandleData(pressXToJSON)
+	err = h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -941,7 +963,7 @@ func TestWsMarketByPrice(t *testing.T) {
 			]
 		}
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -967,7 +989,7 @@ func TestWsMarketByPrice(t *testing.T) {
 			]
 		}
 	}`)
-	err = h.wsHandleData(pressXToJSON)
+	err = h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -992,7 +1014,7 @@ func TestWsOrdersUpdate(t *testing.T) {
 			"order-type": "buy-limit"
 	}
 	}`)
-	err := h.wsHandleData(pressXToJSON)
+	err := h.wsHandleData(h.Websocket.Conn, pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -1,5 +1,7 @@
 package huobi
 
+import "github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+
 // Response stores the Huobi response information
 type Response struct {
 	Status       string `json:"status"`
@@ -411,8 +413,9 @@ type WsAuthenticationRequest struct {
 
 // WsMessage defines read data from the websocket connection
 type WsMessage struct {
-	Raw []byte
-	URL string
+	Raw  []byte
+	URL  string
+	Conn stream.Connection
 }
 
 // WsAuthenticatedSubscriptionRequest request for subscription on authenticated connection
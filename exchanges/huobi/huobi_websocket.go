@@ -51,6 +51,11 @@ const (
 
 	loginDelay = 50 * time.Millisecond
 	rateLimit  = 20
+
+	// wsMaxSubscriptionsPerConnection keeps market connections well below
+	// the topic count at which Huobi throttles a connection, additional
+	// connections carry the rest
+	wsMaxSubscriptionsPerConnection = 100
 )
 
 // Instantiates a communications channel between websocket connections
@@ -108,6 +113,18 @@ func (h *HUOBI) wsAuthenticatedDial(dialer *websocket.Dialer) error {
 	return nil
 }
 
+// wsConnectShard dials an additional market connection for subscriptions
+// beyond the per connection limit, authenticated channels remain on AuthConn
+func (h *HUOBI) wsConnectShard(conn stream.Connection) error {
+	var dialer websocket.Dialer
+	err := conn.Dial(&dialer, http.Header{})
+	if err != nil {
+		return err
+	}
+	go h.wsFunnelConnectionData(conn, wsMarketURL)
+	return nil
+}
+
 // wsFunnelConnectionData manages data from multiple endpoints and passes it to
 // a channel
 func (h *HUOBI) wsFunnelConnectionData(ws stream.Connection, url string) {
@@ -118,7 +135,7 @@ func (h *HUOBI) wsFunnelConnectionData(ws stream.Connection, url string) {
 		if resp.Raw == nil {
 			return
 		}
-		comms <- WsMessage{Raw: resp.Raw, URL: url}
+		comms <- WsMessage{Raw: resp.Raw, URL: url, Conn: ws}
 	}
 }
 
@@ -128,7 +145,7 @@ func (h *HUOBI) wsReadData() {
 	defer h.Websocket.Wg.Done()
 	for {
 		resp := <-comms
-		err := h.wsHandleData(resp.Raw)
+		err := h.wsHandleData(resp.Conn, resp.Raw)
 		if err != nil {
 			h.Websocket.DataHandler <- err
 		}
@@ -175,7 +192,9 @@ func stringToOrderType(oType string) (order.Type, error) {
 		errors.New(oType + " not recognised as order type")
 }
 
-func (h *HUOBI) wsHandleData(respRaw []byte) error {
+// wsHandleData processes a message read from conn, market pings are answered
+// on the connection which sent them
+func (h *HUOBI) wsHandleData(conn stream.Connection, respRaw []byte) error {
 	var init WsResponse
 	err := json.Unmarshal(respRaw, &init)
 	if err != nil {
@@ -189,7 +208,7 @@ func (h *HUOBI) wsHandleData(respRaw []byte) error {
 		return nil
 	}
 	if init.Ping != 0 {
-		h.sendPingResponse(init.Ping)
+		h.sendPingResponse(conn, init.Ping)
 		return nil
 	}
 
@@ -418,8 +437,8 @@ func (h *HUOBI) wsHandleData(respRaw []byte) error {
 	return nil
 }
 
-func (h *HUOBI) sendPingResponse(pong int64) {
-	err := h.Websocket.Conn.SendJSONMessage(WsPong{Pong: pong})
+func (h *HUOBI) sendPingResponse(conn stream.Connection, pong int64) {
+	err := conn.SendJSONMessage(WsPong{Pong: pong})
 	if err != nil {
 		log.Error(log.ExchangeSys, err)
 	}
@@ -501,7 +520,8 @@ func (h *HUOBI) GenerateDefaultSubscriptions() ([]stream.ChannelSubscription, er
 	return subscriptions, nil
 }
 
-// Subscribe sends a websocket message to receive data from the channel
+// Subscribe sends a websocket message to receive data from the channel on
+// the connection assigned to carry it
 func (h *HUOBI) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
 	var errs common.Errors
 	for i := range channelsToSubscribe {
@@ -517,7 +537,12 @@ func (h *HUOBI) Subscribe(channelsToSubscribe []stream.ChannelSubscription) erro
 			h.Websocket.AddSuccessfulSubscriptions(channelsToSubscribe[i])
 			continue
 		}
-		err := h.Websocket.Conn.SendJSONMessage(WsRequest{
+		conn, err := h.Websocket.GetConnection(channelsToSubscribe[i].Connection)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = conn.SendJSONMessage(WsRequest{
 			Subscribe: channelsToSubscribe[i].Channel,
 		})
 		if err != nil {
@@ -532,7 +557,8 @@ func (h *HUOBI) Subscribe(channelsToSubscribe []stream.ChannelSubscription) erro
 	return nil
 }
 
-// Unsubscribe sends a websocket message to stop receiving data from the channel
+// Unsubscribe sends a websocket message to stop receiving data from the
+// channel on the connection carrying it
 func (h *HUOBI) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) error {
 	var errs common.Errors
 	for i := range channelsToUnsubscribe {
@@ -548,7 +574,12 @@ func (h *HUOBI) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription)
 			h.Websocket.RemoveSuccessfulUnsubscriptions(channelsToUnsubscribe[i])
 			continue
 		}
-		err := h.Websocket.Conn.SendJSONMessage(WsRequest{
+		conn, err := h.Websocket.GetConnection(channelsToUnsubscribe[i].Connection)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = conn.SendJSONMessage(WsRequest{
 			Unsubscribe: channelsToUnsubscribe[i].Channel,
 		})
 		if err != nil {
@@ -165,6 +165,8 @@ func (h *HUOBI) Setup(exch *config.ExchangeConfig) error {
 		Features:                         &h.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
+		MaxSubscriptionsPerConnection:    wsMaxSubscriptionsPerConnection,
+		ShardConnector:                   h.wsConnectShard,
 	})
 	if err != nil {
 		return err
@@ -443,6 +445,7 @@ func (h *HUOBI) GetAccountID() ([]Account, error) {
 func (h *HUOBI) UpdateAccountInfo() (account.Holdings, error) {
 	var info account.Holdings
 	info.Exchange = h.Name
+	info.Account = h.Account
 	if h.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
 		resp, err := h.wsGetAccountsList()
 		if err != nil {
@@ -531,7 +534,7 @@ func (h *HUOBI) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (h *HUOBI) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(h.Name)
+	acc, err := account.GetAccountHoldings(h.Name, h.Account)
 	if err != nil {
 		return h.UpdateAccountInfo()
 	}
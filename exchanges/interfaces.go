@@ -8,6 +8,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -73,8 +75,18 @@ type IBotExchange interface {
 	SupportsAsset(assetType asset.Item) bool
 	GetHistoricCandles(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time, interval kline.Interval) (kline.Item, error)
 	GetHistoricCandlesExtended(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time, interval kline.Interval) (kline.Item, error)
+	GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error)
+	GetFundingRateHistory(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time) ([]futures.FundingRate, error)
+	GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error)
+	GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error)
+	GetFuturesContracts(a asset.Item) ([]futures.Contract, error)
+	GetLeveragedPositions(a asset.Item) ([]margin.Position, error)
+	SetLeverage(p currency.Pair, a asset.Item, leverage float64) error
+	SetMarginType(p currency.Pair, a asset.Item, t margin.Type) error
+	ModifyIsolatedMargin(p currency.Pair, a asset.Item, amount float64) error
 	DisableRateLimiter() error
 	EnableRateLimiter() error
+	GetAPIKeyPermissions() (KeyPermissions, error)
 
 	// Websocket specific wrapper functionality
 	// GetWebsocket returns a pointer to the websocket
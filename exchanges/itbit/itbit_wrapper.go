@@ -247,6 +247,7 @@ func (i *ItBit) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 func (i *ItBit) UpdateAccountInfo() (account.Holdings, error) {
 	var info account.Holdings
 	info.Exchange = i.Name
+	info.Account = i.Account
 
 	wallets, err := i.GetWallets(url.Values{})
 	if err != nil {
@@ -294,7 +295,7 @@ func (i *ItBit) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (i *ItBit) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(i.Name)
+	acc, err := account.GetAccountHoldings(i.Name, i.Account)
 	if err != nil {
 		return i.UpdateAccountInfo()
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -1643,8 +1644,14 @@ func TestChecksumCalculation(t *testing.T) {
 		t.Fatalf("expected %s but received %s", expected, v)
 	}
 
-	err := validateCRC32(&testOb, krakenAPIDocChecksum, 5, 8)
+	check, err := orderbookChecksum.Calculate(&testOb, &buffer.Update{
+		PriceDecimals:  5,
+		AmountDecimals: 8,
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
+	if check != krakenAPIDocChecksum {
+		t.Fatalf("expected %d but received %d", krakenAPIDocChecksum, check)
+	}
 }
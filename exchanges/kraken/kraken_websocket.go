@@ -1,10 +1,10 @@
 package kraken
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"hash/crc32"
 	"net/http"
 	"strconv"
 	"strings"
@@ -788,6 +788,16 @@ func (k *Kraken) wsProcessOrderBook(channelData *WebsocketChannelData, data map[
 	return nil
 }
 
+// wsResubscribeOrderbook resubscribes to a book which failed checksum
+// verification so a new snapshot is sent
+func (k *Kraken) wsResubscribeOrderbook(p currency.Pair, a asset.Item) error {
+	return k.Websocket.ResubscribeToChannel(&stream.ChannelSubscription{
+		Channel:  krakenWsOrderbook,
+		Currency: p,
+		Asset:    a,
+	})
+}
+
 // wsProcessOrderBookPartial creates a new orderbook entry for a given currency pair
 func (k *Kraken) wsProcessOrderBookPartial(channelData *WebsocketChannelData, askData, bidData []interface{}) error {
 	base := orderbook.Base{
@@ -985,62 +995,36 @@ func (k *Kraken) wsProcessOrderBookUpdate(channelData *WebsocketChannelData, ask
 			amtDP = len(aSplit[1])
 		}
 	}
-	update.UpdateTime = highestLastUpdate
-	err := k.Websocket.Orderbook.Update(&update)
-	if err != nil {
-		return err
-	}
-
-	book := k.Websocket.Orderbook.GetOrderbook(channelData.Pair, asset.Spot)
-	if book == nil {
-		return fmt.Errorf("cannot calculate websocket checksum: book not found for %s %s",
+	if priceDP == 0 || amtDP == 0 {
+		return fmt.Errorf("%s %s trailing decimal count not calculated",
 			channelData.Pair,
 			asset.Spot)
 	}
 
-	token, err := strconv.ParseInt(checksum, 10, 64)
+	token, err := strconv.ParseUint(checksum, 10, 32)
 	if err != nil {
 		return err
 	}
 
-	return validateCRC32(book, uint32(token), priceDP, amtDP)
+	update.UpdateTime = highestLastUpdate
+	update.Checksum = uint32(token)
+	update.VerifyChecksum = true
+	update.PriceDecimals = priceDP
+	update.AmountDecimals = amtDP
+	return k.Websocket.Orderbook.Update(&update)
 }
 
-func validateCRC32(b *orderbook.Base, token uint32, decPrice, decAmount int) error {
-	if len(b.Asks) < 10 || len(b.Bids) < 10 {
-		return fmt.Errorf("%s %s insufficient bid and asks to calculate checksum",
-			b.Pair,
-			b.AssetType)
-	}
-
-	if decPrice == 0 || decAmount == 0 {
-		return fmt.Errorf("%s %s trailing decimal count not calculated", b.Pair,
-			b.AssetType)
-	}
-
-	var checkStr strings.Builder
-	for i := 0; i < 10; i++ {
-		priceStr := trim(strconv.FormatFloat(b.Asks[i].Price, 'f', decPrice, 64))
-		checkStr.WriteString(priceStr)
-		amountStr := trim(strconv.FormatFloat(b.Asks[i].Amount, 'f', decAmount, 64))
-		checkStr.WriteString(amountStr)
-	}
-
-	for i := 0; i < 10; i++ {
-		priceStr := trim(strconv.FormatFloat(b.Bids[i].Price, 'f', decPrice, 64))
-		checkStr.WriteString(priceStr)
-		amountStr := trim(strconv.FormatFloat(b.Bids[i].Amount, 'f', decAmount, 64))
-		checkStr.WriteString(amountStr)
-	}
-
-	if check := crc32.ChecksumIEEE([]byte(checkStr.String())); check != token {
-		return fmt.Errorf("%s %s invalid checksum %d, expected %d",
-			b.Pair,
-			b.AssetType,
-			check,
-			token)
-	}
-	return nil
+// orderbookChecksum concatenates the top 10 asks then the top 10 bids, the
+// price and amount of each formatted to the update's decimal places with the
+// '.' and leading zeros removed
+var orderbookChecksum = &buffer.CRC32{
+	Depth:        10,
+	RequireDepth: true,
+	AsksFirst:    true,
+	Format: func(item orderbook.Item, _ bool, u *buffer.Update) string {
+		return trim(strconv.FormatFloat(item.Price, 'f', u.PriceDecimals, 64)) +
+			trim(strconv.FormatFloat(item.Amount, 'f', u.AmountDecimals, 64))
+	},
 }
 
 // trim removes '.' and prefixed '0' from subsequent string
@@ -1285,18 +1269,15 @@ func (k *Kraken) wsAddOrder(request *WsAddOrderRequest) (string, error) {
 	request.RequestID = id
 	request.Event = krakenWsAddOrder
 	request.Token = authToken
-	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponse(id, request)
-	if err != nil {
-		return "", err
-	}
 	var resp WsAddOrderResponse
-	err = json.Unmarshal(jsonResp, &resp)
+	err := k.Websocket.AuthConn.SendRequest(context.Background(), &stream.Request{
+		Signature:      id,
+		Payload:        request,
+		ErrorExtractor: wsResponseError,
+	}, &resp)
 	if err != nil {
 		return "", err
 	}
-	if resp.ErrorMessage != "" {
-		return "", fmt.Errorf(k.Name + " - " + resp.ErrorMessage)
-	}
 	return resp.TransactionID, nil
 }
 
@@ -1349,17 +1330,29 @@ func (k *Kraken) wsCancelAllOrders() (*WsCancelOrderResponse, error) {
 		RequestID: id,
 	}
 
-	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponse(id, request)
-	if err != nil {
-		return &WsCancelOrderResponse{}, err
-	}
 	var resp WsCancelOrderResponse
-	err = json.Unmarshal(jsonResp, &resp)
+	err := k.Websocket.AuthConn.SendRequest(context.Background(), &stream.Request{
+		Signature:      id,
+		Payload:        request,
+		ErrorExtractor: wsResponseError,
+	}, &resp)
 	if err != nil {
 		return &WsCancelOrderResponse{}, err
 	}
-	if resp.ErrorMessage != "" {
-		return &WsCancelOrderResponse{}, fmt.Errorf(k.Name + " - " + resp.ErrorMessage)
-	}
 	return &resp, nil
 }
+
+// wsResponseError returns the error message carried by an authenticated
+// websocket request response
+func wsResponseError(resp []byte) error {
+	var r struct {
+		ErrorMessage string `json:"errorMessage"`
+	}
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return err
+	}
+	if r.ErrorMessage != "" {
+		return errors.New(r.ErrorMessage)
+	}
+	return nil
+}
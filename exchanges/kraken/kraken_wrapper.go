@@ -187,6 +187,8 @@ func (k *Kraken) Setup(exch *config.ExchangeConfig) error {
 		GenerateSubscriptions:            k.GenerateDefaultSubscriptions,
 		Features:                         &k.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
+		OrderbookChecksum:                orderbookChecksum,
+		OrderbookResubscriber:            k.wsResubscribeOrderbook,
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
 		SortBuffer:                       true,
 	})
@@ -466,6 +468,7 @@ func (k *Kraken) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 func (k *Kraken) UpdateAccountInfo() (account.Holdings, error) {
 	var info account.Holdings
 	info.Exchange = k.Name
+	info.Account = k.Account
 
 	bal, err := k.GetBalance()
 	if err != nil {
@@ -501,7 +504,7 @@ func (k *Kraken) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (k *Kraken) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(k.Name)
+	acc, err := account.GetAccountHoldings(k.Name, k.Account)
 	if err != nil {
 		return k.UpdateAccountInfo()
 	}
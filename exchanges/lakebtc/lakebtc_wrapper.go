@@ -308,6 +308,7 @@ func (l *LakeBTC) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 func (l *LakeBTC) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = l.Name
+	response.Account = l.Account
 	accountInfo, err := l.GetAccountInformation()
 	if err != nil {
 		return response, err
@@ -341,7 +342,7 @@ func (l *LakeBTC) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *LakeBTC) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name)
+	acc, err := account.GetAccountHoldings(l.Name, l.Account)
 	if err != nil {
 		return l.UpdateAccountInfo()
 	}
@@ -324,6 +324,7 @@ func (l *Lbank) UpdateAccountInfo() (account.Holdings, error) {
 
 	info.Accounts = append(info.Accounts, acc)
 	info.Exchange = l.Name
+	info.Account = l.Account
 
 	err = account.Process(&info)
 	if err != nil {
@@ -334,7 +335,7 @@ func (l *Lbank) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *Lbank) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name)
+	acc, err := account.GetAccountHoldings(l.Name, l.Account)
 	if err != nil {
 		return l.UpdateAccountInfo()
 	}
@@ -253,6 +253,7 @@ func (l *LocalBitcoins) UpdateOrderbook(p currency.Pair, assetType asset.Item) (
 func (l *LocalBitcoins) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = l.Name
+	response.Account = l.Account
 	accountBalance, err := l.GetWalletBalance()
 	if err != nil {
 		return response, err
@@ -275,7 +276,7 @@ func (l *LocalBitcoins) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *LocalBitcoins) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name)
+	acc, err := account.GetAccountHoldings(l.Name, l.Account)
 	if err != nil {
 		return l.UpdateAccountInfo()
 	}
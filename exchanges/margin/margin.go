@@ -0,0 +1,54 @@
+package margin
+
+import (
+	"math"
+	"strings"
+)
+
+// String implements the stringer interface
+func (t Type) String() string {
+	return string(t)
+}
+
+// String implements the stringer interface
+func (s Side) String() string {
+	return string(s)
+}
+
+// StringToType converts a case insensitive margin type string, including
+// common exchange aliases, to a Type
+func StringToType(t string) (Type, error) {
+	switch {
+	case strings.EqualFold(t, Isolated.String()),
+		strings.EqualFold(t, "fixed"):
+		return Isolated, nil
+	case strings.EqualFold(t, Cross.String()),
+		strings.EqualFold(t, "crossed"):
+		return Cross, nil
+	default:
+		return UnknownType, ErrInvalidMarginType
+	}
+}
+
+// IsValid returns whether the margin type is a supported type
+func (t Type) IsValid() bool {
+	return t == Isolated || t == Cross
+}
+
+// CheckLeverage verifies a requested leverage value
+func CheckLeverage(leverage float64) error {
+	if leverage <= 0 || math.IsNaN(leverage) || math.IsInf(leverage, 0) {
+		return ErrInvalidLeverage
+	}
+	return nil
+}
+
+// LiquidationDistance returns the fractional price move against the position
+// required to reach the liquidation price. Zero is returned when either the
+// mark or liquidation price is unknown
+func (p *Position) LiquidationDistance() float64 {
+	if p.MarkPrice <= 0 || p.LiquidationPrice <= 0 {
+		return 0
+	}
+	return math.Abs(p.MarkPrice-p.LiquidationPrice) / p.MarkPrice
+}
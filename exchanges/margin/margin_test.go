@@ -0,0 +1,71 @@
+package margin
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStringToType(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		in       string
+		expected Type
+		err      error
+	}{
+		{"isolated", Isolated, nil},
+		{"FIXED", Isolated, nil},
+		{"Cross", Cross, nil},
+		{"crossed", Cross, nil},
+		{"hedge", UnknownType, ErrInvalidMarginType},
+		{"", UnknownType, ErrInvalidMarginType},
+	}
+	for i := range tt {
+		r, err := StringToType(tt[i].in)
+		if !errors.Is(err, tt[i].err) {
+			t.Errorf("%s: expected %v received %v", tt[i].in, tt[i].err, err)
+		}
+		if r != tt[i].expected {
+			t.Errorf("%s: expected %v received %v", tt[i].in, tt[i].expected, r)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+	if !Isolated.IsValid() || !Cross.IsValid() {
+		t.Error("expected margin types to be valid")
+	}
+	if UnknownType.IsValid() {
+		t.Error("expected unknown margin type to be invalid")
+	}
+}
+
+func TestCheckLeverage(t *testing.T) {
+	t.Parallel()
+	if err := CheckLeverage(10); err != nil {
+		t.Error(err)
+	}
+	for _, l := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if err := CheckLeverage(l); !errors.Is(err, ErrInvalidLeverage) {
+			t.Errorf("%v: expected %v received %v", l, ErrInvalidLeverage, err)
+		}
+	}
+}
+
+func TestLiquidationDistance(t *testing.T) {
+	t.Parallel()
+	p := Position{MarkPrice: 10000, LiquidationPrice: 9000}
+	if d := p.LiquidationDistance(); d != 0.1 {
+		t.Errorf("expected 0.1 received %v", d)
+	}
+	p.Side = Short
+	p.LiquidationPrice = 12000
+	if d := p.LiquidationDistance(); d != 0.2 {
+		t.Errorf("expected 0.2 received %v", d)
+	}
+	p.LiquidationPrice = 0
+	if d := p.LiquidationDistance(); d != 0 {
+		t.Errorf("expected 0 received %v", d)
+	}
+}
@@ -0,0 +1,69 @@
+package margin
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	// ErrInvalidMarginType is returned when a margin type cannot be parsed
+	ErrInvalidMarginType = errors.New("invalid margin type")
+	// ErrMarginTypeUnsupported is returned when an exchange does not support
+	// the requested margin type
+	ErrMarginTypeUnsupported = errors.New("margin type not supported by exchange")
+	// ErrInvalidLeverage is returned when a leverage of zero or less is
+	// requested
+	ErrInvalidLeverage = errors.New("leverage must be greater than zero")
+	// ErrInvalidMarginAmount is returned when an isolated margin adjustment of
+	// zero is requested
+	ErrInvalidMarginAmount = errors.New("margin amount cannot be zero")
+)
+
+// Type defines how collateral is allocated to a position
+type Type string
+
+// Margin types
+const (
+	// Isolated margin restricts the collateral at risk to the margin
+	// allocated to the position
+	Isolated Type = "ISOLATED"
+	// Cross margin shares the available account balance between all
+	// positions
+	Cross       Type = "CROSS"
+	UnknownType Type = "UNKNOWN"
+)
+
+// Side defines the direction of a position
+type Side string
+
+// Position sides
+const (
+	Long        Side = "LONG"
+	Short       Side = "SHORT"
+	UnknownSide Side = "UNKNOWN"
+)
+
+// Position defines an open leveraged position on an exchange
+type Position struct {
+	Exchange         string
+	Asset            asset.Item
+	Pair             currency.Pair
+	Side             Side
+	MarginType       Type
+	Size             float64
+	EntryPrice       float64
+	MarkPrice        float64
+	LiquidationPrice float64
+	Leverage         float64
+	// Margin is the collateral allocated to the position, denominated in
+	// MarginCurrency
+	Margin            float64
+	MaintenanceMargin float64
+	MarginCurrency    currency.Code
+	UnrealisedPNL     float64
+	RealisedPNL       float64
+	LastUpdated       time.Time
+}
@@ -31,6 +31,7 @@ const (
 	okGroupRate           = "rate"
 	okGroupEsimtatedPrice = "estimated_price"
 	okGroupOpenInterest   = "open_interest"
+	okGroupMarginMode     = "margin_mode"
 	// Perpetual swap based endpoints
 	okGroupSettings              = "settings"
 	okGroupDepth                 = "depth"
@@ -84,6 +85,13 @@ func (o *OKEX) SetFuturesLeverage(request okgroup.SetFuturesLeverageRequest) (re
 	return resp, o.SendHTTPRequest(http.MethodPost, okGroupFuturesSubsection, requestURL, request, &resp, true)
 }
 
+// SetFuturesMarginMode switches the margin mode of an underlying between
+// "crossed" and "fixed", this can only be done with no open positions or orders
+func (o *OKEX) SetFuturesMarginMode(request okgroup.SetFuturesMarginModeRequest) (resp okgroup.SetFuturesMarginModeResponse, _ error) {
+	requestURL := fmt.Sprintf("%v/%v", okgroup.OKGroupAccounts, okGroupMarginMode)
+	return resp, o.SendHTTPRequest(http.MethodPost, okGroupFuturesSubsection, requestURL, request, &resp, true)
+}
+
 // GetFuturesBillDetails Shows the account’s historical coin in flow and out flow.
 // All paginated requests return the latest information (newest) as the first page sorted by newest (in chronological time) first.
 func (o *OKEX) GetFuturesBillDetails(request okgroup.GetSpotBillDetailsForCurrencyRequest) (resp []okgroup.GetSpotBillDetailsForCurrencyResponse, _ error) {
@@ -333,7 +341,7 @@ func (o *OKEX) GetSwapExchangeRates() (resp okgroup.GetSwapExchangeRatesResponse
 }
 
 // GetSwapOpenInterest Get the open interest of a contract.
-func (o *OKEX) GetSwapOpenInterest(instrumentID string) (resp okgroup.GetSwapExchangeRatesResponse, _ error) {
+func (o *OKEX) GetSwapOpenInterest(instrumentID string) (resp okgroup.GetSwapOpenInterestResponse, _ error) {
 	requestURL := fmt.Sprintf("%v/%v/%v", okgroup.OKGroupInstruments, instrumentID, okGroupOpenInterest)
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupSwapSubsection, requestURL, nil, &resp, false)
 }
@@ -362,7 +370,7 @@ func (o *OKEX) GetSwapNextSettlementTime(instrumentID string) (resp okgroup.GetS
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupSwapSubsection, requestURL, nil, &resp, false)
 }
 
-// GetSwapMarkPrice Get the time of next settlement.
+// GetSwapMarkPrice Get the mark price of a contract.
 func (o *OKEX) GetSwapMarkPrice(instrumentID string) (resp okgroup.GetSwapMarkPriceResponse, _ error) {
 	requestURL := fmt.Sprintf("%v/%v/%v", okgroup.OKGroupInstruments, instrumentID, okgroup.OKGroupMarkPrice)
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupSwapSubsection, requestURL, nil, &resp, false)
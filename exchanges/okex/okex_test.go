@@ -2,6 +2,7 @@ package okex
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,7 +19,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/okgroup"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
@@ -2158,3 +2161,165 @@ func TestGetHistoricTrades(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestInstrumentIDToPair(t *testing.T) {
+	t.Parallel()
+	p, err := instrumentIDToPair("BTC-USD-SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "BTC-USD_SWAP" {
+		t.Errorf("expected BTC-USD_SWAP received %v", p)
+	}
+	_, err = instrumentIDToPair("BTC-USD")
+	if err == nil {
+		t.Error("expected error for unhandled instrument ID")
+	}
+}
+
+func TestGetFundingRate(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = o.GetFundingRate(currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = o.GetFundingRate(currencyPair, asset.Futures)
+	if !errors.Is(err, futures.ErrNotPerpetualAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotPerpetualAsset, err)
+	}
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = o.GetFundingRateHistory(currencyPair, asset.PerpetualSwap, time.Now().Add(-time.Hour*24), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = o.GetOpenInterest(currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMarkPrice(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = o.GetMarkPrice(currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFuturesContracts(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetFuturesContracts(asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = o.GetFuturesContracts(asset.Futures)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = o.GetFuturesContracts(asset.Spot)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestGetLeveragedPositions(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetLeveragedPositions(asset.PerpetualSwap)
+	testStandardErrorHandling(t, err)
+	_, err = o.GetLeveragedPositions(asset.Futures)
+	testStandardErrorHandling(t, err)
+	_, err = o.GetLeveragedPositions(asset.Spot)
+	if !errors.Is(err, futures.ErrNotFuturesAsset) {
+		t.Errorf("expected %v received %v", futures.ErrNotFuturesAsset, err)
+	}
+}
+
+func TestFuturesHoldingToPositions(t *testing.T) {
+	t.Parallel()
+	p, err := o.futuresHoldingToPositions(&okgroup.GetFuturePostionsDetails{
+		InstrumentID:     "BTC-USD-201225",
+		MarginMode:       "crossed",
+		Leverage:         "10",
+		LiquidationPrice: "9000",
+		LongQty:          "5",
+		LongAvgCost:      "11000",
+		LongMargin:       "0.01",
+		ShortQty:         "0",
+		UpdatedAt:        "2020-11-02T10:34:17.000Z",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 1 {
+		t.Fatalf("expected 1 position received %v", len(p))
+	}
+	if p[0].Side != margin.Long ||
+		p[0].MarginType != margin.Cross ||
+		p[0].Size != 5 ||
+		p[0].Leverage != 10 ||
+		p[0].LiquidationPrice != 9000 ||
+		p[0].MarginCurrency != currency.BTC {
+		t.Errorf("unexpected position %+v", p[0])
+	}
+}
+
+func TestSetLeverage(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = o.SetLeverage(currencyPair, asset.PerpetualSwap, 2.5)
+	if !errors.Is(err, margin.ErrInvalidLeverage) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidLeverage, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders set to false, skipping test")
+	}
+	err = o.SetLeverage(currencyPair, asset.PerpetualSwap, 10)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetMarginType(t *testing.T) {
+	t.Parallel()
+	currencyPair, err := currency.NewPairFromString("BTC-USD_SWAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = o.SetMarginType(currencyPair, asset.PerpetualSwap, margin.UnknownType)
+	if !errors.Is(err, margin.ErrInvalidMarginType) {
+		t.Errorf("expected %v received %v", margin.ErrInvalidMarginType, err)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders set to false, skipping test")
+	}
+	err = o.SetMarginType(currencyPair, asset.PerpetualSwap, margin.Cross)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -3,7 +3,9 @@ package okex
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,7 +16,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/okgroup"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
@@ -799,3 +803,477 @@ func (o *OKEX) GetRecentTrades(p currency.Pair, assetType asset.Item) ([]trade.D
 func (o *OKEX) CancelBatchOrders(ord []order.Cancel) (order.CancelBatchResponse, error) {
 	return order.CancelBatchResponse{}, common.ErrNotYetImplemented
 }
+
+// instrumentIDToPair converts an OKEX contract instrument ID e.g.
+// BTC-USD-SWAP into the config formatted pair BTC-USD_SWAP
+func instrumentIDToPair(instrumentID string) (currency.Pair, error) {
+	p := strings.Split(instrumentID, currency.DashDelimiter)
+	if len(p) != 3 {
+		return currency.Pair{}, fmt.Errorf("unhandled instrument ID %s", instrumentID)
+	}
+	return currency.NewPairWithDelimiter(p[0]+currency.DashDelimiter+p[1],
+		p[2],
+		currency.UnderscoreDelimiter), nil
+}
+
+// GetFundingRate returns the current funding rate for a perpetual contract
+func (o *OKEX) GetFundingRate(p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	if a != asset.PerpetualSwap {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotPerpetualAsset)
+	}
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.GetSwapNextSettlementTime(fPair.String())
+	if err != nil {
+		return nil, err
+	}
+	return &futures.FundingRate{
+		Exchange:      o.Name,
+		Asset:         a,
+		Pair:          p,
+		Rate:          resp.FundingRate,
+		PredictedRate: resp.EstimatedRate,
+		Time:          resp.FundingTime,
+	}, nil
+}
+
+// GetFundingRateHistory returns historic funding rates for a perpetual
+// contract, OKEX only returns the most recent funding periods
+func (o *OKEX) GetFundingRateHistory(p currency.Pair, a asset.Item, start, end time.Time) ([]futures.FundingRate, error) {
+	if a != asset.PerpetualSwap {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotPerpetualAsset)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := o.GetSwapFundingRateHistory(okgroup.GetSwapFundingRateHistoryRequest{
+		InstrumentID: fPair.String(),
+		Limit:        100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resp []futures.FundingRate
+	for i := range rates {
+		if rates[i].FundingTime.Before(start) || rates[i].FundingTime.After(end) {
+			continue
+		}
+		resp = append(resp, futures.FundingRate{
+			Exchange: o.Name,
+			Asset:    a,
+			Pair:     p,
+			Rate:     rates[i].RealizedRate,
+			Time:     rates[i].FundingTime,
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Time.Before(resp[j].Time)
+	})
+	return resp, nil
+}
+
+// GetOpenInterest returns the open interest for a derivative contract
+func (o *OKEX) GetOpenInterest(p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.OpenInterest{
+		Exchange: o.Name,
+		Asset:    a,
+		Pair:     p,
+	}
+	switch a {
+	case asset.Futures:
+		oi, err := o.GetFuturesOpenInterests(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		resp.Amount = oi.Amount
+		resp.Time = oi.Timestamp
+	case asset.PerpetualSwap:
+		oi, err := o.GetSwapOpenInterest(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		resp.Amount = oi.Amount
+		resp.Time = oi.Timestamp
+	default:
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	return resp, nil
+}
+
+// GetMarkPrice returns the mark and index price for a derivative contract
+func (o *OKEX) GetMarkPrice(p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.MarkPrice{
+		Exchange: o.Name,
+		Asset:    a,
+		Pair:     p,
+	}
+	switch a {
+	case asset.Futures:
+		mark, err := o.GetFuturesCurrentMarkPrice(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		index, err := o.GetFuturesIndices(p.Base.String())
+		if err != nil {
+			return nil, err
+		}
+		resp.MarkPrice = mark.MarkPrice
+		resp.IndexPrice = index.Index
+		resp.Time = mark.Timestamp
+	case asset.PerpetualSwap:
+		mark, err := o.GetSwapMarkPrice(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		index, err := o.GetSwapIndices(fPair.String())
+		if err != nil {
+			return nil, err
+		}
+		resp.MarkPrice = mark.MarkPrice
+		resp.IndexPrice = index.Index
+		resp.Time = mark.Timestamp
+	default:
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	return resp, nil
+}
+
+// GetFuturesContracts returns the contract specifications for a derivative
+// asset type
+func (o *OKEX) GetFuturesContracts(a asset.Item) ([]futures.Contract, error) {
+	var resp []futures.Contract
+	switch a {
+	case asset.Futures:
+		contracts, err := o.GetFuturesContractInformation()
+		if err != nil {
+			return nil, err
+		}
+		for i := range contracts {
+			p, err := instrumentIDToPair(contracts[i].InstrumentID)
+			if err != nil {
+				return nil, err
+			}
+			expiry, err := time.Parse("2006-01-02", contracts[i].Delivery)
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, futures.Contract{
+				Exchange:           o.Name,
+				Asset:              a,
+				Pair:               p,
+				Underlying:         contracts[i].Underlying,
+				Multiplier:         contracts[i].ContractValue,
+				SettlementCurrency: currency.NewCode(contracts[i].SettlementCurrency),
+				IsInverse:          contracts[i].IsInverse,
+				Expiry:             expiry,
+			})
+		}
+	case asset.PerpetualSwap:
+		contracts, err := o.GetSwapContractInformation()
+		if err != nil {
+			return nil, err
+		}
+		for i := range contracts {
+			p, err := instrumentIDToPair(contracts[i].InstrumentID)
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, futures.Contract{
+				Exchange:           o.Name,
+				Asset:              a,
+				Pair:               p,
+				Underlying:         contracts[i].UnderlyingIndex + currency.DashDelimiter + contracts[i].QuoteCurrency,
+				Multiplier:         contracts[i].ContractVal,
+				SettlementCurrency: currency.NewCode(contracts[i].Coin),
+				IsInverse:          strings.EqualFold(contracts[i].Coin, contracts[i].UnderlyingIndex),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	return resp, nil
+}
+
+const (
+	okexCrossedMargin = "crossed"
+	okexFixedMargin   = "fixed"
+
+	okexSwapFixedLong  = 1
+	okexSwapFixedShort = 2
+	okexSwapCrossed    = 3
+)
+
+// parsePositionFloats parses OKEX position string values where an empty
+// string denotes zero
+func parsePositionFloats(values ...string) ([]float64, error) {
+	resp := make([]float64, len(values))
+	for i := range values {
+		if values[i] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(values[i], 64)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = v
+	}
+	return resp, nil
+}
+
+// okexLeverage converts leverage to the whole number OKEX requires
+func okexLeverage(leverage float64) (int64, error) {
+	if err := margin.CheckLeverage(leverage); err != nil {
+		return 0, err
+	}
+	if leverage != math.Trunc(leverage) {
+		return 0, fmt.Errorf("%w, OKEX only supports whole number leverage", margin.ErrInvalidLeverage)
+	}
+	return int64(leverage), nil
+}
+
+// GetLeveragedPositions returns all open futures or perpetual swap positions
+func (o *OKEX) GetLeveragedPositions(a asset.Item) ([]margin.Position, error) {
+	var resp []margin.Position
+	switch a {
+	case asset.Futures:
+		positions, err := o.GetFuturesPostions()
+		if err != nil {
+			return nil, err
+		}
+		for i := range positions.Holding {
+			for j := range positions.Holding[i] {
+				p, err := o.futuresHoldingToPositions(&positions.Holding[i][j])
+				if err != nil {
+					return nil, err
+				}
+				resp = append(resp, p...)
+			}
+		}
+	case asset.PerpetualSwap:
+		positions, err := o.GetSwapPostions()
+		if err != nil {
+			return nil, err
+		}
+		for i := range positions {
+			marginType, err := margin.StringToType(positions[i].MarginMode)
+			if err != nil {
+				return nil, err
+			}
+			for j := range positions[i].Holding {
+				h := &positions[i].Holding[j]
+				v, err := parsePositionFloats(h.Position, h.AvgCost, h.LiquidationPrice,
+					h.Leverage, h.Margin, h.RealizedPnl)
+				if err != nil {
+					return nil, err
+				}
+				if v[0] == 0 {
+					continue
+				}
+				p, err := instrumentIDToPair(h.InstrumentID)
+				if err != nil {
+					return nil, err
+				}
+				side := margin.Long
+				if strings.EqualFold(h.Side, margin.Short.String()) {
+					side = margin.Short
+				}
+				resp = append(resp, margin.Position{
+					Exchange:         o.Name,
+					Asset:            a,
+					Pair:             p,
+					Side:             side,
+					MarginType:       marginType,
+					Size:             v[0],
+					EntryPrice:       v[1],
+					LiquidationPrice: v[2],
+					Leverage:         v[3],
+					Margin:           v[4],
+					MarginCurrency:   currency.NewCode(strings.Split(h.InstrumentID, currency.DashDelimiter)[0]),
+					RealisedPNL:      v[5],
+					LastUpdated:      h.Timestamp,
+				})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	return resp, nil
+}
+
+// futuresHoldingToPositions splits an OKEX futures holding into its long and
+// short positions
+func (o *OKEX) futuresHoldingToPositions(h *okgroup.GetFuturePostionsDetails) ([]margin.Position, error) {
+	marginType, err := margin.StringToType(h.MarginMode)
+	if err != nil {
+		return nil, err
+	}
+	p, err := instrumentIDToPair(h.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+	v, err := parsePositionFloats(h.LongQty, h.LongAvgCost, h.LongLiquiPrice,
+		h.LongLeverage, h.LongMargin,
+		h.ShortQty, h.ShortAvgCost, h.ShortLiquiPrice, h.ShortLeverage,
+		h.ShortMargin, h.LiquidationPrice, h.Leverage, h.RealisedPnl)
+	if err != nil {
+		return nil, err
+	}
+	var updated time.Time
+	if h.UpdatedAt != "" {
+		updated, err = time.Parse(time.RFC3339, h.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp []margin.Position
+	for i, side := range []margin.Side{margin.Long, margin.Short} {
+		offset := i * 5
+		if v[offset] == 0 {
+			continue
+		}
+		pos := margin.Position{
+			Exchange:         o.Name,
+			Asset:            asset.Futures,
+			Pair:             p,
+			Side:             side,
+			MarginType:       marginType,
+			Size:             v[offset],
+			EntryPrice:       v[offset+1],
+			LiquidationPrice: v[offset+2],
+			Leverage:         v[offset+3],
+			Margin:           v[offset+4],
+			MarginCurrency:   currency.NewCode(strings.Split(h.InstrumentID, currency.DashDelimiter)[0]),
+			RealisedPNL:      v[12],
+			LastUpdated:      updated,
+		}
+		if marginType == margin.Cross {
+			// crossed positions share a single liquidation price and leverage
+			pos.LiquidationPrice = v[10]
+			pos.Leverage = v[11]
+		}
+		resp = append(resp, pos)
+	}
+	return resp, nil
+}
+
+// SetLeverage sets the leverage used for a contract, fixed margin contracts
+// have the leverage of both the long and short side updated
+func (o *OKEX) SetLeverage(p currency.Pair, a asset.Item, leverage float64) error {
+	lev, err := okexLeverage(leverage)
+	if err != nil {
+		return err
+	}
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return err
+	}
+	switch a {
+	case asset.Futures:
+		underlying := fPair.Base.String()
+		settings, err := o.GetFuturesLeverage(underlying)
+		if err != nil {
+			return err
+		}
+		if settings.MarginMode == okexCrossedMargin {
+			_, err = o.SetFuturesLeverage(okgroup.SetFuturesLeverageRequest{
+				Currency: underlying,
+				Leverage: lev,
+			})
+			return err
+		}
+		for _, direction := range []string{"long", "short"} {
+			_, err = o.SetFuturesLeverage(okgroup.SetFuturesLeverageRequest{
+				Currency:     underlying,
+				InstrumentID: fPair.String(),
+				Direction:    direction,
+				Leverage:     lev,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case asset.PerpetualSwap:
+		settings, err := o.GetSwapAccountSettingsOfAContract(fPair.String())
+		if err != nil {
+			return err
+		}
+		sides := []int64{okexSwapFixedLong, okexSwapFixedShort}
+		if settings.MarginMode == okexCrossedMargin {
+			sides = []int64{okexSwapCrossed}
+		}
+		return o.setSwapLeverage(fPair.String(), lev, sides...)
+	default:
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+}
+
+// setSwapLeverage sets the leverage for each perpetual swap side, OKEX
+// switches the margin mode of the contract to match the sides sent
+func (o *OKEX) setSwapLeverage(instrumentID string, leverage int64, sides ...int64) error {
+	for i := range sides {
+		_, err := o.SetSwapLeverageLevelOfAContract(okgroup.SetSwapLeverageLevelOfAContractRequest{
+			InstrumentID: instrumentID,
+			Leverage:     leverage,
+			Side:         sides[i],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetMarginType sets whether a contract uses fixed (isolated) or crossed
+// margin. For futures this applies to all contracts of the underlying
+func (o *OKEX) SetMarginType(p currency.Pair, a asset.Item, t margin.Type) error {
+	if !t.IsValid() {
+		return margin.ErrInvalidMarginType
+	}
+	fPair, err := o.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return err
+	}
+	mode := okexFixedMargin
+	if t == margin.Cross {
+		mode = okexCrossedMargin
+	}
+	switch a {
+	case asset.Futures:
+		_, err = o.SetFuturesMarginMode(okgroup.SetFuturesMarginModeRequest{
+			Underlying: fPair.Base.String(),
+			MarginMode: mode,
+		})
+		return err
+	case asset.PerpetualSwap:
+		settings, err := o.GetSwapAccountSettingsOfAContract(fPair.String())
+		if err != nil {
+			return err
+		}
+		if settings.MarginMode == mode {
+			return nil
+		}
+		lev := int64(settings.LongLeverage)
+		if mode == okexCrossedMargin {
+			return o.setSwapLeverage(fPair.String(), lev, okexSwapCrossed)
+		}
+		return o.setSwapLeverage(fPair.String(), lev, okexSwapFixedLong, okexSwapFixedShort)
+	default:
+		return fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+}
@@ -549,6 +549,19 @@ type SetFuturesLeverageResponse struct {
 	ShortLongDataPerContract map[string]SetFutureLeverageShortLongData
 }
 
+// SetFuturesMarginModeRequest request data for SetFuturesMarginMode
+type SetFuturesMarginModeRequest struct {
+	Underlying string `json:"underlying"`  // [required] Underlying, e.g. "BTC-USD"
+	MarginMode string `json:"margin_mode"` // [required] "crossed" or "fixed"
+}
+
+// SetFuturesMarginModeResponse response data for SetFuturesMarginMode
+type SetFuturesMarginModeResponse struct {
+	Underlying string `json:"underlying"`
+	MarginMode string `json:"margin_mode"`
+	Result     bool   `json:"result"`
+}
+
 // SetFutureLeverageShortLongData long and short data from SetFuturesLeverage
 type SetFutureLeverageShortLongData struct {
 	Long  int `json:"long"`
@@ -1159,20 +1172,23 @@ type GetSwapOnHoldAmountForOpenOrdersResponse struct {
 
 // GetSwapNextSettlementTimeResponse response data for GetSwapNextSettlementTime
 type GetSwapNextSettlementTimeResponse struct {
-	InstrumentID string `json:"instrument_id"`
-	FundingTime  string `json:"funding_time"`
+	InstrumentID   string    `json:"instrument_id"`
+	FundingTime    time.Time `json:"funding_time"`
+	FundingRate    float64   `json:"funding_rate,string"`
+	EstimatedRate  float64   `json:"estimated_rate,string"`
+	SettlementTime time.Time `json:"settlement_time"`
 }
 
 // GetSwapMarkPriceResponse response data for GetSwapMarkPrice
 type GetSwapMarkPriceResponse struct {
-	InstrumentID string `json:"instrument_id"`
-	MarkPrice    string `json:"mark_price"`
-	Timstamp     string `json:"timstamp"`
+	InstrumentID string    `json:"instrument_id"`
+	MarkPrice    float64   `json:"mark_price,string"`
+	Timestamp    time.Time `json:"timestamp"`
 }
 
 // GetSwapFundingRateHistoryRequest request data for GetSwapFundingRateHistory
 type GetSwapFundingRateHistoryRequest struct {
-	InstrumentID string `url:"ins-trument_id"`         // [required] Contract ID, e.g. "BTC-USD-SWAP
+	InstrumentID string `url:"-"`                      // [required] Contract ID, e.g. "BTC-USD-SWAP
 	From         int64  `url:"from,string,omitempty"`  // [optional] Request paging content for this page number.（Example: 1,2,3,4,5. From 4 we only have 4, to 4 we only have 3）
 	To           int64  `url:"to,string,omitempty"`    // [optional] Request page after (older) this pagination id. （Example: 1,2,3,4,5. From 4 we only have 4, to 4 we only have 3）
 	Limit        int64  `url:"limit,string,omitempty"` // [optional] Number of results per request. Maximum 100.
@@ -1180,12 +1196,12 @@ type GetSwapFundingRateHistoryRequest struct {
 
 // GetSwapFundingRateHistoryResponse response data for GetSwapFundingRateHistory
 type GetSwapFundingRateHistoryResponse struct {
-	InstrumentID string  `json:"instrument_id"`
-	FundingRate  float64 `json:"funding_rate,string,omitempty"`
-	RealizedRate float64 `json:"realized_rate,string"`
-	InterestRate float64 `json:"interest_rate,string"`
-	FundingTime  string  `json:"funding_time"`
-	FundingFee   float64 `json:"funding_fee,string,omitempty"`
+	InstrumentID string    `json:"instrument_id"`
+	FundingRate  float64   `json:"funding_rate,string,omitempty"`
+	RealizedRate float64   `json:"realized_rate,string"`
+	InterestRate float64   `json:"interest_rate,string"`
+	FundingTime  time.Time `json:"funding_time"`
+	FundingFee   float64   `json:"funding_fee,string,omitempty"`
 }
 
 // GetETTResponse response data for GetETT
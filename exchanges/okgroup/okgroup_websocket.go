@@ -632,6 +632,27 @@ func (o *OKGroup) wsResubscribeToOrderbook(response *WebsocketOrderBooksData) er
 	return nil
 }
 
+// wsResubscribeOrderbook resubscribes to a book which failed checksum
+// verification so a new partial is sent
+func (o *OKGroup) wsResubscribeOrderbook(p currency.Pair, a asset.Item) error {
+	var channel string
+	switch a {
+	case asset.Spot:
+		channel = okGroupWsSpotDepth
+	case asset.Futures:
+		channel = okGroupWsFuturesDepth
+	case asset.PerpetualSwap:
+		channel = okGroupWsSwapDepth
+	default:
+		return fmt.Errorf("%s orderbook resubscription unsupported for asset %s", o.Name, a)
+	}
+	return o.Websocket.ResubscribeToChannel(&stream.ChannelSubscription{
+		Channel:  channel,
+		Currency: p,
+		Asset:    a,
+	})
+}
+
 // AppendWsOrderbookItems adds websocket orderbook data bid/asks into an
 // orderbook item array
 func (o *OKGroup) AppendWsOrderbookItems(entries [][]interface{}) ([]orderbook.Item, error) {
@@ -695,9 +716,11 @@ func (o *OKGroup) WsProcessPartialOrderBook(wsEventData *WebsocketOrderBook, ins
 // orderbook
 func (o *OKGroup) WsProcessUpdateOrderbook(wsEventData *WebsocketOrderBook, instrument currency.Pair, a asset.Item) error {
 	update := buffer.Update{
-		Asset:      a,
-		Pair:       instrument,
-		UpdateTime: wsEventData.Timestamp,
+		Asset:          a,
+		Pair:           instrument,
+		UpdateTime:     wsEventData.Timestamp,
+		Checksum:       uint32(wsEventData.Checksum),
+		VerifyChecksum: true,
 	}
 
 	var err error
@@ -710,22 +733,7 @@ func (o *OKGroup) WsProcessUpdateOrderbook(wsEventData *WebsocketOrderBook, inst
 		return err
 	}
 
-	err = o.Websocket.Orderbook.Update(&update)
-	if err != nil {
-		return err
-	}
-
-	updatedOb := o.Websocket.Orderbook.GetOrderbook(instrument, a)
-	checksum := o.CalculateUpdateOrderbookChecksum(updatedOb)
-
-	if checksum != wsEventData.Checksum {
-		// re-sub
-		log.Warnf(log.ExchangeSys, "%s checksum failure for item %s",
-			o.Name,
-			wsEventData.InstrumentID)
-		return errors.New("checksum failed")
-	}
-	return nil
+	return o.Websocket.Orderbook.Update(&update)
 }
 
 // CalculatePartialOrderbookChecksum alternates over the first 25 bid and ask
@@ -753,27 +761,19 @@ func (o *OKGroup) CalculatePartialOrderbookChecksum(orderbookData *WebsocketOrde
 	return int32(crc32.ChecksumIEEE([]byte(checksumStr)))
 }
 
-// CalculateUpdateOrderbookChecksum alternates over the first 25 bid and ask
-// entries of a merged orderbook. The checksum is made up of the price and the
-// quantity with a semicolon (:) deliminating them. This will also work when
-// there are less than 25 entries (for whatever reason)
+// orderbookChecksum alternates over the first 25 bid and ask entries of a
+// merged orderbook. The checksum is made up of the price and the quantity with
+// a semicolon (:) deliminating them. This will also work when there are less
+// than 25 entries (for whatever reason)
 // eg Bid:Ask:Bid:Ask:Ask:Ask
-func (o *OKGroup) CalculateUpdateOrderbookChecksum(orderbookData *orderbook.Base) int32 {
-	var checksum strings.Builder
-	for i := 0; i < allowableIterations; i++ {
-		if len(orderbookData.Bids)-1 >= i {
-			price := strconv.FormatFloat(orderbookData.Bids[i].Price, 'f', -1, 64)
-			amount := strconv.FormatFloat(orderbookData.Bids[i].Amount, 'f', -1, 64)
-			checksum.WriteString(price + delimiterColon + amount + delimiterColon)
-		}
-		if len(orderbookData.Asks)-1 >= i {
-			price := strconv.FormatFloat(orderbookData.Asks[i].Price, 'f', -1, 64)
-			amount := strconv.FormatFloat(orderbookData.Asks[i].Amount, 'f', -1, 64)
-			checksum.WriteString(price + delimiterColon + amount + delimiterColon)
-		}
-	}
-	checksumStr := strings.TrimSuffix(checksum.String(), delimiterColon)
-	return int32(crc32.ChecksumIEEE([]byte(checksumStr)))
+var orderbookChecksum = &buffer.CRC32{
+	Depth:     allowableIterations,
+	Delimiter: delimiterColon,
+	Format: func(item orderbook.Item, _ bool, _ *buffer.Update) string {
+		return strconv.FormatFloat(item.Price, 'f', -1, 64) +
+			delimiterColon +
+			strconv.FormatFloat(item.Amount, 'f', -1, 64)
+	},
 }
 
 // GenerateDefaultSubscriptions Adds default subscriptions to websocket to be
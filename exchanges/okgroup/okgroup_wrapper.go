@@ -50,6 +50,8 @@ func (o *OKGroup) Setup(exch *config.ExchangeConfig) error {
 		GenerateSubscriptions:            o.GenerateDefaultSubscriptions,
 		Features:                         &o.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
+		OrderbookChecksum:                orderbookChecksum,
+		OrderbookResubscriber:            o.wsResubscribeOrderbook,
 		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
 	})
 	if err != nil {
@@ -182,6 +184,7 @@ func (o *OKGroup) UpdateAccountInfo() (account.Holdings, error) {
 
 	var resp account.Holdings
 	resp.Exchange = o.Name
+	resp.Account = o.Account
 	currencyAccount := account.SubAccount{}
 
 	for i := range currencies {
@@ -213,7 +216,7 @@ func (o *OKGroup) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (o *OKGroup) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(o.Name)
+	acc, err := account.GetAccountHoldings(o.Name, o.Account)
 	if err != nil {
 		return o.UpdateAccountInfo()
 	}
@@ -378,6 +378,7 @@ func (p *Poloniex) UpdateOrderbook(c currency.Pair, assetType asset.Item) (*orde
 func (p *Poloniex) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = p.Name
+	response.Account = p.Account
 	accountBalance, err := p.GetBalances()
 	if err != nil {
 		return response, err
@@ -405,7 +406,7 @@ func (p *Poloniex) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (p *Poloniex) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(p.Name)
+	acc, err := account.GetAccountHoldings(p.Name, p.Account)
 	if err != nil {
 		return p.UpdateAccountInfo()
 	}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/metrics"
+)
+
+var (
+	requestDuration = metrics.NewHistogramVec("gct_exchange_request_duration_seconds",
+		"Duration of exchange HTTP requests by exchange, method, endpoint and status code",
+		nil, "exchange", "method", "endpoint", "status")
+	requestRetries = metrics.NewCounterVec("gct_exchange_request_retries_total",
+		"Exchange HTTP requests retried by exchange and endpoint",
+		"exchange", "endpoint")
+	rateLimitWait = metrics.NewHistogramVec("gct_exchange_rate_limit_wait_seconds",
+		"Time spent waiting on exchange rate limits before sending a request",
+		nil, "exchange")
+)
+
+// observeRequest records the duration and outcome of an exchange request
+func (r *Requester) observeRequest(req *http.Request, p *Item, resp *http.Response, start time.Time) {
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	requestDuration.WithLabelValues(r.Name, req.Method, p.metricsEndpoint(), status).
+		Observe(time.Since(start).Seconds())
+}
+
+// metricsEndpoint returns the endpoint label of the request. Paths are not
+// used as they may hold order IDs or symbols which would grow the number of
+// series without bound, the route set by the wrapper or the rate limit
+// endpoint is used instead
+func (i *Item) metricsEndpoint() string {
+	if i.Route != "" {
+		return i.Route
+	}
+	return "ratelimit_" + strconv.Itoa(int(i.Endpoint))
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -111,40 +112,38 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 		return errors.New("request item cannot be nil")
 	}
 
+	// Entries are only needed for verbose output and do nothing when nil
+	var l *log.Entry
+	if p.Verbose || p.HTTPDebugging {
+		l = log.With(log.RequestSys,
+			log.Exchange(r.Name),
+			log.RequestID(strconv.FormatUint(uint64(atomic.AddUint32(&r.requests, 1)), 10)))
+	}
 	if p.Verbose {
-		log.Debugf(log.RequestSys,
-			"%s request path: %s",
-			r.Name,
-			p.Path)
+		l.Debugf("request path: %s", p.Path)
 
 		for k, d := range req.Header {
-			log.Debugf(log.RequestSys,
-				"%s request header [%s]: %s",
-				r.Name,
-				k,
-				d)
+			l.Debugf("request header [%s]: %s", k, d)
 		}
-		log.Debugf(log.RequestSys,
-			"%s request type: %s",
-			r.Name,
-			req.Method)
+		l.Debugf("request type: %s", req.Method)
 
 		if p.Body != nil {
-			log.Debugf(log.RequestSys,
-				"%s request body: %v",
-				r.Name,
-				p.Body)
+			l.Debugf("request body: %v", p.Body)
 		}
 	}
 
 	for attempt := 1; ; attempt++ {
 		// Initiate a rate limit reservation and sleep on requested endpoint
+		start := time.Now()
 		err := r.InitiateRateLimit(p.Endpoint)
 		if err != nil {
 			return err
 		}
+		rateLimitWait.WithLabelValues(r.Name).Observe(time.Since(start).Seconds())
 
+		start = time.Now()
 		resp, err := r.HTTPClient.Do(req)
+		r.observeRequest(req, p, resp, start)
 		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
 			return checkErr
 		} else if retry {
@@ -182,13 +181,12 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 			}
 
 			if p.Verbose {
-				log.Errorf(log.RequestSys,
-					"%s request has failed. Retrying request in %s, attempt %d",
-					r.Name,
+				l.Errorf("request has failed. Retrying request in %s, attempt %d",
 					delay,
 					attempt)
 			}
 
+			requestRetries.WithLabelValues(r.Name, p.metricsEndpoint()).Inc()
 			time.Sleep(delay)
 			continue
 		}
@@ -223,23 +221,19 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 		if p.HTTPDebugging {
 			dump, err := httputil.DumpResponse(resp, false)
 			if err != nil {
-				log.Errorf(log.RequestSys, "DumpResponse invalid response: %v:", err)
+				l.Errorf("DumpResponse invalid response: %v:", err)
 			}
-			log.Debugf(log.RequestSys, "DumpResponse Headers (%v):\n%s", p.Path, dump)
-			log.Debugf(log.RequestSys, "DumpResponse Body (%v):\n %s", p.Path, string(contents))
+			l.Debugf("DumpResponse Headers (%v):\n%s", p.Path, dump)
+			l.Debugf("DumpResponse Body (%v):\n %s", p.Path, string(contents))
 		}
 
 		resp.Body.Close()
 		if p.Verbose {
-			log.Debugf(log.RequestSys,
-				"HTTP status: %s, Code: %v",
+			l.Debugf("HTTP status: %s, Code: %v",
 				resp.Status,
 				resp.StatusCode)
 			if !p.HTTPDebugging {
-				log.Debugf(log.RequestSys,
-					"%s raw response: %s",
-					r.Name,
-					string(contents))
+				l.Debugf("raw response: %s", string(contents))
 			}
 		}
 		if p.Result != nil {
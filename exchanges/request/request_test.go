@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/metrics"
 	"golang.org/x/time/rate"
 )
 
@@ -584,3 +585,39 @@ func TestEnableDisableRateLimit(t *testing.T) {
 		// Correct test
 	}
 }
+
+func TestRequestMetricsEndpoint(t *testing.T) {
+	t.Parallel()
+	r := New("metricstest", new(http.Client))
+	for _, id := range []string{"1", "2"} {
+		err := r.SendPayload(context.Background(), &Item{
+			Method:   http.MethodGet,
+			Path:     testURL + "/orders/" + id,
+			Endpoint: Auth,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/orders/3",
+		Route:  "/orders/{id}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint := "ratelimit_" + strconv.Itoa(int(Auth))
+	if n := requestDuration.WithLabelValues("metricstest", http.MethodGet, endpoint, "200").Count(); n != 2 {
+		t.Errorf("expected requests labelled by rate limit endpoint, received %d", n)
+	}
+	if n := requestDuration.WithLabelValues("metricstest", http.MethodGet, "/orders/{id}", "200").Count(); n != 1 {
+		t.Errorf("expected request labelled by route, received %d", n)
+	}
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if strings.Contains(rec.Body.String(), "/orders/1") {
+		t.Error("expected request paths not to be used as labels")
+	}
+}
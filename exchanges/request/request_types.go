@@ -33,6 +33,7 @@ type Requester struct {
 	UserAgent          string
 	maxRetries         int
 	jobs               int32
+	requests           uint32
 	Nonce              nonce.Nonce
 	disableRateLimiter int32
 	backoff            Backoff
@@ -57,6 +58,10 @@ type Item struct {
 	// pagination
 	HeaderResponse *http.Header
 	Endpoint       EndpointLimit
+	// Route optionally names the endpoint in request metrics, such as a path
+	// template without IDs or symbols. Requests without a route are labelled
+	// by their rate limit endpoint
+	Route string
 }
 
 // Backoff determines how long to wait between request attempts.
@@ -8,9 +8,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
-const packageError = "websocket orderbook buffer error: %w"
+const (
+	packageError = "websocket orderbook buffer error: %w"
+	// maxPendingUpdates limits the updates held while a book is
+	// resynchronised, the oldest are discarded first
+	maxPendingUpdates = 1000
+)
 
 var (
 	errUnsetExchangeName            = errors.New("exchange name unset")
@@ -18,6 +24,7 @@ var (
 	errIssueBufferEnabledButNoLimit = errors.New("buffer enabled but no limit set")
 	errUpdateIsNil                  = errors.New("update is nil")
 	errUpdateNoTargets              = errors.New("update bid/ask targets cannot be nil")
+	errSequenceGap                  = errors.New("orderbook update sequence gap")
 )
 
 // Setup sets private variables
@@ -46,6 +53,23 @@ func (w *Orderbook) Setup(obBufferLimit int,
 	return nil
 }
 
+// SetSnapshotFetcher enables update sequence verification. Each update must
+// follow the book's last update ID, on a gap the book is discarded, a snapshot
+// is fetched and the updates received since are replayed on top of it
+func (w *Orderbook) SetSnapshotFetcher(f SnapshotFetcher) {
+	w.m.Lock()
+	w.fetchSnapshot = f
+	w.m.Unlock()
+}
+
+// GetResyncStats returns the sequence gaps and resynchronisations counted
+// across all books
+func (w *Orderbook) GetResyncStats() ResyncStats {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.stats
+}
+
 // validate validates update against setup values
 func (w *Orderbook) validate(u *Update) error {
 	if u == nil {
@@ -76,6 +100,23 @@ func (w *Orderbook) Update(u *Update) error {
 			u.Asset)
 	}
 
+	if obLookup.resyncing {
+		w.holdUpdates(obLookup, []Update{*u})
+		if obLookup.resyncFailed {
+			obLookup.resyncFailed = false
+			go w.resync(obLookup, u.Pair, u.Asset)
+		}
+		return nil
+	}
+
+	if obLookup.awaitingSnapshot {
+		if obLookup.resubscribeFailed {
+			obLookup.resubscribeFailed = false
+			go w.resubscribeBook(obLookup, u.Pair, u.Asset)
+		}
+		return nil
+	}
+
 	if w.bufferEnabled {
 		processed, err := w.processBufferUpdate(obLookup, u)
 		if err != nil {
@@ -87,7 +128,13 @@ func (w *Orderbook) Update(u *Update) error {
 		}
 	} else {
 		err := w.processObUpdate(obLookup, u)
-		if err != nil {
+		switch {
+		case errors.Is(err, errSequenceGap):
+			w.startResync(obLookup, []Update{*u}, err)
+			return nil
+		case errors.Is(err, ErrChecksumFailure) && w.recoverChecksumFailure(obLookup, nil, err):
+			return nil
+		case err != nil:
 			return err
 		}
 	}
@@ -127,9 +174,18 @@ func (w *Orderbook) processBufferUpdate(o *orderbookHolder, u *Update) (bool, er
 	}
 	for i := range *o.buffer {
 		err := w.processObUpdate(o, &(*o.buffer)[i])
-		if err != nil {
+		switch {
+		case errors.Is(err, errSequenceGap):
+			w.startResync(o, (*o.buffer)[i:], err)
+		case errors.Is(err, ErrChecksumFailure) && w.recoverChecksumFailure(o, (*o.buffer)[i+1:], err):
+			// The failed update is reflected in the book already
+		case err != nil:
 			return false, err
+		default:
+			continue
 		}
+		*o.buffer = nil
+		return false, nil
 	}
 	// clear buffer of old updates
 	*o.buffer = nil
@@ -137,13 +193,130 @@ func (w *Orderbook) processBufferUpdate(o *orderbookHolder, u *Update) (bool, er
 }
 
 // processObUpdate processes updates either by its corresponding id or by
-// price level
+// price level then verifies the book's checksum if the update carries one
 func (w *Orderbook) processObUpdate(o *orderbookHolder, u *Update) error {
+	if w.fetchSnapshot != nil {
+		apply, err := o.checkSequence(u)
+		if !apply {
+			return err
+		}
+	}
 	o.ob.LastUpdateID = u.UpdateID
+	var err error
 	if w.updateEntriesByID {
-		return o.updateByIDAndAction(u)
+		err = o.updateByIDAndAction(u)
+	} else {
+		err = o.updateByPrice(u)
+	}
+	if err != nil || !u.VerifyChecksum || w.checksum == nil {
+		return err
+	}
+	return w.verifyChecksum(o, u, u.Checksum)
+}
+
+// checkSequence returns whether the update follows the last applied update.
+// Updates already reflected in the book are skipped and an update starting
+// after the next expected ID returns errSequenceGap
+func (o *orderbookHolder) checkSequence(u *Update) (bool, error) {
+	last := o.ob.LastUpdateID
+	if last == 0 {
+		// No sequence to verify against until the first update is applied
+		return true, nil
+	}
+	if u.UpdateID <= last {
+		return false, nil
+	}
+	first := u.FirstUpdateID
+	if first == 0 {
+		first = u.UpdateID
+	}
+	if first > last+1 {
+		return false, fmt.Errorf("%w expected update %d received %d",
+			errSequenceGap, last+1, first)
+	}
+	return true, nil
+}
+
+// holdUpdates stores updates received while a book is resynchronised, w.m
+// must be held
+func (w *Orderbook) holdUpdates(o *orderbookHolder, updates []Update) {
+	o.pending = append(o.pending, updates...)
+	if len(o.pending) > maxPendingUpdates {
+		o.pending = o.pending[len(o.pending)-maxPendingUpdates:]
+	}
+}
+
+// startResync discards the book after a sequence gap or checksum failure and
+// fetches a snapshot, the updates not yet reflected in the book are replayed
+// once it arrives. w.m must be held
+func (w *Orderbook) startResync(o *orderbookHolder, updates []Update, reason error) {
+	if errors.Is(reason, errSequenceGap) {
+		w.stats.Gaps++
+	}
+	log.Warnf(log.WebsocketMgr, "%s %s %s %v, resynchronising orderbook\n",
+		w.exchangeName, o.ob.Pair, o.ob.AssetType, reason)
+	o.ob.Bids = nil
+	o.ob.Asks = nil
+	o.resyncing = true
+	o.resyncFailed = false
+	o.pending = nil
+	w.holdUpdates(o, updates)
+	go w.resync(o, o.ob.Pair, o.ob.AssetType)
+}
+
+// resync fetches a snapshot for a book discarded after a sequence gap then
+// replays the updates held since. If fetching fails the next update received
+// for the book retries
+func (w *Orderbook) resync(o *orderbookHolder, p currency.Pair, a asset.Item) {
+	book, err := w.fetchSnapshot(p, a)
+	w.m.Lock()
+	defer w.m.Unlock()
+	if !o.resyncing || w.ob[p.Base][p.Quote][a] != o {
+		// A new snapshot was loaded or the buffer flushed meanwhile
+		return
+	}
+	if err == nil && book == nil {
+		err = errors.New("snapshot is nil")
+	}
+	if err != nil {
+		w.stats.Failures++
+		o.resyncFailed = true
+		log.Errorf(log.WebsocketMgr, "%s %s %s orderbook resync failed: %v\n",
+			w.exchangeName, p, a, err)
+		return
+	}
+
+	o.ob.Bids = append(book.Bids[:0:0], book.Bids...)
+	o.ob.Asks = append(book.Asks[:0:0], book.Asks...)
+	o.ob.LastUpdateID = book.LastUpdateID
+	o.resyncing = false
+	pending := o.pending
+	o.pending = nil
+	for i := range pending {
+		err = w.processObUpdate(o, &pending[i])
+		switch {
+		case errors.Is(err, errSequenceGap):
+			w.startResync(o, pending[i:], err)
+			return
+		case errors.Is(err, ErrChecksumFailure):
+			w.startResync(o, pending[i+1:], err)
+			return
+		case err != nil:
+			log.Errorf(log.WebsocketMgr, "%s %s %s orderbook resync replay error: %v\n",
+				w.exchangeName, p, a, err)
+		}
+	}
+	w.stats.Resyncs++
+
+	if err = o.ob.Process(); err != nil {
+		log.Errorf(log.WebsocketMgr, "%s %s %s orderbook resync error: %v\n",
+			w.exchangeName, p, a, err)
+		return
+	}
+	select {
+	case w.dataHandler <- o.ob:
+	default:
 	}
-	return o.updateByPrice(u)
 }
 
 // updateByPrice ammends amount if match occurs by price, deletes if amount is
@@ -380,6 +553,15 @@ func (w *Orderbook) LoadSnapshot(book *orderbook.Base) error {
 	} else {
 		m3.ob.Bids = book.Bids
 		m3.ob.Asks = book.Asks
+		m3.ob.LastUpdateID = book.LastUpdateID
+		m3.resyncing = false
+		m3.resyncFailed = false
+		m3.pending = nil
+		if m3.awaitingSnapshot {
+			m3.awaitingSnapshot = false
+			m3.resubscribeFailed = false
+			w.stats.Resyncs++
+		}
 	}
 	w.dataHandler <- book
 	return nil
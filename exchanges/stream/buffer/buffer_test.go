@@ -1105,3 +1105,112 @@ func TestFlushOrderbook(t *testing.T) {
 		t.Fatal("orderbook items not flushed")
 	}
 }
+
+func TestCheckSequence(t *testing.T) {
+	t.Parallel()
+	o := orderbookHolder{ob: &orderbook.Base{}}
+	if apply, err := o.checkSequence(&Update{UpdateID: 10}); !apply || err != nil {
+		t.Fatalf("expected first update to apply, received %v %v", apply, err)
+	}
+	o.ob.LastUpdateID = 10
+	if apply, err := o.checkSequence(&Update{UpdateID: 10}); apply || err != nil {
+		t.Errorf("expected stale update to be skipped, received %v %v", apply, err)
+	}
+	if apply, err := o.checkSequence(&Update{UpdateID: 11}); !apply || err != nil {
+		t.Errorf("expected next update to apply, received %v %v", apply, err)
+	}
+	if apply, err := o.checkSequence(&Update{FirstUpdateID: 8, UpdateID: 15}); !apply || err != nil {
+		t.Errorf("expected overlapping update range to apply, received %v %v", apply, err)
+	}
+	if _, err := o.checkSequence(&Update{UpdateID: 12}); !errors.Is(err, errSequenceGap) {
+		t.Errorf("expected %v, received %v", errSequenceGap, err)
+	}
+	if _, err := o.checkSequence(&Update{FirstUpdateID: 12, UpdateID: 15}); !errors.Is(err, errSequenceGap) {
+		t.Errorf("expected %v, received %v", errSequenceGap, err)
+	}
+}
+
+// waitForResyncs waits until the expected number of resyncs are counted
+func waitForResyncs(t *testing.T, obl *Orderbook, resyncs uint64) ResyncStats {
+	t.Helper()
+	for i := 0; i < 200; i++ {
+		if s := obl.GetResyncStats(); s.Resyncs+s.Failures >= resyncs {
+			return s
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatal("timed out waiting for orderbook resync")
+	return ResyncStats{}
+}
+
+func TestSequenceGapResync(t *testing.T) {
+	t.Parallel()
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	var fetches int
+	obl.SetSnapshotFetcher(func(p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+		<-release
+		fetches++
+		if fetches == 1 {
+			return nil, errors.New("rest unavailable")
+		}
+		lastUpdateID := int64(5)
+		if fetches > 2 {
+			lastUpdateID = 7
+		}
+		return &orderbook.Base{
+			Pair:         p,
+			AssetType:    a,
+			ExchangeName: exchangeName,
+			Bids:         []orderbook.Item{{Price: 100, Amount: 1}},
+			Asks:         []orderbook.Item{{Price: 200, Amount: 1}},
+			LastUpdateID: lastUpdateID,
+		}, nil
+	})
+
+	update := func(id int64, price float64) {
+		t.Helper()
+		err = obl.Update(&Update{
+			Bids:     []orderbook.Item{{Price: price, Amount: 1}},
+			Pair:     cp,
+			UpdateID: id,
+			Asset:    asset.Spot,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	update(1, 10)
+	update(2, 20)
+	update(4, 40)
+	if ob := obl.GetOrderbook(cp, asset.Spot); len(ob.Bids) != 0 || len(ob.Asks) != 0 {
+		t.Errorf("expected book to be discarded on a sequence gap, received %+v", ob)
+	}
+	update(5, 50)
+	close(release)
+	if s := waitForResyncs(t, obl, 1); s.Failures != 1 || s.Gaps != 1 {
+		t.Fatalf("expected a failed resync, received %+v", s)
+	}
+
+	update(6, 60)
+	s := waitForResyncs(t, obl, 2)
+	if s.Gaps != 1 || s.Resyncs != 1 {
+		t.Fatalf("unexpected resync stats %+v", s)
+	}
+	ob := obl.GetOrderbook(cp, asset.Spot)
+	if ob.LastUpdateID != 6 || len(ob.Bids) != 2 || ob.Bids[0].Price != 100 || ob.Bids[1].Price != 60 {
+		t.Errorf("expected snapshot with newer updates replayed, received %+v", ob)
+	}
+
+	update(8, 80)
+	if s = obl.GetResyncStats(); s.Gaps != 2 {
+		t.Errorf("expected a second gap, received %+v", s)
+	}
+	waitForResyncs(t, obl, 3)
+	if ob = obl.GetOrderbook(cp, asset.Spot); ob.LastUpdateID != 8 || len(ob.Bids) != 2 {
+		t.Errorf("expected snapshot with newer updates replayed, received %+v", ob)
+	}
+}
@@ -20,19 +20,88 @@ type Orderbook struct {
 	updateEntriesByID     bool // Use the update IDs to match ob entries
 	exchangeName          string
 	dataHandler           chan interface{}
+	fetchSnapshot         SnapshotFetcher // Set to verify update sequences
+	checksum              Checksum        // Set to verify update checksums
+	resubscribe           Resubscriber    // Set to recover from checksum failures
+	stats                 ResyncStats
 	m                     sync.Mutex
 }
 
+// SnapshotFetcher fetches a full orderbook, usually over REST, which is used
+// to resynchronise a book after a missed update. The snapshot should carry
+// the LastUpdateID it reflects so buffered updates can be replayed on top
+type SnapshotFetcher func(p currency.Pair, a asset.Item) (*orderbook.Base, error)
+
+// Resubscriber resubscribes to a book's channel so the exchange sends a new
+// snapshot, it recovers books failing checksum verification on exchanges
+// without a REST snapshot to resynchronise from
+type Resubscriber func(p currency.Pair, a asset.Item) error
+
+// ResyncStats counts the update sequence gaps and checksum verification
+// failures detected and the orderbook resynchronisations which followed
+type ResyncStats struct {
+	Gaps             uint64
+	Resyncs          uint64
+	Failures         uint64
+	ChecksumFailures uint64
+}
+
+// Checksum calculates the checksum an exchange sends to verify the top of an
+// orderbook. Calculate must not modify the book, the update is nil when a
+// checksum is verified outside of an update
+type Checksum interface {
+	Calculate(b *orderbook.Base, u *Update) (uint32, error)
+}
+
+// ChecksumFunc allows a function to be used as a Checksum
+type ChecksumFunc func(b *orderbook.Base, u *Update) (uint32, error)
+
+// CRC32 is a Checksum of the CRC32 IEEE of the top levels of a book, each
+// level formatted in an exchange specific way
+type CRC32 struct {
+	// Depth is the number of levels included from each side
+	Depth int
+	// RequireDepth fails the calculation when a side is shallower than Depth
+	RequireDepth bool
+	// AsksFirst writes every ask level before the bid levels, otherwise bid
+	// and ask levels alternate starting with the best bid
+	AsksFirst bool
+	// Delimiter separates formatted levels
+	Delimiter string
+	// Format formats a level, bid is false for ask levels
+	Format func(item orderbook.Item, bid bool, u *Update) string
+}
+
 type orderbookHolder struct {
 	ob     *orderbook.Base
 	buffer *[]Update
+	// resyncing is set while a snapshot is fetched after a sequence gap,
+	// updates received meanwhile are held in pending and replayed
+	resyncing    bool
+	resyncFailed bool
+	pending      []Update
+	// awaitingSnapshot is set while a book is resubscribed after a checksum
+	// failure, updates received meanwhile are dropped
+	awaitingSnapshot  bool
+	resubscribeFailed bool
 }
 
 // Update stores orderbook updates and dictates what features to use when processing
 type Update struct {
-	UpdateID   int64 // Used when no time is provided
-	UpdateTime time.Time
-	Asset      asset.Item
+	UpdateID int64 // Used when no time is provided
+	// FirstUpdateID is the first update ID covered when an update spans a
+	// range of IDs ending at UpdateID, zero when it covers UpdateID only
+	FirstUpdateID int64
+	UpdateTime    time.Time
+	Asset         asset.Item
+	// Checksum is the exchange's checksum of the book once the update is
+	// applied, it is verified when VerifyChecksum is set
+	Checksum       uint32
+	VerifyChecksum bool
+	// PriceDecimals and AmountDecimals are the decimal places of the levels
+	// for exchanges which format checksums with them
+	PriceDecimals  int
+	AmountDecimals int
 	Action
 	Bids []orderbook.Item
 	Asks []orderbook.Item
@@ -0,0 +1,161 @@
+package buffer
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	// ErrChecksumFailure is returned when an orderbook fails checksum
+	// verification, the book should be resubscribed or reloaded
+	ErrChecksumFailure = errors.New("orderbook checksum verification failed")
+
+	errChecksumUnset     = errors.New("checksum unset")
+	errInsufficientDepth = errors.New("insufficient bid and ask levels to calculate checksum")
+)
+
+// SetChecksum enables checksum verification. Updates with VerifyChecksum set
+// are verified once applied, a book failing verification is resynchronised
+// when a snapshot fetcher is set or resubscribed when a resubscriber is set,
+// otherwise ErrChecksumFailure is returned
+func (w *Orderbook) SetChecksum(c Checksum) {
+	w.m.Lock()
+	w.checksum = c
+	w.m.Unlock()
+}
+
+// VerifyChecksum verifies a checksum an exchange sends separately from its
+// updates against the current book
+func (w *Orderbook) VerifyChecksum(p currency.Pair, a asset.Item, checksum uint32) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.checksum == nil {
+		return fmt.Errorf(packageError, errChecksumUnset)
+	}
+	obLookup, ok := w.ob[p.Base][p.Quote][a]
+	if !ok {
+		return fmt.Errorf("ob.Base could not be found for Exchange %s CurrencyPair: %s AssetType: %s",
+			w.exchangeName,
+			p,
+			a)
+	}
+	if obLookup.resyncing || obLookup.awaitingSnapshot {
+		return nil
+	}
+	err := w.verifyChecksum(obLookup, nil, checksum)
+	if err != nil && w.recoverChecksumFailure(obLookup, nil, err) {
+		return nil
+	}
+	return err
+}
+
+// SetResubscriber enables recovery from checksum failures when no snapshot
+// fetcher is set. A book failing verification is discarded and its channel
+// resubscribed, updates are dropped until the exchange sends a new snapshot
+func (w *Orderbook) SetResubscriber(f Resubscriber) {
+	w.m.Lock()
+	w.resubscribe = f
+	w.m.Unlock()
+}
+
+// recoverChecksumFailure resynchronises a book which failed checksum
+// verification from a fetched snapshot, or resubscribes to it. It returns
+// false when neither is set. w.m must be held
+func (w *Orderbook) recoverChecksumFailure(o *orderbookHolder, updates []Update, reason error) bool {
+	switch {
+	case w.fetchSnapshot != nil:
+		w.startResync(o, updates, reason)
+	case w.resubscribe != nil:
+		log.Warnf(log.WebsocketMgr, "%s %s %s %v, resubscribing to orderbook\n",
+			w.exchangeName, o.ob.Pair, o.ob.AssetType, reason)
+		o.ob.Bids = nil
+		o.ob.Asks = nil
+		o.awaitingSnapshot = true
+		o.resubscribeFailed = false
+		go w.resubscribeBook(o, o.ob.Pair, o.ob.AssetType)
+	default:
+		return false
+	}
+	return true
+}
+
+// resubscribeBook resubscribes to the channel of a book discarded after a
+// checksum failure. If it fails the next update received for the book retries
+func (w *Orderbook) resubscribeBook(o *orderbookHolder, p currency.Pair, a asset.Item) {
+	err := w.resubscribe(p, a)
+	if err == nil {
+		return
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if !o.awaitingSnapshot || w.ob[p.Base][p.Quote][a] != o {
+		// A new snapshot was loaded or the buffer flushed meanwhile
+		return
+	}
+	w.stats.Failures++
+	o.resubscribeFailed = true
+	log.Errorf(log.WebsocketMgr, "%s %s %s orderbook resubscription failed: %v\n",
+		w.exchangeName, p, a, err)
+}
+
+// verifyChecksum compares the checksum calculated for a book against the
+// checksum sent by the exchange, w.m must be held
+func (w *Orderbook) verifyChecksum(o *orderbookHolder, u *Update, expected uint32) error {
+	calculated, err := w.checksum.Calculate(o.ob, u)
+	if err == nil && calculated != expected {
+		err = fmt.Errorf("calculated %d expected %d", calculated, expected)
+	}
+	if err != nil {
+		w.stats.ChecksumFailures++
+		checksumFailures.WithLabelValues(w.exchangeName).Inc()
+		log.Warnf(log.WebsocketMgr, "%s %s %s checksum failure: %v\n",
+			w.exchangeName, o.ob.Pair, o.ob.AssetType, err)
+		return fmt.Errorf("%w for %s %s: %v",
+			ErrChecksumFailure, o.ob.Pair, o.ob.AssetType, err)
+	}
+	return nil
+}
+
+// Calculate calls f(b, u)
+func (f ChecksumFunc) Calculate(b *orderbook.Base, u *Update) (uint32, error) {
+	return f(b, u)
+}
+
+// Calculate formats the top levels of the book and returns their CRC32 IEEE
+// checksum
+func (c *CRC32) Calculate(b *orderbook.Base, u *Update) (uint32, error) {
+	if c.RequireDepth && (len(b.Bids) < c.Depth || len(b.Asks) < c.Depth) {
+		return 0, errInsufficientDepth
+	}
+	var sb strings.Builder
+	write := func(side []orderbook.Item, i int, bid bool) {
+		if i >= len(side) {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(c.Delimiter)
+		}
+		sb.WriteString(c.Format(side[i], bid, u))
+	}
+	if c.AsksFirst {
+		for i := 0; i < c.Depth; i++ {
+			write(b.Asks, i, false)
+		}
+		for i := 0; i < c.Depth; i++ {
+			write(b.Bids, i, true)
+		}
+	} else {
+		for i := 0; i < c.Depth; i++ {
+			write(b.Bids, i, true)
+			write(b.Asks, i, false)
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(sb.String())), nil
+}
@@ -0,0 +1,243 @@
+package buffer
+
+import (
+	"errors"
+	"hash/crc32"
+	"strconv"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+var testChecksum = &CRC32{
+	Depth:     2,
+	Delimiter: ":",
+	Format: func(item orderbook.Item, _ bool, _ *Update) string {
+		return strconv.FormatFloat(item.Price, 'f', -1, 64) + ":" +
+			strconv.FormatFloat(item.Amount, 'f', -1, 64)
+	},
+}
+
+func TestCRC32Calculate(t *testing.T) {
+	t.Parallel()
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 3, Amount: 1}, {Price: 2, Amount: 2}, {Price: 1, Amount: 3}},
+		Asks: []orderbook.Item{{Price: 4, Amount: 4}},
+	}
+	check, err := testChecksum.Calculate(book, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := crc32.ChecksumIEEE([]byte("3:1:4:4:2:2")); check != expected {
+		t.Errorf("expected %d received %d", expected, check)
+	}
+
+	asksFirst := &CRC32{
+		Depth:        2,
+		RequireDepth: true,
+		AsksFirst:    true,
+		Format: func(item orderbook.Item, bid bool, u *Update) string {
+			if bid {
+				return "b" + strconv.Itoa(u.PriceDecimals)
+			}
+			return "a" + strconv.Itoa(u.PriceDecimals)
+		},
+	}
+	_, err = asksFirst.Calculate(book, &Update{PriceDecimals: 1})
+	if !errors.Is(err, errInsufficientDepth) {
+		t.Fatalf("expected %v received %v", errInsufficientDepth, err)
+	}
+	book.Asks = append(book.Asks, orderbook.Item{Price: 5, Amount: 5})
+	check, err = asksFirst.Calculate(book, &Update{PriceDecimals: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := crc32.ChecksumIEEE([]byte("a1a1b1b1")); check != expected {
+		t.Errorf("expected %d received %d", expected, check)
+	}
+
+	f := ChecksumFunc(func(*orderbook.Base, *Update) (uint32, error) { return 1337, nil })
+	if check, _ = f.Calculate(book, nil); check != 1337 {
+		t.Errorf("expected 1337 received %d", check)
+	}
+}
+
+func TestUpdateChecksum(t *testing.T) {
+	t.Parallel()
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obl.SetChecksum(testChecksum)
+
+	bids := []orderbook.Item{{Price: 3000, Amount: 1}}
+	err = obl.Update(&Update{
+		Bids:           bids,
+		Pair:           cp,
+		Asset:          asset.Spot,
+		Checksum:       crc32.ChecksumIEEE([]byte("4000:1:4000:1:3000:1")),
+		VerifyChecksum: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Updates without a checksum are applied unverified
+	err = obl.Update(&Update{Bids: []orderbook.Item{{Price: 3000, Amount: 2}}, Pair: cp, Asset: asset.Spot})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = obl.Update(&Update{
+		Bids:           []orderbook.Item{{Price: 2000, Amount: 1}},
+		Pair:           cp,
+		Asset:          asset.Spot,
+		Checksum:       1,
+		VerifyChecksum: true,
+	})
+	if !errors.Is(err, ErrChecksumFailure) {
+		t.Fatalf("expected %v received %v", ErrChecksumFailure, err)
+	}
+	if s := obl.GetResyncStats(); s.ChecksumFailures != 1 {
+		t.Errorf("expected a checksum failure, received %+v", s)
+	}
+}
+
+func TestChecksumFailureResync(t *testing.T) {
+	t.Parallel()
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obl.SetChecksum(testChecksum)
+	obl.SetSnapshotFetcher(func(p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+		return &orderbook.Base{
+			Pair:         p,
+			AssetType:    a,
+			ExchangeName: exchangeName,
+			Bids:         []orderbook.Item{{Price: 100, Amount: 1}},
+			Asks:         []orderbook.Item{{Price: 200, Amount: 1}},
+		}, nil
+	})
+
+	err = obl.Update(&Update{
+		Bids:           []orderbook.Item{{Price: 3000, Amount: 1}},
+		Pair:           cp,
+		Asset:          asset.Spot,
+		Checksum:       1,
+		VerifyChecksum: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := waitForResyncs(t, obl, 1)
+	if s.ChecksumFailures != 1 || s.Resyncs != 1 || s.Gaps != 0 {
+		t.Fatalf("unexpected resync stats %+v", s)
+	}
+	ob := obl.GetOrderbook(cp, asset.Spot)
+	if len(ob.Bids) != 1 || ob.Bids[0].Price != 100 || len(ob.Asks) != 1 || ob.Asks[0].Price != 200 {
+		t.Errorf("expected book to be replaced by the snapshot, received %+v", ob)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	t.Parallel()
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = obl.VerifyChecksum(cp, asset.Spot, 0)
+	if !errors.Is(err, errChecksumUnset) {
+		t.Fatalf("expected %v received %v", errChecksumUnset, err)
+	}
+	obl.SetChecksum(testChecksum)
+	if err = obl.VerifyChecksum(cp, asset.Futures, 0); err == nil {
+		t.Error("expected error for an unknown book")
+	}
+	err = obl.VerifyChecksum(cp, asset.Spot, crc32.ChecksumIEEE([]byte("4000:1:4000:1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = obl.VerifyChecksum(cp, asset.Spot, 1)
+	if !errors.Is(err, ErrChecksumFailure) {
+		t.Fatalf("expected %v received %v", ErrChecksumFailure, err)
+	}
+}
+
+func TestChecksumFailureResubscribe(t *testing.T) {
+	t.Parallel()
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obl.exchangeName = "ResubscribeTest"
+	obl.SetChecksum(testChecksum)
+	counted := checksumFailures.WithLabelValues(obl.exchangeName).Value()
+	resubscribed := make(chan struct{}, 2)
+	var calls int
+	obl.SetResubscriber(func(p currency.Pair, a asset.Item) error {
+		calls++
+		first := calls == 1
+		resubscribed <- struct{}{}
+		if first {
+			return errors.New("connection lost")
+		}
+		return nil
+	})
+
+	err = obl.Update(&Update{
+		Bids:           []orderbook.Item{{Price: 3000, Amount: 1}},
+		Pair:           cp,
+		Asset:          asset.Spot,
+		Checksum:       1,
+		VerifyChecksum: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-resubscribed
+	if s := waitForResyncs(t, obl, 1); s.ChecksumFailures != 1 || s.Failures != 1 {
+		t.Fatalf("unexpected resync stats %+v", s)
+	}
+	if v := checksumFailures.WithLabelValues(obl.exchangeName).Value(); v != counted+1 {
+		t.Errorf("expected checksum failure to be counted, received %v", v)
+	}
+	if ob := obl.GetOrderbook(cp, asset.Spot); len(ob.Bids) != 0 || len(ob.Asks) != 0 {
+		t.Fatalf("expected book to be discarded, received %+v", ob)
+	}
+
+	// Updates are dropped until a snapshot arrives, the failed resubscription
+	// is retried
+	err = obl.Update(&Update{Bids: []orderbook.Item{{Price: 3000, Amount: 2}}, Pair: cp, Asset: asset.Spot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-resubscribed
+	if ob := obl.GetOrderbook(cp, asset.Spot); len(ob.Bids) != 0 {
+		t.Fatalf("expected update to be dropped, received %+v", ob)
+	}
+
+	err = obl.LoadSnapshot(&orderbook.Base{
+		Pair:          cp,
+		AssetType:     asset.Spot,
+		ExchangeName:  exchangeName,
+		NotAggregated: true,
+		Bids:          []orderbook.Item{{Price: 100, Amount: 1}},
+		Asks:          []orderbook.Item{{Price: 200, Amount: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := obl.GetResyncStats(); s.Resyncs != 1 {
+		t.Errorf("expected snapshot to complete the resync, received %+v", s)
+	}
+	err = obl.Update(&Update{Bids: []orderbook.Item{{Price: 150, Amount: 1}}, Pair: cp, Asset: asset.Spot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ob := obl.GetOrderbook(cp, asset.Spot); len(ob.Bids) != 2 || ob.Bids[0].Price != 150 {
+		t.Errorf("expected updates to be applied after the snapshot, received %+v", ob)
+	}
+}
@@ -0,0 +1,7 @@
+package buffer
+
+import "github.com/thrasher-corp/gocryptotrader/metrics"
+
+var checksumFailures = metrics.NewCounterVec("gct_websocket_orderbook_checksum_failures_total",
+	"Exchange websocket orderbooks failing checksum verification",
+	"exchange")
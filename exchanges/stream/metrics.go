@@ -0,0 +1,24 @@
+package stream
+
+import "github.com/thrasher-corp/gocryptotrader/metrics"
+
+var (
+	websocketConnected = metrics.NewGaugeVec("gct_websocket_connected",
+		"Whether the exchange websocket is connected",
+		"exchange")
+	websocketConnects = metrics.NewCounterVec("gct_websocket_connects_total",
+		"Exchange websocket connection attempts by result",
+		"exchange", "result")
+	websocketDisconnects = metrics.NewCounterVec("gct_websocket_disconnects_total",
+		"Exchange websocket disconnections",
+		"exchange")
+	websocketMessagesReceived = metrics.NewCounterVec("gct_websocket_messages_received_total",
+		"Messages received over exchange websocket connections",
+		"exchange")
+	websocketBytesReceived = metrics.NewCounterVec("gct_websocket_received_bytes_total",
+		"Bytes received over exchange websocket connections",
+		"exchange")
+	websocketMessagesSent = metrics.NewCounterVec("gct_websocket_messages_sent_total",
+		"Messages sent over exchange websocket connections",
+		"exchange")
+)
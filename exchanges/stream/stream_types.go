@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -18,6 +19,7 @@ type Connection interface {
 	SetupPingHandler(PingHandler)
 	GenerateMessageID(highPrecision bool) int64
 	SendMessageReturnResponse(signature interface{}, request interface{}) ([]byte, error)
+	SendRequest(ctx context.Context, r *Request, result interface{}) error
 	SendRawMessage(messageType int, message []byte) error
 	SetURL(string)
 	SetProxy(string)
@@ -37,15 +39,33 @@ type ChannelSubscription struct {
 	Currency currency.Pair
 	Asset    asset.Item
 	Params   map[string]interface{}
+	// Connection is the index of the pooled connection carrying the
+	// subscription, zero being Conn
+	Connection int
 }
 
 // ConnectionSetup defines variables for an individual stream connection
 type ConnectionSetup struct {
 	ResponseCheckTimeout time.Duration
 	ResponseMaxLimit     time.Duration
-	RateLimit            int64
-	URL                  string
-	Authenticated        bool
+	// RateLimit is the interval in milliseconds at which outbound messages
+	// are permitted, RateLimitBurst messages can be sent without waiting
+	RateLimit      int64
+	RateLimitBurst int
+	URL            string
+	Authenticated  bool
+}
+
+// Request defines an outbound message awaiting a correlated response
+type Request struct {
+	// Signature identifies the response, the exchange's data handler passes
+	// it to Match.IncomingWithData
+	Signature interface{}
+	Payload   interface{}
+	// Timeout overrides the connection's ResponseMaxLimit
+	Timeout time.Duration
+	// ErrorExtractor returns the error carried by a response, if any
+	ErrorExtractor func(response []byte) error
 }
 
 // PingHandler container for ping handler settings
@@ -73,6 +73,15 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 
 	w.GenerateSubs = s.GenerateSubscriptions
 
+	if s.MaxSubscriptionsPerConnection < 0 {
+		return errors.New("max subscriptions per connection cannot be negative")
+	}
+	if s.MaxSubscriptionsPerConnection > 0 && s.ShardConnector == nil {
+		return errors.New("max subscriptions per connection set yet shard connector is not set")
+	}
+	w.maxSubscriptionsPerConnection = s.MaxSubscriptionsPerConnection
+	w.shardConnector = s.ShardConnector
+
 	w.enabled = s.Enabled
 	if s.DefaultURL == "" {
 		return errors.New("default url is empty")
@@ -108,13 +117,20 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 	w.Wg = new(sync.WaitGroup)
 	w.SetCanUseAuthenticatedEndpoints(s.AuthenticatedWebsocketAPISupport)
 
-	return w.Orderbook.Setup(s.OrderbookBufferLimit,
+	err = w.Orderbook.Setup(s.OrderbookBufferLimit,
 		s.BufferEnabled,
 		s.SortBuffer,
 		s.SortBufferByUpdateIDs,
 		s.UpdateEntriesByID,
 		w.exchangeName,
 		w.DataHandler)
+	if err != nil {
+		return err
+	}
+	w.Orderbook.SetSnapshotFetcher(s.OrderbookSnapshotFetcher)
+	w.Orderbook.SetChecksum(s.OrderbookChecksum)
+	w.Orderbook.SetResubscriber(s.OrderbookResubscriber)
+	return nil
 }
 
 // SetupNewConnection sets up an auth or unauth streaming connection
@@ -138,12 +154,29 @@ func (w *Websocket) SetupNewConnection(c ConnectionSetup) error {
 		return errors.New("setting up new connection error: read message errors is nil, please call setup first")
 	}
 
+	if c.RateLimit < 0 || c.RateLimitBurst < 0 {
+		return errors.New("setting up new connection error: rate limit cannot be negative")
+	}
+
+	if c.Authenticated {
+		w.AuthConn = w.newConnection(&c)
+	} else {
+		w.Conn = w.newConnection(&c)
+		// Additional connections of the pool are set up alike
+		w.connectionSetup = c
+	}
+
+	return nil
+}
+
+// newConnection returns a stream connection for the connection setup
+func (w *Websocket) newConnection(c *ConnectionSetup) *WebsocketConnection {
 	connectionURL := w.GetWebsocketURL()
 	if c.URL != "" {
 		connectionURL = c.URL
 	}
 
-	newConn := &WebsocketConnection{
+	return &WebsocketConnection{
 		ExchangeName:      w.exchangeName,
 		URL:               connectionURL,
 		ProxyURL:          w.GetProxyAddress(),
@@ -155,15 +188,8 @@ func (w *Websocket) SetupNewConnection(c ConnectionSetup) error {
 		Wg:                w.Wg,
 		Match:             w.Match,
 		RateLimit:         c.RateLimit,
+		RateLimitBurst:    c.RateLimitBurst,
 	}
-
-	if c.Authenticated {
-		w.AuthConn = newConn
-	} else {
-		w.Conn = newConn
-	}
-
-	return nil
 }
 
 // Connect initiates a websocket connection by using a package defined connection
@@ -193,10 +219,12 @@ func (w *Websocket) Connect() error {
 
 	err := w.connector()
 	if err != nil {
+		websocketConnects.WithLabelValues(w.exchangeName, "error").Inc()
 		w.setConnectingStatus(false)
 		return fmt.Errorf("%v Error connecting %s",
 			w.exchangeName, err)
 	}
+	websocketConnects.WithLabelValues(w.exchangeName, "success").Inc()
 	w.setConnectedStatus(true)
 	w.setConnectingStatus(false)
 	w.setInit(true)
@@ -207,8 +235,18 @@ func (w *Websocket) Connect() error {
 
 	// Resubscribe after re-connection
 	if len(w.subscriptions) != 0 {
-		err = w.Subscriber(w.subscriptions)
+		subs := w.subscriptions
+		if w.maxSubscriptionsPerConnection > 0 {
+			subs, err = w.rebalanceConnections()
+			if err != nil {
+				return fmt.Errorf("%v Error subscribing %s", w.exchangeName, err)
+			}
+		}
+		err = w.Subscriber(subs)
 		if err != nil {
+			if w.maxSubscriptionsPerConnection > 0 {
+				w.restoreSubscriptions(subs)
+			}
 			return fmt.Errorf("%v Error subscribing %s", w.exchangeName, err)
 		}
 	}
@@ -327,6 +365,7 @@ func (w *Websocket) connectionMonitor() {
 					log.Warnf(log.WebsocketMgr,
 						"%v websocket has been disconnected. Reason: %v",
 						w.exchangeName, err)
+					websocketDisconnects.WithLabelValues(w.exchangeName).Inc()
 					w.setConnectedStatus(false)
 				} else {
 					// pass off non disconnect errors to datahandler to manage
@@ -387,6 +426,10 @@ func (w *Websocket) Shutdown() error {
 		}
 	}
 
+	if err := w.shutdownShards(); err != nil {
+		return err
+	}
+
 	// flush any subscriptions from last connection if needed
 	w.subscriptionMutex.Lock()
 	w.subscriptions = nil
@@ -541,6 +584,11 @@ func (w *Websocket) setConnectedStatus(b bool) {
 	w.connectionMutex.Lock()
 	w.connected = b
 	w.connectionMutex.Unlock()
+	var connected float64
+	if b {
+		connected = 1
+	}
+	websocketConnected.WithLabelValues(w.exchangeName).Set(connected)
 }
 
 // IsConnected returns status of connection
@@ -685,6 +733,11 @@ func (w *Websocket) SetWebsocketURL(url string, auth, reconnect bool) error {
 		if w.Conn != nil {
 			w.Conn.SetURL(url)
 		}
+		w.shardMutex.RLock()
+		for i := range w.shards {
+			w.shards[i].SetURL(url)
+		}
+		w.shardMutex.RUnlock()
 	}
 
 	if w.IsConnected() && reconnect {
@@ -734,6 +787,11 @@ func (w *Websocket) SetProxyAddress(proxyAddr string) error {
 	if w.AuthConn != nil {
 		w.AuthConn.SetProxy(proxyAddr)
 	}
+	w.shardMutex.RLock()
+	for i := range w.shards {
+		w.shards[i].SetProxy(proxyAddr)
+	}
+	w.shardMutex.RUnlock()
 
 	w.proxyAddr = proxyAddr
 	if w.IsInit() && w.IsEnabled() {
@@ -795,10 +853,13 @@ func (w *Websocket) UnsubscribeChannels(channels []ChannelSubscription) error {
 	w.subscriptionMutex.Lock()
 	defer w.subscriptionMutex.Unlock()
 
+	// Unsubscribe on the connections carrying the subscriptions
+	channels = append(channels[:0:0], channels...)
 channels:
 	for x := range channels {
 		for y := range w.subscriptions {
 			if channels[x].Equal(&w.subscriptions[y]) {
+				channels[x].Connection = w.subscriptions[y].Connection
 				continue channels
 			}
 		}
@@ -809,13 +870,23 @@ channels:
 	return w.Unsubscriber(channels)
 }
 
-// ResubscribeToChannel resubscribes to channel
+// ResubscribeToChannel resubscribes to channel, the stored subscription is
+// used so its parameters are sent again
 func (w *Websocket) ResubscribeToChannel(subscribedChannel *ChannelSubscription) error {
-	err := w.UnsubscribeChannels([]ChannelSubscription{*subscribedChannel})
+	ch := *subscribedChannel
+	w.subscriptionMutex.Lock()
+	for i := range w.subscriptions {
+		if w.subscriptions[i].Equal(subscribedChannel) {
+			ch = w.subscriptions[i]
+			break
+		}
+	}
+	w.subscriptionMutex.Unlock()
+	err := w.UnsubscribeChannels([]ChannelSubscription{ch})
 	if err != nil {
 		return err
 	}
-	return w.SubscribeToChannels([]ChannelSubscription{*subscribedChannel})
+	return w.SubscribeToChannels([]ChannelSubscription{ch})
 }
 
 // SubscribeToChannels appends supplied channels to channelsToSubscribe
@@ -835,6 +906,13 @@ func (w *Websocket) SubscribeToChannels(channels []ChannelSubscription) error {
 			}
 		}
 	}
+	if w.maxSubscriptionsPerConnection > 0 {
+		var err error
+		channels, err = w.assignConnections(channels)
+		if err != nil {
+			return err
+		}
+	}
 	return w.Subscriber(channels)
 }
 
@@ -865,8 +943,9 @@ func (w *ChannelSubscription) Equal(s *ChannelSubscription) bool {
 		w.Currency.Equal(s.Currency)
 }
 
-// GetSubscriptions returns a copied list of subscriptions
-// subscriptions is a private member and cannot be manipulated
+// GetSubscriptions returns a copied list of subscriptions, each with the
+// index of the connection carrying it. subscriptions is a private member and
+// cannot be manipulated
 func (w *Websocket) GetSubscriptions() []ChannelSubscription {
 	w.subscriptionMutex.Lock()
 	defer w.subscriptionMutex.Unlock()
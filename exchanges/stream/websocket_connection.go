@@ -4,10 +4,9 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
-	"crypto/rand"
+	"context"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"net"
 	"net/http"
 	"net/url"
@@ -21,28 +20,10 @@ import (
 // SendMessageReturnResponse will send a WS message to the connection and wait
 // for response
 func (w *WebsocketConnection) SendMessageReturnResponse(signature, request interface{}) ([]byte, error) {
-	m, err := w.Match.set(signature)
-	if err != nil {
-		return nil, err
-	}
-	defer m.Cleanup()
-
-	err = w.SendJSONMessage(request)
-	if err != nil {
-		return nil, err
-	}
-
-	timer := time.NewTimer(w.ResponseMaxLimit)
-
-	select {
-	case payload := <-m.C:
-		return payload, nil
-	case <-timer.C:
-		timer.Stop()
-		return nil, fmt.Errorf("%s websocket connection: timeout waiting for response with signature: %v",
-			w.ExchangeName,
-			signature)
-	}
+	return w.sendRequest(context.Background(), &Request{
+		Signature: signature,
+		Payload:   request,
+	})
 }
 
 // Dial sets proxy urls and then connects to the websocket
@@ -91,11 +72,22 @@ func (w *WebsocketConnection) Dial(dialer *websocket.Dialer, headers http.Header
 
 // SendJSONMessage sends a JSON encoded message over the connection
 func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
+	return w.sendJSONMessage(context.Background(), data)
+}
+
+// sendJSONMessage sends a JSON encoded message once permitted by the rate
+// limiter, or returns when ctx is cancelled
+func (w *WebsocketConnection) sendJSONMessage(ctx context.Context, data interface{}) error {
 	if !w.IsConnected() {
 		return fmt.Errorf("%s websocket connection: cannot send message to a disconnected websocket",
 			w.ExchangeName)
 	}
 
+	err := w.waitForRateLimit(ctx)
+	if err != nil {
+		return err
+	}
+
 	w.writeControl.Lock()
 	defer w.writeControl.Unlock()
 
@@ -106,14 +98,15 @@ func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
 			data)
 	}
 
-	if w.RateLimit > 0 {
-		time.Sleep(time.Duration(w.RateLimit) * time.Millisecond)
-		if !w.IsConnected() {
-			return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-				w.ExchangeName)
-		}
+	if !w.IsConnected() {
+		return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
+			w.ExchangeName)
 	}
-	return w.Connection.WriteJSON(data)
+	err = w.Connection.WriteJSON(data)
+	if err == nil {
+		websocketMessagesSent.WithLabelValues(w.ExchangeName).Inc()
+	}
+	return err
 }
 
 // SendRawMessage sends a message over the connection without JSON encoding it
@@ -123,6 +116,11 @@ func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) er
 			w.ExchangeName)
 	}
 
+	err := w.waitForRateLimit(context.Background())
+	if err != nil {
+		return err
+	}
+
 	w.writeControl.Lock()
 	defer w.writeControl.Unlock()
 
@@ -132,18 +130,15 @@ func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) er
 			w.ExchangeName,
 			message)
 	}
-	if w.RateLimit > 0 {
-		time.Sleep(time.Duration(w.RateLimit) * time.Millisecond)
-		if !w.IsConnected() {
-			return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-				w.ExchangeName)
-		}
-	}
 	if !w.IsConnected() {
 		return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
 			w.ExchangeName)
 	}
-	return w.Connection.WriteMessage(messageType, message)
+	err = w.Connection.WriteMessage(messageType, message)
+	if err == nil {
+		websocketMessagesSent.WithLabelValues(w.ExchangeName).Inc()
+	}
+	return err
 }
 
 // SetupPingHandler will automatically send ping or pong messages based on
@@ -211,6 +206,9 @@ func (w *WebsocketConnection) ReadMessage() Response {
 		return Response{}
 	}
 
+	websocketMessagesReceived.WithLabelValues(w.ExchangeName).Inc()
+	websocketBytesReceived.WithLabelValues(w.ExchangeName).Add(float64(len(resp)))
+
 	select {
 	case w.Traffic <- struct{}{}:
 	default: // causes contention, just bypass if there is no receiver.
@@ -273,21 +271,10 @@ func (w *WebsocketConnection) parseBinaryResponse(resp []byte) ([]byte, error) {
 	return standardMessage, nil
 }
 
-// GenerateMessageID Creates a messageID to checkout
+// GenerateMessageID returns a request ID from the generator shared by all
+// connections, high precision IDs have 13 digits and others 9
 func (w *WebsocketConnection) GenerateMessageID(highPrec bool) int64 {
-	var min int64 = 1e8
-	var max int64 = 2e8
-	if highPrec {
-		max = 2e12
-		min = 1e12
-	}
-	// utlization of hard coded positive numbers and default crypto/rand
-	// io.reader will panic on error instead of returning
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(max-min+1))
-	if err != nil {
-		panic(err)
-	}
-	return randomNumber.Int64() + min
+	return nextRequestID(highPrec)
 }
 
 // Shutdown shuts down and closes specific connection
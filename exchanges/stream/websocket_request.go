@@ -0,0 +1,120 @@
+package stream
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRequestTimeout is returned when no response is matched to a request
+// within its timeout
+var ErrRequestTimeout = errors.New("timeout waiting for response")
+
+// requestID is shared by all connections so outstanding requests never share
+// a signature, it is seeded randomly so IDs differ between runs
+var requestID = seedRequestID()
+
+func seedRequestID() int64 {
+	// utlization of hard coded positive numbers and default crypto/rand
+	// io.reader will panic on error instead of returning
+	n, err := rand.Int(rand.Reader, big.NewInt(1e8))
+	if err != nil {
+		panic(err)
+	}
+	return n.Int64()
+}
+
+// nextRequestID returns a unique, increasing request ID in the range
+// [1e12, 2e12) when highPrecision is set, otherwise [1e8, 2e8)
+func nextRequestID(highPrecision bool) int64 {
+	n := atomic.AddInt64(&requestID, 1)
+	if highPrecision {
+		return 1e12 + n%1e12
+	}
+	return 1e8 + n%1e8
+}
+
+// waitForRateLimit blocks until the connection's token bucket permits a
+// message or ctx is cancelled
+func (w *WebsocketConnection) waitForRateLimit(ctx context.Context) error {
+	if w.RateLimit <= 0 {
+		return nil
+	}
+	w.limiterInit.Do(func() {
+		burst := w.RateLimitBurst
+		if burst < 1 {
+			burst = 1
+		}
+		w.limiter = rate.NewLimiter(rate.Every(time.Duration(w.RateLimit)*time.Millisecond), burst)
+	})
+	if err := w.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("%s websocket connection: rate limit: %w", w.ExchangeName, err)
+	}
+	return nil
+}
+
+// SendRequest sends a request and waits for the response matched to its
+// signature, returning the error extracted from the response if any,
+// otherwise decoding the response into result when set
+func (w *WebsocketConnection) SendRequest(ctx context.Context, r *Request, result interface{}) error {
+	resp, err := w.sendRequest(ctx, r)
+	if err != nil {
+		return err
+	}
+	if r.ErrorExtractor != nil {
+		if err = r.ErrorExtractor(resp); err != nil {
+			return fmt.Errorf("%s websocket connection: request with signature %v error: %w",
+				w.ExchangeName,
+				r.Signature,
+				err)
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp, result)
+}
+
+// sendRequest sends a request and returns the matched response, or an error
+// when the request times out or ctx is cancelled
+func (w *WebsocketConnection) sendRequest(ctx context.Context, r *Request) ([]byte, error) {
+	m, err := w.Match.set(r.Signature)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Cleanup()
+
+	err = w.sendJSONMessage(ctx, r.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = w.ResponseMaxLimit
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case payload := <-m.C:
+		return payload, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%s websocket connection: %w with signature: %v",
+			w.ExchangeName,
+			ErrRequestTimeout,
+			r.Signature)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s websocket connection: request with signature %v: %w",
+			w.ExchangeName,
+			r.Signature,
+			ctx.Err())
+	}
+}
@@ -0,0 +1,180 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type testCorrelatedRequest struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error,omitempty"`
+	// Silent requests are not answered by the test server
+	Silent bool `json:"silent,omitempty"`
+}
+
+// newRequestTestConnection dials a local server echoing requests, matching
+// the echoes to their request IDs
+func newRequestTestConnection(t *testing.T) (*WebsocketConnection, func()) {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			var req testCorrelatedRequest
+			if err = c.ReadJSON(&req); err != nil {
+				return
+			}
+			if req.Silent {
+				continue
+			}
+			if err = c.WriteJSON(req); err != nil {
+				return
+			}
+		}
+	}))
+	wc := &WebsocketConnection{
+		ExchangeName:      "test",
+		URL:               "ws" + strings.TrimPrefix(srv.URL, "http"),
+		ResponseMaxLimit:  time.Second * 5,
+		Match:             NewMatch(),
+		readMessageErrors: make(chan error, 1),
+	}
+	if err := wc.Dial(&websocket.Dialer{}, http.Header{}); err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			resp := wc.ReadMessage()
+			if resp.Raw == nil {
+				return
+			}
+			var r testCorrelatedRequest
+			if err := json.Unmarshal(resp.Raw, &r); err == nil {
+				wc.Match.IncomingWithData(r.ID, resp.Raw)
+			}
+		}
+	}()
+	return wc, func() {
+		_ = wc.Shutdown()
+		srv.Close()
+	}
+}
+
+func TestSendRequest(t *testing.T) {
+	t.Parallel()
+	wc, shutdown := newRequestTestConnection(t)
+	defer shutdown()
+
+	extractor := func(resp []byte) error {
+		var r testCorrelatedRequest
+		if err := json.Unmarshal(resp, &r); err != nil {
+			return err
+		}
+		if r.Error != "" {
+			return errors.New(r.Error)
+		}
+		return nil
+	}
+
+	id := wc.GenerateMessageID(false)
+	var result testCorrelatedRequest
+	err := wc.SendRequest(context.Background(), &Request{
+		Signature:      id,
+		Payload:        testCorrelatedRequest{ID: id},
+		ErrorExtractor: extractor,
+	}, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ID != id {
+		t.Errorf("expected response %d received %d", id, result.ID)
+	}
+
+	id = wc.GenerateMessageID(false)
+	err = wc.SendRequest(context.Background(), &Request{
+		Signature:      id,
+		Payload:        testCorrelatedRequest{ID: id, Error: "insufficient funds"},
+		ErrorExtractor: extractor,
+	}, &result)
+	if err == nil || !strings.Contains(err.Error(), "insufficient funds") {
+		t.Errorf("expected extracted error, received %v", err)
+	}
+
+	id = wc.GenerateMessageID(false)
+	err = wc.SendRequest(context.Background(), &Request{
+		Signature: id,
+		Payload:   testCorrelatedRequest{ID: id, Silent: true},
+		Timeout:   time.Millisecond * 50,
+	}, nil)
+	if !errors.Is(err, ErrRequestTimeout) {
+		t.Errorf("expected %v received %v", ErrRequestTimeout, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		cancel()
+	}()
+	id = wc.GenerateMessageID(false)
+	err = wc.SendRequest(ctx, &Request{
+		Signature: id,
+		Payload:   testCorrelatedRequest{ID: id, Silent: true},
+	}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v received %v", context.Canceled, err)
+	}
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	t.Parallel()
+	wc := &WebsocketConnection{RateLimit: 50, RateLimitBurst: 3}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := wc.waitForRateLimit(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Millisecond*40 {
+		t.Errorf("expected burst to be sent without waiting, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := wc.waitForRateLimit(ctx); err == nil {
+		t.Error("expected error when the context expires before a token is available")
+	}
+	if err := wc.waitForRateLimit(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond*40 {
+		t.Errorf("expected message beyond burst to wait for the refill, took %v", elapsed)
+	}
+}
+
+func TestNextRequestID(t *testing.T) {
+	t.Parallel()
+	seen := make(map[int64]struct{})
+	for i := 0; i < 1000; i++ {
+		low, high := nextRequestID(false), nextRequestID(true)
+		if low < 1e8 || low >= 2e8 || high < 1e12 || high >= 2e12 {
+			t.Fatalf("request IDs %d %d out of range", low, high)
+		}
+		if _, ok := seen[low]; ok {
+			t.Fatalf("duplicate request ID %d", low)
+		}
+		seen[low] = struct{}{}
+	}
+}
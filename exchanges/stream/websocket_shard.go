@@ -0,0 +1,157 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errConnectionNotFound = errors.New("connection not found")
+
+// GetConnection returns the pooled connection at index, zero being Conn
+func (w *Websocket) GetConnection(index int) (Connection, error) {
+	if index == 0 && w.Conn != nil {
+		return w.Conn, nil
+	}
+	w.shardMutex.RLock()
+	defer w.shardMutex.RUnlock()
+	if index < 1 || index > len(w.shards) {
+		return nil, fmt.Errorf("%s websocket: %w at index %d",
+			w.exchangeName,
+			errConnectionNotFound,
+			index)
+	}
+	return w.shards[index-1], nil
+}
+
+// GetConnectionCount returns the number of pooled connections including Conn
+func (w *Websocket) GetConnectionCount() int {
+	w.shardMutex.RLock()
+	defer w.shardMutex.RUnlock()
+	return len(w.shards) + 1
+}
+
+// GroupByConnection groups subscriptions by the index of the connection
+// carrying them so each connection can be sent its own request
+func GroupByConnection(channels []ChannelSubscription) [][]ChannelSubscription {
+	var groups [][]ChannelSubscription
+	for i := range channels {
+		for len(groups) <= channels[i].Connection {
+			groups = append(groups, nil)
+		}
+		groups[channels[i].Connection] = append(groups[channels[i].Connection], channels[i])
+	}
+	return groups
+}
+
+// assignConnections distributes new subscriptions to the least loaded
+// connections, dialing additional connections so none carries more than
+// maxSubscriptionsPerConnection. subscriptionMutex must be held
+func (w *Websocket) assignConnections(channels []ChannelSubscription) ([]ChannelSubscription, error) {
+	loads := make([]int, w.GetConnectionCount())
+	for i := range w.subscriptions {
+		if c := w.subscriptions[i].Connection; c < len(loads) {
+			loads[c]++
+		}
+	}
+
+	total := len(w.subscriptions) + len(channels)
+	required := (total + w.maxSubscriptionsPerConnection - 1) / w.maxSubscriptionsPerConnection
+	for len(loads) < required {
+		if err := w.dialShard(); err != nil {
+			return nil, err
+		}
+		loads = append(loads, 0)
+	}
+
+	assigned := append(channels[:0:0], channels...)
+	for i := range assigned {
+		least := 0
+		for j := range loads {
+			if loads[j] < loads[least] {
+				least = j
+			}
+		}
+		assigned[i].Connection = least
+		loads[least]++
+	}
+	return assigned, nil
+}
+
+// rebalanceConnections discards the additional connections after a
+// reconnection and distributes the prior subscriptions evenly across a new
+// pool, returning them to be resubscribed. The subscriptions are kept when
+// the pool cannot be dialed so the next reconnection retries them
+func (w *Websocket) rebalanceConnections() ([]ChannelSubscription, error) {
+	if err := w.shutdownShards(); err != nil {
+		log.Errorf(log.WebsocketMgr, "%s websocket: %v\n", w.exchangeName, err)
+	}
+	w.subscriptionMutex.Lock()
+	defer w.subscriptionMutex.Unlock()
+	subs := w.subscriptions
+	// The subscriber adds them back once they are resubscribed
+	w.subscriptions = nil
+	assigned, err := w.assignConnections(subs)
+	if err != nil {
+		w.subscriptions = subs
+		return nil, err
+	}
+	return assigned, nil
+}
+
+// restoreSubscriptions adds back rebalanced subscriptions the subscriber
+// failed to resubscribe so the next reconnection retries them
+func (w *Websocket) restoreSubscriptions(subs []ChannelSubscription) {
+	w.subscriptionMutex.Lock()
+	defer w.subscriptionMutex.Unlock()
+restore:
+	for i := range subs {
+		for j := range w.subscriptions {
+			if subs[i].Equal(&w.subscriptions[j]) {
+				continue restore
+			}
+		}
+		w.subscriptions = append(w.subscriptions, subs[i])
+	}
+}
+
+// dialShard sets up an additional connection like Conn and dials it through
+// the exchange's shard connector
+func (w *Websocket) dialShard() error {
+	conn := w.newConnection(&w.connectionSetup)
+	if err := w.shardConnector(conn); err != nil {
+		return fmt.Errorf("%s websocket: connection %d error: %w",
+			w.exchangeName,
+			w.GetConnectionCount(),
+			err)
+	}
+	w.shardMutex.Lock()
+	w.shards = append(w.shards, conn)
+	w.shardMutex.Unlock()
+	if w.verbose {
+		log.Debugf(log.WebsocketMgr,
+			"%s websocket: connection %d added to pool\n",
+			w.exchangeName,
+			w.GetConnectionCount()-1)
+	}
+	return nil
+}
+
+// shutdownShards shuts down and discards the additional connections
+func (w *Websocket) shutdownShards() error {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	var errs common.Errors
+	for i := range w.shards {
+		if err := w.shards[i].Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	w.shards = nil
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
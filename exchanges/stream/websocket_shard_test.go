@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestShardSetup(t *testing.T) {
+	t.Parallel()
+	s := *defaultSetup
+	s.MaxSubscriptionsPerConnection = -1
+	if err := New().Setup(&s); err == nil {
+		t.Error("expected error for a negative subscription limit")
+	}
+	s.MaxSubscriptionsPerConnection = 2
+	if err := New().Setup(&s); err == nil {
+		t.Error("expected error for an unset shard connector")
+	}
+	s.ShardConnector = func(Connection) error { return nil }
+	if err := New().Setup(&s); err != nil {
+		t.Error(err)
+	}
+}
+
+func newShardedWebsocket(t *testing.T, limit int) (w *Websocket, dialed *[]Connection, unsubscribed *[]ChannelSubscription) {
+	t.Helper()
+	w = New()
+	var conns []Connection
+	var unsubs []ChannelSubscription
+	s := *defaultSetup
+	s.MaxSubscriptionsPerConnection = limit
+	s.ShardConnector = func(c Connection) error {
+		conns = append(conns, c)
+		return nil
+	}
+	s.Subscriber = func(subs []ChannelSubscription) error {
+		w.AddSuccessfulSubscriptions(subs...)
+		return nil
+	}
+	s.UnSubscriber = func(subs []ChannelSubscription) error {
+		unsubs = append(unsubs, subs...)
+		w.RemoveSuccessfulUnsubscriptions(subs...)
+		return nil
+	}
+	if err := w.Setup(&s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetupNewConnection(ConnectionSetup{URL: "wss://testRunningURL"}); err != nil {
+		t.Fatal(err)
+	}
+	return w, &conns, &unsubs
+}
+
+func connectionLoads(w *Websocket) []int {
+	loads := make([]int, w.GetConnectionCount())
+	subs := w.GetSubscriptions()
+	for i := range subs {
+		loads[subs[i].Connection]++
+	}
+	return loads
+}
+
+func TestSubscriptionSharding(t *testing.T) {
+	t.Parallel()
+	w, dialed, unsubscribed := newShardedWebsocket(t, 2)
+	err := w.SubscribeToChannels([]ChannelSubscription{
+		{Channel: "1"}, {Channel: "2"}, {Channel: "3"}, {Channel: "4"}, {Channel: "5"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*dialed) != 2 || w.GetConnectionCount() != 3 {
+		t.Fatalf("expected 2 additional connections, dialed %d", len(*dialed))
+	}
+	if loads := connectionLoads(w); loads[0] != 2 || loads[1] != 2 || loads[2] != 1 {
+		t.Errorf("unexpected connection loads %v", loads)
+	}
+
+	conn, err := w.GetConnection(0)
+	if err != nil || conn != w.Conn {
+		t.Errorf("expected Conn at index 0, received %v %v", conn, err)
+	}
+	conn, err = w.GetConnection(2)
+	if err != nil || conn != (*dialed)[1] {
+		t.Errorf("expected second dialed connection at index 2, received %v %v", conn, err)
+	}
+	if _, err = w.GetConnection(3); !errors.Is(err, errConnectionNotFound) {
+		t.Errorf("expected %v received %v", errConnectionNotFound, err)
+	}
+
+	// Unsubscriptions are routed to the connection carrying the channel
+	var target ChannelSubscription
+	for _, s := range w.GetSubscriptions() {
+		if s.Connection == 1 {
+			target = s
+			break
+		}
+	}
+	err = w.UnsubscribeChannels([]ChannelSubscription{{Channel: target.Channel}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*unsubscribed) != 1 || (*unsubscribed)[0].Connection != 1 {
+		t.Errorf("expected unsubscription from connection 1, received %+v", *unsubscribed)
+	}
+
+	err = w.SubscribeToChannels([]ChannelSubscription{{Channel: "6"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*dialed) != 2 {
+		t.Error("expected the new channel to use an existing connection")
+	}
+	if loads := connectionLoads(w); loads[0] != 2 || loads[1] != 2 || loads[2] != 1 {
+		t.Errorf("expected the least loaded connection to be used, received %v", loads)
+	}
+}
+
+func TestRebalanceConnections(t *testing.T) {
+	t.Parallel()
+	w, dialed, _ := newShardedWebsocket(t, 2)
+	w.AddSuccessfulSubscriptions(
+		ChannelSubscription{Channel: "1", Connection: 0},
+		ChannelSubscription{Channel: "2", Connection: 3},
+		ChannelSubscription{Channel: "3", Connection: 3},
+	)
+	subs, err := w.rebalanceConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.GetSubscriptions()) != 0 {
+		t.Error("expected subscriptions to be cleared until resubscribed")
+	}
+	if len(*dialed) != 1 || w.GetConnectionCount() != 2 {
+		t.Fatalf("expected a single additional connection, dialed %d", len(*dialed))
+	}
+	groups := GroupByConnection(subs)
+	if len(groups) != 2 || len(groups[0]) != 2 || len(groups[1]) != 1 {
+		t.Errorf("unexpected rebalanced subscriptions %+v", groups)
+	}
+
+	if err = w.shutdownShards(); err != nil {
+		t.Fatal(err)
+	}
+	if w.GetConnectionCount() != 1 {
+		t.Error("expected additional connections to be discarded")
+	}
+}
+
+func TestShardConnectorError(t *testing.T) {
+	t.Parallel()
+	w, _, _ := newShardedWebsocket(t, 1)
+	errDial := errors.New("dial failure")
+	w.shardConnector = func(Connection) error { return errDial }
+	err := w.SubscribeToChannels([]ChannelSubscription{{Channel: "1"}, {Channel: "2"}})
+	if !errors.Is(err, errDial) {
+		t.Fatalf("expected %v received %v", errDial, err)
+	}
+	if len(w.GetSubscriptions()) != 0 {
+		t.Error("expected no subscriptions when a connection cannot be dialed")
+	}
+}
+
+func TestRebalanceConnectionsError(t *testing.T) {
+	t.Parallel()
+	w, _, _ := newShardedWebsocket(t, 1)
+	w.AddSuccessfulSubscriptions(
+		ChannelSubscription{Channel: "1", Connection: 0},
+		ChannelSubscription{Channel: "2", Connection: 1},
+	)
+	errDial := errors.New("dial failure")
+	w.shardConnector = func(Connection) error { return errDial }
+	if _, err := w.rebalanceConnections(); !errors.Is(err, errDial) {
+		t.Fatalf("expected %v received %v", errDial, err)
+	}
+	if subs := w.GetSubscriptions(); len(subs) != 2 {
+		t.Fatalf("expected subscriptions to be kept for the next reconnection, received %+v", subs)
+	}
+
+	w.shardConnector = func(Connection) error { return nil }
+	subs, err := w.rebalanceConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The subscriber resubscribed the first subscription only
+	w.AddSuccessfulSubscriptions(subs[0])
+	w.restoreSubscriptions(subs)
+	if restored := w.GetSubscriptions(); len(restored) != 2 {
+		t.Errorf("expected failed resubscriptions to be restored, received %+v", restored)
+	}
+}
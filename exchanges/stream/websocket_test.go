@@ -502,7 +502,9 @@ func TestResubscribe(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	var resubscribed []ChannelSubscription
 	fnSub := func(subs []ChannelSubscription) error {
+		resubscribed = subs
 		ws.AddSuccessfulSubscriptions(subs...)
 		return nil
 	}
@@ -513,7 +515,7 @@ func TestResubscribe(t *testing.T) {
 	ws.Subscriber = fnSub
 	ws.Unsubscriber = fnUnsub
 
-	channel := []ChannelSubscription{{Channel: "resubTest"}}
+	channel := []ChannelSubscription{{Channel: "resubTest", Params: map[string]interface{}{"len": "100"}}}
 	err = ws.ResubscribeToChannel(&channel[0])
 	if err == nil {
 		t.Fatal("error cannot be nil")
@@ -524,10 +526,13 @@ func TestResubscribe(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = ws.ResubscribeToChannel(&channel[0])
+	err = ws.ResubscribeToChannel(&ChannelSubscription{Channel: "resubTest"})
 	if err != nil {
 		t.Fatal("error cannot be nil")
 	}
+	if len(resubscribed) != 1 || resubscribed[0].Params["len"] != "100" {
+		t.Errorf("expected stored subscription to be resubscribed, received %+v", resubscribed)
+	}
 }
 
 // TestConnectionMonitorNoConnection logic test
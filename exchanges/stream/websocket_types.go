@@ -7,6 +7,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
+	"golang.org/x/time/rate"
 )
 
 // Websocket functionality list and state consts
@@ -82,6 +83,14 @@ type Websocket struct {
 	Conn Connection
 	// Authenticated stream connection
 	AuthConn Connection
+
+	// shards are the connections dialed in addition to Conn once it carries
+	// maxSubscriptionsPerConnection subscriptions
+	shards                        []Connection
+	shardMutex                    sync.RWMutex
+	shardConnector                func(Connection) error
+	maxSubscriptionsPerConnection int
+	connectionSetup               ConnectionSetup
 }
 
 // WebsocketSetup defines variables for setting up a websocket connection
@@ -105,6 +114,21 @@ type WebsocketSetup struct {
 	SortBuffer            bool
 	SortBufferByUpdateIDs bool
 	UpdateEntriesByID     bool
+	// OrderbookSnapshotFetcher enables orderbook update sequence
+	// verification, books are resynchronised from its snapshot after a gap
+	OrderbookSnapshotFetcher buffer.SnapshotFetcher
+	// OrderbookChecksum enables verification of the checksums exchanges send
+	// with their orderbook updates
+	OrderbookChecksum buffer.Checksum
+	// OrderbookResubscriber recovers books failing checksum verification by
+	// resubscribing to them when no snapshot fetcher is set
+	OrderbookResubscriber buffer.Resubscriber
+	// MaxSubscriptionsPerConnection shards subscriptions across a pool of
+	// connections so none carries more than this many, zero uses Conn only
+	MaxSubscriptionsPerConnection int
+	// ShardConnector dials an additional connection of the pool and starts
+	// reading from it, required when MaxSubscriptionsPerConnection is set
+	ShardConnector func(Connection) error
 }
 
 // WebsocketConnection contains all the data needed to send a message to a WS
@@ -117,7 +141,12 @@ type WebsocketConnection struct {
 	// writes methods
 	writeControl sync.Mutex
 
-	RateLimit    int64
+	RateLimit      int64
+	RateLimitBurst int
+	// limiter is built from RateLimit and RateLimitBurst on first use
+	limiter     *rate.Limiter
+	limiterInit sync.Once
+
 	ExchangeName string
 	URL          string
 	ProxyURL     string
@@ -278,6 +278,7 @@ func (y *Yobit) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 func (y *Yobit) UpdateAccountInfo() (account.Holdings, error) {
 	var response account.Holdings
 	response.Exchange = y.Name
+	response.Account = y.Account
 	accountBalance, err := y.GetAccountInformation()
 	if err != nil {
 		return response, err
@@ -312,7 +313,7 @@ func (y *Yobit) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (y *Yobit) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(y.Name)
+	acc, err := account.GetAccountHoldings(y.Name, y.Account)
 	if err != nil {
 		return y.UpdateAccountInfo()
 	}
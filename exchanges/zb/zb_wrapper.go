@@ -367,6 +367,7 @@ func (z *ZB) UpdateAccountInfo() (account.Holdings, error) {
 	}
 
 	info.Exchange = z.Name
+	info.Account = z.Account
 	info.Accounts = append(info.Accounts, account.SubAccount{
 		Currencies: balances,
 	})
@@ -381,7 +382,7 @@ func (z *ZB) UpdateAccountInfo() (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (z *ZB) FetchAccountInfo() (account.Holdings, error) {
-	acc, err := account.GetHoldings(z.Name)
+	acc, err := account.GetAccountHoldings(z.Name, z.Account)
 	if err != nil {
 		return z.UpdateAccountInfo()
 	}
@@ -24,3 +24,20 @@ func (b BasicAuth) GetRequestMetadata(ctx context.Context, in ...string) (map[st
 func (BasicAuth) RequireTransportSecurity() bool {
 	return true
 }
+
+// TokenAuth stores an API token sent as a bearer token
+type TokenAuth struct {
+	Token string
+}
+
+// GetRequestMetadata is a implementation of the GetRequestMetadata function
+func (t TokenAuth) GetRequestMetadata(ctx context.Context, in ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + t.Token,
+	}, nil
+}
+
+// RequireTransportSecurity is required for token auth
+func (TokenAuth) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Permission is a group of remote control actions which can be granted to a
+// role
+type Permission uint8
+
+// Permissions, each RPC method requires exactly one
+const (
+	PermissionRead Permission = 1 << iota
+	PermissionTrade
+	PermissionWithdraw
+	PermissionAdmin
+)
+
+// Roles which can be assigned to remote control users
+const (
+	RoleReadOnly   = "readonly"
+	RoleTrader     = "trader"
+	RoleWithdrawer = "withdrawer"
+	RoleAdmin      = "admin"
+)
+
+var roles = map[string]Permission{
+	RoleReadOnly:   PermissionRead,
+	RoleTrader:     PermissionRead | PermissionTrade,
+	RoleWithdrawer: PermissionRead | PermissionWithdraw,
+	RoleAdmin:      PermissionRead | PermissionTrade | PermissionWithdraw | PermissionAdmin,
+}
+
+// methodPermissions maps RPC method names to the permission they require,
+// methods which are not listed require PermissionAdmin
+var methodPermissions = map[string]Permission{
+	"GetInfo":                           PermissionRead,
+	"GetSubsystems":                     PermissionRead,
+	"GetRPCEndpoints":                   PermissionRead,
+	"GetCommunicationRelayers":          PermissionRead,
+	"GetExchanges":                      PermissionRead,
+	"GetExchangeInfo":                   PermissionRead,
+	"GetTicker":                         PermissionRead,
+	"GetTickers":                        PermissionRead,
+	"GetOrderbook":                      PermissionRead,
+	"GetOrderbooks":                     PermissionRead,
+	"GetAccountInfo":                    PermissionRead,
+	"UpdateAccountInfo":                 PermissionRead,
+	"GetAccountInfoStream":              PermissionRead,
+	"GetPortfolio":                      PermissionRead,
+	"GetPortfolioSummary":               PermissionRead,
+	"GetForexProviders":                 PermissionRead,
+	"GetForexRates":                     PermissionRead,
+	"GetOrders":                         PermissionRead,
+	"GetOrder":                          PermissionRead,
+	"GetEvents":                         PermissionRead,
+	"GetCryptocurrencyDepositAddresses": PermissionRead,
+	"GetCryptocurrencyDepositAddress":   PermissionRead,
+	"WithdrawalEventByID":               PermissionRead,
+	"WithdrawalEventsByExchange":        PermissionRead,
+	"WithdrawalEventsByDate":            PermissionRead,
+	"GetLoggerDetails":                  PermissionRead,
+	"GetExchangePairs":                  PermissionRead,
+	"GetOrderbookStream":                PermissionRead,
+	"GetExchangeOrderbookStream":        PermissionRead,
+	"GetTickerStream":                   PermissionRead,
+	"GetExchangeTickerStream":           PermissionRead,
+	"GetHistoricCandles":                PermissionRead,
+	"GetExchangeAssets":                 PermissionRead,
+	"WebsocketGetInfo":                  PermissionRead,
+	"WebsocketGetSubscriptions":         PermissionRead,
+	"GetRecentTrades":                   PermissionRead,
+	"GetHistoricTrades":                 PermissionRead,
+	"GetSavedTrades":                    PermissionRead,
+	"FindMissingSavedCandleIntervals":   PermissionRead,
+	"FindMissingSavedTradeIntervals":    PermissionRead,
+	"GetFundingRate":                    PermissionRead,
+	"GetFundingRateHistory":             PermissionRead,
+	"GetOpenInterest":                   PermissionRead,
+	"GetMarkPrice":                      PermissionRead,
+	"GetFuturesContracts":               PermissionRead,
+	"GetLeveragedPositions":             PermissionRead,
+	"GetPortfolioEquityCurve":           PermissionRead,
+	"GetPortfolioAllocation":            PermissionRead,
+	"GetRebalanceProposals":             PermissionRead,
+	"GetPendingWithdrawals":             PermissionRead,
+	"GetDepositHistory":                 PermissionRead,
+	"GetBalanceAlertStream":             PermissionRead,
+	"SimulateOrder":                     PermissionRead,
+
+	"SubmitOrder":          PermissionTrade,
+	"WhaleBomb":            PermissionTrade,
+	"CancelOrder":          PermissionTrade,
+	"CancelBatchOrders":    PermissionTrade,
+	"CancelAllOrders":      PermissionTrade,
+	"AddEvent":             PermissionTrade,
+	"RemoveEvent":          PermissionTrade,
+	"SetLeverage":          PermissionTrade,
+	"SetMarginType":        PermissionTrade,
+	"ModifyIsolatedMargin": PermissionTrade,
+
+	"WithdrawFiatFunds":           PermissionWithdraw,
+	"WithdrawCryptocurrencyFunds": PermissionWithdraw,
+	"ApproveWithdrawal":           PermissionWithdraw,
+	"RejectWithdrawal":            PermissionWithdraw,
+	"PlanRebalance":               PermissionWithdraw,
+	"ApproveRebalanceProposal":    PermissionWithdraw,
+	"RejectRebalanceProposal":     PermissionWithdraw,
+}
+
+// String returns the permission name
+func (p Permission) String() string {
+	switch p {
+	case PermissionRead:
+		return "read"
+	case PermissionTrade:
+		return "trade"
+	case PermissionWithdraw:
+		return "withdraw"
+	case PermissionAdmin:
+		return "admin"
+	}
+	return "unknown"
+}
+
+// IsValidRole returns whether the role exists
+func IsValidRole(role string) bool {
+	_, ok := roles[strings.ToLower(role)]
+	return ok
+}
+
+// RoleHasPermission returns whether the role is granted the permission
+func RoleHasPermission(role string, p Permission) bool {
+	return roles[strings.ToLower(role)]&p != 0
+}
+
+// MethodPermission returns the permission required by a full gRPC method name
+// e.g. /gctrpc.GoCryptoTrader/GetInfo
+func MethodPermission(fullMethod string) Permission {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if p, ok := methodPermissions[method]; ok {
+		return p
+	}
+	return PermissionAdmin
+}
+
+// Identity is an authenticated remote control user
+type Identity struct {
+	Name string
+	Role string
+}
+
+// String returns the identity name and role
+func (i Identity) String() string {
+	return i.Name + "(" + i.Role + ")"
+}
+
+// Can returns whether the identity is granted the permission
+func (i Identity) Can(p Permission) bool {
+	return RoleHasPermission(i.Role, p)
+}
+
+type identityKey struct{}
+
+// NewContext returns a context carrying the identity
+func NewContext(ctx context.Context, i Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, i)
+}
+
+// FromContext returns the identity stored in the context
+func FromContext(ctx context.Context) (Identity, bool) {
+	i, ok := ctx.Value(identityKey{}).(Identity)
+	return i, ok
+}
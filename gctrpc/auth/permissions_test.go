@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRoleHasPermission(t *testing.T) {
+	t.Parallel()
+	if !IsValidRole("Trader") || IsValidRole("superuser") {
+		t.Error("unexpected role validity")
+	}
+	if !RoleHasPermission(RoleReadOnly, PermissionRead) ||
+		RoleHasPermission(RoleReadOnly, PermissionTrade) {
+		t.Error("unexpected readonly permissions")
+	}
+	if !RoleHasPermission(RoleTrader, PermissionTrade) ||
+		RoleHasPermission(RoleTrader, PermissionWithdraw) {
+		t.Error("unexpected trader permissions")
+	}
+	if !RoleHasPermission(RoleWithdrawer, PermissionWithdraw) ||
+		RoleHasPermission(RoleWithdrawer, PermissionTrade) {
+		t.Error("unexpected withdrawer permissions")
+	}
+	if !RoleHasPermission(RoleAdmin, PermissionAdmin) ||
+		RoleHasPermission("", PermissionRead) {
+		t.Error("unexpected permissions")
+	}
+}
+
+func TestMethodPermission(t *testing.T) {
+	t.Parallel()
+	if p := MethodPermission("/gctrpc.GoCryptoTrader/GetInfo"); p != PermissionRead {
+		t.Errorf("expected %v, received %v", PermissionRead, p)
+	}
+	if p := MethodPermission("/gctrpc.GoCryptoTrader/SubmitOrder"); p != PermissionTrade {
+		t.Errorf("expected %v, received %v", PermissionTrade, p)
+	}
+	if p := MethodPermission("/gctrpc.GoCryptoTrader/WithdrawCryptocurrencyFunds"); p != PermissionWithdraw {
+		t.Errorf("expected %v, received %v", PermissionWithdraw, p)
+	}
+	if p := MethodPermission("/gctrpc.GoCryptoTrader/GetConfig"); p != PermissionAdmin {
+		t.Errorf("expected %v, received %v", PermissionAdmin, p)
+	}
+	if p := MethodPermission("NewMethod"); p != PermissionAdmin {
+		t.Errorf("expected %v, received %v", PermissionAdmin, p)
+	}
+}
+
+func TestIdentityContext(t *testing.T) {
+	t.Parallel()
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("expected no identity")
+	}
+	ctx := NewContext(context.Background(), Identity{Name: "bot", Role: RoleTrader})
+	i, ok := FromContext(ctx)
+	if !ok || i.Name != "bot" || !i.Can(PermissionTrade) || i.Can(PermissionAdmin) {
+		t.Errorf("unexpected identity %v", i)
+	}
+	if i.String() != "bot(trader)" {
+		t.Errorf("unexpected identity string %s", i)
+	}
+}
@@ -96,6 +96,29 @@ type GoCryptoTraderClient interface {
 	FindMissingSavedCandleIntervals(ctx context.Context, in *FindMissingCandlePeriodsRequest, opts ...grpc.CallOption) (*FindMissingIntervalsResponse, error)
 	FindMissingSavedTradeIntervals(ctx context.Context, in *FindMissingTradePeriodsRequest, opts ...grpc.CallOption) (*FindMissingIntervalsResponse, error)
 	SetExchangeTradeProcessing(ctx context.Context, in *SetExchangeTradeProcessingRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GetFundingRate(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error)
+	GetFundingRateHistory(ctx context.Context, in *GetFundingRateHistoryRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error)
+	GetOpenInterest(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetOpenInterestResponse, error)
+	GetMarkPrice(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetMarkPriceResponse, error)
+	GetFuturesContracts(ctx context.Context, in *GetFuturesContractsRequest, opts ...grpc.CallOption) (*GetFuturesContractsResponse, error)
+	GetLeveragedPositions(ctx context.Context, in *GetLeveragedPositionsRequest, opts ...grpc.CallOption) (*GetLeveragedPositionsResponse, error)
+	SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	SetMarginType(ctx context.Context, in *SetMarginTypeRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	ModifyIsolatedMargin(ctx context.Context, in *ModifyIsolatedMarginRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GetPortfolioEquityCurve(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*GetPortfolioEquityCurveResponse, error)
+	GetPortfolioAllocation(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*GetPortfolioAllocationResponse, error)
+	PlanRebalance(ctx context.Context, in *PlanRebalanceRequest, opts ...grpc.CallOption) (*RebalanceProposalsResponse, error)
+	GetRebalanceProposals(ctx context.Context, in *GetRebalanceProposalsRequest, opts ...grpc.CallOption) (*RebalanceProposalsResponse, error)
+	ApproveRebalanceProposal(ctx context.Context, in *RebalanceProposalRequest, opts ...grpc.CallOption) (*RebalanceProposal, error)
+	RejectRebalanceProposal(ctx context.Context, in *RebalanceProposalRequest, opts ...grpc.CallOption) (*RebalanceProposal, error)
+	GetPendingWithdrawals(ctx context.Context, in *GetPendingWithdrawalsRequest, opts ...grpc.CallOption) (*GetPendingWithdrawalsResponse, error)
+	ApproveWithdrawal(ctx context.Context, in *WithdrawalApprovalRequest, opts ...grpc.CallOption) (*WithdrawalEventResponse, error)
+	RejectWithdrawal(ctx context.Context, in *WithdrawalApprovalRequest, opts ...grpc.CallOption) (*WithdrawalEventResponse, error)
+	GetDepositHistory(ctx context.Context, in *GetDepositHistoryRequest, opts ...grpc.CallOption) (*GetDepositHistoryResponse, error)
+	GetBalanceAlertStream(ctx context.Context, in *GetBalanceAlertStreamRequest, opts ...grpc.CallOption) (GoCryptoTrader_GetBalanceAlertStreamClient, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	VerifyAuditEvents(ctx context.Context, in *VerifyAuditEventsRequest, opts ...grpc.CallOption) (*VerifyAuditEventsResponse, error)
+	ExportAuditEvents(ctx context.Context, in *ExportAuditEventsRequest, opts ...grpc.CallOption) (*ExportAuditEventsResponse, error)
 }
 
 type goCryptoTraderClient struct {
@@ -955,6 +978,236 @@ func (c *goCryptoTraderClient) SetExchangeTradeProcessing(ctx context.Context, i
 	return out, nil
 }
 
+func (c *goCryptoTraderClient) GetFundingRate(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error) {
+	out := new(GetFundingRatesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetFundingRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetFundingRateHistory(ctx context.Context, in *GetFundingRateHistoryRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error) {
+	out := new(GetFundingRatesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetFundingRateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetOpenInterest(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetOpenInterestResponse, error) {
+	out := new(GetOpenInterestResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetOpenInterest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetMarkPrice(ctx context.Context, in *GetFuturesDataRequest, opts ...grpc.CallOption) (*GetMarkPriceResponse, error) {
+	out := new(GetMarkPriceResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetMarkPrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetFuturesContracts(ctx context.Context, in *GetFuturesContractsRequest, opts ...grpc.CallOption) (*GetFuturesContractsResponse, error) {
+	out := new(GetFuturesContractsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetFuturesContracts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetLeveragedPositions(ctx context.Context, in *GetLeveragedPositionsRequest, opts ...grpc.CallOption) (*GetLeveragedPositionsResponse, error) {
+	out := new(GetLeveragedPositionsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetLeveragedPositions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/SetLeverage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) SetMarginType(ctx context.Context, in *SetMarginTypeRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/SetMarginType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) ModifyIsolatedMargin(ctx context.Context, in *ModifyIsolatedMarginRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ModifyIsolatedMargin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetPortfolioEquityCurve(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*GetPortfolioEquityCurveResponse, error) {
+	out := new(GetPortfolioEquityCurveResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetPortfolioEquityCurve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetPortfolioAllocation(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*GetPortfolioAllocationResponse, error) {
+	out := new(GetPortfolioAllocationResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetPortfolioAllocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) PlanRebalance(ctx context.Context, in *PlanRebalanceRequest, opts ...grpc.CallOption) (*RebalanceProposalsResponse, error) {
+	out := new(RebalanceProposalsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/PlanRebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetRebalanceProposals(ctx context.Context, in *GetRebalanceProposalsRequest, opts ...grpc.CallOption) (*RebalanceProposalsResponse, error) {
+	out := new(RebalanceProposalsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetRebalanceProposals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) ApproveRebalanceProposal(ctx context.Context, in *RebalanceProposalRequest, opts ...grpc.CallOption) (*RebalanceProposal, error) {
+	out := new(RebalanceProposal)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ApproveRebalanceProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RejectRebalanceProposal(ctx context.Context, in *RebalanceProposalRequest, opts ...grpc.CallOption) (*RebalanceProposal, error) {
+	out := new(RebalanceProposal)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RejectRebalanceProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetPendingWithdrawals(ctx context.Context, in *GetPendingWithdrawalsRequest, opts ...grpc.CallOption) (*GetPendingWithdrawalsResponse, error) {
+	out := new(GetPendingWithdrawalsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetPendingWithdrawals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) ApproveWithdrawal(ctx context.Context, in *WithdrawalApprovalRequest, opts ...grpc.CallOption) (*WithdrawalEventResponse, error) {
+	out := new(WithdrawalEventResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ApproveWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RejectWithdrawal(ctx context.Context, in *WithdrawalApprovalRequest, opts ...grpc.CallOption) (*WithdrawalEventResponse, error) {
+	out := new(WithdrawalEventResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RejectWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetDepositHistory(ctx context.Context, in *GetDepositHistoryRequest, opts ...grpc.CallOption) (*GetDepositHistoryResponse, error) {
+	out := new(GetDepositHistoryResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetDepositHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetBalanceAlertStream(ctx context.Context, in *GetBalanceAlertStreamRequest, opts ...grpc.CallOption) (GoCryptoTrader_GetBalanceAlertStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GoCryptoTrader_serviceDesc.Streams[6], "/gctrpc.GoCryptoTrader/GetBalanceAlertStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderGetBalanceAlertStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTrader_GetBalanceAlertStreamClient interface {
+	Recv() (*BalanceAlertResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderGetBalanceAlertStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderGetBalanceAlertStreamClient) Recv() (*BalanceAlertResponse, error) {
+	m := new(BalanceAlertResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) VerifyAuditEvents(ctx context.Context, in *VerifyAuditEventsRequest, opts ...grpc.CallOption) (*VerifyAuditEventsResponse, error) {
+	out := new(VerifyAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/VerifyAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) ExportAuditEvents(ctx context.Context, in *ExportAuditEventsRequest, opts ...grpc.CallOption) (*ExportAuditEventsResponse, error) {
+	out := new(ExportAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ExportAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoCryptoTraderServer is the server API for GoCryptoTrader service.
 // All implementations must embed UnimplementedGoCryptoTraderServer
 // for forward compatibility
@@ -1038,6 +1291,29 @@ type GoCryptoTraderServer interface {
 	FindMissingSavedCandleIntervals(context.Context, *FindMissingCandlePeriodsRequest) (*FindMissingIntervalsResponse, error)
 	FindMissingSavedTradeIntervals(context.Context, *FindMissingTradePeriodsRequest) (*FindMissingIntervalsResponse, error)
 	SetExchangeTradeProcessing(context.Context, *SetExchangeTradeProcessingRequest) (*GenericResponse, error)
+	GetFundingRate(context.Context, *GetFuturesDataRequest) (*GetFundingRatesResponse, error)
+	GetFundingRateHistory(context.Context, *GetFundingRateHistoryRequest) (*GetFundingRatesResponse, error)
+	GetOpenInterest(context.Context, *GetFuturesDataRequest) (*GetOpenInterestResponse, error)
+	GetMarkPrice(context.Context, *GetFuturesDataRequest) (*GetMarkPriceResponse, error)
+	GetFuturesContracts(context.Context, *GetFuturesContractsRequest) (*GetFuturesContractsResponse, error)
+	GetLeveragedPositions(context.Context, *GetLeveragedPositionsRequest) (*GetLeveragedPositionsResponse, error)
+	SetLeverage(context.Context, *SetLeverageRequest) (*GenericResponse, error)
+	SetMarginType(context.Context, *SetMarginTypeRequest) (*GenericResponse, error)
+	ModifyIsolatedMargin(context.Context, *ModifyIsolatedMarginRequest) (*GenericResponse, error)
+	GetPortfolioEquityCurve(context.Context, *GetPortfolioValuationRequest) (*GetPortfolioEquityCurveResponse, error)
+	GetPortfolioAllocation(context.Context, *GetPortfolioValuationRequest) (*GetPortfolioAllocationResponse, error)
+	PlanRebalance(context.Context, *PlanRebalanceRequest) (*RebalanceProposalsResponse, error)
+	GetRebalanceProposals(context.Context, *GetRebalanceProposalsRequest) (*RebalanceProposalsResponse, error)
+	ApproveRebalanceProposal(context.Context, *RebalanceProposalRequest) (*RebalanceProposal, error)
+	RejectRebalanceProposal(context.Context, *RebalanceProposalRequest) (*RebalanceProposal, error)
+	GetPendingWithdrawals(context.Context, *GetPendingWithdrawalsRequest) (*GetPendingWithdrawalsResponse, error)
+	ApproveWithdrawal(context.Context, *WithdrawalApprovalRequest) (*WithdrawalEventResponse, error)
+	RejectWithdrawal(context.Context, *WithdrawalApprovalRequest) (*WithdrawalEventResponse, error)
+	GetDepositHistory(context.Context, *GetDepositHistoryRequest) (*GetDepositHistoryResponse, error)
+	GetBalanceAlertStream(*GetBalanceAlertStreamRequest, GoCryptoTrader_GetBalanceAlertStreamServer) error
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	VerifyAuditEvents(context.Context, *VerifyAuditEventsRequest) (*VerifyAuditEventsResponse, error)
+	ExportAuditEvents(context.Context, *ExportAuditEventsRequest) (*ExportAuditEventsResponse, error)
 	mustEmbedUnimplementedGoCryptoTraderServer()
 }
 
@@ -1282,6 +1558,75 @@ func (UnimplementedGoCryptoTraderServer) FindMissingSavedTradeIntervals(context.
 func (UnimplementedGoCryptoTraderServer) SetExchangeTradeProcessing(context.Context, *SetExchangeTradeProcessingRequest) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetExchangeTradeProcessing not implemented")
 }
+func (UnimplementedGoCryptoTraderServer) GetFundingRate(context.Context, *GetFuturesDataRequest) (*GetFundingRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFundingRate not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetFundingRateHistory(context.Context, *GetFundingRateHistoryRequest) (*GetFundingRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFundingRateHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetOpenInterest(context.Context, *GetFuturesDataRequest) (*GetOpenInterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenInterest not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetMarkPrice(context.Context, *GetFuturesDataRequest) (*GetMarkPriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarkPrice not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetFuturesContracts(context.Context, *GetFuturesContractsRequest) (*GetFuturesContractsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuturesContracts not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetLeveragedPositions(context.Context, *GetLeveragedPositionsRequest) (*GetLeveragedPositionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeveragedPositions not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) SetLeverage(context.Context, *SetLeverageRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLeverage not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) SetMarginType(context.Context, *SetMarginTypeRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMarginType not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) ModifyIsolatedMargin(context.Context, *ModifyIsolatedMarginRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyIsolatedMargin not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetPortfolioEquityCurve(context.Context, *GetPortfolioValuationRequest) (*GetPortfolioEquityCurveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioEquityCurve not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetPortfolioAllocation(context.Context, *GetPortfolioValuationRequest) (*GetPortfolioAllocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioAllocation not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) PlanRebalance(context.Context, *PlanRebalanceRequest) (*RebalanceProposalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanRebalance not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetRebalanceProposals(context.Context, *GetRebalanceProposalsRequest) (*RebalanceProposalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRebalanceProposals not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) ApproveRebalanceProposal(context.Context, *RebalanceProposalRequest) (*RebalanceProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRebalanceProposal not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) RejectRebalanceProposal(context.Context, *RebalanceProposalRequest) (*RebalanceProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectRebalanceProposal not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetPendingWithdrawals(context.Context, *GetPendingWithdrawalsRequest) (*GetPendingWithdrawalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingWithdrawals not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) ApproveWithdrawal(context.Context, *WithdrawalApprovalRequest) (*WithdrawalEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveWithdrawal not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) RejectWithdrawal(context.Context, *WithdrawalApprovalRequest) (*WithdrawalEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectWithdrawal not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetDepositHistory(context.Context, *GetDepositHistoryRequest) (*GetDepositHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepositHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) GetBalanceAlertStream(*GetBalanceAlertStreamRequest, GoCryptoTrader_GetBalanceAlertStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBalanceAlertStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) VerifyAuditEvents(context.Context, *VerifyAuditEventsRequest) (*VerifyAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditEvents not implemented")
+}
+func (UnimplementedGoCryptoTraderServer) ExportAuditEvents(context.Context, *ExportAuditEventsRequest) (*ExportAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAuditEvents not implemented")
+}
 func (UnimplementedGoCryptoTraderServer) mustEmbedUnimplementedGoCryptoTraderServer() {}
 
 // UnsafeGoCryptoTraderServer may be embedded to opt out of forward compatibility for this service.
@@ -2735,80 +3080,497 @@ func _GoCryptoTrader_SetExchangeTradeProcessing_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
-var _GoCryptoTrader_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "gctrpc.GoCryptoTrader",
-	HandlerType: (*GoCryptoTraderServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetInfo",
-			Handler:    _GoCryptoTrader_GetInfo_Handler,
-		},
-		{
-			MethodName: "GetSubsystems",
-			Handler:    _GoCryptoTrader_GetSubsystems_Handler,
-		},
-		{
-			MethodName: "EnableSubsystem",
-			Handler:    _GoCryptoTrader_EnableSubsystem_Handler,
-		},
-		{
-			MethodName: "DisableSubsystem",
-			Handler:    _GoCryptoTrader_DisableSubsystem_Handler,
-		},
-		{
-			MethodName: "GetRPCEndpoints",
-			Handler:    _GoCryptoTrader_GetRPCEndpoints_Handler,
-		},
-		{
-			MethodName: "GetCommunicationRelayers",
-			Handler:    _GoCryptoTrader_GetCommunicationRelayers_Handler,
-		},
-		{
-			MethodName: "GetExchanges",
-			Handler:    _GoCryptoTrader_GetExchanges_Handler,
-		},
-		{
-			MethodName: "DisableExchange",
-			Handler:    _GoCryptoTrader_DisableExchange_Handler,
-		},
-		{
-			MethodName: "GetExchangeInfo",
-			Handler:    _GoCryptoTrader_GetExchangeInfo_Handler,
-		},
-		{
-			MethodName: "GetExchangeOTPCode",
-			Handler:    _GoCryptoTrader_GetExchangeOTPCode_Handler,
-		},
-		{
-			MethodName: "GetExchangeOTPCodes",
-			Handler:    _GoCryptoTrader_GetExchangeOTPCodes_Handler,
-		},
-		{
-			MethodName: "EnableExchange",
-			Handler:    _GoCryptoTrader_EnableExchange_Handler,
-		},
-		{
-			MethodName: "GetTicker",
-			Handler:    _GoCryptoTrader_GetTicker_Handler,
-		},
-		{
-			MethodName: "GetTickers",
-			Handler:    _GoCryptoTrader_GetTickers_Handler,
-		},
-		{
-			MethodName: "GetOrderbook",
-			Handler:    _GoCryptoTrader_GetOrderbook_Handler,
-		},
-		{
-			MethodName: "GetOrderbooks",
-			Handler:    _GoCryptoTrader_GetOrderbooks_Handler,
-		},
-		{
-			MethodName: "GetAccountInfo",
-			Handler:    _GoCryptoTrader_GetAccountInfo_Handler,
-		},
-		{
-			MethodName: "UpdateAccountInfo",
+func _GoCryptoTrader_GetFundingRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFuturesDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetFundingRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetFundingRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetFundingRate(ctx, req.(*GetFuturesDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetFundingRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFundingRateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetFundingRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetFundingRateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetFundingRateHistory(ctx, req.(*GetFundingRateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetOpenInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFuturesDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetOpenInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetOpenInterest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetOpenInterest(ctx, req.(*GetFuturesDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetMarkPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFuturesDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetMarkPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetMarkPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetMarkPrice(ctx, req.(*GetFuturesDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetFuturesContracts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFuturesContractsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetFuturesContracts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetFuturesContracts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetFuturesContracts(ctx, req.(*GetFuturesContractsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetLeveragedPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeveragedPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetLeveragedPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetLeveragedPositions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetLeveragedPositions(ctx, req.(*GetLeveragedPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_SetLeverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).SetLeverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/SetLeverage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).SetLeverage(ctx, req.(*SetLeverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_SetMarginType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMarginTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).SetMarginType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/SetMarginType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).SetMarginType(ctx, req.(*SetMarginTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_ModifyIsolatedMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyIsolatedMarginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ModifyIsolatedMargin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ModifyIsolatedMargin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ModifyIsolatedMargin(ctx, req.(*ModifyIsolatedMarginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetPortfolioEquityCurve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetPortfolioEquityCurve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetPortfolioEquityCurve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetPortfolioEquityCurve(ctx, req.(*GetPortfolioValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetPortfolioAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetPortfolioAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetPortfolioAllocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetPortfolioAllocation(ctx, req.(*GetPortfolioValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_PlanRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanRebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).PlanRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/PlanRebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).PlanRebalance(ctx, req.(*PlanRebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetRebalanceProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRebalanceProposalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetRebalanceProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetRebalanceProposals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetRebalanceProposals(ctx, req.(*GetRebalanceProposalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_ApproveRebalanceProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ApproveRebalanceProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ApproveRebalanceProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ApproveRebalanceProposal(ctx, req.(*RebalanceProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RejectRebalanceProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RejectRebalanceProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RejectRebalanceProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RejectRebalanceProposal(ctx, req.(*RebalanceProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetPendingWithdrawals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingWithdrawalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetPendingWithdrawals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetPendingWithdrawals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetPendingWithdrawals(ctx, req.(*GetPendingWithdrawalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_ApproveWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawalApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ApproveWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ApproveWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ApproveWithdrawal(ctx, req.(*WithdrawalApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RejectWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawalApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RejectWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RejectWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RejectWithdrawal(ctx, req.(*WithdrawalApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetDepositHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDepositHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetDepositHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetDepositHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetDepositHistory(ctx, req.(*GetDepositHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetBalanceAlertStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBalanceAlertStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServer).GetBalanceAlertStream(m, &goCryptoTraderGetBalanceAlertStreamServer{stream})
+}
+
+type GoCryptoTrader_GetBalanceAlertStreamServer interface {
+	Send(*BalanceAlertResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderGetBalanceAlertStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderGetBalanceAlertStreamServer) Send(m *BalanceAlertResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTrader_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_VerifyAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).VerifyAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/VerifyAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).VerifyAuditEvents(ctx, req.(*VerifyAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_ExportAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ExportAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ExportAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ExportAuditEvents(ctx, req.(*ExportAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GoCryptoTrader_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gctrpc.GoCryptoTrader",
+	HandlerType: (*GoCryptoTraderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _GoCryptoTrader_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetSubsystems",
+			Handler:    _GoCryptoTrader_GetSubsystems_Handler,
+		},
+		{
+			MethodName: "EnableSubsystem",
+			Handler:    _GoCryptoTrader_EnableSubsystem_Handler,
+		},
+		{
+			MethodName: "DisableSubsystem",
+			Handler:    _GoCryptoTrader_DisableSubsystem_Handler,
+		},
+		{
+			MethodName: "GetRPCEndpoints",
+			Handler:    _GoCryptoTrader_GetRPCEndpoints_Handler,
+		},
+		{
+			MethodName: "GetCommunicationRelayers",
+			Handler:    _GoCryptoTrader_GetCommunicationRelayers_Handler,
+		},
+		{
+			MethodName: "GetExchanges",
+			Handler:    _GoCryptoTrader_GetExchanges_Handler,
+		},
+		{
+			MethodName: "DisableExchange",
+			Handler:    _GoCryptoTrader_DisableExchange_Handler,
+		},
+		{
+			MethodName: "GetExchangeInfo",
+			Handler:    _GoCryptoTrader_GetExchangeInfo_Handler,
+		},
+		{
+			MethodName: "GetExchangeOTPCode",
+			Handler:    _GoCryptoTrader_GetExchangeOTPCode_Handler,
+		},
+		{
+			MethodName: "GetExchangeOTPCodes",
+			Handler:    _GoCryptoTrader_GetExchangeOTPCodes_Handler,
+		},
+		{
+			MethodName: "EnableExchange",
+			Handler:    _GoCryptoTrader_EnableExchange_Handler,
+		},
+		{
+			MethodName: "GetTicker",
+			Handler:    _GoCryptoTrader_GetTicker_Handler,
+		},
+		{
+			MethodName: "GetTickers",
+			Handler:    _GoCryptoTrader_GetTickers_Handler,
+		},
+		{
+			MethodName: "GetOrderbook",
+			Handler:    _GoCryptoTrader_GetOrderbook_Handler,
+		},
+		{
+			MethodName: "GetOrderbooks",
+			Handler:    _GoCryptoTrader_GetOrderbooks_Handler,
+		},
+		{
+			MethodName: "GetAccountInfo",
+			Handler:    _GoCryptoTrader_GetAccountInfo_Handler,
+		},
+		{
+			MethodName: "UpdateAccountInfo",
 			Handler:    _GoCryptoTrader_UpdateAccountInfo_Handler,
 		},
 		{
@@ -3031,6 +3793,94 @@ var _GoCryptoTrader_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetExchangeTradeProcessing",
 			Handler:    _GoCryptoTrader_SetExchangeTradeProcessing_Handler,
 		},
+		{
+			MethodName: "GetFundingRate",
+			Handler:    _GoCryptoTrader_GetFundingRate_Handler,
+		},
+		{
+			MethodName: "GetFundingRateHistory",
+			Handler:    _GoCryptoTrader_GetFundingRateHistory_Handler,
+		},
+		{
+			MethodName: "GetOpenInterest",
+			Handler:    _GoCryptoTrader_GetOpenInterest_Handler,
+		},
+		{
+			MethodName: "GetMarkPrice",
+			Handler:    _GoCryptoTrader_GetMarkPrice_Handler,
+		},
+		{
+			MethodName: "GetFuturesContracts",
+			Handler:    _GoCryptoTrader_GetFuturesContracts_Handler,
+		},
+		{
+			MethodName: "GetLeveragedPositions",
+			Handler:    _GoCryptoTrader_GetLeveragedPositions_Handler,
+		},
+		{
+			MethodName: "SetLeverage",
+			Handler:    _GoCryptoTrader_SetLeverage_Handler,
+		},
+		{
+			MethodName: "SetMarginType",
+			Handler:    _GoCryptoTrader_SetMarginType_Handler,
+		},
+		{
+			MethodName: "ModifyIsolatedMargin",
+			Handler:    _GoCryptoTrader_ModifyIsolatedMargin_Handler,
+		},
+		{
+			MethodName: "GetPortfolioEquityCurve",
+			Handler:    _GoCryptoTrader_GetPortfolioEquityCurve_Handler,
+		},
+		{
+			MethodName: "GetPortfolioAllocation",
+			Handler:    _GoCryptoTrader_GetPortfolioAllocation_Handler,
+		},
+		{
+			MethodName: "PlanRebalance",
+			Handler:    _GoCryptoTrader_PlanRebalance_Handler,
+		},
+		{
+			MethodName: "GetRebalanceProposals",
+			Handler:    _GoCryptoTrader_GetRebalanceProposals_Handler,
+		},
+		{
+			MethodName: "ApproveRebalanceProposal",
+			Handler:    _GoCryptoTrader_ApproveRebalanceProposal_Handler,
+		},
+		{
+			MethodName: "RejectRebalanceProposal",
+			Handler:    _GoCryptoTrader_RejectRebalanceProposal_Handler,
+		},
+		{
+			MethodName: "GetPendingWithdrawals",
+			Handler:    _GoCryptoTrader_GetPendingWithdrawals_Handler,
+		},
+		{
+			MethodName: "ApproveWithdrawal",
+			Handler:    _GoCryptoTrader_ApproveWithdrawal_Handler,
+		},
+		{
+			MethodName: "RejectWithdrawal",
+			Handler:    _GoCryptoTrader_RejectWithdrawal_Handler,
+		},
+		{
+			MethodName: "GetDepositHistory",
+			Handler:    _GoCryptoTrader_GetDepositHistory_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _GoCryptoTrader_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "VerifyAuditEvents",
+			Handler:    _GoCryptoTrader_VerifyAuditEvents_Handler,
+		},
+		{
+			MethodName: "ExportAuditEvents",
+			Handler:    _GoCryptoTrader_ExportAuditEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3063,6 +3913,11 @@ var _GoCryptoTrader_serviceDesc = grpc.ServiceDesc{
 			Handler:       _GoCryptoTrader_GetHistoricTrades_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetBalanceAlertStream",
+			Handler:       _GoCryptoTrader_GetBalanceAlertStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -28,6 +28,10 @@ var exchangeModule = map[string]objects.Object{
 	"withdrawcrypto": &objects.UserFunction{Name: "withdrawcrypto", Value: ExchangeWithdrawCrypto},
 	"withdrawfiat":   &objects.UserFunction{Name: "withdrawfiat", Value: ExchangeWithdrawFiat},
 	"ohlcv":          &objects.UserFunction{Name: "ohlcv", Value: exchangeOHLCV},
+	"fundingrate":    &objects.UserFunction{Name: "fundingrate", Value: ExchangeFundingRate},
+	"fundingrates":   &objects.UserFunction{Name: "fundingrates", Value: ExchangeFundingRateHistory},
+	"openinterest":   &objects.UserFunction{Name: "openinterest", Value: ExchangeOpenInterest},
+	"markprice":      &objects.UserFunction{Name: "markprice", Value: ExchangeMarkPrice},
 }
 
 // ExchangeOrderbook returns orderbook for requested exchange & currencypair
@@ -679,6 +683,158 @@ func exchangeOHLCV(args ...objects.Object) (objects.Object, error) {
 	return c, nil
 }
 
+// parseContractArgs parses the exchange name, pair and asset parameters
+// shared by the derivative contract functions
+func parseContractArgs(args ...objects.Object) (string, currency.Pair, asset.Item, error) {
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return "", currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	currencyPair, ok := objects.ToString(args[1])
+	if !ok {
+		return "", currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, currencyPair)
+	}
+	delimiter, ok := objects.ToString(args[2])
+	if !ok {
+		return "", currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, delimiter)
+	}
+	assetTypeParam, ok := objects.ToString(args[3])
+	if !ok {
+		return "", currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, assetTypeParam)
+	}
+
+	pair, err := currency.NewPairDelimiter(currencyPair, delimiter)
+	if err != nil {
+		return "", currency.Pair{}, "", err
+	}
+	assetType, err := asset.New(assetTypeParam)
+	if err != nil {
+		return "", currency.Pair{}, "", err
+	}
+	return exchangeName, pair, assetType, nil
+}
+
+// ExchangeFundingRate returns the current funding rate for a perpetual contract
+func ExchangeFundingRate(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, pair, assetType, err := parseContractArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := wrappers.GetWrapper().FundingRate(exchangeName, pair, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]objects.Object, 6)
+	data["exchange"] = &objects.String{Value: rate.Exchange}
+	data["pair"] = &objects.String{Value: rate.Pair.String()}
+	data["asset"] = &objects.String{Value: rate.Asset.String()}
+	data["rate"] = &objects.Float{Value: rate.Rate}
+	data["predictedrate"] = &objects.Float{Value: rate.PredictedRate}
+	data["time"] = &objects.Time{Value: rate.Time}
+
+	return &objects.Map{
+		Value: data,
+	}, nil
+}
+
+// ExchangeFundingRateHistory returns funding rates for a perpetual contract
+// between start and end
+func ExchangeFundingRateHistory(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 6 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, pair, assetType, err := parseContractArgs(args[:4]...)
+	if err != nil {
+		return nil, err
+	}
+	startTime, ok := objects.ToTime(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, startTime)
+	}
+	endTime, ok := objects.ToTime(args[5])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, endTime)
+	}
+
+	rates, err := wrappers.GetWrapper().FundingRateHistory(exchangeName, pair, assetType, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	r := objects.Array{}
+	for x := range rates {
+		data := make(map[string]objects.Object, 2)
+		data["rate"] = &objects.Float{Value: rates[x].Rate}
+		data["time"] = &objects.Time{Value: rates[x].Time}
+		r.Value = append(r.Value, &objects.Map{Value: data})
+	}
+	return &r, nil
+}
+
+// ExchangeOpenInterest returns the open interest for a contract
+func ExchangeOpenInterest(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, pair, assetType, err := parseContractArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	oi, err := wrappers.GetWrapper().OpenInterest(exchangeName, pair, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]objects.Object, 5)
+	data["exchange"] = &objects.String{Value: oi.Exchange}
+	data["pair"] = &objects.String{Value: oi.Pair.String()}
+	data["asset"] = &objects.String{Value: oi.Asset.String()}
+	data["amount"] = &objects.Float{Value: oi.Amount}
+	data["time"] = &objects.Time{Value: oi.Time}
+
+	return &objects.Map{
+		Value: data,
+	}, nil
+}
+
+// ExchangeMarkPrice returns the mark and index price for a contract
+func ExchangeMarkPrice(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, pair, assetType, err := parseContractArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	mp, err := wrappers.GetWrapper().MarkPrice(exchangeName, pair, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]objects.Object, 6)
+	data["exchange"] = &objects.String{Value: mp.Exchange}
+	data["pair"] = &objects.String{Value: mp.Pair.String()}
+	data["asset"] = &objects.String{Value: mp.Asset.String()}
+	data["markprice"] = &objects.Float{Value: mp.MarkPrice}
+	data["indexprice"] = &objects.Float{Value: mp.IndexPrice}
+	data["time"] = &objects.Time{Value: mp.Time}
+
+	return &objects.Map{
+		Value: data,
+	}, nil
+}
+
 // parseInterval will parse the interval param of indictors that have them and convert to time.Duration
 func parseInterval(in string) (time.Duration, error) {
 	if !common.StringDataContainsInsensitive(supportedDurations, in) {
@@ -29,6 +29,9 @@ var (
 	assetType = &objects.String{
 		Value: "SPOT",
 	}
+	perpAsset = &objects.String{
+		Value: "PERPETUALSWAP",
+	}
 	orderID = &objects.String{
 		Value: "1235",
 	}
@@ -291,6 +294,83 @@ func TestExchangeWithdrawFiat(t *testing.T) {
 	}
 }
 
+func TestExchangeFundingRate(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeFundingRate(exch, currencyPair, delimiter, perpAsset)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = ExchangeFundingRate(exchError, currencyPair, delimiter, perpAsset)
+	if err != nil && errors.Is(err, errTestFailed) {
+		t.Error(err)
+	}
+
+	_, err = ExchangeFundingRate()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+}
+
+func TestExchangeFundingRateHistory(t *testing.T) {
+	t.Parallel()
+	end := &objects.Time{Value: time.Now()}
+	start := &objects.Time{Value: end.Value.Add(-time.Hour * 24)}
+	r, err := ExchangeFundingRateHistory(exch, currencyPair, delimiter, perpAsset, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates, ok := r.(*objects.Array); !ok || len(rates.Value) == 0 {
+		t.Error("expected funding rates to be returned")
+	}
+
+	_, err = ExchangeFundingRateHistory(exchError, currencyPair, delimiter, perpAsset, start, end)
+	if err != nil && errors.Is(err, errTestFailed) {
+		t.Error(err)
+	}
+
+	_, err = ExchangeFundingRateHistory(exch, currencyPair, delimiter, perpAsset)
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+}
+
+func TestExchangeOpenInterest(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeOpenInterest(exch, currencyPair, delimiter, perpAsset)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = ExchangeOpenInterest(exchError, currencyPair, delimiter, perpAsset)
+	if err != nil && errors.Is(err, errTestFailed) {
+		t.Error(err)
+	}
+
+	_, err = ExchangeOpenInterest()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+}
+
+func TestExchangeMarkPrice(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeMarkPrice(exch, currencyPair, delimiter, perpAsset)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = ExchangeMarkPrice(exchError, currencyPair, delimiter, perpAsset)
+	if err != nil && errors.Is(err, errTestFailed) {
+		t.Error(err)
+	}
+
+	_, err = ExchangeMarkPrice()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+}
+
 func TestParseInterval(t *testing.T) {
 	t.Parallel()
 	v, err := parseInterval("1h")
@@ -6,6 +6,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -43,6 +44,10 @@ type Exchange interface {
 	WithdrawalFiatFunds(bankAccountID string, request *withdraw.Request) (out string, err error)
 	WithdrawalCryptoFunds(request *withdraw.Request) (out string, err error)
 	OHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error)
+	FundingRate(exch string, pair currency.Pair, item asset.Item) (*futures.FundingRate, error)
+	FundingRateHistory(exch string, pair currency.Pair, item asset.Item, start, end time.Time) ([]futures.FundingRate, error)
+	OpenInterest(exch string, pair currency.Pair, item asset.Item) (*futures.OpenInterest, error)
+	MarkPrice(exch string, pair currency.Pair, item asset.Item) (*futures.MarkPrice, error)
 }
 
 // SetModuleWrapper link the wrapper and interface to use for modules
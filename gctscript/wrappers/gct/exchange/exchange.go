@@ -12,6 +12,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -231,3 +232,49 @@ func (e Exchange) OHLCV(exch string, pair currency.Pair, item asset.Item, start,
 
 	return ret, nil
 }
+
+// FundingRate returns the current funding rate for a perpetual contract
+func (e Exchange) FundingRate(exch string, pair currency.Pair, item asset.Item) (*futures.FundingRate, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetFundingRate(pair, item)
+}
+
+// FundingRateHistory returns funding rates for a perpetual contract between
+// start and end
+func (e Exchange) FundingRateHistory(exch string, pair currency.Pair, item asset.Item, start, end time.Time) ([]futures.FundingRate, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := ex.GetFundingRateHistory(pair, item, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Time.Before(ret[j].Time)
+	})
+
+	return ret, nil
+}
+
+// OpenInterest returns the open interest for a contract
+func (e Exchange) OpenInterest(exch string, pair currency.Pair, item asset.Item) (*futures.OpenInterest, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetOpenInterest(pair, item)
+}
+
+// MarkPrice returns the mark and index price for a contract
+func (e Exchange) MarkPrice(exch string, pair currency.Pair, item asset.Item) (*futures.MarkPrice, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetMarkPrice(pair, item)
+}
@@ -1,12 +1,14 @@
 package exchange
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -200,6 +202,32 @@ func TestOHLCV(t *testing.T) {
 	}
 }
 
+func TestFundingRate(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.AUD)
+	_, err := exchangeTest.FundingRate(exchName, cp, assetType)
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v received %v", common.ErrFunctionNotSupported, err)
+	}
+	_, err = exchangeTest.FundingRateHistory(exchName, cp, assetType, time.Now().Add(-time.Hour*24), time.Now())
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v received %v", common.ErrFunctionNotSupported, err)
+	}
+}
+
+func TestOpenInterest(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.AUD)
+	_, err := exchangeTest.OpenInterest(exchName, cp, assetType)
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v received %v", common.ErrFunctionNotSupported, err)
+	}
+	_, err = exchangeTest.MarkPrice(exchName, cp, assetType)
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("expected %v received %v", common.ErrFunctionNotSupported, err)
+	}
+}
+
 func setupEngine() (err error) {
 	engine.Bot, err = engine.NewFromSettings(&settings, nil)
 	if err != nil {
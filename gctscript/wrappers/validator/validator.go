@@ -7,6 +7,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -20,6 +21,8 @@ const (
 	validatorLow   float64 = 5500
 	validatorClose float64 = 5700
 	validatorVol   float64 = 10
+
+	validatorFundingRate float64 = 0.0001
 )
 
 // Exchanges validator for test execution/scripts
@@ -278,3 +281,69 @@ func (w Wrapper) OHLCV(exch string, p currency.Pair, a asset.Item, start, end ti
 		Candles:  candles,
 	}, nil
 }
+
+// FundingRate validator for test execution/scripts
+func (w Wrapper) FundingRate(exch string, p currency.Pair, a asset.Item) (*futures.FundingRate, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	return &futures.FundingRate{
+		Exchange:      exch,
+		Asset:         a,
+		Pair:          p,
+		Rate:          validatorFundingRate,
+		PredictedRate: validatorFundingRate,
+		Time:          time.Now(),
+	}, nil
+}
+
+// FundingRateHistory validator for test execution/scripts
+func (w Wrapper) FundingRateHistory(exch string, p currency.Pair, a asset.Item, start, end time.Time) ([]futures.FundingRate, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	var rates []futures.FundingRate
+	for t := start; !t.After(end); t = t.Add(time.Hour * 8) {
+		rates = append(rates, futures.FundingRate{
+			Exchange: exch,
+			Asset:    a,
+			Pair:     p,
+			Rate:     validatorFundingRate,
+			Time:     t,
+		})
+	}
+	return rates, nil
+}
+
+// OpenInterest validator for test execution/scripts
+func (w Wrapper) OpenInterest(exch string, p currency.Pair, a asset.Item) (*futures.OpenInterest, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	return &futures.OpenInterest{
+		Exchange: exch,
+		Asset:    a,
+		Pair:     p,
+		Amount:   validatorVol,
+		Time:     time.Now(),
+	}, nil
+}
+
+// MarkPrice validator for test execution/scripts
+func (w Wrapper) MarkPrice(exch string, p currency.Pair, a asset.Item) (*futures.MarkPrice, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	return &futures.MarkPrice{
+		Exchange:   exch,
+		Asset:      a,
+		Pair:       p,
+		MarkPrice:  validatorClose,
+		IndexPrice: validatorClose,
+		Time:       time.Now(),
+	}, nil
+}
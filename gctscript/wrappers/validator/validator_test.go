@@ -247,3 +247,55 @@ func TestWrapper_OHLCV(t *testing.T) {
 		t.Fatal("expected OHLCV to return error with invalid name")
 	}
 }
+
+func TestWrapper_FundingRate(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.FundingRate(exchName, currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testWrapper.FundingRate(exchError.String(), currencyPair, asset.PerpetualSwap)
+	if err == nil {
+		t.Fatal("expected FundingRate to return error on invalid name")
+	}
+}
+
+func TestWrapper_FundingRateHistory(t *testing.T) {
+	t.Parallel()
+	end := time.Now()
+	rates, err := testWrapper.FundingRateHistory(exchName, currencyPair, asset.PerpetualSwap, end.Add(-time.Hour*24), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) == 0 {
+		t.Error("expected funding rates to be returned")
+	}
+	_, err = testWrapper.FundingRateHistory(exchError.String(), currencyPair, asset.PerpetualSwap, end.Add(-time.Hour*24), end)
+	if err == nil {
+		t.Fatal("expected FundingRateHistory to return error on invalid name")
+	}
+}
+
+func TestWrapper_OpenInterest(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.OpenInterest(exchName, currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testWrapper.OpenInterest(exchError.String(), currencyPair, asset.PerpetualSwap)
+	if err == nil {
+		t.Fatal("expected OpenInterest to return error on invalid name")
+	}
+}
+
+func TestWrapper_MarkPrice(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.MarkPrice(exchName, currencyPair, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testWrapper.MarkPrice(exchError.String(), currencyPair, asset.PerpetualSwap)
+	if err == nil {
+		t.Fatal("expected MarkPrice to return error on invalid name")
+	}
+}
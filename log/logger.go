@@ -1,7 +1,6 @@
 package log
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -21,7 +20,7 @@ func newLogger(c *Config) *Logger {
 
 func (l *Logger) newLogEvent(data, header, slName string, w io.Writer) error {
 	if w == nil {
-		return errors.New("io.Writer not set")
+		return errWriterNotSet
 	}
 
 	e := eventPool.Get().(*Event)
@@ -54,6 +53,14 @@ func CloseLogger() error {
 	if err != nil {
 		return err
 	}
+	subLoggerFilesMtx.Lock()
+	defer subLoggerFilesMtx.Unlock()
+	for _, r := range subLoggerFiles {
+		err = r.Close()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,197 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Exchange returns a field naming the exchange a log entry relates to
+func Exchange(name string) Field {
+	return Field{Key: ExchangeKey, Value: name}
+}
+
+// Pair returns a field holding the currency pair a log entry relates to
+func Pair(p fmt.Stringer) Field {
+	return Field{Key: PairKey, Value: p}
+}
+
+// Asset returns a field holding the asset type a log entry relates to
+func Asset(a fmt.Stringer) Field {
+	return Field{Key: AssetKey, Value: a}
+}
+
+// OrderID returns a field holding the order ID a log entry relates to
+func OrderID(id string) Field {
+	return Field{Key: OrderIDKey, Value: id}
+}
+
+// RequestID returns a field holding the request ID a log entry relates to
+func RequestID(id string) Field {
+	return Field{Key: RequestIDKey, Value: id}
+}
+
+// Any returns a field with a custom key
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// With returns an entry which attaches the fields to every message logged to
+// the sublogger
+func With(sl *subLogger, fields ...Field) *Entry {
+	return &Entry{sl: sl, fields: fields}
+}
+
+// With returns a copy of the entry with the fields added
+func (e *Entry) With(fields ...Field) *Entry {
+	merged := make([]Field, 0, len(e.fields)+len(fields))
+	merged = append(merged, e.fields...)
+	return &Entry{sl: e.sl, fields: append(merged, fields...)}
+}
+
+// Info logs the data with the entry fields at info level
+func (e *Entry) Info(data string) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Info {
+		return
+	}
+	e.sl.write(data, logger.InfoHeader, infoLevel, e.fields)
+}
+
+// Infof formats and logs the data with the entry fields at info level
+func (e *Entry) Infof(data string, v ...interface{}) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Info {
+		return
+	}
+	e.sl.write(fmt.Sprintf(data, v...), logger.InfoHeader, infoLevel, e.fields)
+}
+
+// Debug logs the data with the entry fields at debug level
+func (e *Entry) Debug(data string) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Debug {
+		return
+	}
+	e.sl.write(data, logger.DebugHeader, debugLevel, e.fields)
+}
+
+// Debugf formats and logs the data with the entry fields at debug level
+func (e *Entry) Debugf(data string, v ...interface{}) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Debug {
+		return
+	}
+	e.sl.write(fmt.Sprintf(data, v...), logger.DebugHeader, debugLevel, e.fields)
+}
+
+// Warn logs the data with the entry fields at warn level
+func (e *Entry) Warn(data string) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Warn {
+		return
+	}
+	e.sl.write(data, logger.WarnHeader, warnLevel, e.fields)
+}
+
+// Warnf formats and logs the data with the entry fields at warn level
+func (e *Entry) Warnf(data string, v ...interface{}) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Warn {
+		return
+	}
+	e.sl.write(fmt.Sprintf(data, v...), logger.WarnHeader, warnLevel, e.fields)
+}
+
+// Error logs the data with the entry fields at error level
+func (e *Entry) Error(data ...interface{}) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Error {
+		return
+	}
+	e.sl.write(fmt.Sprint(data...), logger.ErrorHeader, errorLevel, e.fields)
+}
+
+// Errorf formats and logs the data with the entry fields at error level
+func (e *Entry) Errorf(data string, v ...interface{}) {
+	if e == nil || e.sl == nil || !enabled() || !e.sl.Error {
+		return
+	}
+	e.sl.write(fmt.Sprintf(data, v...), logger.ErrorHeader, errorLevel, e.fields)
+}
+
+// write sends the data and fields to the sublogger output in its format
+func (sl *subLogger) write(data, header, level string, fields []Field) {
+	if sl.json {
+		displayError(logger.newJSONLogEvent(data, level, sl.name, fields, sl.output))
+		return
+	}
+	displayError(logger.newLogEvent(appendFields(data, fields), header, sl.name, sl.output))
+}
+
+// appendFields appends the fields to text output as key=value pairs ahead of
+// any trailing line break
+func appendFields(data string, fields []Field) string {
+	if len(fields) == 0 {
+		return data
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(data, "\n"))
+	sb.WriteString(logger.Spacer)
+	for i := range fields {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(fields[i].Key)
+		sb.WriteByte('=')
+		sb.WriteString(fmt.Sprint(fields[i].Value))
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+// newJSONLogEvent writes the data as a single JSON object with the standard
+// timestamp, level and sublogger keys followed by the fields
+func (l *Logger) newJSONLogEvent(data, level, slName string, fields []Field, w io.Writer) error {
+	if w == nil {
+		return errWriterNotSet
+	}
+
+	e := eventPool.Get().(*Event)
+	e.output = w
+	e.data = append(e.data, `{"timestamp":"`...)
+	e.data = time.Now().UTC().AppendFormat(e.data, time.RFC3339Nano)
+	e.data = append(e.data, `","level":"`...)
+	e.data = append(e.data, level...)
+	e.data = append(e.data, `","sublogger":`...)
+	e.data = appendJSONValue(e.data, slName)
+	for i := range fields {
+		if fields[i].Key == "" {
+			continue
+		}
+		e.data = append(e.data, ',')
+		e.data = appendJSONValue(e.data, fields[i].Key)
+		e.data = append(e.data, ':')
+		e.data = appendJSONValue(e.data, fields[i].Value)
+	}
+	e.data = append(e.data, `,"message":`...)
+	e.data = appendJSONValue(e.data, strings.TrimRight(data, "\n"))
+	e.data = append(e.data, "}\n"...)
+	_, err := e.output.Write(e.data)
+
+	e.data = e.data[:0]
+	eventPool.Put(e)
+
+	return err
+}
+
+// appendJSONValue appends the JSON encoding of the value, values which
+// implement fmt.Stringer are encoded as their string
+func appendJSONValue(dst []byte, v interface{}) []byte {
+	switch val := v.(type) {
+	case error:
+		v = val.Error()
+	case fmt.Stringer:
+		v = val.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return append(dst, b...)
+}
@@ -1,9 +1,14 @@
 package log
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -29,12 +34,11 @@ func (r *Rotate) Write(output []byte) (n int, err error) {
 		}
 	}
 
-	if *r.Rotate {
-		if r.size+outputLen > r.maxSize() {
-			err = r.rotateFile()
-			if err != nil {
-				return 0, err
-			}
+	if (r.Rotate != nil && *r.Rotate && r.size+outputLen > r.maxSize()) ||
+		(r.RotateDaily && r.size > 0 && !sameDay(r.opened, time.Now())) {
+		err = r.rotateFile()
+		if err != nil {
+			return 0, err
 		}
 	}
 
@@ -55,7 +59,7 @@ func (r *Rotate) openOrCreateFile(n int64) error {
 		return fmt.Errorf("error opening log file info: %s", err)
 	}
 
-	if *r.Rotate {
+	if r.Rotate != nil && *r.Rotate {
 		if info.Size()+n >= r.maxSize() {
 			return r.rotateFile()
 		}
@@ -68,6 +72,7 @@ func (r *Rotate) openOrCreateFile(n int64) error {
 
 	r.output = file
 	r.size = info.Size()
+	r.opened = info.ModTime()
 
 	return nil
 }
@@ -77,13 +82,14 @@ func (r *Rotate) openNew() error {
 	_, err := os.Stat(name)
 
 	if err == nil {
-		timestamp := time.Now().Format("2006-01-02T15-04-05")
+		timestamp := time.Now().Format(rotatedTimestampFormat)
 		newName := filepath.Join(LogPath, timestamp+"-"+r.FileName)
 
 		err = file.Move(name, newName)
 		if err != nil {
 			return fmt.Errorf("can't rename log file: %s", err)
 		}
+		r.archiveRotated(newName)
 	}
 
 	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
@@ -93,10 +99,106 @@ func (r *Rotate) openNew() error {
 
 	r.output = file
 	r.size = 0
+	r.opened = time.Now()
 
 	return nil
 }
 
+// archiveRotated compresses the rotated file and removes the oldest rotated
+// files beyond the backup limit in the background so writes are not blocked
+func (r *Rotate) archiveRotated(name string) {
+	if !r.Compress && r.MaxBackups <= 0 {
+		return
+	}
+	r.archiveWG.Add(1)
+	go func() {
+		defer r.archiveWG.Done()
+		r.archive.Lock()
+		defer r.archive.Unlock()
+		if r.Compress {
+			displayError(compressFile(name))
+		}
+		if r.MaxBackups > 0 {
+			displayError(r.removeOldBackups())
+		}
+	}()
+}
+
+// compressFile replaces the file with a gzip compressed copy
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("can't compress rotated log file: %w", err)
+	}
+	src.Close()
+	return os.Remove(name)
+}
+
+// removeOldBackups removes the oldest rotated files so at most MaxBackups
+// remain
+func (r *Rotate) removeOldBackups() error {
+	backups, err := r.backups()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(backups)-r.MaxBackups; i++ {
+		err = os.Remove(filepath.Join(LogPath, backups[i]))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// backups returns the names of the rotated files, oldest first
+func (r *Rotate) backups() ([]string, error) {
+	entries, err := ioutil.ReadDir(LogPath)
+	if err != nil {
+		return nil, err
+	}
+	suffix := "-" + r.FileName
+	var backups []string
+	for i := range entries {
+		name := entries[i].Name()
+		trimmed := strings.TrimSuffix(name, ".gz")
+		if entries[i].IsDir() ||
+			len(trimmed) != len(rotatedTimestampFormat)+len(suffix) ||
+			!strings.HasSuffix(trimmed, suffix) {
+			continue
+		}
+		if _, err := time.Parse(rotatedTimestampFormat, trimmed[:len(rotatedTimestampFormat)]); err != nil {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	// The timestamp prefix sorts rotated files by age
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// sameDay returns whether the times fall on the same local calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
 func (r *Rotate) close() (err error) {
 	if r.output == nil {
 		return nil
@@ -106,10 +208,11 @@ func (r *Rotate) close() (err error) {
 	return err
 }
 
-// Close handler for open file
+// Close handler for open file, waits for rotated files to be archived
 func (r *Rotate) Close() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.archiveWG.Wait()
 	return r.close()
 }
 
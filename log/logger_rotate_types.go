@@ -3,20 +3,31 @@ package log
 import (
 	"os"
 	"sync"
+	"time"
 )
 
 const (
 	defaultMaxSize = 250
 	megabyte       = 1024 * 1024
+	// rotatedTimestampFormat prefixes the names of rotated files
+	rotatedTimestampFormat = "2006-01-02T15-04-05"
 )
 
 // Rotate struct for each instance of Rotate
 type Rotate struct {
-	FileName string
-	Rotate   *bool
-	MaxSize  int64
+	FileName    string
+	Rotate      *bool
+	MaxSize     int64
+	RotateDaily bool
+	MaxBackups  int
+	Compress    bool
 
 	size   int64
+	opened time.Time
 	output *os.File
 	mu     sync.Mutex
+
+	// archive serialises compression and removal of rotated files
+	archive   sync.Mutex
+	archiveWG sync.WaitGroup
 }
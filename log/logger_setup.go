@@ -22,7 +22,9 @@ func getWriters(s *SubLoggerConfig) io.Writer {
 		case "stderr":
 			m.Add(os.Stderr)
 		case "file":
-			if FileLoggingConfiguredCorrectly {
+			if s.FileSettings != nil {
+				m.Add(fileWriter(s.FileSettings))
+			} else if FileLoggingConfiguredCorrectly {
 				m.Add(GlobalLogFile)
 			}
 		default:
@@ -40,7 +42,7 @@ func GenDefaultSettings() (log Config) {
 			Level:  "INFO|DEBUG|WARN|ERROR",
 			Output: "console",
 		},
-		LoggerFileConfig: &loggerFileConfig{
+		LoggerFileConfig: &FileConfig{
 			FileName: "log.txt",
 			Rotate:   convert.BoolPtr(false),
 			MaxSize:  0,
@@ -60,6 +62,38 @@ func GenDefaultSettings() (log Config) {
 	return
 }
 
+// newRotate returns a rotating file writer for the file settings
+func newRotate(c *FileConfig) *Rotate {
+	rotate := c.Rotate
+	if rotate == nil {
+		rotate = new(bool)
+	}
+	return &Rotate{
+		FileName:    c.FileName,
+		Rotate:      rotate,
+		MaxSize:     c.MaxSize,
+		RotateDaily: c.RotateDaily,
+		MaxBackups:  c.MaxBackups,
+		Compress:    c.Compress,
+	}
+}
+
+// fileWriter returns the rotating file writer of a sublogger file, subloggers
+// which name the same file share a writer
+func fileWriter(c *FileConfig) io.Writer {
+	if FileLoggingConfiguredCorrectly && c.FileName == GlobalLogFile.FileName {
+		return GlobalLogFile
+	}
+	subLoggerFilesMtx.Lock()
+	defer subLoggerFilesMtx.Unlock()
+	if r, ok := subLoggerFiles[c.FileName]; ok {
+		return r
+	}
+	r := newRotate(c)
+	subLoggerFiles[c.FileName] = r
+	return r
+}
+
 func configureSubLogger(logger, levels string, output io.Writer) error {
 	found, logPtr := validSubLogger(logger)
 	if !found {
@@ -78,10 +112,14 @@ func configureSubLogger(logger, levels string, output io.Writer) error {
 func SetupSubLoggers(s []SubLoggerConfig) {
 	for x := range s {
 		output := getWriters(&s[x])
-		err := configureSubLogger(strings.ToUpper(s[x].Name), s[x].Level, output)
+		name := strings.ToUpper(s[x].Name)
+		err := configureSubLogger(name, s[x].Level, output)
 		if err != nil {
 			continue
 		}
+		if s[x].Format != "" {
+			subLoggers[name].json = isJSONFormat(s[x].Format)
+		}
 	}
 }
 
@@ -89,22 +127,33 @@ func SetupSubLoggers(s []SubLoggerConfig) {
 func SetupGlobalLogger() {
 	RWM.Lock()
 	if FileLoggingConfiguredCorrectly {
-		GlobalLogFile = &Rotate{
-			FileName: GlobalLogConfig.LoggerFileConfig.FileName,
-			MaxSize:  GlobalLogConfig.LoggerFileConfig.MaxSize,
-			Rotate:   GlobalLogConfig.LoggerFileConfig.Rotate,
-		}
+		GlobalLogFile = newRotate(GlobalLogConfig.LoggerFileConfig)
 	}
 
 	for x := range subLoggers {
 		subLoggers[x].Levels = splitLevel(GlobalLogConfig.Level)
 		subLoggers[x].output = getWriters(&GlobalLogConfig.SubLoggerConfig)
+		subLoggers[x].json = isJSONFormat(GlobalLogConfig.Format)
 	}
 
 	logger = newLogger(GlobalLogConfig)
 	RWM.Unlock()
 }
 
+// ValidFormat returns whether the output format is supported, an empty format
+// defaults to text
+func ValidFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "", FormatText, FormatJSON:
+		return true
+	}
+	return false
+}
+
+func isJSONFormat(format string) bool {
+	return strings.EqualFold(format, FormatJSON)
+}
+
 func splitLevel(level string) (l Levels) {
 	enabledLevels := strings.Split(level, "|")
 	for x := range enabledLevels {
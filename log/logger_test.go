@@ -2,8 +2,11 @@ package log
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -225,9 +228,9 @@ func TestInfo(t *testing.T) {
 	w := &bytes.Buffer{}
 
 	tempSL := subLogger{
-		"TESTYMCTESTALOT",
-		splitLevel("INFO|WARN|DEBUG|ERROR"),
-		w,
+		name:   "TESTYMCTESTALOT",
+		Levels: splitLevel("INFO|WARN|DEBUG|ERROR"),
+		output: w,
 	}
 
 	Info(&tempSL, "Hello")
@@ -262,3 +265,135 @@ func TestSubLoggerName(t *testing.T) {
 		t.Error("Unexpected SUBLOGGER in output")
 	}
 }
+
+func TestJSONFormat(t *testing.T) {
+	w := &bytes.Buffer{}
+	sl := subLogger{
+		name:   "JSONTEST",
+		Levels: splitLevel("INFO|WARN|DEBUG|ERROR"),
+		output: w,
+		json:   true,
+	}
+
+	With(&sl, Exchange("Binance"), OrderID("1337")).
+		With(RequestID("42")).
+		Infof("order %s\n", "placed")
+	var entry map[string]string
+	if err := json.Unmarshal(w.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "info" ||
+		entry["sublogger"] != "JSONTEST" ||
+		entry[ExchangeKey] != "Binance" ||
+		entry[OrderIDKey] != "1337" ||
+		entry[RequestIDKey] != "42" ||
+		entry["message"] != "order placed" ||
+		entry["timestamp"] == "" {
+		t.Errorf("unexpected JSON log entry %v", entry)
+	}
+
+	w.Reset()
+	sl.json = false
+	With(&sl, Exchange("Binance")).Warn("text\n")
+	if !strings.HasSuffix(w.String(), "text"+logger.Spacer+"exchange=Binance\n") {
+		t.Errorf("expected fields appended to text output, received %q", w.String())
+	}
+
+	w.Reset()
+	sl.Levels = splitLevel("ERROR")
+	With(&sl, Exchange("Binance")).Debug("hidden")
+	if w.Len() != 0 {
+		t.Error("expected disabled level to be skipped")
+	}
+}
+
+func TestValidFormat(t *testing.T) {
+	if !ValidFormat("") || !ValidFormat("JSON") || ValidFormat("xml") {
+		t.Error("unexpected format validation")
+	}
+}
+
+func TestRotateDaily(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	logPath := LogPath
+	LogPath = dir
+	defer func() { LogPath = logPath }()
+
+	for _, ts := range []string{"2020-01-01T00-00-00", "2020-01-02T00-00-00"} {
+		err = ioutil.WriteFile(filepath.Join(dir, ts+"-test.log.gz"), nil, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Rotate{
+		FileName:    "test.log",
+		Rotate:      convert.BoolPtr(false),
+		RotateDaily: true,
+		MaxBackups:  2,
+		Compress:    true,
+	}
+	if _, err = r.Write([]byte("yesterday\n")); err != nil {
+		t.Fatal(err)
+	}
+	r.opened = r.opened.AddDate(0, 0, -1)
+	if _, err = r.Write([]byte("today\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 ||
+		backups[0] != "2020-01-02T00-00-00-test.log.gz" ||
+		!strings.HasSuffix(backups[1], "-test.log.gz") {
+		t.Fatalf("expected the oldest backup to be removed, received %v", backups)
+	}
+
+	f, err := os.Open(filepath.Join(dir, backups[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := ioutil.ReadFile(filepath.Join(dir, "test.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rotated) != "yesterday\n" || string(current) != "today\n" {
+		t.Errorf("unexpected rotated %q and current %q contents", rotated, current)
+	}
+}
+
+func TestSubLoggerFileSettings(t *testing.T) {
+	c := &SubLoggerConfig{
+		Output:       "file|console",
+		FileSettings: &FileConfig{FileName: "exchange.log"},
+	}
+	w := getWriters(c).(*multiWriter)
+	if len(w.writers) != 2 {
+		t.Fatalf("expected file and console writers, received %d", len(w.writers))
+	}
+	r, ok := w.writers[0].(*Rotate)
+	if !ok || r.FileName != "exchange.log" || r == GlobalLogFile {
+		t.Fatal("expected sublogger file writer")
+	}
+	if fileWriter(&FileConfig{FileName: "exchange.log"}) != r {
+		t.Error("expected subloggers naming the same file to share a writer")
+	}
+}
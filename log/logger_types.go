@@ -1,6 +1,7 @@
 package log
 
 import (
+	"errors"
 	"io"
 	"sync"
 )
@@ -10,9 +11,31 @@ const (
 	spacer          = " | "
 	// DefaultMaxFileSize for logger rotation file
 	DefaultMaxFileSize int64 = 100
+
+	// FormatText writes log entries as lines of text with the configured
+	// headers and spacers
+	FormatText = "text"
+	// FormatJSON writes log entries as JSON objects, one per line
+	FormatJSON = "json"
+
+	infoLevel  = "info"
+	warnLevel  = "warn"
+	debugLevel = "debug"
+	errorLevel = "error"
+)
+
+// Standard keys of contextual fields
+const (
+	ExchangeKey  = "exchange"
+	PairKey      = "pair"
+	AssetKey     = "asset"
+	OrderIDKey   = "order_id"
+	RequestIDKey = "request_id"
 )
 
 var (
+	errWriterNotSet = errors.New("io.Writer not set")
+
 	logger = &Logger{}
 	// FileLoggingConfiguredCorrectly flag set during config check if file logging meets requirements
 	FileLoggingConfiguredCorrectly bool
@@ -21,6 +44,9 @@ var (
 	// GlobalLogFile hold global configuration options for file logger
 	GlobalLogFile = &Rotate{}
 
+	subLoggerFiles    = map[string]*Rotate{}
+	subLoggerFilesMtx sync.Mutex
+
 	eventPool = &sync.Pool{
 		New: func() interface{} {
 			return &Event{
@@ -40,7 +66,7 @@ var (
 type Config struct {
 	Enabled *bool `json:"enabled"`
 	SubLoggerConfig
-	LoggerFileConfig *loggerFileConfig `json:"fileSettings,omitempty"`
+	LoggerFileConfig *FileConfig       `json:"fileSettings,omitempty"`
 	AdvancedSettings advancedSettings  `json:"advancedSettings"`
 	SubLoggers       []SubLoggerConfig `json:"subloggers,omitempty"`
 }
@@ -59,17 +85,28 @@ type headers struct {
 	Error string `json:"error"`
 }
 
-// SubLoggerConfig holds sub logger configuration settings loaded from bot config
+// SubLoggerConfig holds sub logger configuration settings loaded from bot config.
+// A sublogger with file settings writes its file output to its own file
+// instead of the global log file
 type SubLoggerConfig struct {
-	Name   string `json:"name,omitempty"`
-	Level  string `json:"level"`
-	Output string `json:"output"`
+	Name         string      `json:"name,omitempty"`
+	Level        string      `json:"level"`
+	Output       string      `json:"output"`
+	Format       string      `json:"format,omitempty"`
+	FileSettings *FileConfig `json:"fileSettings,omitempty"`
 }
 
-type loggerFileConfig struct {
-	FileName string `json:"filename,omitempty"`
-	Rotate   *bool  `json:"rotate,omitempty"`
-	MaxSize  int64  `json:"maxsize,omitempty"`
+// FileConfig holds log file settings. Files are rotated when they exceed
+// MaxSize megabytes and, with RotateDaily, at the start of each day. Rotated
+// files are gzip compressed with Compress and only the newest MaxBackups are
+// kept, zero keeps every rotated file
+type FileConfig struct {
+	FileName    string `json:"filename,omitempty"`
+	Rotate      *bool  `json:"rotate,omitempty"`
+	MaxSize     int64  `json:"maxsize,omitempty"`
+	RotateDaily bool   `json:"rotateDaily,omitempty"`
+	MaxBackups  int    `json:"maxBackups,omitempty"`
+	Compress    bool   `json:"compress,omitempty"`
 }
 
 // Logger each instance of logger settings
@@ -89,6 +126,19 @@ type subLogger struct {
 	name string
 	Levels
 	output io.Writer
+	json   bool
+}
+
+// Field is a key value pair attached to a log entry for context
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Entry logs to a sublogger with contextual fields attached to each message
+type Entry struct {
+	sl     *subLogger
+	fields []Field
 }
 
 // Event holds the data sent to the log and which multiwriter to send to
@@ -15,7 +15,7 @@ func Info(sl *subLogger, data string) {
 		return
 	}
 
-	displayError(logger.newLogEvent(data, logger.InfoHeader, sl.name, sl.output))
+	sl.write(data, logger.InfoHeader, infoLevel, nil)
 }
 
 // Infoln takes a pointer subLogger struct and interface sends to newLogEvent
@@ -28,7 +28,7 @@ func Infoln(sl *subLogger, v ...interface{}) {
 		return
 	}
 
-	displayError(logger.newLogEvent(fmt.Sprintln(v...), logger.InfoHeader, sl.name, sl.output))
+	sl.write(fmt.Sprintln(v...), logger.InfoHeader, infoLevel, nil)
 }
 
 // Infof takes a pointer subLogger struct, string & interface formats and sends to Info()
@@ -54,7 +54,7 @@ func Debug(sl *subLogger, data string) {
 		return
 	}
 
-	displayError(logger.newLogEvent(data, logger.DebugHeader, sl.name, sl.output))
+	sl.write(data, logger.DebugHeader, debugLevel, nil)
 }
 
 // Debugln  takes a pointer subLogger struct, string and interface sends to newLogEvent
@@ -67,7 +67,7 @@ func Debugln(sl *subLogger, v ...interface{}) {
 		return
 	}
 
-	displayError(logger.newLogEvent(fmt.Sprintln(v...), logger.DebugHeader, sl.name, sl.output))
+	sl.write(fmt.Sprintln(v...), logger.DebugHeader, debugLevel, nil)
 }
 
 // Debugf takes a pointer subLogger struct, string & interface formats and sends to Info()
@@ -93,7 +93,7 @@ func Warn(sl *subLogger, data string) {
 		return
 	}
 
-	displayError(logger.newLogEvent(data, logger.WarnHeader, sl.name, sl.output))
+	sl.write(data, logger.WarnHeader, warnLevel, nil)
 }
 
 // Warnln takes a pointer subLogger struct & interface formats and sends to newLogEvent()
@@ -106,7 +106,7 @@ func Warnln(sl *subLogger, v ...interface{}) {
 		return
 	}
 
-	displayError(logger.newLogEvent(fmt.Sprintln(v...), logger.WarnHeader, sl.name, sl.output))
+	sl.write(fmt.Sprintln(v...), logger.WarnHeader, warnLevel, nil)
 }
 
 // Warnf takes a pointer subLogger struct, string & interface formats and sends to Warn()
@@ -132,7 +132,7 @@ func Error(sl *subLogger, data ...interface{}) {
 		return
 	}
 
-	displayError(logger.newLogEvent(fmt.Sprint(data...), logger.ErrorHeader, sl.name, sl.output))
+	sl.write(fmt.Sprint(data...), logger.ErrorHeader, errorLevel, nil)
 }
 
 // Errorln takes a pointer subLogger struct, string & interface formats and sends to newLogEvent()
@@ -145,7 +145,7 @@ func Errorln(sl *subLogger, v ...interface{}) {
 		return
 	}
 
-	displayError(logger.newLogEvent(fmt.Sprintln(v...), logger.ErrorHeader, sl.name, sl.output))
+	sl.write(fmt.Sprintln(v...), logger.ErrorHeader, errorLevel, nil)
 }
 
 // Errorf takes a pointer subLogger struct, string & interface formats and sends to Debug()
@@ -134,6 +134,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	go func() {
+		for sig := range signaler.ReloadRequested() {
+			gctlog.Infof(gctlog.Global, "Captured %v, config reload requested.\n", sig)
+			if _, err := engine.Bot.ReloadConfig(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Unable to reload config. Error: %s\n", err)
+			}
+		}
+	}()
+
 	interrupt := signaler.WaitForInterrupt()
 	gctlog.Infof(gctlog.Global, "Captured %v, shutdown requested.\n", interrupt)
 	engine.Bot.Stop()
@@ -0,0 +1,307 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// register adds the family, metric names must be unique
+func (r *Registry) register(f family) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.names[f.name()] {
+		panic(fmt.Sprintf("metrics: %s registered twice", f.name()))
+	}
+	r.names[f.name()] = true
+	r.families = append(r.families, f)
+}
+
+// NewCounterVec registers a counter with the label names
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{vec: newVec(name, help, typeCounter, labels)}
+	r.register(c)
+	return c
+}
+
+// NewGaugeVec registers a gauge with the label names
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{vec: newVec(name, help, typeGauge, labels)}
+	r.register(g)
+	return g
+}
+
+// NewHistogramVec registers a histogram with the bucket upper bounds and label
+// names, DefaultBuckets are used when no buckets are supplied
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	h := &HistogramVec{vec: newVec(name, help, typeHistogram, labels), buckets: sorted}
+	r.register(h)
+	return h
+}
+
+// NewGaugeFunc registers a gauge whose series are returned by collect each
+// time metrics are written
+func (r *Registry) NewGaugeFunc(name, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	g := &GaugeFunc{metricName: name, help: help, labels: labels, collect: collect}
+	r.register(g)
+	return g
+}
+
+// NewCounterVec registers a counter with the default registry
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return defaultRegistry.NewCounterVec(name, help, labels...)
+}
+
+// NewGaugeVec registers a gauge with the default registry
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return defaultRegistry.NewGaugeVec(name, help, labels...)
+}
+
+// NewHistogramVec registers a histogram with the default registry
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return defaultRegistry.NewHistogramVec(name, help, buckets, labels...)
+}
+
+// NewGaugeFunc registers a collected gauge with the default registry
+func NewGaugeFunc(name, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	return defaultRegistry.NewGaugeFunc(name, help, labels, collect)
+}
+
+// Handler returns a HTTP handler serving the default registry
+func Handler() http.Handler {
+	return defaultRegistry
+}
+
+// ServeHTTP writes every registered metric in the text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, err := fmt.Fprint(w, r.String())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// String returns every registered metric in the text exposition format
+func (r *Registry) String() string {
+	r.m.Lock()
+	families := append([]family(nil), r.families...)
+	r.m.Unlock()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name() < families[j].name()
+	})
+
+	tw := &textWriter{}
+	for i := range families {
+		families[i].write(tw)
+	}
+	return tw.String()
+}
+
+func newVec(name, help, metricType string, labels []string) vec {
+	return vec{
+		metricName: name,
+		help:       help,
+		metricType: metricType,
+		labels:     labels,
+		series:     make(map[string]*series),
+	}
+}
+
+func (v *vec) name() string {
+	return v.metricName
+}
+
+// with returns the metric for the label values, creating it with newMetric
+// when it does not exist
+func (v *vec) with(values []string, newMetric func() interface{}) interface{} {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s %v", v.metricName, errLabelCount))
+	}
+	key := strings.Join(values, "\xff")
+	v.m.RLock()
+	s, ok := v.series[key]
+	v.m.RUnlock()
+	if ok {
+		return s.metric
+	}
+
+	v.m.Lock()
+	defer v.m.Unlock()
+	if s, ok = v.series[key]; ok {
+		return s.metric
+	}
+	s = &series{
+		labelValues: append([]string(nil), values...),
+		metric:      newMetric(),
+	}
+	v.series[key] = s
+	return s.metric
+}
+
+// sortedSeries returns the series ordered by label values
+func (v *vec) sortedSeries() []*series {
+	v.m.RLock()
+	resp := make([]*series, 0, len(v.series))
+	for _, s := range v.series {
+		resp = append(resp, s)
+	}
+	v.m.RUnlock()
+	sort.Slice(resp, func(i, j int) bool {
+		return strings.Join(resp[i].labelValues, "\xff") < strings.Join(resp[j].labelValues, "\xff")
+	})
+	return resp
+}
+
+// WithLabelValues returns the counter for the label values
+func (c *CounterVec) WithLabelValues(values ...string) *Counter {
+	return c.with(values, func() interface{} { return new(Counter) }).(*Counter)
+}
+
+func (c *CounterVec) write(w *textWriter) {
+	w.header(c.metricName, c.help, c.metricType)
+	for _, s := range c.sortedSeries() {
+		w.sample(c.metricName, c.labels, s.labelValues, "", "", s.metric.(*Counter).Value())
+	}
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increases the counter, negative values are ignored
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	addFloat(&c.bits, v)
+}
+
+// Value returns the counter value
+func (c *Counter) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&c.bits))
+}
+
+// WithLabelValues returns the gauge for the label values
+func (g *GaugeVec) WithLabelValues(values ...string) *Gauge {
+	return g.with(values, func() interface{} { return new(Gauge) }).(*Gauge)
+}
+
+func (g *GaugeVec) write(w *textWriter) {
+	w.header(g.metricName, g.help, g.metricType)
+	for _, s := range g.sortedSeries() {
+		w.sample(g.metricName, g.labels, s.labelValues, "", "", s.metric.(*Gauge).Value())
+	}
+}
+
+// Set sets the gauge value
+func (g *Gauge) Set(v float64) {
+	atomic.StoreUint64(&g.bits, math.Float64bits(v))
+}
+
+// Inc increments the gauge by one
+func (g *Gauge) Inc() {
+	addFloat(&g.bits, 1)
+}
+
+// Dec decrements the gauge by one
+func (g *Gauge) Dec() {
+	addFloat(&g.bits, -1)
+}
+
+// Add adds to the gauge value
+func (g *Gauge) Add(v float64) {
+	addFloat(&g.bits, v)
+}
+
+// Value returns the gauge value
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&g.bits))
+}
+
+// WithLabelValues returns the histogram for the label values
+func (h *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	return h.with(values, func() interface{} {
+		return &Histogram{upper: h.buckets, counts: make([]uint64, len(h.buckets))}
+	}).(*Histogram)
+}
+
+func (h *HistogramVec) write(w *textWriter) {
+	w.header(h.metricName, h.help, h.metricType)
+	for _, s := range h.sortedSeries() {
+		hist := s.metric.(*Histogram)
+		hist.m.Lock()
+		var cumulative uint64
+		for i := range hist.upper {
+			cumulative += hist.counts[i]
+			w.sample(h.metricName+"_bucket", h.labels, s.labelValues, "le", formatFloat(hist.upper[i]), float64(cumulative))
+		}
+		w.sample(h.metricName+"_bucket", h.labels, s.labelValues, "le", "+Inf", float64(hist.count))
+		w.sample(h.metricName+"_sum", h.labels, s.labelValues, "", "", hist.sum)
+		w.sample(h.metricName+"_count", h.labels, s.labelValues, "", "", float64(hist.count))
+		hist.m.Unlock()
+	}
+}
+
+// Observe records a value
+func (h *Histogram) Observe(v float64) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	h.sum += v
+	h.count++
+	for i := range h.upper {
+		if v <= h.upper[i] {
+			h.counts[i]++
+			return
+		}
+	}
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() uint64 {
+	h.m.Lock()
+	defer h.m.Unlock()
+	return h.count
+}
+
+func (g *GaugeFunc) name() string {
+	return g.metricName
+}
+
+func (g *GaugeFunc) write(w *textWriter) {
+	w.header(g.metricName, g.help, typeGauge)
+	samples := g.collect()
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].LabelValues, "\xff") < strings.Join(samples[j].LabelValues, "\xff")
+	})
+	for i := range samples {
+		if len(samples[i].LabelValues) != len(g.labels) {
+			continue
+		}
+		w.sample(g.metricName, g.labels, samples[i].LabelValues, "", "", samples[i].Value)
+	}
+}
+
+// addFloat atomically adds to a float64 stored as bits
+func addFloat(bits *uint64, v float64) {
+	for {
+		old := atomic.LoadUint64(bits)
+		updated := math.Float64bits(math.Float64frombits(old) + v)
+		if atomic.CompareAndSwapUint64(bits, old, updated) {
+			return
+		}
+	}
+}
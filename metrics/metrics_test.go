@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "A test counter", "exchange")
+	c.WithLabelValues("Bitstamp").Inc()
+	c.WithLabelValues("Bitstamp").Add(2)
+	c.WithLabelValues("Bitstamp").Add(-1)
+	c.WithLabelValues(`Quote"d`).Inc()
+
+	if v := c.WithLabelValues("Bitstamp").Value(); v != 3 {
+		t.Errorf("expected 3, received %v", v)
+	}
+	expected := `# HELP test_total A test counter
+# TYPE test_total counter
+test_total{exchange="Bitstamp"} 3
+test_total{exchange="Quote\"d"} 1
+`
+	if out := r.String(); out != expected {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	g := r.NewGaugeVec("test_gauge", "A test gauge")
+	g.WithLabelValues().Set(5)
+	g.WithLabelValues().Dec()
+	g.WithLabelValues().Add(0.5)
+	if v := g.WithLabelValues().Value(); v != 4.5 {
+		t.Errorf("expected 4.5, received %v", v)
+	}
+	if !strings.Contains(r.String(), "test_gauge 4.5\n") {
+		t.Errorf("unexpected output:\n%s", r.String())
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_seconds", "A test histogram", []float64{1, 0.1}, "endpoint")
+	h.WithLabelValues("/ticker").Observe(0.05)
+	h.WithLabelValues("/ticker").Observe(0.5)
+	h.WithLabelValues("/ticker").Observe(2)
+	if c := h.WithLabelValues("/ticker").Count(); c != 3 {
+		t.Errorf("expected 3, received %v", c)
+	}
+	expected := `# HELP test_seconds A test histogram
+# TYPE test_seconds histogram
+test_seconds_bucket{endpoint="/ticker",le="0.1"} 1
+test_seconds_bucket{endpoint="/ticker",le="1"} 2
+test_seconds_bucket{endpoint="/ticker",le="+Inf"} 3
+test_seconds_sum{endpoint="/ticker"} 2.55
+test_seconds_count{endpoint="/ticker"} 3
+`
+	if out := r.String(); out != expected {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.NewGaugeFunc("test_func", "A collected gauge", []string{"asset"}, func() []Sample {
+		return []Sample{
+			{LabelValues: []string{"spot"}, Value: 2},
+			{LabelValues: []string{"futures", "extra"}, Value: 1},
+		}
+	})
+	if !strings.Contains(r.String(), "test_func{asset=\"spot\"} 2\n") ||
+		strings.Contains(r.String(), "futures") {
+		t.Errorf("unexpected output:\n%s", r.String())
+	}
+}
+
+func TestRegistryPanics(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	c := r.NewCounterVec("dupe_total", "", "a")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected duplicate registration panic")
+			}
+		}()
+		r.NewGaugeVec("dupe_total", "")
+	}()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected label count panic")
+			}
+		}()
+		c.WithLabelValues("a", "b")
+	}()
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.NewCounterVec("served_total", "Served").WithLabelValues().Inc()
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if resp.Code != http.StatusOK ||
+		!strings.HasPrefix(resp.Header().Get("Content-Type"), "text/plain") ||
+		!strings.Contains(resp.Body.String(), "served_total 1") {
+		t.Errorf("unexpected response %d %s", resp.Code, resp.Body.String())
+	}
+}
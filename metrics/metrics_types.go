@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+)
+
+// Metric types as named in the Prometheus text exposition format
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// DefaultBuckets are histogram buckets in seconds suited to network latency
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var (
+	errLabelCount = errors.New("label value count does not match label names")
+
+	defaultRegistry = NewRegistry()
+)
+
+// Registry holds metric families and writes them in the Prometheus text
+// exposition format
+type Registry struct {
+	m        sync.Mutex
+	families []family
+	names    map[string]bool
+}
+
+// family is a named metric with a series per set of label values
+type family interface {
+	name() string
+	write(w *textWriter)
+}
+
+// Sample is a single series value returned by a GaugeFunc
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// vec holds the series of a metric family keyed by their label values
+type vec struct {
+	metricName string
+	help       string
+	metricType string
+	labels     []string
+
+	m      sync.RWMutex
+	series map[string]*series
+}
+
+// series is a single labelled time series
+type series struct {
+	labelValues []string
+	metric      interface{}
+}
+
+// CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	vec
+}
+
+// Counter is a monotonically increasing value
+type Counter struct {
+	bits uint64
+}
+
+// GaugeVec is a gauge partitioned by label values
+type GaugeVec struct {
+	vec
+}
+
+// Gauge is a value which can go up and down
+type Gauge struct {
+	bits uint64
+}
+
+// HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	vec
+	buckets []float64
+}
+
+// Histogram counts observations into cumulative buckets
+type Histogram struct {
+	m      sync.Mutex
+	upper  []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// GaugeFunc is a gauge whose series are collected when metrics are written
+type GaugeFunc struct {
+	metricName string
+	help       string
+	labels     []string
+	collect    func() []Sample
+}
@@ -0,0 +1,175 @@
+package rebalance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dustThreshold is the amount below which an imbalance is treated as settled
+// to prevent float rounding producing endless tiny transfers
+const dustThreshold = 1e-8
+
+// Validate checks that the target is well formed
+func (t *Target) Validate() error {
+	if t.Currency.IsEmpty() {
+		return errCurrencyUnset
+	}
+	if t.MinimumTransfer < 0 {
+		return fmt.Errorf("%s %w", t.Currency, errNegativeMinimum)
+	}
+	if len(t.Allocations) < 2 {
+		return fmt.Errorf("%s %w", t.Currency, errNoAllocations)
+	}
+	seen := make(map[string]bool)
+	for i := range t.Allocations {
+		if t.Allocations[i].Exchange == "" {
+			return fmt.Errorf("%s %w", t.Currency, errExchangeNameUnset)
+		}
+		if t.Allocations[i].Weight <= 0 {
+			return fmt.Errorf("%s %s %w",
+				t.Currency,
+				t.Allocations[i].Exchange,
+				errInvalidWeight)
+		}
+		name := strings.ToLower(t.Allocations[i].Exchange)
+		if seen[name] {
+			return fmt.Errorf("%s %s %w",
+				t.Currency,
+				t.Allocations[i].Exchange,
+				errDuplicateExchange)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// ValidateTargets checks that every target is well formed and that each
+// currency is only targeted once
+func ValidateTargets(targets []Target) error {
+	if len(targets) == 0 {
+		return errNoTargets
+	}
+	seen := make(map[string]bool)
+	for i := range targets {
+		err := targets[i].Validate()
+		if err != nil {
+			return err
+		}
+		c := targets[i].Currency.Upper().String()
+		if seen[c] {
+			return fmt.Errorf("%s %w", c, errDuplicateCurrency)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// Plan computes the transfers required to move balances towards their target
+// allocations. The largest surplus is matched against the largest deficit
+// first, with the withdrawal fee added to the amount sent so the destination
+// receives what it is short of where the surplus allows
+func Plan(targets []Target, balances []Balance, fees FeeLookup) ([]Transfer, error) {
+	err := ValidateTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+	var transfers []Transfer
+	for i := range targets {
+		t, err := planTarget(&targets[i], balances, fees)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t...)
+	}
+	return transfers, nil
+}
+
+func planTarget(t *Target, balances []Balance, fees FeeLookup) ([]Transfer, error) {
+	held := make(map[string]float64)
+	var total, totalWeight float64
+	for i := range t.Allocations {
+		held[strings.ToLower(t.Allocations[i].Exchange)] = 0
+		totalWeight += t.Allocations[i].Weight
+	}
+	for i := range balances {
+		name := strings.ToLower(balances[i].Exchange)
+		if _, ok := held[name]; !ok || !balances[i].Currency.Match(t.Currency) {
+			continue
+		}
+		held[name] += balances[i].Amount
+		total += balances[i].Amount
+	}
+	if total <= 0 {
+		return nil, nil
+	}
+
+	var surpluses, deficits []imbalance
+	for i := range t.Allocations {
+		name := strings.ToLower(t.Allocations[i].Exchange)
+		diff := held[name] - total*t.Allocations[i].Weight/totalWeight
+		switch {
+		case diff > dustThreshold:
+			surpluses = append(surpluses, imbalance{exchange: name, amount: diff})
+		case diff < -dustThreshold:
+			deficits = append(deficits, imbalance{exchange: name, amount: -diff})
+		}
+	}
+	sortImbalances(surpluses)
+	sortImbalances(deficits)
+
+	var transfers []Transfer
+	for s, d := 0, 0; s < len(surpluses) && d < len(deficits); {
+		var fee float64
+		if fees != nil {
+			var err error
+			fee, err = fees(surpluses[s].exchange, t.Currency, deficits[d].amount)
+			if err != nil {
+				return nil, err
+			}
+			if fee < 0 {
+				return nil, fmt.Errorf("%s %s %w",
+					surpluses[s].exchange,
+					t.Currency,
+					errNegativeFeeReturned)
+			}
+		}
+		amount := deficits[d].amount + fee
+		if amount > surpluses[s].amount {
+			amount = surpluses[s].amount
+		}
+		received := amount - fee
+		if received < 0 {
+			received = 0
+		}
+		if received > 0 && received >= t.MinimumTransfer {
+			transfers = append(transfers, Transfer{
+				From:     surpluses[s].exchange,
+				To:       deficits[d].exchange,
+				Currency: t.Currency,
+				Amount:   amount,
+				Fee:      fee,
+			})
+		}
+		surpluses[s].amount -= amount
+		deficits[d].amount -= received
+		if surpluses[s].amount <= dustThreshold {
+			s++
+		}
+		if deficits[d].amount <= dustThreshold {
+			d++
+		}
+	}
+	return transfers, nil
+}
+
+// sortImbalances orders imbalances by amount descending, then by exchange
+// name so plans are deterministic
+func sortImbalances(i []imbalance) {
+	sort.Slice(i, func(a, b int) bool {
+		if i[a].amount == i[b].amount {
+			return i[a].exchange < i[b].exchange
+		}
+		return i[a].amount > i[b].amount
+	})
+}
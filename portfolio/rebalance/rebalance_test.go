@@ -0,0 +1,168 @@
+package rebalance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestValidateTargets(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name   string
+		target Target
+		err    error
+	}{
+		{"no currency", Target{}, errCurrencyUnset},
+		{"negative minimum", Target{Currency: currency.BTC, MinimumTransfer: -1}, errNegativeMinimum},
+		{"single allocation", Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}}}, errNoAllocations},
+		{"no exchange", Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}, {"", 1}}}, errExchangeNameUnset},
+		{"zero weight", Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}, {"b", 0}}}, errInvalidWeight},
+		{"duplicate exchange", Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}, {"A", 1}}}, errDuplicateExchange},
+		{"valid", Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}, {"b", 1}}}, nil},
+	}
+	for i := range tt {
+		err := ValidateTargets([]Target{tt[i].target})
+		if !errors.Is(err, tt[i].err) {
+			t.Errorf("%s: expected %v received %v", tt[i].name, tt[i].err, err)
+		}
+	}
+
+	err := ValidateTargets(nil)
+	if !errors.Is(err, errNoTargets) {
+		t.Errorf("expected %v received %v", errNoTargets, err)
+	}
+
+	valid := Target{Currency: currency.BTC, Allocations: []Allocation{{"a", 1}, {"b", 1}}}
+	err = ValidateTargets([]Target{valid, valid})
+	if !errors.Is(err, errDuplicateCurrency) {
+		t.Errorf("expected %v received %v", errDuplicateCurrency, err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	targets := []Target{
+		{
+			Currency: currency.BTC,
+			Allocations: []Allocation{
+				{Exchange: "Binance", Weight: 1},
+				{Exchange: "Kraken", Weight: 1},
+				{Exchange: "Bitstamp", Weight: 2},
+			},
+		},
+	}
+	balances := []Balance{
+		{Exchange: "binance", Currency: currency.BTC, Amount: 8},
+		{Exchange: "kraken", Currency: currency.BTC, Amount: 0},
+		{Exchange: "bitstamp", Currency: currency.BTC, Amount: 0},
+		{Exchange: "binance", Currency: currency.ETH, Amount: 100},
+		{Exchange: "huobi", Currency: currency.BTC, Amount: 100},
+	}
+	transfers, err := Plan(targets, balances, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 2 {
+		t.Fatalf("expected 2 transfers received %v", len(transfers))
+	}
+	if transfers[0].From != "binance" || transfers[0].To != "bitstamp" || transfers[0].Amount != 4 {
+		t.Errorf("unexpected first transfer %+v", transfers[0])
+	}
+	if transfers[1].From != "binance" || transfers[1].To != "kraken" || transfers[1].Amount != 2 {
+		t.Errorf("unexpected second transfer %+v", transfers[1])
+	}
+
+	transfers, err = Plan(targets, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 0 {
+		t.Errorf("expected no transfers without balances received %v", len(transfers))
+	}
+}
+
+func TestPlanFees(t *testing.T) {
+	t.Parallel()
+	targets := []Target{
+		{
+			Currency: currency.BTC,
+			Allocations: []Allocation{
+				{Exchange: "binance", Weight: 1},
+				{Exchange: "kraken", Weight: 1},
+			},
+		},
+	}
+	balances := []Balance{
+		{Exchange: "binance", Currency: currency.BTC, Amount: 10},
+		{Exchange: "kraken", Currency: currency.BTC, Amount: 6},
+	}
+	fee := func(string, currency.Code, float64) (float64, error) {
+		return 0.5, nil
+	}
+	transfers, err := Plan(targets, balances, fee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 1 {
+		t.Fatalf("expected 1 transfer received %v", len(transfers))
+	}
+	if transfers[0].Amount != 2 || transfers[0].Fee != 0.5 || transfers[0].Received() != 1.5 {
+		t.Errorf("expected transfer capped at surplus with fee deducted, received %+v", transfers[0])
+	}
+
+	negativeFee := func(string, currency.Code, float64) (float64, error) {
+		return -1, nil
+	}
+	_, err = Plan(targets, balances, negativeFee)
+	if !errors.Is(err, errNegativeFeeReturned) {
+		t.Errorf("expected %v received %v", errNegativeFeeReturned, err)
+	}
+
+	errFee := errors.New("fee lookup failed")
+	failingFee := func(string, currency.Code, float64) (float64, error) {
+		return 0, errFee
+	}
+	_, err = Plan(targets, balances, failingFee)
+	if !errors.Is(err, errFee) {
+		t.Errorf("expected %v received %v", errFee, err)
+	}
+}
+
+func TestPlanMinimumTransfer(t *testing.T) {
+	t.Parallel()
+	targets := []Target{
+		{
+			Currency:        currency.BTC,
+			MinimumTransfer: 1,
+			Allocations: []Allocation{
+				{Exchange: "binance", Weight: 1},
+				{Exchange: "kraken", Weight: 1},
+			},
+		},
+	}
+	balances := []Balance{
+		{Exchange: "binance", Currency: currency.BTC, Amount: 5.5},
+		{Exchange: "kraken", Currency: currency.BTC, Amount: 4.5},
+	}
+	transfers, err := Plan(targets, balances, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 0 {
+		t.Errorf("expected transfer below minimum to be skipped, received %+v", transfers)
+	}
+
+	fee := func(string, currency.Code, float64) (float64, error) {
+		return 10, nil
+	}
+	targets[0].MinimumTransfer = 0
+	transfers, err = Plan(targets, balances, fee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 0 {
+		t.Errorf("expected transfer consumed by fees to be skipped, received %+v", transfers)
+	}
+}
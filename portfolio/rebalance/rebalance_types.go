@@ -0,0 +1,67 @@
+package rebalance
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+var (
+	errNoTargets           = errors.New("no rebalance targets")
+	errNoAllocations       = errors.New("at least two exchange allocations are required")
+	errInvalidWeight       = errors.New("allocation weight must be greater than zero")
+	errDuplicateExchange   = errors.New("duplicate exchange allocation")
+	errDuplicateCurrency   = errors.New("duplicate currency target")
+	errExchangeNameUnset   = errors.New("allocation exchange name unset")
+	errCurrencyUnset       = errors.New("target currency unset")
+	errNegativeMinimum     = errors.New("minimum transfer cannot be negative")
+	errNegativeFeeReturned = errors.New("fee lookup returned a negative fee")
+)
+
+// Allocation is the share of a currency's total holdings an exchange should
+// hold. Weights are relative to the other allocations of the same target
+type Allocation struct {
+	Exchange string  `json:"exchange"`
+	Weight   float64 `json:"weight"`
+}
+
+// Target defines how holdings of a currency should be distributed across
+// exchanges. Transfers which would deliver less than the minimum transfer
+// amount are not proposed
+type Target struct {
+	Currency        currency.Code `json:"currency"`
+	MinimumTransfer float64       `json:"minimumTransfer"`
+	Allocations     []Allocation  `json:"allocations"`
+}
+
+// Balance is the amount of a currency held on an exchange
+type Balance struct {
+	Exchange string
+	Currency currency.Code
+	Amount   float64
+}
+
+// FeeLookup returns the withdrawal fee charged by an exchange for withdrawing
+// an amount of a currency
+type FeeLookup func(exchange string, c currency.Code, amount float64) (float64, error)
+
+// Transfer is a withdrawal from one exchange to another. Amount is withdrawn
+// from the source exchange and Amount minus Fee is expected to be received
+type Transfer struct {
+	From     string
+	To       string
+	Currency currency.Code
+	Amount   float64
+	Fee      float64
+}
+
+// Received returns the amount expected to arrive at the destination exchange
+func (t *Transfer) Received() float64 {
+	return t.Amount - t.Fee
+}
+
+// imbalance is the amount an exchange holds above or below its target
+type imbalance struct {
+	exchange string
+	amount   float64
+}
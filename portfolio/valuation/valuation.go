@@ -0,0 +1,266 @@
+package valuation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/valuation"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+// PersonalHoldings converts personal portfolio addresses to holdings using
+// the address as their source. Exchange entries are skipped as they are
+// sourced from exchange account holdings
+func PersonalHoldings(p *portfolio.Base) []Holding {
+	var holdings []Holding
+	for i := range p.Addresses {
+		if p.Addresses[i].Description == portfolio.PortfolioAddressExchange ||
+			p.Addresses[i].Balance == 0 {
+			continue
+		}
+		holdings = append(holdings, Holding{
+			Source:   p.Addresses[i].Address,
+			Currency: p.Addresses[i].CoinType,
+			Amount:   p.Addresses[i].Balance,
+		})
+	}
+	return holdings
+}
+
+// ExchangeHoldings converts exchange account holdings to holdings using the
+// exchange name as their source, balances across sub accounts are summed
+func ExchangeHoldings(accounts []account.Holdings) []Holding {
+	var holdings []Holding
+	for i := range accounts {
+		index := make(map[*currency.Item]int)
+		for j := range accounts[i].Accounts {
+			for k := range accounts[i].Accounts[j].Currencies {
+				b := accounts[i].Accounts[j].Currencies[k]
+				if b.TotalValue == 0 {
+					continue
+				}
+				x, ok := index[b.CurrencyName.Item]
+				if ok {
+					holdings[x].Amount += b.TotalValue
+					continue
+				}
+				index[b.CurrencyName.Item] = len(holdings)
+				holdings = append(holdings, Holding{
+					Source:   strings.ToLower(accounts[i].Exchange),
+					Currency: b.CurrencyName,
+					Amount:   b.TotalValue,
+				})
+			}
+		}
+	}
+	return holdings
+}
+
+// TickerPriceLookup returns a PriceLookup which values fiat currencies using
+// foreign exchange rates and cryptocurrencies using the stored spot tickers of
+// the supplied exchanges. Cryptocurrencies without a ticker quoted in the fiat
+// currency are valued via their USD or USDT ticker
+func TickerPriceLookup(exchanges []string) PriceLookup {
+	return func(c, fiat currency.Code) (float64, error) {
+		if c.Match(fiat) {
+			return 1, nil
+		}
+		if c.IsFiatCurrency() {
+			return currency.ConvertCurrency(1, c, fiat)
+		}
+		for _, quote := range []currency.Code{fiat, currency.USD, currency.USDT} {
+			for i := range exchanges {
+				t, err := ticker.GetTicker(exchanges[i],
+					currency.NewPair(c, quote),
+					asset.Spot)
+				if err != nil || t.Last == 0 {
+					continue
+				}
+				if quote.Match(fiat) {
+					return t.Last, nil
+				}
+				if quote.Match(currency.USDT) {
+					quote = currency.USD
+				}
+				return currency.ConvertCurrency(t.Last, quote, fiat)
+			}
+		}
+		return 0, fmt.Errorf("%s %w in %s", c, errNoPriceFound, fiat)
+	}
+}
+
+// Evaluate values holdings in the fiat currency. Holdings which cannot be
+// priced are logged and left out of the snapshot
+func Evaluate(holdings []Holding, fiat currency.Code, lookup PriceLookup, t time.Time) (*Snapshot, error) {
+	if fiat.IsEmpty() {
+		return nil, errFiatCurrencyUnset
+	}
+	if lookup == nil {
+		return nil, errNoPriceLookup
+	}
+	s := &Snapshot{
+		FiatCurrency: fiat,
+		Timestamp:    t,
+	}
+	prices := make(map[*currency.Item]float64)
+	for i := range holdings {
+		price, ok := prices[holdings[i].Currency.Item]
+		if !ok {
+			var err error
+			price, err = lookup(holdings[i].Currency, fiat)
+			if err != nil {
+				log.Warnf(log.PortfolioMgr,
+					"Portfolio valuation: unable to value %s %s: %v\n",
+					holdings[i].Source,
+					holdings[i].Currency,
+					err)
+				continue
+			}
+			prices[holdings[i].Currency.Item] = price
+		}
+		v := Value{
+			Holding: holdings[i],
+			Price:   price,
+			Value:   holdings[i].Amount * price,
+		}
+		s.Values = append(s.Values, v)
+		s.Total += v.Value
+	}
+	return s, nil
+}
+
+// Save stores the snapshot in the database
+func (s *Snapshot) Save() error {
+	if len(s.Values) == 0 {
+		return errNoValuationData
+	}
+	data := make([]valuation.Data, len(s.Values))
+	for i := range s.Values {
+		data[i] = valuation.Data{
+			Source:       s.Values[i].Source,
+			Currency:     s.Values[i].Currency.String(),
+			Amount:       s.Values[i].Amount,
+			Price:        s.Values[i].Price,
+			Value:        s.Values[i].Value,
+			FiatCurrency: s.FiatCurrency.String(),
+			Timestamp:    s.Timestamp,
+		}
+	}
+	return valuation.Insert(data...)
+}
+
+// EquityCurve sums stored valuations by timestamp into a time series of total
+// portfolio value
+func EquityCurve(data []valuation.Data) []EquityPoint {
+	totals := make(map[int64]float64)
+	for i := range data {
+		totals[data[i].Timestamp.Unix()] += data[i].Value
+	}
+	curve := make([]EquityPoint, 0, len(totals))
+	for ts, total := range totals {
+		curve = append(curve, EquityPoint{
+			Timestamp: time.Unix(ts, 0).UTC(),
+			Value:     total,
+		})
+	}
+	sort.Slice(curve, func(i, j int) bool {
+		return curve[i].Timestamp.Before(curve[j].Timestamp)
+	})
+	return curve
+}
+
+// AllocationSeries returns the per currency allocation of each snapshot in
+// the stored valuations ordered by timestamp, allocations within a snapshot
+// are ordered by value descending
+func AllocationSeries(data []valuation.Data) ([]AllocationPoint, error) {
+	if len(data) == 0 {
+		return nil, errNoValuationData
+	}
+	points := make(map[int64]*AllocationPoint)
+	index := make(map[int64]map[string]int)
+	for i := range data {
+		ts := data[i].Timestamp.Unix()
+		p, ok := points[ts]
+		if !ok {
+			p = &AllocationPoint{Timestamp: time.Unix(ts, 0).UTC()}
+			points[ts] = p
+			index[ts] = make(map[string]int)
+		}
+		p.Total += data[i].Value
+		x, ok := index[ts][data[i].Currency]
+		if !ok {
+			index[ts][data[i].Currency] = len(p.Allocations)
+			p.Allocations = append(p.Allocations, Allocation{
+				Currency: data[i].Currency,
+				Amount:   data[i].Amount,
+				Value:    data[i].Value,
+			})
+			continue
+		}
+		p.Allocations[x].Amount += data[i].Amount
+		p.Allocations[x].Value += data[i].Value
+	}
+	series := make([]AllocationPoint, 0, len(points))
+	for _, p := range points {
+		for i := range p.Allocations {
+			if p.Total != 0 {
+				p.Allocations[i].Percentage = p.Allocations[i].Value / p.Total * 100
+			}
+		}
+		sort.Slice(p.Allocations, func(i, j int) bool {
+			return p.Allocations[i].Value > p.Allocations[j].Value
+		})
+		series = append(series, *p)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Timestamp.Before(series[j].Timestamp)
+	})
+	return series, nil
+}
+
+// ExportEquityCurveCSV writes the equity curve to a CSV file
+func ExportEquityCurveCSV(filename string, curve []EquityPoint) error {
+	if len(curve) == 0 {
+		return errNoValuationData
+	}
+	records := [][]string{{"timestamp", "value"}}
+	for i := range curve {
+		records = append(records, []string{
+			curve[i].Timestamp.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(curve[i].Value, 'f', -1, 64),
+		})
+	}
+	return file.WriteAsCSV(filename, records)
+}
+
+// ExportAllocationCSV writes a portfolio allocation series to a CSV file, one
+// row per timestamp and currency
+func ExportAllocationCSV(filename string, series []AllocationPoint) error {
+	if len(series) == 0 {
+		return errNoValuationData
+	}
+	records := [][]string{{"timestamp", "currency", "amount", "value", "percentage"}}
+	for i := range series {
+		ts := series[i].Timestamp.UTC().Format(time.RFC3339)
+		for j := range series[i].Allocations {
+			a := &series[i].Allocations[j]
+			records = append(records, []string{
+				ts,
+				a.Currency,
+				strconv.FormatFloat(a.Amount, 'f', -1, 64),
+				strconv.FormatFloat(a.Value, 'f', -1, 64),
+				strconv.FormatFloat(a.Percentage, 'f', -1, 64),
+			})
+		}
+	}
+	return file.WriteAsCSV(filename, records)
+}
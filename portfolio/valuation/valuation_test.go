@@ -0,0 +1,227 @@
+package valuation
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/valuation"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func fixedPrices(prices map[string]float64) PriceLookup {
+	return func(c, fiat currency.Code) (float64, error) {
+		p, ok := prices[c.String()]
+		if !ok {
+			return 0, errNoPriceFound
+		}
+		return p, nil
+	}
+}
+
+func TestPersonalHoldings(t *testing.T) {
+	p := portfolio.Base{
+		Addresses: []portfolio.Address{
+			{Address: "binance", CoinType: currency.BTC, Balance: 1, Description: portfolio.PortfolioAddressExchange},
+			{Address: "0xdead", CoinType: currency.ETH, Balance: 2, Description: portfolio.PortfolioAddressPersonal},
+			{Address: "empty", CoinType: currency.LTC},
+		},
+	}
+	h := PersonalHoldings(&p)
+	if len(h) != 1 {
+		t.Fatalf("expected 1 holding received %v", len(h))
+	}
+	if h[0].Source != "0xdead" || h[0].Amount != 2 {
+		t.Errorf("unexpected holding %+v", h[0])
+	}
+}
+
+func TestExchangeHoldings(t *testing.T) {
+	h := ExchangeHoldings([]account.Holdings{
+		{
+			Exchange: "Binance",
+			Accounts: []account.SubAccount{
+				{ID: "main", Currencies: []account.Balance{
+					{CurrencyName: currency.BTC, TotalValue: 1},
+					{CurrencyName: currency.ETH},
+				}},
+				{ID: "sub", Currencies: []account.Balance{
+					{CurrencyName: currency.BTC, TotalValue: 0.5},
+				}},
+			},
+		},
+	})
+	if len(h) != 1 {
+		t.Fatalf("expected 1 holding received %v", len(h))
+	}
+	if h[0].Source != "binance" || h[0].Amount != 1.5 {
+		t.Errorf("unexpected holding %+v", h[0])
+	}
+}
+
+func TestTickerPriceLookup(t *testing.T) {
+	err := ticker.ProcessTicker(&ticker.Price{
+		Last:         100,
+		Pair:         currency.NewPair(currency.LTC, currency.AUD),
+		ExchangeName: "valuationtest",
+		AssetType:    asset.Spot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := TickerPriceLookup([]string{"valuationtest"})
+	p, err := lookup(currency.AUD, currency.AUD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != 1 {
+		t.Errorf("expected 1 received %v", p)
+	}
+	p, err = lookup(currency.LTC, currency.AUD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != 100 {
+		t.Errorf("expected 100 received %v", p)
+	}
+	_, err = lookup(currency.XRP, currency.AUD)
+	if !errors.Is(err, errNoPriceFound) {
+		t.Errorf("expected %v received %v", errNoPriceFound, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	holdings := []Holding{
+		{Source: "binance", Currency: currency.BTC, Amount: 0.5},
+		{Source: "0xdead", Currency: currency.ETH, Amount: 2},
+		{Source: "kraken", Currency: currency.XRP, Amount: 100},
+	}
+	_, err := Evaluate(holdings, currency.Code{}, nil, time.Now())
+	if !errors.Is(err, errFiatCurrencyUnset) {
+		t.Errorf("expected %v received %v", errFiatCurrencyUnset, err)
+	}
+	_, err = Evaluate(holdings, currency.USD, nil, time.Now())
+	if !errors.Is(err, errNoPriceLookup) {
+		t.Errorf("expected %v received %v", errNoPriceLookup, err)
+	}
+	s, err := Evaluate(holdings,
+		currency.USD,
+		fixedPrices(map[string]float64{"BTC": 10000, "ETH": 400}),
+		time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Values) != 2 {
+		t.Errorf("expected unpriced holding to be skipped, received %v values", len(s.Values))
+	}
+	if s.Total != 5800 {
+		t.Errorf("expected 5800 received %v", s.Total)
+	}
+}
+
+func testData() []valuation.Data {
+	start := time.Date(2020, 11, 9, 0, 0, 0, 0, time.UTC)
+	return []valuation.Data{
+		{Source: "binance", Currency: "BTC", Amount: 1, Price: 10000, Value: 10000, FiatCurrency: "USD", Timestamp: start},
+		{Source: "0xdead", Currency: "ETH", Amount: 2, Price: 400, Value: 800, FiatCurrency: "USD", Timestamp: start},
+		{Source: "binance", Currency: "BTC", Amount: 1, Price: 11000, Value: 11000, FiatCurrency: "USD", Timestamp: start.Add(time.Hour)},
+		{Source: "kraken", Currency: "BTC", Amount: 0.5, Price: 11000, Value: 5500, FiatCurrency: "USD", Timestamp: start.Add(time.Hour)},
+		{Source: "0xdead", Currency: "ETH", Amount: 2, Price: 450, Value: 900, FiatCurrency: "USD", Timestamp: start.Add(time.Hour)},
+	}
+}
+
+func TestEquityCurve(t *testing.T) {
+	curve := EquityCurve(testData())
+	if len(curve) != 2 {
+		t.Fatalf("expected 2 points received %v", len(curve))
+	}
+	if curve[0].Value != 10800 || curve[1].Value != 17400 {
+		t.Errorf("unexpected equity curve values %v %v", curve[0].Value, curve[1].Value)
+	}
+	if !curve[0].Timestamp.Before(curve[1].Timestamp) {
+		t.Error("expected equity curve ordered by timestamp")
+	}
+}
+
+func TestAllocationSeries(t *testing.T) {
+	_, err := AllocationSeries(nil)
+	if !errors.Is(err, errNoValuationData) {
+		t.Errorf("expected %v received %v", errNoValuationData, err)
+	}
+	series, err := AllocationSeries(testData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("expected an allocation per snapshot received %v", len(series))
+	}
+	if !series[0].Timestamp.Equal(time.Date(2020, 11, 9, 0, 0, 0, 0, time.UTC)) ||
+		!series[1].Timestamp.Equal(time.Date(2020, 11, 9, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected allocations ordered by timestamp received %v %v",
+			series[0].Timestamp, series[1].Timestamp)
+	}
+	if series[0].Total != 10800 || series[1].Total != 17400 {
+		t.Errorf("unexpected allocation totals %v %v", series[0].Total, series[1].Total)
+	}
+	a := series[1].Allocations
+	if len(a) != 2 {
+		t.Fatalf("expected 2 allocations received %v", len(a))
+	}
+	if a[0].Currency != "BTC" || a[0].Amount != 1.5 || a[0].Value != 16500 {
+		t.Errorf("unexpected BTC allocation %+v", a[0])
+	}
+	if a[1].Percentage+a[0].Percentage != 100 {
+		t.Errorf("expected percentages to total 100 received %v", a[1].Percentage+a[0].Percentage)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "valuation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = ExportEquityCurveCSV(filepath.Join(tempDir, "curve.csv"), nil)
+	if !errors.Is(err, errNoValuationData) {
+		t.Errorf("expected %v received %v", errNoValuationData, err)
+	}
+	err = ExportEquityCurveCSV(filepath.Join(tempDir, "curve.csv"), EquityCurve(testData()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := AllocationSeries(testData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ExportAllocationCSV(filepath.Join(tempDir, "allocation.csv"), series)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(tempDir, "curve.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "timestamp,value\n2020-11-09T00:00:00Z,10800\n2020-11-09T01:00:00Z,17400\n" {
+		t.Errorf("unexpected csv output %q", b)
+	}
+	b, err = ioutil.ReadFile(filepath.Join(tempDir, "allocation.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(rows) != 5 ||
+		rows[0] != "timestamp,currency,amount,value,percentage" ||
+		!strings.HasPrefix(rows[1], "2020-11-09T00:00:00Z,BTC,1,10000,") ||
+		!strings.HasPrefix(rows[4], "2020-11-09T01:00:00Z,ETH,2,900,") {
+		t.Errorf("expected a row per timestamp and currency received %q", b)
+	}
+}
@@ -0,0 +1,64 @@
+package valuation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+var (
+	errFiatCurrencyUnset = errors.New("fiat currency not set")
+	errNoPriceLookup     = errors.New("no price lookup function supplied")
+	errNoPriceFound      = errors.New("no price found")
+	errNoValuationData   = errors.New("no valuation data")
+)
+
+// PriceLookup returns the price of one unit of a currency in the fiat currency
+type PriceLookup func(c, fiat currency.Code) (float64, error)
+
+// Holding is an amount of a currency held at a source, which is either an
+// exchange name or a personal address
+type Holding struct {
+	Source   string
+	Currency currency.Code
+	Amount   float64
+}
+
+// Value is a holding valued in a fiat currency
+type Value struct {
+	Holding
+	Price float64
+	Value float64
+}
+
+// Snapshot is the fiat valuation of all holdings at a point in time
+type Snapshot struct {
+	FiatCurrency currency.Code
+	Timestamp    time.Time
+	Values       []Value
+	Total        float64
+}
+
+// EquityPoint is the total fiat value of the portfolio at a point in time
+type EquityPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Allocation is the fiat value of a single currency across all sources and
+// its share of the total portfolio value
+type Allocation struct {
+	Currency   string
+	Amount     float64
+	Value      float64
+	Percentage float64
+}
+
+// AllocationPoint is the per currency allocation of the portfolio at a point
+// in time
+type AllocationPoint struct {
+	Timestamp   time.Time
+	Total       float64
+	Allocations []Allocation
+}
@@ -0,0 +1,53 @@
+package withdraw
+
+import "strings"
+
+var (
+	completedStatuses = []string{
+		"complete",
+		"completed",
+		"confirmed",
+		"done",
+		"finished",
+		"processed",
+		"success",
+		"successful",
+	}
+	failedStatuses = []string{
+		"approval_expired",
+		"approval_rejected",
+		"canceled",
+		"cancelled",
+		"error",
+		"fail",
+		"failed",
+		"failure",
+		"refused",
+		"rejected",
+	}
+)
+
+// Lifecycle maps an exchange reported withdrawal status to a lifecycle state.
+// Unrecognised statuses are treated as pending
+func Lifecycle(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	for i := range completedStatuses {
+		if status == completedStatuses[i] {
+			return LifecycleCompleted
+		}
+	}
+	for i := range failedStatuses {
+		if status == failedStatuses[i] {
+			return LifecycleFailed
+		}
+	}
+	return LifecyclePending
+}
+
+// FinalStatuses returns the lower case statuses which end a withdrawal
+// lifecycle
+func FinalStatuses() []string {
+	resp := make([]string, 0, len(completedStatuses)+len(failedStatuses))
+	resp = append(resp, completedStatuses...)
+	return append(resp, failedStatuses...)
+}
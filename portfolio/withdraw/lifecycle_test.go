@@ -0,0 +1,22 @@
+package withdraw
+
+import "testing"
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+	for status, expected := range map[string]string{
+		"Completed":         LifecycleCompleted,
+		" SUCCESS ":         LifecycleCompleted,
+		"Cancelled":         LifecycleFailed,
+		"approval_rejected": LifecycleFailed,
+		"processing":        LifecyclePending,
+		"":                  LifecyclePending,
+	} {
+		if r := Lifecycle(status); r != expected {
+			t.Errorf("%q expected %v received %v", status, expected, r)
+		}
+	}
+	if len(FinalStatuses()) != len(completedStatuses)+len(failedStatuses) {
+		t.Error("unexpected final statuses")
+	}
+}
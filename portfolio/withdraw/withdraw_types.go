@@ -87,6 +87,7 @@ type FiatRequest struct {
 // Request holds complete details for request
 type Request struct {
 	Exchange    string        `json:"exchange"`
+	AccountID   string        `json:"account_id,omitempty"`
 	Currency    currency.Code `json:"currency"`
 	Description string        `json:"description"`
 	Amount      float64       `json:"amount"`
@@ -117,4 +118,12 @@ type ExchangeResponse struct {
 	UUID   uuid.UUID
 	ID     string `json:"id"`
 	Status string `json:"status"`
+	TxID   string `json:"tx_id"`
 }
+
+// Lifecycle states derived from exchange reported withdrawal statuses
+const (
+	LifecyclePending   = "pending"
+	LifecycleCompleted = "completed"
+	LifecycleFailed    = "failed"
+)
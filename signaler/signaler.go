@@ -7,7 +7,8 @@ import (
 )
 
 var (
-	s = make(chan os.Signal, 1)
+	s      = make(chan os.Signal, 1)
+	reload = make(chan os.Signal, 1)
 )
 
 func init() {
@@ -18,6 +19,7 @@ func init() {
 		syscall.SIGABRT,
 	}
 	signal.Notify(s, sigs...)
+	signal.Notify(reload, syscall.SIGHUP)
 }
 
 // WaitForInterrupt waits until a os.Signal is
@@ -25,3 +27,9 @@ func init() {
 func WaitForInterrupt() os.Signal {
 	return <-s
 }
+
+// ReloadRequested returns a channel which receives a os.Signal each time a
+// config reload is requested via SIGHUP
+func ReloadRequested() <-chan os.Signal {
+	return reload
+}